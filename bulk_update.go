@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/drewfead/cali/internal/calendar"
+	"github.com/drewfead/cali/proto"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// bulkUpdateCommand creates the bulk-update command, which applies a JSONL file
+// of UpdateEventRequests (one per line) and reports a result per line
+func bulkUpdateCommand(svc *calendarService) *cli.Command {
+	return &cli.Command{
+		Name:  "bulk-update",
+		Usage: "apply UpdateEventRequests from a JSONL file (one request per line)",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "file",
+				Value: "-",
+				Usage: "JSONL file to read requests from (- for stdin)",
+			},
+			&cli.BoolFlag{
+				Name:  "stop-on-error",
+				Usage: "stop dispatching updates after the first failure",
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Value: 4,
+				Usage: "maximum number of updates in flight",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			var r io.Reader = os.Stdin
+			if path := cmd.String("file"); path != "" && path != "-" {
+				f, err := os.Open(path)
+				if err != nil {
+					return fmt.Errorf("failed to open requests file: %w", err)
+				}
+				defer f.Close()
+				r = f
+			}
+
+			if err := svc.ensureInitialized(ctx); err != nil {
+				return err
+			}
+
+			return runBulkUpdate(ctx, svc.calendarClient, r, cmd.Root().Writer, calendar.BulkOptions{
+				Concurrency: cmd.Int("concurrency"),
+				StopOnError: cmd.Bool("stop-on-error"),
+			})
+		},
+	}
+}
+
+// runBulkUpdate parses JSONL requests from r, applies them, and writes one
+// result line per input line to w. It returns an error if any line failed to
+// parse or update, after all results have been written.
+func runBulkUpdate(ctx context.Context, client *calendar.Client, r io.Reader, w io.Writer, opts calendar.BulkOptions) error {
+	if w == nil {
+		w = os.Stdout
+	}
+
+	// Parse all lines up front so results can be reported against line numbers
+	type parsedLine struct {
+		number int
+		req    *proto.UpdateEventRequest
+		err    error
+	}
+	var lines []parsedLine
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		req := &proto.UpdateEventRequest{}
+		if err := protojson.Unmarshal([]byte(text), req); err != nil {
+			lines = append(lines, parsedLine{number: number, err: fmt.Errorf("invalid request: %w", err)})
+			continue
+		}
+		lines = append(lines, parsedLine{number: number, req: req})
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read requests: %w", err)
+	}
+
+	// A malformed line is a hard error; with --stop-on-error nothing is sent
+	var reqs []*proto.UpdateEventRequest
+	var lineForReq []int
+	parseFailed := false
+	for i, line := range lines {
+		if line.err != nil {
+			parseFailed = true
+			continue
+		}
+		reqs = append(reqs, line.req)
+		lineForReq = append(lineForReq, i)
+	}
+
+	var results []calendar.BulkResult
+	if !parseFailed || !opts.StopOnError {
+		results = client.BulkUpdate(ctx, reqs, opts)
+	}
+
+	outcomes := make([]string, len(lines))
+	failures := 0
+	for i, line := range lines {
+		if line.err != nil {
+			outcomes[i] = fmt.Sprintf("error: %v", line.err)
+			failures++
+		} else {
+			outcomes[i] = "skipped"
+		}
+	}
+	for _, result := range results {
+		i := lineForReq[result.Index]
+		switch {
+		case result.Skipped:
+			outcomes[i] = "skipped"
+		case result.Err != nil:
+			outcomes[i] = fmt.Sprintf("error: %v", result.Err)
+			failures++
+		default:
+			outcomes[i] = fmt.Sprintf("updated %s", result.Event.Id)
+		}
+	}
+
+	for i, line := range lines {
+		fmt.Fprintf(w, "line %d: %s\n", line.number, outcomes[i])
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d of %d updates failed", failures, len(lines))
+	}
+	return nil
+}
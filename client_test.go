@@ -0,0 +1,2826 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/drewfead/cali/internal/calendar"
+	"github.com/drewfead/cali/pkg/googlecaltest"
+	"github.com/drewfead/cali/proto"
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/oauth2"
+	gcalendar "google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newMockClient creates a calendar client pointed at the given mock server
+func newMockClient(t *testing.T, server *googlecaltest.Server, opts ...calendar.ClientOption) *calendar.Client {
+	t.Helper()
+
+	opts = append([]calendar.ClientOption{calendar.WithEndpoint(server.URL)}, opts...)
+	client, err := calendar.NewClient(context.Background(), &http.Client{}, opts...)
+	if err != nil {
+		t.Fatalf("failed to create calendar client: %v", err)
+	}
+	return client
+}
+
+func TestClient_BulkUpdate(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	for _, id := range []string{"a", "b", "c", "d"} {
+		server.AddEvent("primary", &gcalendar.Event{Id: id, Summary: "Original " + id})
+	}
+
+	client := newMockClient(t, server)
+	reqs := []*proto.UpdateEventRequest{
+		{EventId: "a", Summary: ptr("Updated a")},
+		{EventId: "missing", Summary: ptr("Updated missing")},
+		{EventId: "b", Summary: ptr("Updated b")},
+		{EventId: "c", Summary: ptr("Updated c")},
+		{EventId: "d", Summary: ptr("Updated d")},
+	}
+
+	results := client.BulkUpdate(context.Background(), reqs, calendar.BulkOptions{Concurrency: 2})
+
+	if len(results) != len(reqs) {
+		t.Fatalf("expected %d results, got %d", len(reqs), len(results))
+	}
+	for i, result := range results {
+		if result.Index != i {
+			t.Errorf("result %d: expected index %d, got %d", i, i, result.Index)
+		}
+		if result.Skipped {
+			t.Errorf("result %d: expected update to be attempted", i)
+		}
+		if reqs[i].EventId == "missing" {
+			if result.Err == nil {
+				t.Errorf("result %d: expected error for missing event", i)
+			}
+			continue
+		}
+		if result.Err != nil {
+			t.Errorf("result %d: unexpected error: %v", i, result.Err)
+			continue
+		}
+		if result.Event.Id != reqs[i].EventId || result.Event.Summary != *reqs[i].Summary {
+			t.Errorf("result %d: expected %s/%q, got %s/%q", i, reqs[i].EventId, *reqs[i].Summary, result.Event.Id, result.Event.Summary)
+		}
+	}
+}
+
+func TestClient_CountEvents(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	base := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 12; i++ {
+		start := base.AddDate(0, 0, i)
+		server.AddEvent("primary", &gcalendar.Event{
+			Id:      fmt.Sprintf("e%d", i),
+			Summary: fmt.Sprintf("Event %d", i),
+			Start:   &gcalendar.EventDateTime{DateTime: start.Format(time.RFC3339)},
+			End:     &gcalendar.EventDateTime{DateTime: start.Add(time.Hour).Format(time.RFC3339)},
+		})
+	}
+
+	ctx := context.Background()
+	client := newMockClient(t, server)
+
+	// Five a page takes three pages, each asking only for IDs
+	count, err := client.CountEvents(ctx, &proto.ListEventsRequest{Limit: ptr(int32(5))})
+	if err != nil {
+		t.Fatalf("CountEvents() failed: %v", err)
+	}
+	if count.Count != 12 {
+		t.Errorf("expected 12 events, got %d", count.Count)
+	}
+	if !count.After.IsZero() || !count.Before.IsZero() {
+		t.Errorf("expected an unbounded window, got %v to %v", count.After, count.Before)
+	}
+	requests := server.Requests()
+	if len(requests) != 3 {
+		t.Errorf("expected 3 page requests, got %d", len(requests))
+	}
+	for _, r := range requests {
+		if fields := r.Query.Get("fields"); fields != "nextPageToken,items(id)" {
+			t.Errorf("expected only IDs requested, got fields=%q", fields)
+		}
+	}
+
+	// The same filters as ListEvents apply, and the window is reported
+	after, before := base.AddDate(0, 0, 3), base.AddDate(0, 0, 7).Add(-time.Hour)
+	count, err = client.CountEvents(ctx, &proto.ListEventsRequest{After: timestamppb.New(after), Before: timestamppb.New(before)})
+	if err != nil {
+		t.Fatalf("CountEvents() failed: %v", err)
+	}
+	if count.Count != 4 {
+		t.Errorf("expected 4 events in the window, got %d", count.Count)
+	}
+	if !count.After.Equal(after) || !count.Before.Equal(before) {
+		t.Errorf("expected the window %v to %v, got %v to %v", after, before, count.After, count.Before)
+	}
+}
+
+func TestClient_ListEventsSeq(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	for i := 0; i < 7; i++ {
+		server.AddEvent("primary", &gcalendar.Event{Id: fmt.Sprintf("e%d", i), Summary: fmt.Sprintf("Event %d", i)})
+	}
+
+	ctx := context.Background()
+	client := newMockClient(t, server)
+	req := &proto.ListEventsRequest{Limit: ptr(int32(3))}
+
+	// Every page is followed
+	var ids []string
+	for event, err := range client.ListEventsSeq(ctx, req) {
+		if err != nil {
+			t.Fatalf("ListEventsSeq() failed: %v", err)
+		}
+		ids = append(ids, event.Id)
+	}
+	if len(ids) != 7 {
+		t.Errorf("expected 7 events across pages, got %d: %v", len(ids), ids)
+	}
+	if got := len(server.Requests()); got != 3 {
+		t.Errorf("expected 3 page requests, got %d", got)
+	}
+	if req.FollowPages != nil {
+		t.Error("expected the caller's request to be left unchanged")
+	}
+
+	// Breaking early stops the listing before later pages are fetched
+	requests := len(server.Requests())
+	seen := 0
+	for _, err := range client.ListEventsSeq(ctx, req) {
+		if err != nil {
+			t.Fatalf("ListEventsSeq() failed: %v", err)
+		}
+		if seen++; seen == 2 {
+			break
+		}
+	}
+	if got := len(server.Requests()) - requests; got != 1 {
+		t.Errorf("expected only the first page to be fetched, got %d requests", got)
+	}
+
+	// Failures are yielded once, at the end
+	server.FailNext(1, http.StatusBadRequest)
+	var errs []error
+	for event, err := range client.ListEventsSeq(ctx, req) {
+		if err != nil {
+			if event != nil {
+				t.Errorf("expected no event alongside the error, got %v", event)
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) != 1 {
+		t.Errorf("expected exactly one error, got %v", errs)
+	}
+}
+
+func TestClient_DeleteEvents(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	day := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	at := func(h int) *gcalendar.EventDateTime {
+		return &gcalendar.EventDateTime{DateTime: day.Add(time.Duration(h) * time.Hour).Format(time.RFC3339)}
+	}
+	server.AddEvent("primary", &gcalendar.Event{Id: "standup", Summary: "Load test standup", Start: at(9), End: at(10)})
+	server.AddEvent("primary", &gcalendar.Event{Id: "lunch", Summary: "Lunch", Start: at(12), End: at(13)})
+	server.AddEvent("primary", &gcalendar.Event{Id: "retro", Summary: "Load test retro", Start: at(15), End: at(16)})
+	server.AddEvent("primary", &gcalendar.Event{Id: "tomorrow", Summary: "Load test review", Start: at(33), End: at(34)})
+
+	ctx := context.Background()
+	client := newMockClient(t, server)
+
+	// Refuse to wipe the calendar without an explicit filter
+	if _, err := client.DeleteEvents(ctx, "primary", calendar.DeleteFilter{}); !errors.Is(err, calendar.ErrUnfilteredDelete) {
+		t.Fatalf("expected ErrUnfilteredDelete, got %v", err)
+	}
+	if got := len(server.GetEvents("primary")); got != 4 {
+		t.Fatalf("expected nothing deleted, got %d events left", got)
+	}
+
+	summary, err := client.DeleteEvents(ctx, "primary", calendar.DeleteFilter{
+		After:       day,
+		Before:      day.Add(24 * time.Hour),
+		Query:       "load test",
+		Concurrency: 2,
+	})
+	if err != nil {
+		t.Fatalf("DeleteEvents() failed: %v", err)
+	}
+	if summary.Matched != 2 || summary.Deleted != 2 || len(summary.Failed) != 0 {
+		t.Errorf("expected 2 matched and deleted, got %+v", summary)
+	}
+
+	var left []string
+	for _, e := range server.GetEvents("primary") {
+		left = append(left, e.Id)
+	}
+	sort.Strings(left)
+	if got := strings.Join(left, ","); got != "lunch,tomorrow" {
+		t.Errorf("expected lunch and tomorrow to remain, got %s", got)
+	}
+
+	// Per-event failures are reported without stopping the rest
+	server.AddEvent("primary", &gcalendar.Event{Id: "a", Summary: "Load test a", Start: at(40), End: at(41)})
+	server.AddEvent("primary", &gcalendar.Event{Id: "b", Summary: "Load test b", Start: at(42), End: at(43)})
+	server.OnRequest(func(r *http.Request) {
+		if r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/events/a") {
+			server.FailNext(1, http.StatusForbidden)
+		}
+	})
+	summary, err = client.DeleteEvents(ctx, "primary", calendar.DeleteFilter{After: day.Add(36 * time.Hour), Concurrency: 1})
+	if err != nil {
+		t.Fatalf("DeleteEvents() failed: %v", err)
+	}
+	if summary.Matched != 2 || summary.Deleted != 1 || summary.Failed["a"] == nil {
+		t.Errorf("expected b deleted and a to fail, got %+v", summary)
+	}
+
+	// ConfirmAll clears everything left
+	server.OnRequest(nil)
+	summary, err = client.DeleteEvents(ctx, "primary", calendar.DeleteFilter{ConfirmAll: true})
+	if err != nil {
+		t.Fatalf("DeleteEvents() failed: %v", err)
+	}
+	if summary.Deleted != 3 || len(server.GetEvents("primary")) != 0 {
+		t.Errorf("expected every event deleted, got %+v with %d left", summary, len(server.GetEvents("primary")))
+	}
+}
+
+func TestRunBulkUpdate_ReportsPerLineResults(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.AddEvent("primary", &gcalendar.Event{Id: "a", Summary: "Original a"})
+	server.AddEvent("primary", &gcalendar.Event{Id: "b", Summary: "Original b"})
+
+	input := strings.Join([]string{
+		`{"eventId": "a", "summary": "Updated a"}`,
+		`not json`,
+		`{"eventId": "b", "summary": "Updated b"}`,
+	}, "\n")
+
+	var out bytes.Buffer
+	err := runBulkUpdate(context.Background(), newMockClient(t, server), strings.NewReader(input), &out, calendar.BulkOptions{})
+	if err == nil {
+		t.Error("expected an error when a line fails")
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 result lines, got %d: %q", len(lines), out.String())
+	}
+	if lines[0] != "line 1: updated a" {
+		t.Errorf("unexpected line 1 result: %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "line 2: error:") {
+		t.Errorf("unexpected line 2 result: %q", lines[1])
+	}
+	if lines[2] != "line 3: updated b" {
+		t.Errorf("unexpected line 3 result: %q", lines[2])
+	}
+
+	// Stopping on error leaves the store untouched when a line is malformed
+	server.Reset()
+	server.AddEvent("primary", &gcalendar.Event{Id: "a", Summary: "Original a"})
+	out.Reset()
+	err = runBulkUpdate(context.Background(), newMockClient(t, server), strings.NewReader(input), &out, calendar.BulkOptions{StopOnError: true})
+	if err == nil {
+		t.Error("expected an error with --stop-on-error")
+	}
+	if events := server.GetEvents("primary"); events[0].Summary != "Original a" {
+		t.Errorf("expected no updates to be applied, got summary %q", events[0].Summary)
+	}
+}
+
+func TestClient_ListEventsSendsExpectedQuery(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	client := newMockClient(t, server)
+	after := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	respChan, errChan := client.ListEvents(context.Background(), &proto.ListEventsRequest{
+		After: timestamppb.New(after),
+	})
+	for range respChan {
+	}
+	if err := <-errChan; err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+
+	last, ok := server.LastRequest()
+	if !ok {
+		t.Fatal("expected a recorded request")
+	}
+	if got := last.Query.Get("singleEvents"); got != "true" {
+		t.Errorf("expected singleEvents=true, got %q", got)
+	}
+	if got := last.Query.Get("timeMin"); got != "2024-01-15T00:00:00Z" {
+		t.Errorf("expected timeMin 2024-01-15T00:00:00Z, got %q", got)
+	}
+}
+
+func TestAddEvent_ReturnsSelfLink(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	svc := &calendarService{calendarClient: newMockClient(t, server)}
+	resp, err := svc.AddEvent(context.Background(), &proto.AddEventRequest{
+		Summary:    "Linked Event",
+		CalendarId: ptr("team@example.com"),
+	})
+	if err != nil {
+		t.Fatalf("AddEvent() failed: %v", err)
+	}
+
+	link, err := url.Parse(resp.SelfLink)
+	if err != nil || link.Scheme == "" || link.Host == "" {
+		t.Fatalf("expected an absolute self link, got %q (%v)", resp.SelfLink, err)
+	}
+	want := server.URL + "/calendars/team@example.com/events/" + resp.EventId
+	if resp.SelfLink != want {
+		t.Errorf("expected self link %q, got %q", want, resp.SelfLink)
+	}
+
+	// The self link re-fetches the created event
+	fetched, err := http.Get(resp.SelfLink)
+	if err != nil {
+		t.Fatalf("failed to fetch self link: %v", err)
+	}
+	defer fetched.Body.Close()
+	var event gcalendar.Event
+	if err := json.NewDecoder(fetched.Body).Decode(&event); err != nil {
+		t.Fatalf("failed to decode fetched event: %v", err)
+	}
+	if event.Id != resp.EventId || event.Etag == "" {
+		t.Errorf("expected fetched event %s with an etag, got id %q etag %q", resp.EventId, event.Id, event.Etag)
+	}
+}
+
+func TestClient_ToleratesUnknownResponseFields(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.AddEvent("primary", &gcalendar.Event{Id: "known", Summary: "Known Event"})
+	server.InjectResponseField("notYetInvented", []string{"a", "b"})
+
+	client := newMockClient(t, server)
+	event, err := client.GetEvent(context.Background(), &proto.GetEventRequest{EventId: "known"})
+	if err != nil {
+		t.Fatalf("GetEvent() failed with unknown response field: %v", err)
+	}
+	if event.Summary != "Known Event" {
+		t.Errorf("expected summary 'Known Event', got %q", event.Summary)
+	}
+}
+
+func TestClient_FindDuplicates(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	at := func(hour int) *gcalendar.EventDateTime {
+		return &gcalendar.EventDateTime{DateTime: time.Date(2024, 3, 1, hour, 0, 0, 0, time.UTC).Format(time.RFC3339)}
+	}
+
+	// Same summary and times (one copy written in another zone)
+	server.AddEvent("primary", &gcalendar.Event{Id: "standup-1", Summary: "Standup", Start: at(9), End: at(10)})
+	server.AddEvent("primary", &gcalendar.Event{Id: "standup-2", Summary: "Standup", Start: at(9), End: at(10)})
+	server.AddEvent("primary", &gcalendar.Event{
+		Id:      "standup-3",
+		Summary: "Standup",
+		Start:   &gcalendar.EventDateTime{DateTime: "2024-03-01T10:00:00+01:00"},
+		End:     &gcalendar.EventDateTime{DateTime: "2024-03-01T11:00:00+01:00"},
+	})
+	// Same iCalUID despite a retitled copy
+	server.AddEvent("primary", &gcalendar.Event{Id: "review-1", Summary: "Review", ICalUID: "review@example.com", Start: at(13), End: at(14)})
+	server.AddEvent("primary", &gcalendar.Event{Id: "review-2", Summary: "Review (copy)", ICalUID: "review@example.com", Start: at(13), End: at(14)})
+	// Distinct events
+	server.AddEvent("primary", &gcalendar.Event{Id: "lunch", Summary: "Lunch", Start: at(12), End: at(13)})
+	server.AddEvent("primary", &gcalendar.Event{Id: "standup-late", Summary: "Standup", Start: at(15), End: at(16)})
+	// Outside the range
+	server.AddEvent("primary", &gcalendar.Event{
+		Id:      "old-1",
+		Summary: "Old",
+		Start:   &gcalendar.EventDateTime{DateTime: "2023-01-01T09:00:00Z"},
+		End:     &gcalendar.EventDateTime{DateTime: "2023-01-01T10:00:00Z"},
+	})
+	server.AddEvent("primary", &gcalendar.Event{
+		Id:      "old-2",
+		Summary: "Old",
+		Start:   &gcalendar.EventDateTime{DateTime: "2023-01-01T09:00:00Z"},
+		End:     &gcalendar.EventDateTime{DateTime: "2023-01-01T10:00:00Z"},
+	})
+
+	client := newMockClient(t, server)
+	clusters, err := client.FindDuplicates(context.Background(), "primary",
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("FindDuplicates() failed: %v", err)
+	}
+
+	var got []string
+	for _, cluster := range clusters {
+		var ids []string
+		for _, event := range cluster {
+			ids = append(ids, event.Id)
+		}
+		sort.Strings(ids)
+		got = append(got, strings.Join(ids, ","))
+	}
+	sort.Strings(got)
+
+	want := []string{"review-1,review-2", "standup-1,standup-2,standup-3"}
+	if strings.Join(got, " | ") != strings.Join(want, " | ") {
+		t.Errorf("expected clusters %v, got %v", want, got)
+	}
+}
+
+func TestClient_CreateEventUsesDefaultDuration(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	client := newMockClient(t, server)
+	client.SetDefaultEventDuration(45 * time.Minute)
+
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	event, err := client.CreateEvent(context.Background(), &proto.AddEventRequest{
+		Summary:   "Standup",
+		StartTime: timestamppb.New(start),
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() failed: %v", err)
+	}
+	if event.End.DateTime != "2024-03-01T09:45:00Z" {
+		t.Errorf("expected end 45 minutes after start, got %s", event.End.DateTime)
+	}
+}
+
+func TestClient_AuthenticatedEmail(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.SetCurrentUser("me@example.com")
+	client := newMockClient(t, server)
+
+	for i := 0; i < 2; i++ {
+		email, err := client.AuthenticatedEmail(context.Background())
+		if err != nil {
+			t.Fatalf("AuthenticatedEmail() failed: %v", err)
+		}
+		if email != "me@example.com" {
+			t.Errorf("expected me@example.com, got %q", email)
+		}
+	}
+
+	// The second call is served from the cache
+	if got := len(server.Requests()); got != 1 {
+		t.Errorf("expected 1 request to resolve the user, got %d", got)
+	}
+}
+
+func TestClient_ListEventsSurfacesDroppedConnection(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	for i := 0; i < 3; i++ {
+		server.AddEvent("primary", &gcalendar.Event{Summary: "Event"})
+	}
+	server.DropAfterItems(2)
+
+	client := newMockClient(t, server)
+	limit := int32(2)
+	var anchor string
+	respChan, errChan := client.ListEvents(context.Background(), &proto.ListEventsRequest{Limit: &limit})
+	for resp := range respChan {
+		if resp.NextAnchor != nil {
+			anchor = *resp.NextAnchor
+		}
+	}
+	if err := <-errChan; err != nil {
+		t.Fatalf("expected the first page to succeed, got %v", err)
+	}
+	if anchor == "" {
+		t.Fatal("expected a next anchor after the first page")
+	}
+
+	respChan, errChan = client.ListEvents(context.Background(), &proto.ListEventsRequest{Limit: &limit, Anchor: &anchor})
+	var received int
+	for range respChan {
+		received++
+	}
+	err := <-errChan
+	if err == nil {
+		t.Fatal("expected the dropped page to fail")
+	}
+	if !strings.Contains(err.Error(), "unable to retrieve events") {
+		t.Errorf("expected a wrapped retrieval error, got %v", err)
+	}
+	if received != 0 {
+		t.Errorf("expected no events from the dropped page, got %d", received)
+	}
+}
+
+func TestClient_ListEventsRecurringMasters(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	rule := "RRULE:FREQ=DAILY;COUNT=5"
+	server.AddEvent("primary", &gcalendar.Event{
+		Id:         "standup",
+		Summary:    "Standup",
+		Start:      &gcalendar.EventDateTime{DateTime: "2024-03-04T09:00:00Z"},
+		End:        &gcalendar.EventDateTime{DateTime: "2024-03-04T09:15:00Z"},
+		Recurrence: []string{rule},
+	})
+
+	client := newMockClient(t, server)
+	listAll := func(req *proto.ListEventsRequest) []*proto.Event {
+		t.Helper()
+		respChan, errChan := client.ListEvents(context.Background(), req)
+		var events []*proto.Event
+		for resp := range respChan {
+			if resp.Event != nil {
+				events = append(events, resp.Event)
+			}
+		}
+		if err := <-errChan; err != nil {
+			t.Fatalf("failed to list events: %v", err)
+		}
+		return events
+	}
+
+	singleEvents := false
+	after := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	masters := listAll(&proto.ListEventsRequest{SingleEvents: &singleEvents, After: timestamppb.New(after)})
+	if len(masters) != 1 {
+		t.Fatalf("expected the unexpanded master only, got %d events", len(masters))
+	}
+	if masters[0].Id != "standup" {
+		t.Errorf("expected master ID standup, got %q", masters[0].Id)
+	}
+	if got := masters[0].Recurrence; len(got) != 1 || got[0] != rule {
+		t.Errorf("expected recurrence [%s], got %v", rule, got)
+	}
+
+	last, _ := server.LastRequest()
+	if got := last.Query.Get("singleEvents"); got != "false" {
+		t.Errorf("expected singleEvents=false, got %q", got)
+	}
+	if got := last.Query.Get("orderBy"); got != "" {
+		t.Errorf("expected no orderBy when listing masters, got %q", got)
+	}
+
+	// The default still expands instances
+	instances := listAll(&proto.ListEventsRequest{})
+	if len(instances) != 5 {
+		t.Fatalf("expected 5 expanded instances by default, got %d", len(instances))
+	}
+	if len(instances[0].Recurrence) != 0 {
+		t.Errorf("expected instances to carry no recurrence, got %v", instances[0].Recurrence)
+	}
+}
+
+func TestClient_ListEventsFollowsPages(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	for i := 0; i < 10; i++ {
+		server.AddEvent("primary", &gcalendar.Event{Summary: "Event"})
+	}
+
+	client := newMockClient(t, server)
+	limit := int32(3)
+	follow := true
+	respChan, errChan := client.ListEvents(context.Background(), &proto.ListEventsRequest{
+		Limit:       &limit,
+		FollowPages: &follow,
+	})
+
+	seen := make(map[string]bool)
+	for resp := range respChan {
+		if resp.NextAnchor != nil {
+			t.Errorf("expected no next anchor when following pages, got %q", *resp.NextAnchor)
+		}
+		if resp.Event != nil {
+			seen[resp.Event.Id] = true
+		}
+	}
+	if err := <-errChan; err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(seen) != 10 {
+		t.Errorf("expected all 10 events streamed, got %d", len(seen))
+	}
+
+	// 10 events at 3 per page take 4 requests
+	if got := len(server.Requests()); got != 4 {
+		t.Errorf("expected 4 page requests, got %d", got)
+	}
+}
+
+func TestClient_ListEventsFollowPagesStopsOnCancel(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	for i := 0; i < 10; i++ {
+		server.AddEvent("primary", &gcalendar.Event{Summary: "Event"})
+	}
+
+	client := newMockClient(t, server)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	limit := int32(3)
+	follow := true
+	respChan, errChan := client.ListEvents(ctx, &proto.ListEventsRequest{
+		Limit:       &limit,
+		FollowPages: &follow,
+	})
+
+	<-respChan
+	cancel()
+	for range respChan {
+	}
+	if err := <-errChan; err == nil {
+		t.Error("expected an error after cancellation")
+	}
+	if got := len(server.Requests()); got >= 4 {
+		t.Errorf("expected paging to stop early, got %d requests", got)
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	fastRetry := calendar.WithRetry(calendar.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})
+
+	tests := []struct {
+		name         string
+		fail         func(server *googlecaltest.Server)
+		opts         []calendar.ClientOption
+		eventID      string
+		wantErr      bool
+		wantRequests int
+	}{
+		{
+			name:         "succeeds after transient failures",
+			fail:         func(server *googlecaltest.Server) { server.FailNext(2, http.StatusServiceUnavailable) },
+			opts:         []calendar.ClientOption{fastRetry},
+			eventID:      "evt",
+			wantRequests: 3,
+		},
+		{
+			name:         "retries rate limits",
+			fail:         func(server *googlecaltest.Server) { server.FailNext(2, http.StatusTooManyRequests) },
+			opts:         []calendar.ClientOption{fastRetry},
+			eventID:      "evt",
+			wantRequests: 3,
+		},
+		{
+			name: "honors Retry-After over backoff",
+			fail: func(server *googlecaltest.Server) {
+				server.FailNextWithRetryAfter(2, http.StatusTooManyRequests, 0)
+			},
+			opts:         []calendar.ClientOption{calendar.WithRetry(calendar.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour})},
+			eventID:      "evt",
+			wantRequests: 3,
+		},
+		{
+			name:         "gives up after max attempts",
+			fail:         func(server *googlecaltest.Server) { server.FailNext(5, http.StatusInternalServerError) },
+			opts:         []calendar.ClientOption{fastRetry},
+			eventID:      "evt",
+			wantErr:      true,
+			wantRequests: 3,
+		},
+		{
+			name:         "does not retry client errors",
+			fail:         func(server *googlecaltest.Server) {},
+			opts:         []calendar.ClientOption{fastRetry},
+			eventID:      "missing",
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name:         "does not retry without a policy",
+			fail:         func(server *googlecaltest.Server) { server.FailNext(2, http.StatusServiceUnavailable) },
+			eventID:      "evt",
+			wantErr:      true,
+			wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := googlecaltest.NewServer()
+			defer server.Close()
+			server.AddEvent("primary", &gcalendar.Event{Id: "evt", Summary: "Event"})
+			tt.fail(server)
+
+			client := newMockClient(t, server, tt.opts...)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			_, err := client.GetEvent(ctx, &proto.GetEventRequest{EventId: tt.eventID})
+			if tt.wantErr && err == nil {
+				t.Error("expected an error")
+			} else if !tt.wantErr && err != nil {
+				t.Errorf("expected success, got %v", err)
+			}
+			if got := len(server.Requests()); got != tt.wantRequests {
+				t.Errorf("expected %d requests, got %d", tt.wantRequests, got)
+			}
+		})
+	}
+}
+
+func TestClient_RetryStopsOnCancel(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+	server.FailNext(5, http.StatusServiceUnavailable)
+
+	client := newMockClient(t, server, calendar.WithRetry(calendar.RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour}))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := client.DeleteEvent(ctx, &proto.DeleteEventRequest{EventId: "evt"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to abort retries, got %v", err)
+	}
+	if got := len(server.Requests()); got != 1 {
+		t.Errorf("expected 1 request before cancellation, got %d", got)
+	}
+}
+
+func TestClient_CreateEventRetryDoesNotDuplicate(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	// The first insert is stored but answered with a 503, as when Google
+	// fails after committing the write
+	var failed atomic.Bool
+	server.OnRequest(func(r *http.Request) {
+		if r.Method != http.MethodPost || failed.Swap(true) {
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		var event gcalendar.Event
+		if err := json.Unmarshal(body, &event); err != nil || event.Id == "" {
+			t.Errorf("expected the insert to carry an event ID, got %s", body)
+			return
+		}
+		server.AddEvent("primary", &event)
+		server.FailNext(1, http.StatusServiceUnavailable)
+	})
+
+	client := newMockClient(t, server, calendar.WithRetry(calendar.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+	created, err := client.CreateEvent(context.Background(), &proto.AddEventRequest{
+		Summary:   "Retro",
+		StartTime: timestamppb.New(time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)),
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() failed: %v", err)
+	}
+
+	events := server.GetEvents("primary")
+	if len(events) != 1 {
+		t.Fatalf("expected the retry not to store a second copy, got %d events", len(events))
+	}
+	if created.Id != events[0].Id || created.Summary != "Retro" {
+		t.Errorf("expected the stored event %s returned, got %s %q", events[0].Id, created.Id, created.Summary)
+	}
+}
+
+func TestClient_CreateRecurringEvent(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	client := newMockClient(t, server)
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	created, err := client.CreateEvent(context.Background(), &proto.AddEventRequest{
+		Summary:    "Weekly planning",
+		StartTime:  timestamppb.New(start),
+		Recurrence: &proto.Recurrence{Rules: []string{"RRULE:FREQ=WEEKLY;COUNT=3"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create recurring event: %v", err)
+	}
+	if len(created.Recurrence) != 1 {
+		t.Errorf("expected the created event to keep its recurrence, got %v", created.Recurrence)
+	}
+
+	respChan, errChan := client.ListEvents(context.Background(), &proto.ListEventsRequest{})
+	var instances int
+	for resp := range respChan {
+		if resp.Event != nil {
+			instances++
+		}
+	}
+	if err := <-errChan; err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if instances != 3 {
+		t.Errorf("expected 3 weekly instances, got %d", instances)
+	}
+
+	// Malformed lines are rejected before reaching the API
+	_, err = client.CreateEvent(context.Background(), &proto.AddEventRequest{
+		Summary:    "Broken",
+		Recurrence: &proto.Recurrence{Rules: []string{"FREQ=WEEKLY"}},
+	})
+	if err == nil {
+		t.Error("expected an error for a recurrence line without a prefix")
+	}
+}
+
+func TestClient_CreateEventWithConference(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	client := newMockClient(t, server)
+	addConference := true
+	created, err := client.CreateEvent(context.Background(), &proto.AddEventRequest{
+		Summary:       "Design review",
+		AddConference: &addConference,
+	})
+	if err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	last, _ := server.LastRequest()
+	if got := last.Query.Get("conferenceDataVersion"); got != "1" {
+		t.Errorf("expected conferenceDataVersion=1, got %q", got)
+	}
+	createRequest, _ := last.Body["conferenceData"].(map[string]any)["createRequest"].(map[string]any)
+	if createRequest["requestId"] == "" || createRequest["conferenceSolutionKey"].(map[string]any)["type"] != "hangoutsMeet" {
+		t.Errorf("expected a hangoutsMeet create request with an ID, got %v", createRequest)
+	}
+
+	protoEvent := calendar.MapEventToProto(created, "primary")
+	if !strings.HasPrefix(protoEvent.GetConferenceUri(), "https://meet.google.com/") {
+		t.Errorf("expected a Meet link, got %q", protoEvent.GetConferenceUri())
+	}
+	if protoEvent.GetConferenceId() == "" {
+		t.Error("expected a conference ID")
+	}
+
+	// Without the flag, no conference is requested
+	plain, err := client.CreateEvent(context.Background(), &proto.AddEventRequest{Summary: "Lunch"})
+	if err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+	if plain.ConferenceData != nil {
+		t.Errorf("expected no conference data, got %+v", plain.ConferenceData)
+	}
+}
+
+func TestClient_CreateEventWithAttachments(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	client := newMockClient(t, server)
+	created, err := client.CreateEvent(context.Background(), &proto.AddEventRequest{
+		Summary: "Planning",
+		Attachments: &proto.AttachmentList{Attachments: []*proto.Attachment{{
+			FileUrl: "https://drive.google.com/open?id=1a2b3c",
+			Title:   ptr("Agenda"),
+		}}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	last, _ := server.LastRequest()
+	if got := last.Query.Get("supportsAttachments"); got != "true" {
+		t.Errorf("expected supportsAttachments=true, got %q", got)
+	}
+
+	protoEvent := calendar.MapEventToProto(created, "primary")
+	if len(protoEvent.Attachments) != 1 || protoEvent.Attachments[0].GetTitle() != "Agenda" {
+		t.Errorf("expected the attachment to be echoed back, got %v", protoEvent.Attachments)
+	}
+
+	if _, err := client.UpdateEvent(context.Background(), &proto.UpdateEventRequest{
+		EventId: created.Id,
+		Summary: ptr("Planning (moved)"),
+	}); err != nil {
+		t.Fatalf("failed to update event: %v", err)
+	}
+	last, _ = server.LastRequest()
+	if got := last.Query.Get("supportsAttachments"); got != "true" {
+		t.Errorf("expected supportsAttachments=true on update, got %q", got)
+	}
+	if events := server.GetEvents("primary"); len(events) != 1 || len(events[0].Attachments) != 1 {
+		t.Errorf("expected the attachment to survive an unrelated update, got %+v", events)
+	}
+}
+
+func TestClient_SendUpdates(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := newMockClient(t, server)
+
+	created, err := client.CreateEvent(ctx, &proto.AddEventRequest{
+		Summary:     "Offsite",
+		Attendees:   &proto.AttendeeList{Attendees: []*proto.Attendee{{Email: "guest@example.com"}}},
+		SendUpdates: ptr("all"),
+	})
+	if err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+	if last, _ := server.LastRequest(); last.Query.Get("sendUpdates") != "all" {
+		t.Errorf("expected sendUpdates=all on insert, got %q", last.Query.Get("sendUpdates"))
+	}
+
+	if _, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{
+		EventId:     created.Id,
+		Summary:     ptr("Offsite (moved)"),
+		SendUpdates: ptr("externalOnly"),
+	}); err != nil {
+		t.Fatalf("failed to update event: %v", err)
+	}
+	if last, _ := server.LastRequest(); last.Query.Get("sendUpdates") != "externalOnly" {
+		t.Errorf("expected sendUpdates=externalOnly on update, got %q", last.Query.Get("sendUpdates"))
+	}
+
+	if err := client.DeleteEvent(ctx, &proto.DeleteEventRequest{EventId: created.Id, SendUpdates: ptr("all")}); err != nil {
+		t.Fatalf("failed to delete event: %v", err)
+	}
+	if last, _ := server.LastRequest(); last.Query.Get("sendUpdates") != "all" {
+		t.Errorf("expected sendUpdates=all on delete, got %q", last.Query.Get("sendUpdates"))
+	}
+
+	// Unset sends nothing, leaving Google's default (none)
+	if _, err := client.CreateEvent(ctx, &proto.AddEventRequest{Summary: "Quiet"}); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+	if last, _ := server.LastRequest(); last.Query.Has("sendUpdates") {
+		t.Errorf("expected no sendUpdates parameter, got %q", last.Query.Get("sendUpdates"))
+	}
+
+	// Invalid values are rejected before reaching the API
+	requests := len(server.Requests())
+	if _, err := client.CreateEvent(ctx, &proto.AddEventRequest{Summary: "Loud", SendUpdates: ptr("everyone")}); err == nil ||
+		!strings.Contains(err.Error(), "invalid send_updates") {
+		t.Errorf("expected an invalid send_updates error, got %v", err)
+	}
+	if got := len(server.Requests()); got != requests {
+		t.Errorf("expected no request for an invalid send_updates, got %d", got-requests)
+	}
+}
+
+func TestQuickAdd_CLI(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc := &calendarService{calendarClient: newMockClient(t, server)}
+
+	output := filepath.Join(t.TempDir(), "event.json")
+	root := &cli.Command{
+		Name:     "cali",
+		Commands: proto.CalendarServiceCommandsFlat(ctx, svc, protocli.WithOutputFormats(protocli.JSON())),
+	}
+	if err := root.Run(ctx, []string{"cali", "quick-add",
+		"--text", "Lunch tomorrow at noon", "--calendar-id", "team@example.com", "--output", output}); err != nil {
+		t.Fatalf("quick-add failed: %v", err)
+	}
+
+	last, _ := server.LastRequest()
+	if last.Method != http.MethodPost || !strings.HasSuffix(last.Path, "/calendars/team@example.com/events/quickAdd") {
+		t.Errorf("expected POST to events/quickAdd, got %s %s", last.Method, last.Path)
+	}
+	if got := last.Query.Get("text"); got != "Lunch tomorrow at noon" {
+		t.Errorf("expected text to be forwarded, got %q", got)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	var resp proto.QuickAddResponse
+	if err := protojson.Unmarshal(data, &resp); err != nil {
+		t.Fatalf("failed to decode output %q: %v", data, err)
+	}
+	if resp.GetEvent().GetSummary() != "Lunch tomorrow at noon" || resp.GetEvent().GetCalendarId() != "team@example.com" {
+		t.Errorf("unexpected event: %v", resp.GetEvent())
+	}
+	if events := server.GetEvents("team@example.com"); len(events) != 1 || events[0].Id != resp.GetEvent().GetId() {
+		t.Errorf("expected the quick-added event to be stored, got %+v", events)
+	}
+
+	// Blank text is rejected before reaching the API
+	requests := len(server.Requests())
+	if _, err := svc.QuickAdd(ctx, &proto.QuickAddRequest{Text: "  "}); err == nil {
+		t.Error("expected an error for blank text")
+	}
+	if got := len(server.Requests()); got != requests {
+		t.Errorf("expected no request for blank text, got %d", got-requests)
+	}
+}
+
+func TestClient_ApplicationName(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		opts []calendar.ClientOption
+		want string
+	}{
+		{name: "default", want: "cali/"},
+		{name: "custom", opts: []calendar.ClientOption{calendar.WithApplicationName("cali-sync/1.2")}, want: "cali-sync/1.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newMockClient(t, server, tt.opts...)
+			if _, err := client.ListCalendars(ctx, &proto.ListCalendarsRequest{}); err != nil {
+				t.Fatalf("ListCalendars() failed: %v", err)
+			}
+			last, _ := server.LastRequest()
+			if ua := last.Header.Get("User-Agent"); !strings.Contains(ua, tt.want) {
+				t.Errorf("User-Agent = %q, want it to contain %q", ua, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListCalendars(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.SetCurrentUser("me@example.com")
+	server.AddCalendar(&gcalendar.CalendarListEntry{Id: "team@group.calendar.google.com", Summary: "Team", AccessRole: "writer"})
+	server.AddCalendar(&gcalendar.CalendarListEntry{Id: "holidays@group.v.calendar.google.com", Summary: "Holidays", AccessRole: "reader"})
+
+	client := newMockClient(t, server)
+	entries, err := client.ListCalendars(context.Background(), &proto.ListCalendarsRequest{})
+	if err != nil {
+		t.Fatalf("ListCalendars() failed: %v", err)
+	}
+
+	got := make(map[string]*proto.Calendar)
+	for _, entry := range entries {
+		cal := calendar.MapCalendarListEntryToProto(entry)
+		got[cal.Id] = cal
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected the primary and both added calendars, got %v", got)
+	}
+	if cal := got["me@example.com"]; cal == nil || !cal.Primary || cal.AccessRole != "owner" {
+		t.Errorf("unexpected primary calendar: %v", cal)
+	}
+	if cal := got["team@group.calendar.google.com"]; cal == nil || cal.Summary != "Team" || cal.AccessRole != "writer" || cal.Primary {
+		t.Errorf("unexpected team calendar: %v", cal)
+	}
+	if cal := got["holidays@group.v.calendar.google.com"]; cal == nil || cal.Summary != "Holidays" || cal.AccessRole != "reader" {
+		t.Errorf("unexpected holidays calendar: %v", cal)
+	}
+
+	// min_access_role is forwarded, and filters out read-only calendars
+	writable, err := client.ListCalendars(context.Background(), &proto.ListCalendarsRequest{MinAccessRole: ptr("writer")})
+	if err != nil {
+		t.Fatalf("ListCalendars() failed: %v", err)
+	}
+	if len(writable) != 2 {
+		t.Errorf("expected 2 writable calendars, got %d", len(writable))
+	}
+	if last, _ := server.LastRequest(); last.Query.Get("minAccessRole") != "writer" {
+		t.Errorf("expected minAccessRole=writer, got %q", last.Query.Get("minAccessRole"))
+	}
+
+	if _, err := client.ListCalendars(context.Background(), &proto.ListCalendarsRequest{MinAccessRole: ptr("admin")}); err == nil ||
+		!strings.Contains(err.Error(), "invalid min_access_role") {
+		t.Errorf("expected an invalid min_access_role error, got %v", err)
+	}
+}
+
+func TestPing(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc := &calendarService{calendarClient: newMockClient(t, server)}
+
+	resp, err := svc.Ping(ctx, &proto.PingRequest{})
+	if err != nil {
+		t.Fatalf("Ping() failed: %v", err)
+	}
+	if !resp.Success {
+		t.Errorf("expected a healthy response, got %v", resp)
+	}
+	if resp.AuthMode != "adc" {
+		t.Errorf("AuthMode = %q, want adc", resp.AuthMode)
+	}
+	if !strings.HasPrefix(resp.Endpoint, server.URL) {
+		t.Errorf("Endpoint = %q, want the mock's %q", resp.Endpoint, server.URL)
+	}
+
+	// One small read of the calendar list, and nothing written
+	last, _ := server.LastRequest()
+	if last.Method != http.MethodGet || !strings.HasSuffix(last.Path, "/users/me/calendarList") || last.Query.Get("maxResults") != "1" {
+		t.Errorf("expected a one-entry calendar list read, got %s %s?%s", last.Method, last.Path, last.Query.Encode())
+	}
+	if got := len(server.GetEvents("primary")); got != 0 {
+		t.Errorf("expected no events created, got %d", got)
+	}
+
+	// Rejected credentials fail the check
+	server.RequireAuth("secret")
+	resp, err = svc.Ping(ctx, &proto.PingRequest{})
+	if err == nil || resp.Success {
+		t.Errorf("expected rejected credentials to fail, got %v (err: %v)", resp, err)
+	}
+}
+
+func TestClient_FreeBusy(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	at := func(hour, minute int) string {
+		return time.Date(2024, 3, 4, hour, minute, 0, 0, time.UTC).Format(time.RFC3339)
+	}
+	// Overlapping opaque meetings merge into one block
+	server.AddEvent("primary", &gcalendar.Event{Id: "standup", Start: &gcalendar.EventDateTime{DateTime: at(9, 0)}, End: &gcalendar.EventDateTime{DateTime: at(10, 0)}})
+	server.AddEvent("primary", &gcalendar.Event{Id: "review", Start: &gcalendar.EventDateTime{DateTime: at(9, 30)}, End: &gcalendar.EventDateTime{DateTime: at(11, 0)}})
+	// Transparent events don't block time
+	server.AddEvent("primary", &gcalendar.Event{Id: "reminder", Transparency: "transparent", Start: &gcalendar.EventDateTime{DateTime: at(12, 0)}, End: &gcalendar.EventDateTime{DateTime: at(13, 0)}})
+	server.AddEvent("primary", &gcalendar.Event{Id: "lunch", Start: &gcalendar.EventDateTime{DateTime: at(13, 0)}, End: &gcalendar.EventDateTime{DateTime: at(14, 0)}})
+	server.AddEvent("team@group.calendar.google.com", &gcalendar.Event{Id: "offsite", Start: &gcalendar.EventDateTime{DateTime: at(15, 0)}, End: &gcalendar.EventDateTime{DateTime: at(18, 0)}})
+
+	client := newMockClient(t, server)
+	calendars, err := client.FreeBusy(context.Background(), &proto.FreeBusyRequest{
+		After:    timestamppb.New(time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC)),
+		Before:   timestamppb.New(time.Date(2024, 3, 4, 17, 0, 0, 0, time.UTC)),
+		Calendar: &proto.CalendarIdList{Ids: []string{"primary", "team@group.calendar.google.com", "missing@example.com"}},
+	})
+	if err != nil {
+		t.Fatalf("FreeBusy() failed: %v", err)
+	}
+	if len(calendars) != 3 {
+		t.Fatalf("expected 3 calendars, got %d", len(calendars))
+	}
+
+	ranges := func(cal *proto.FreeBusyCalendar) []string {
+		var out []string
+		for _, busy := range cal.Busy {
+			out = append(out, busy.Start.AsTime().Format("15:04")+"-"+busy.End.AsTime().Format("15:04"))
+		}
+		return out
+	}
+	if got, want := ranges(calendars[0]), []string{"09:00-11:00", "13:00-14:00"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected primary busy %v, got %v", want, got)
+	}
+	// Blocks are clipped to the query window
+	if got, want := ranges(calendars[1]), []string{"15:00-17:00"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected team busy %v, got %v", want, got)
+	}
+	if calendars[2].CalendarId != "missing@example.com" || len(calendars[2].Errors) != 1 || calendars[2].Errors[0] != "notFound" {
+		t.Errorf("expected a notFound error for the unknown calendar, got %v", calendars[2])
+	}
+
+	if _, err := client.FreeBusy(context.Background(), &proto.FreeBusyRequest{
+		After: timestamppb.New(time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC)),
+	}); err == nil {
+		t.Error("expected an error without before")
+	}
+}
+
+// inFlightTransport records the most requests it has seen in flight at once
+type inFlightTransport struct {
+	mu       sync.Mutex
+	current  int
+	peak     int
+	delegate http.RoundTripper
+}
+
+func (t *inFlightTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.current++
+	t.peak = max(t.peak, t.current)
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		t.current--
+		t.mu.Unlock()
+	}()
+
+	// Hold the slot briefly so concurrent requests overlap
+	time.Sleep(5 * time.Millisecond)
+	return t.delegate.RoundTrip(req)
+}
+
+func TestClient_CreateEvents(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	transport := &inFlightTransport{delegate: http.DefaultTransport}
+	client, err := calendar.NewClient(context.Background(), &http.Client{Transport: transport}, calendar.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar client: %v", err)
+	}
+
+	var reqs []*proto.AddEventRequest
+	for i := range 20 {
+		reqs = append(reqs, &proto.AddEventRequest{Summary: fmt.Sprintf("Imported %d", i)})
+	}
+	results := client.CreateEvents(context.Background(), reqs, calendar.BulkOptions{Concurrency: 5})
+
+	if len(results) != len(reqs) {
+		t.Fatalf("expected %d results, got %d", len(reqs), len(results))
+	}
+	ids := make(map[string]bool)
+	for i, result := range results {
+		if result.Err != nil || result.Skipped || result.Event == nil {
+			t.Fatalf("result %d failed: %+v", i, result)
+		}
+		if result.Index != i || result.Event.Summary != reqs[i].Summary {
+			t.Errorf("result %d out of order: index %d, summary %q", i, result.Index, result.Event.Summary)
+		}
+		ids[result.Event.Id] = true
+	}
+	if len(ids) != len(reqs) {
+		t.Errorf("expected %d distinct IDs, got %d", len(reqs), len(ids))
+	}
+	if got := len(server.GetEvents("primary")); got != len(reqs) {
+		t.Errorf("expected %d stored events, got %d", len(reqs), got)
+	}
+	if transport.peak > 5 {
+		t.Errorf("expected at most 5 requests in flight, saw %d", transport.peak)
+	}
+}
+
+func TestClient_CreateEvents_Cancelled(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	reqs := []*proto.AddEventRequest{{Summary: "One"}, {Summary: "Two"}}
+	results := newMockClient(t, server).CreateEvents(ctx, reqs, calendar.BulkOptions{})
+	for i, result := range results {
+		if !result.Skipped || result.Event != nil {
+			t.Errorf("expected result %d to be skipped, got %+v", i, result)
+		}
+	}
+	if got := len(server.Requests()); got != 0 {
+		t.Errorf("expected no requests after cancellation, got %d", got)
+	}
+}
+
+func TestClient_DefaultTimeout(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.AddEvent("primary", &gcalendar.Event{Id: "slow", Summary: "Slow Event"})
+	server.SetLatency(time.Second)
+
+	client := newMockClient(t, server, calendar.WithDefaultTimeout(50*time.Millisecond))
+
+	start := time.Now()
+	_, err := client.GetEvent(context.Background(), &proto.GetEventRequest{EventId: "slow"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline exceeded error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "unable to get event") {
+		t.Errorf("expected a descriptive error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the call to time out promptly, took %v", elapsed)
+	}
+
+	// ListEvents's goroutine gives up too
+	events, errs := client.ListEvents(context.Background(), &proto.ListEventsRequest{})
+	for range events {
+		t.Error("expected no events from a timed-out list")
+	}
+	if err := <-errs; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected ListEvents to time out, got %v", err)
+	}
+
+	// A caller's own deadline takes precedence over the default
+	server.SetLatency(100 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := client.GetEvent(ctx, &proto.GetEventRequest{EventId: "slow"}); err != nil {
+		t.Errorf("expected the caller's deadline to apply, got %v", err)
+	}
+}
+
+// hookTransport calls afterGet once each GET request has been answered
+type hookTransport struct {
+	afterGet func()
+}
+
+func (t *hookTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err == nil && req.Method == http.MethodGet && t.afterGet != nil {
+		t.afterGet()
+	}
+	return resp, err
+}
+
+// countingTransport counts the requests it sends
+type countingTransport struct {
+	count atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.count.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClient_WithTransport(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.RequireAuth("secret")
+	server.AddEvent("primary", &gcalendar.Event{Id: "standup", Summary: "Standup"})
+
+	// The custom transport goes under the OAuth one, so requests still carry
+	// the token
+	ctx := context.Background()
+	transport := &countingTransport{}
+	authed := &http.Client{Transport: &oauth2.Transport{Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "secret"})}}
+	client, err := calendar.NewClient(ctx, authed, calendar.WithEndpoint(server.URL), calendar.WithTransport(transport))
+	if err != nil {
+		t.Fatalf("failed to create calendar client: %v", err)
+	}
+
+	if _, err := client.GetEvent(ctx, &proto.GetEventRequest{EventId: "standup"}); err != nil {
+		t.Fatalf("GetEvent() failed: %v", err)
+	}
+	if _, err := client.CreateEvent(ctx, &proto.AddEventRequest{
+		Summary:   "Review",
+		StartTime: timestamppb.New(time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)),
+		EndTime:   timestamppb.New(time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC)),
+	}); err != nil {
+		t.Fatalf("CreateEvent() failed: %v", err)
+	}
+	if _, err := client.ListCalendars(ctx, &proto.ListCalendarsRequest{}); err != nil {
+		t.Fatalf("ListCalendars() failed: %v", err)
+	}
+
+	if got, want := int(transport.count.Load()), len(server.Requests()); got != want || got < 3 {
+		t.Errorf("transport sent %d requests, server received %d", got, want)
+	}
+	if server.LastAuthToken() != "secret" {
+		t.Errorf("expected requests to keep the OAuth token, got %q", server.LastAuthToken())
+	}
+}
+
+func TestClient_UpdateEvent_ConcurrentModification(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.AddEvent("primary", &gcalendar.Event{Id: "shared", Summary: "Original"})
+
+	// Someone else edits the event between UpdateEvent's read and its write
+	transport := &hookTransport{afterGet: func() {
+		server.AddEvent("primary", &gcalendar.Event{Id: "shared", Summary: "Edited elsewhere"})
+	}}
+	client, err := calendar.NewClient(context.Background(), &http.Client{Transport: transport}, calendar.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar client: %v", err)
+	}
+
+	_, err = client.UpdateEvent(context.Background(), &proto.UpdateEventRequest{EventId: "shared", Summary: ptr("Mine")})
+	if !errors.Is(err, calendar.ErrConcurrentModification) {
+		t.Fatalf("expected ErrConcurrentModification, got %v", err)
+	}
+	last, _ := server.LastRequest()
+	if last.Method != http.MethodPatch {
+		t.Errorf("expected the rejected request to be the update, got %s", last.Method)
+	}
+	if events := server.GetEvents("primary"); len(events) != 1 || events[0].Summary != "Edited elsewhere" {
+		t.Errorf("expected the other edit to survive, got %+v", events)
+	}
+
+	// Without interference the update goes through
+	transport.afterGet = nil
+	updated, err := client.UpdateEvent(context.Background(), &proto.UpdateEventRequest{EventId: "shared", Summary: ptr("Mine")})
+	if err != nil {
+		t.Fatalf("UpdateEvent() failed: %v", err)
+	}
+	if updated.Summary != "Mine" {
+		t.Errorf("expected summary %q, got %q", "Mine", updated.Summary)
+	}
+}
+
+func TestClient_UpdateEvent_ClearsDescription(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.AddEvent("primary", &gcalendar.Event{Id: "e1", Summary: "Planning", Description: "Agenda", Location: "Room 1"})
+
+	client := newMockClient(t, server)
+	if _, err := client.UpdateEvent(context.Background(), &proto.UpdateEventRequest{EventId: "e1", Description: ptr("")}); err != nil {
+		t.Fatalf("UpdateEvent() failed: %v", err)
+	}
+
+	events := server.GetEvents("primary")
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].Description != "" {
+		t.Errorf("expected description to be cleared, got %q", events[0].Description)
+	}
+	if events[0].Summary != "Planning" || events[0].Location != "Room 1" {
+		t.Errorf("expected unset fields to be preserved, got summary %q, location %q", events[0].Summary, events[0].Location)
+	}
+}
+
+func TestClient_UpdateEvent_PatchesOnlyChangedFields(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.AddEvent("primary", &gcalendar.Event{
+		Id:          "e1",
+		Summary:     "Planning",
+		Description: "Agenda",
+		ColorId:     "5",
+		Reminders: &gcalendar.EventReminders{
+			Overrides: []*gcalendar.EventReminder{{Method: "popup", Minutes: 10}},
+		},
+	})
+
+	client := newMockClient(t, server)
+	if _, err := client.UpdateEvent(context.Background(), &proto.UpdateEventRequest{EventId: "e1", Summary: ptr("Roadmap")}); err != nil {
+		t.Fatalf("UpdateEvent() failed: %v", err)
+	}
+
+	last, _ := server.LastRequest()
+	if last.Method != http.MethodPatch {
+		t.Errorf("expected a PATCH, got %s", last.Method)
+	}
+	if len(last.Body) != 1 || last.Body["summary"] != "Roadmap" {
+		t.Errorf("expected only the summary to be sent, got %v", last.Body)
+	}
+
+	event := server.GetEvents("primary")[0]
+	if event.Summary != "Roadmap" {
+		t.Errorf("expected summary %q, got %q", "Roadmap", event.Summary)
+	}
+	if event.Description != "Agenda" || event.ColorId != "5" {
+		t.Errorf("expected unrelated fields to be kept, got description %q, colorId %q", event.Description, event.ColorId)
+	}
+	if event.Reminders == nil || len(event.Reminders.Overrides) != 1 || event.Reminders.Overrides[0].Minutes != 10 {
+		t.Errorf("expected reminders to be kept, got %+v", event.Reminders)
+	}
+}
+
+func TestClient_UpdateEvent_ClearsReminderOverrides(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.AddEvent("primary", &gcalendar.Event{
+		Id:      "e1",
+		Summary: "Planning",
+		Reminders: &gcalendar.EventReminders{
+			Overrides: []*gcalendar.EventReminder{{Method: "popup", Minutes: 10}},
+		},
+	})
+
+	client := newMockClient(t, server)
+	if _, err := client.UpdateEvent(context.Background(), &proto.UpdateEventRequest{EventId: "e1", Reminders: &proto.ReminderList{}}); err != nil {
+		t.Fatalf("UpdateEvent() failed: %v", err)
+	}
+
+	last, _ := server.LastRequest()
+	reminders, _ := last.Body["reminders"].(map[string]any)
+	if overrides, ok := reminders["overrides"]; !ok || overrides != nil {
+		t.Errorf("expected overrides sent as null, got %v", last.Body["reminders"])
+	}
+
+	event := server.GetEvents("primary")[0]
+	if event.Reminders == nil || !event.Reminders.UseDefault || len(event.Reminders.Overrides) != 0 {
+		t.Errorf("expected the calendar's default reminders only, got %+v", event.Reminders)
+	}
+}
+
+func TestClient_UpdateEvent_ClearsSource(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.AddEvent("primary", &gcalendar.Event{
+		Id:      "e1",
+		Summary: "Planning",
+		Source:  &gcalendar.EventSource{Title: "Tracker", Url: "https://tracker.example.com/1"},
+	})
+	server.AddEvent("primary", &gcalendar.Event{
+		Id:      "e2",
+		Summary: "Review",
+		Source:  &gcalendar.EventSource{Title: "Tracker", Url: "https://tracker.example.com/2"},
+	})
+
+	client := newMockClient(t, server)
+	ctx := context.Background()
+	if _, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{EventId: "e1", SourceTitle: ptr("")}); err != nil {
+		t.Fatalf("UpdateEvent() failed: %v", err)
+	}
+	if _, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{EventId: "e2", SourceTitle: ptr(""), SourceUrl: ptr("")}); err != nil {
+		t.Fatalf("UpdateEvent() failed: %v", err)
+	}
+
+	for _, event := range server.GetEvents("primary") {
+		switch event.Id {
+		case "e1":
+			if event.Source == nil || event.Source.Title != "" || event.Source.Url != "https://tracker.example.com/1" {
+				t.Errorf("expected only the source title cleared, got %+v", event.Source)
+			}
+		case "e2":
+			if event.Source != nil {
+				t.Errorf("expected the source removed, got %+v", event.Source)
+			}
+		}
+	}
+}
+
+func TestClient_UpdateEvent_AllDay(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.AddEvent("primary", &gcalendar.Event{
+		Id:      "holiday",
+		Summary: "Holiday",
+		Start:   &gcalendar.EventDateTime{Date: "2024-12-25"},
+		End:     &gcalendar.EventDateTime{Date: "2024-12-26"},
+	})
+	server.AddEvent("primary", &gcalendar.Event{
+		Id:      "meeting",
+		Summary: "Meeting",
+		Start:   &gcalendar.EventDateTime{DateTime: "2024-12-20T09:00:00Z", TimeZone: "UTC"},
+		End:     &gcalendar.EventDateTime{DateTime: "2024-12-20T10:00:00Z", TimeZone: "UTC"},
+	})
+
+	client := newMockClient(t, server)
+	ctx := context.Background()
+	stored := func(id string) *gcalendar.Event {
+		for _, event := range server.GetEvents("primary") {
+			if event.Id == id {
+				return event
+			}
+		}
+		t.Fatalf("event %s not found", id)
+		return nil
+	}
+
+	t.Run("new start keeps the event all-day", func(t *testing.T) {
+		_, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{
+			EventId:   "holiday",
+			StartTime: timestamppb.New(time.Date(2024, 12, 31, 9, 0, 0, 0, time.UTC)),
+		})
+		if err != nil {
+			t.Fatalf("UpdateEvent() failed: %v", err)
+		}
+		event := stored("holiday")
+		if event.Start.Date != "2024-12-31" || event.End.Date != "2025-01-01" {
+			t.Errorf("expected 2024-12-31 to 2025-01-01, got %s to %s", event.Start.Date, event.End.Date)
+		}
+		if event.Start.DateTime != "" || event.End.DateTime != "" {
+			t.Errorf("expected dates only, got start %+v and end %+v", event.Start, event.End)
+		}
+	})
+
+	t.Run("all_day switches a timed event to dates", func(t *testing.T) {
+		allDay := true
+		if _, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{EventId: "meeting", AllDay: &allDay}); err != nil {
+			t.Fatalf("UpdateEvent() failed: %v", err)
+		}
+		event := stored("meeting")
+		if event.Start.Date != "2024-12-20" || event.End.Date != "2024-12-21" {
+			t.Errorf("expected 2024-12-20 to 2024-12-21, got %s to %s", event.Start.Date, event.End.Date)
+		}
+		if event.Start.DateTime != "" || event.End.DateTime != "" {
+			t.Errorf("expected dates only, got start %+v and end %+v", event.Start, event.End)
+		}
+	})
+
+	t.Run("switching to timed needs both times", func(t *testing.T) {
+		timed := false
+		_, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{
+			EventId:   "holiday",
+			AllDay:    &timed,
+			StartTime: timestamppb.New(time.Date(2024, 12, 31, 9, 0, 0, 0, time.UTC)),
+		})
+		if err == nil || !strings.Contains(err.Error(), "start and end must both be dates") {
+			t.Errorf("expected a mixed times error, got %v", err)
+		}
+
+		_, err = client.UpdateEvent(ctx, &proto.UpdateEventRequest{
+			EventId:   "holiday",
+			AllDay:    &timed,
+			StartTime: timestamppb.New(time.Date(2024, 12, 31, 9, 0, 0, 0, time.UTC)),
+			EndTime:   timestamppb.New(time.Date(2024, 12, 31, 10, 0, 0, 0, time.UTC)),
+		})
+		if err != nil {
+			t.Fatalf("UpdateEvent() failed: %v", err)
+		}
+		event := stored("holiday")
+		if event.Start.Date != "" || event.Start.DateTime != "2024-12-31T09:00:00Z" || event.End.DateTime != "2024-12-31T10:00:00Z" {
+			t.Errorf("expected a timed event, got start %+v and end %+v", event.Start, event.End)
+		}
+	})
+}
+
+func TestClient_UpdateEvent_EditScope(t *testing.T) {
+	ctx := context.Background()
+
+	// setup stores a daily standup, five instances from July 1st at 9:00 UTC
+	setup := func(t *testing.T) (*googlecaltest.Server, *calendar.Client) {
+		server := googlecaltest.NewServer()
+		t.Cleanup(server.Close)
+		server.AddEvent("primary", &gcalendar.Event{
+			Id:         "standup",
+			Summary:    "Standup",
+			Start:      &gcalendar.EventDateTime{DateTime: "2024-07-01T09:00:00Z", TimeZone: "UTC"},
+			End:        &gcalendar.EventDateTime{DateTime: "2024-07-01T09:30:00Z", TimeZone: "UTC"},
+			Recurrence: []string{"RRULE:FREQ=DAILY;COUNT=5"},
+		})
+		return server, newMockClient(t, server)
+	}
+	// summaries lists the series' instances as "start summary" lines
+	summaries := func(t *testing.T, client *calendar.Client) []string {
+		t.Helper()
+		var got []string
+		for event, err := range client.ListEventsSeq(ctx, &proto.ListEventsRequest{}) {
+			if err != nil {
+				t.Fatalf("ListEventsSeq() failed: %v", err)
+			}
+			got = append(got, event.StartTime.AsTime().Format("01-02 15:04")+" "+event.Summary)
+		}
+		sort.Strings(got)
+		return got
+	}
+
+	t.Run("single", func(t *testing.T) {
+		server, client := setup(t)
+		updated, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{
+			EventId:   "standup_20240703T090000Z",
+			Summary:   ptr("Demo day"),
+			EditScope: proto.EditScope_EDIT_SCOPE_SINGLE,
+		})
+		if err != nil {
+			t.Fatalf("UpdateEvent() failed: %v", err)
+		}
+		if updated.Id != "standup_20240703T090000Z" || updated.RecurringEventId != "standup" {
+			t.Errorf("expected the instance updated, got %s (series %q)", updated.Id, updated.RecurringEventId)
+		}
+		want := []string{"07-01 09:00 Standup", "07-02 09:00 Standup", "07-03 09:00 Demo day", "07-04 09:00 Standup", "07-05 09:00 Standup"}
+		if got := summaries(t, client); strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("instances = %q, want %q", got, want)
+		}
+
+		// The series itself can't be edited as a single instance
+		requests := len(server.Requests())
+		if _, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{EventId: "standup", Summary: ptr("x"), EditScope: proto.EditScope_EDIT_SCOPE_SINGLE}); err == nil {
+			t.Error("expected an error editing the series with edit_scope single")
+		}
+		if len(server.Requests()) != requests+1 {
+			t.Error("expected nothing written after reading the series")
+		}
+	})
+
+	t.Run("all", func(t *testing.T) {
+		server, client := setup(t)
+		// Moving the third instance an hour later moves the whole series
+		updated, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{
+			EventId:   "standup_20240703T090000Z",
+			Summary:   ptr("Daily sync"),
+			StartTime: timestamppb.New(time.Date(2024, 7, 3, 10, 0, 0, 0, time.UTC)),
+			EndTime:   timestamppb.New(time.Date(2024, 7, 3, 10, 30, 0, 0, time.UTC)),
+			EditScope: proto.EditScope_EDIT_SCOPE_ALL,
+		})
+		if err != nil {
+			t.Fatalf("UpdateEvent() failed: %v", err)
+		}
+		if updated.Id != "standup" {
+			t.Errorf("expected the series updated, got %s", updated.Id)
+		}
+		want := []string{"07-01 10:00 Daily sync", "07-02 10:00 Daily sync", "07-03 10:00 Daily sync", "07-04 10:00 Daily sync", "07-05 10:00 Daily sync"}
+		if got := summaries(t, client); strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("instances = %q, want %q", got, want)
+		}
+		if events := server.GetEvents("primary"); len(events) != 1 {
+			t.Errorf("expected only the series stored, got %d events", len(events))
+		}
+	})
+
+	t.Run("following", func(t *testing.T) {
+		server, client := setup(t)
+		created, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{
+			EventId:   "standup_20240704T090000Z",
+			Summary:   ptr("Async standup"),
+			EditScope: proto.EditScope_EDIT_SCOPE_FOLLOWING,
+		})
+		if err != nil {
+			t.Fatalf("UpdateEvent() failed: %v", err)
+		}
+		if created.Id == "standup" || strings.Join(created.Recurrence, ",") != "RRULE:FREQ=DAILY;COUNT=2" {
+			t.Errorf("expected a new series of the last two instances, got %s with %q", created.Id, created.Recurrence)
+		}
+		want := []string{"07-01 09:00 Standup", "07-02 09:00 Standup", "07-03 09:00 Standup", "07-04 09:00 Async standup", "07-05 09:00 Async standup"}
+		if got := summaries(t, client); strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("instances = %q, want %q", got, want)
+		}
+		for _, event := range server.GetEvents("primary") {
+			if event.Id == "standup" && strings.Join(event.Recurrence, ",") != "RRULE:FREQ=DAILY;UNTIL=20240704T085959Z" {
+				t.Errorf("expected the original series ended before the split, got %q", event.Recurrence)
+			}
+		}
+	})
+
+	t.Run("following insert fails", func(t *testing.T) {
+		server, client := setup(t)
+		// Every insert of the continuing series fails
+		server.OnRequest(func(r *http.Request) {
+			if r.Method == http.MethodPost {
+				server.FailNext(1, http.StatusServiceUnavailable)
+			}
+		})
+		if _, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{
+			EventId:   "standup_20240704T090000Z",
+			Summary:   ptr("Async standup"),
+			EditScope: proto.EditScope_EDIT_SCOPE_FOLLOWING,
+		}); err == nil {
+			t.Fatal("expected an error when the new series can't be created")
+		}
+		server.OnRequest(nil)
+
+		want := []string{"07-01 09:00 Standup", "07-02 09:00 Standup", "07-03 09:00 Standup", "07-04 09:00 Standup", "07-05 09:00 Standup"}
+		if got := summaries(t, client); strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("instances = %q, want the original series intact %q", got, want)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		server, client := setup(t)
+		if _, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{EventId: "standup", EditScope: proto.EditScope(7)}); err == nil {
+			t.Error("expected an error for an unknown edit scope")
+		}
+		if len(server.Requests()) != 0 {
+			t.Error("expected an unknown edit scope to be rejected before any request")
+		}
+	})
+}
+
+func TestClient_UpdateEventFull(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	start := time.Date(2024, 9, 2, 14, 0, 0, 0, time.UTC)
+	server.AddEvent("team@example.com", &gcalendar.Event{
+		Id:          "sync",
+		Summary:     "Weekly sync",
+		Description: "Old agenda",
+		Start:       &gcalendar.EventDateTime{DateTime: start.Format(time.RFC3339)},
+		End:         &gcalendar.EventDateTime{DateTime: start.Add(time.Hour).Format(time.RFC3339)},
+		Attendees:   []*gcalendar.EventAttendee{{Email: "ann@example.com"}},
+	})
+	server.AddEvent("team@example.com", &gcalendar.Event{
+		Id:      "offsite",
+		Summary: "Offsite",
+		Start:   &gcalendar.EventDateTime{Date: "2024-09-10"},
+		End:     &gcalendar.EventDateTime{Date: "2024-09-12"},
+	})
+
+	ctx := context.Background()
+	client := newMockClient(t, server)
+
+	find := func(id string) *proto.Event {
+		for _, e := range server.GetEvents("team@example.com") {
+			if e.Id == id {
+				return calendar.MapEventToProto(e, "team@example.com")
+			}
+		}
+		t.Fatalf("event %s not found", id)
+		return nil
+	}
+
+	// Edit a copy of the event as read, then write it back whole
+	event := find("sync")
+	event.Summary = "Weekly sync (moved)"
+	event.Description = nil
+	event.StartTime = timestamppb.New(start.Add(time.Hour))
+	event.EndTime = timestamppb.New(start.Add(2 * time.Hour))
+	event.AttendeeDetails = append(event.AttendeeDetails, &proto.Attendee{Email: "bob@example.com", Optional: true})
+
+	requests := len(server.Requests())
+	updated, err := client.UpdateEventFull(ctx, "", event)
+	if err != nil {
+		t.Fatalf("UpdateEventFull() failed: %v", err)
+	}
+	recorded := server.Requests()[requests:]
+	if len(recorded) != 1 || recorded[0].Method != http.MethodPut || recorded[0].Path != "/calendars/team@example.com/events/sync" {
+		t.Fatalf("expected a single PUT to the event, got %+v", recorded)
+	}
+
+	if updated.Summary != "Weekly sync (moved)" || updated.Description != "" {
+		t.Errorf("expected the summary replaced and description cleared, got %q / %q", updated.Summary, updated.Description)
+	}
+	if updated.Start.DateTime != start.Add(time.Hour).Format(time.RFC3339) {
+		t.Errorf("expected the start moved, got %s", updated.Start.DateTime)
+	}
+	if len(updated.Attendees) != 2 || !updated.Attendees[1].Optional {
+		t.Errorf("expected bob added as optional, got %+v", updated.Attendees)
+	}
+
+	// All-day events round-trip as dates
+	allDay := find("offsite")
+	allDay.Summary = "Team offsite"
+	updated, err = client.UpdateEventFull(ctx, "team@example.com", allDay)
+	if err != nil {
+		t.Fatalf("UpdateEventFull() failed: %v", err)
+	}
+	if updated.Start.Date != "2024-09-10" || updated.End.Date != "2024-09-12" || updated.Start.DateTime != "" {
+		t.Errorf("expected the dates kept, got %+v - %+v", updated.Start, updated.End)
+	}
+
+	// Invalid events are rejected without a request
+	requests = len(server.Requests())
+	if _, err := client.UpdateEventFull(ctx, "team@example.com", &proto.Event{Summary: "No ID"}); err == nil {
+		t.Error("expected an error without an event ID")
+	}
+	if _, err := client.UpdateEventFull(ctx, "team@example.com", &proto.Event{Id: "sync", ColorId: ptr("42")}); err == nil {
+		t.Error("expected an error for an invalid color")
+	}
+	if got := len(server.Requests()) - requests; got != 0 {
+		t.Errorf("expected no requests for invalid events, got %d", got)
+	}
+}
+
+func TestClient_UpdateEventFull_Settings(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	start := time.Date(2024, 9, 3, 9, 0, 0, 0, time.UTC)
+	server.AddEvent("primary", &gcalendar.Event{
+		Id:                      "deep-work",
+		Summary:                 "Deep work",
+		Start:                   &gcalendar.EventDateTime{DateTime: start.Format(time.RFC3339)},
+		End:                     &gcalendar.EventDateTime{DateTime: start.Add(2 * time.Hour).Format(time.RFC3339)},
+		EventType:               "focusTime",
+		GuestsCanModify:         true,
+		GuestsCanInviteOthers:   ptr(false),
+		GuestsCanSeeOtherGuests: ptr(false),
+		AnyoneCanAddSelf:        true,
+	})
+
+	ctx := context.Background()
+	client := newMockClient(t, server)
+
+	event := calendar.MapEventToProto(server.GetEvents("primary")[0], "primary")
+	event.Summary = "Deep work (no meetings)"
+	if _, err := client.UpdateEventFull(ctx, "", event); err != nil {
+		t.Fatalf("UpdateEventFull() failed: %v", err)
+	}
+
+	stored := server.GetEvents("primary")[0]
+	if stored.Summary != "Deep work (no meetings)" {
+		t.Errorf("expected the summary replaced, got %q", stored.Summary)
+	}
+
+	// Settings proto.Event carries survive the replacement
+	if stored.EventType != "focusTime" {
+		t.Errorf("expected the event type kept, got %q", stored.EventType)
+	}
+	if !stored.GuestsCanModify {
+		t.Error("expected guests to still be able to modify the event")
+	}
+	if stored.GuestsCanInviteOthers == nil || *stored.GuestsCanInviteOthers {
+		t.Errorf("expected guests still unable to invite others, got %v", stored.GuestsCanInviteOthers)
+	}
+	if stored.GuestsCanSeeOtherGuests == nil || *stored.GuestsCanSeeOtherGuests {
+		t.Errorf("expected guests still unable to see each other, got %v", stored.GuestsCanSeeOtherGuests)
+	}
+
+	// Settings it has no field for reset to their defaults
+	if stored.AnyoneCanAddSelf {
+		t.Error("expected anyoneCanAddSelf to reset, as proto.Event doesn't carry it")
+	}
+}
+
+func TestClient_ClassifiesAPIErrors(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.AddEvent("primary", &gcalendar.Event{Id: "standup", Summary: "Standup"})
+
+	ctx := context.Background()
+	client := newMockClient(t, server)
+	sentinels := []error{calendar.ErrEventNotFound, calendar.ErrForbidden, calendar.ErrRateLimited, calendar.ErrConflict}
+
+	tests := []struct {
+		name   string
+		status int
+		want   error
+	}{
+		{name: "not found", status: http.StatusNotFound, want: calendar.ErrEventNotFound},
+		{name: "forbidden", status: http.StatusForbidden, want: calendar.ErrForbidden},
+		{name: "rate limited", status: http.StatusTooManyRequests, want: calendar.ErrRateLimited},
+		{name: "conflict", status: http.StatusConflict, want: calendar.ErrConflict},
+		{name: "bad request", status: http.StatusBadRequest, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server.FailNext(1, tt.status)
+			_, err := client.GetEvent(ctx, &proto.GetEventRequest{EventId: "standup"})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, sentinel := range sentinels {
+				if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+					t.Errorf("errors.Is(err, %v) = %v for %v", sentinel, got, err)
+				}
+			}
+
+			// The API error itself stays reachable, and the message unchanged
+			var apiErr *googleapi.Error
+			if !errors.As(err, &apiErr) || apiErr.Code != tt.status {
+				t.Errorf("expected a *googleapi.Error with code %d, got %v", tt.status, err)
+			}
+			if !strings.HasPrefix(err.Error(), "unable to get event: googleapi: Error") {
+				t.Errorf("unexpected message: %v", err)
+			}
+		})
+	}
+
+	// Real misses are classified too, across methods
+	if _, err := client.GetEvent(ctx, &proto.GetEventRequest{EventId: "missing"}); !errors.Is(err, calendar.ErrEventNotFound) {
+		t.Errorf("expected ErrEventNotFound getting a missing event, got %v", err)
+	}
+	if err := client.DeleteEvent(ctx, &proto.DeleteEventRequest{EventId: "missing"}); !errors.Is(err, calendar.ErrEventNotFound) {
+		t.Errorf("expected ErrEventNotFound deleting a missing event, got %v", err)
+	}
+	if _, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{EventId: "missing", Summary: ptr("x")}); !errors.Is(err, calendar.ErrEventNotFound) {
+		t.Errorf("expected ErrEventNotFound updating a missing event, got %v", err)
+	}
+}
+
+func TestClient_DryRun(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.AddEvent("primary", &gcalendar.Event{Id: "standup", Summary: "Standup"})
+
+	ctx := context.Background()
+	client := newMockClient(t, server, calendar.WithDryRun(true))
+	start := timestamppb.New(time.Date(2024, 4, 2, 9, 0, 0, 0, time.UTC))
+
+	// Creates return a plausible event without reaching the API
+	created, err := client.CreateEvent(ctx, &proto.AddEventRequest{Summary: "Planning", StartTime: start})
+	if err != nil {
+		t.Fatalf("CreateEvent() failed: %v", err)
+	}
+	if !strings.HasPrefix(created.Id, "dryrun") || !strings.HasPrefix(created.HtmlLink, "https://www.google.com/calendar/event?eid=") {
+		t.Errorf("expected a fake ID and link, got %q / %q", created.Id, created.HtmlLink)
+	}
+	if created.Summary != "Planning" || created.Start.DateTime != "2024-04-02T09:00:00Z" {
+		t.Errorf("expected the requested event back, got %q at %+v", created.Summary, created.Start)
+	}
+	again, err := client.CreateEvent(ctx, &proto.AddEventRequest{Summary: "Planning", StartTime: start})
+	if err != nil {
+		t.Fatalf("CreateEvent() failed: %v", err)
+	}
+	if again.Id != created.Id || again.HtmlLink != created.HtmlLink {
+		t.Errorf("expected the same fake ID for the same event, got %q and %q", created.Id, again.Id)
+	}
+	if _, err := client.QuickAdd(ctx, &proto.QuickAddRequest{Text: "Lunch tomorrow"}); err != nil {
+		t.Fatalf("QuickAdd() failed: %v", err)
+	}
+	if err := client.DeleteEvent(ctx, &proto.DeleteEventRequest{EventId: "standup"}); err != nil {
+		t.Fatalf("DeleteEvent() failed: %v", err)
+	}
+	if got := server.Requests(); len(got) != 0 {
+		t.Errorf("expected no requests in dry-run mode, got %+v", got)
+	}
+
+	// Updates read the event but don't write it
+	updated, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{EventId: "standup", Summary: ptr("Daily standup")})
+	if err != nil {
+		t.Fatalf("UpdateEvent() failed: %v", err)
+	}
+	if updated.Id != "standup" || updated.Summary != "Daily standup" {
+		t.Errorf("expected the updated event back, got %s/%q", updated.Id, updated.Summary)
+	}
+	for _, req := range server.Requests() {
+		if req.Method != http.MethodGet {
+			t.Errorf("expected only reads in dry-run mode, got %s %s", req.Method, req.Path)
+		}
+	}
+
+	// Validation still applies
+	if _, err := client.CreateEvent(ctx, &proto.AddEventRequest{Summary: "Bad", ColorId: ptr("99")}); err == nil {
+		t.Error("expected an invalid color to be rejected in dry-run mode")
+	}
+
+	events := server.GetEvents("primary")
+	if len(events) != 1 || events[0].Summary != "Standup" {
+		t.Errorf("expected the calendar untouched, got %+v", events)
+	}
+}
+
+func TestClient_MaxAttendeesAllowed(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	guests := func(emails ...string) *proto.AttendeeList {
+		list := &proto.AttendeeList{}
+		for _, email := range emails {
+			list.Attendees = append(list.Attendees, &proto.Attendee{Email: email})
+		}
+		return list
+	}
+
+	ctx := context.Background()
+	client := newMockClient(t, server, calendar.WithMaxAttendeesAllowed(2))
+
+	// Up to the limit is fine
+	created, err := client.CreateEvent(ctx, &proto.AddEventRequest{Summary: "Pairing", Attendees: guests("ann@example.com", "bob@example.com")})
+	if err != nil {
+		t.Fatalf("CreateEvent() failed: %v", err)
+	}
+	if len(created.Attendees) != 2 {
+		t.Errorf("expected 2 attendees, got %d", len(created.Attendees))
+	}
+
+	// Over the limit is rejected before reaching the API
+	requests := len(server.Requests())
+	_, err = client.CreateEvent(ctx, &proto.AddEventRequest{Summary: "Mob", Attendees: guests("ann@example.com", "bob@example.com", "cat@example.com")})
+	if err == nil || !strings.Contains(err.Error(), "at most 2") {
+		t.Errorf("expected the attendee limit error, got %v", err)
+	}
+	if got := len(server.Requests()) - requests; got != 0 {
+		t.Errorf("expected no requests for a rejected create, got %d", got)
+	}
+
+	// Updates are checked against the resulting guest list
+	requests = len(server.Requests())
+	_, err = client.UpdateEvent(ctx, &proto.UpdateEventRequest{EventId: created.Id, Attendees: guests("ann@example.com", "bob@example.com", "cat@example.com")})
+	if err == nil {
+		t.Error("expected an update over the limit to fail")
+	}
+	if got := len(server.Requests()) - requests; got != 1 {
+		t.Errorf("expected only the read before rejecting the update, got %d requests", got)
+	}
+	if got := len(server.GetEvents("primary")[0].Attendees); got != 2 {
+		t.Errorf("expected the stored guest list unchanged, got %d attendees", got)
+	}
+
+	// Unrelated updates to an event within the limit still work
+	if _, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{EventId: created.Id, Summary: ptr("Pairing (Tue)")}); err != nil {
+		t.Errorf("UpdateEvent() failed: %v", err)
+	}
+
+	// Non-positive limits remove the cap
+	unlimited := newMockClient(t, server, calendar.WithMaxAttendeesAllowed(0))
+	if _, err := unlimited.CreateEvent(ctx, &proto.AddEventRequest{Summary: "Mob", Attendees: guests("ann@example.com", "bob@example.com", "cat@example.com")}); err != nil {
+		t.Errorf("expected no limit after clearing it, got %v", err)
+	}
+}
+
+func TestClient_ColorID(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	client := newMockClient(t, server)
+	created, err := client.CreateEvent(context.Background(), &proto.AddEventRequest{Summary: "Colored", ColorId: ptr("7")})
+	if err != nil {
+		t.Fatalf("CreateEvent() failed: %v", err)
+	}
+	if created.ColorId != "7" {
+		t.Errorf("expected the mock to echo color %q, got %q", "7", created.ColorId)
+	}
+
+	// Out-of-range colors are rejected before reaching the API
+	requests := len(server.Requests())
+	if _, err := client.UpdateEvent(context.Background(), &proto.UpdateEventRequest{EventId: created.Id, ColorId: ptr("12")}); err == nil {
+		t.Error("expected an error for color 12")
+	}
+	if got := len(server.Requests()) - requests; got != 1 {
+		t.Errorf("expected only the read before rejecting the update, got %d requests", got)
+	}
+	if events := server.GetEvents("primary"); len(events) != 1 || events[0].ColorId != "7" {
+		t.Errorf("expected color to be unchanged, got %+v", events)
+	}
+}
+
+func TestClient_ExtendedProperties(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := newMockClient(t, server)
+	tagged, err := client.CreateEvent(ctx, &proto.AddEventRequest{
+		Summary:           "Incident review",
+		PrivateProperties: &proto.PropertyMap{Properties: map[string]string{"ticket": "OPS-12", "source": "jira"}},
+		SharedProperties:  &proto.PropertyMap{Properties: map[string]string{"team": "sre"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() failed: %v", err)
+	}
+	if _, err := client.CreateEvent(ctx, &proto.AddEventRequest{
+		Summary:           "Other ticket",
+		PrivateProperties: &proto.PropertyMap{Properties: map[string]string{"ticket": "OPS-13"}},
+	}); err != nil {
+		t.Fatalf("CreateEvent() failed: %v", err)
+	}
+
+	list := func(req *proto.ListEventsRequest) []*proto.Event {
+		t.Helper()
+		respChan, errChan := client.ListEvents(ctx, req)
+		var events []*proto.Event
+		for resp := range respChan {
+			if resp.Event != nil {
+				events = append(events, resp.Event)
+			}
+		}
+		if err := <-errChan; err != nil {
+			t.Fatalf("ListEvents() failed: %v", err)
+		}
+		return events
+	}
+
+	events := list(&proto.ListEventsRequest{
+		PrivateProperty: &proto.PropertyMap{Properties: map[string]string{"ticket": "OPS-12"}},
+	})
+	if len(events) != 1 || events[0].Id != tagged.Id {
+		t.Fatalf("expected only the OPS-12 event, got %v", events)
+	}
+	if got := events[0].GetPrivateProperties(); got["ticket"] != "OPS-12" || got["source"] != "jira" {
+		t.Errorf("unexpected private properties: %v", got)
+	}
+	if got := events[0].GetSharedProperties(); got["team"] != "sre" {
+		t.Errorf("unexpected shared properties: %v", got)
+	}
+
+	// Every filter must match, private and shared alike
+	if events := list(&proto.ListEventsRequest{
+		PrivateProperty: &proto.PropertyMap{Properties: map[string]string{"ticket": "OPS-12"}},
+		SharedProperty:  &proto.PropertyMap{Properties: map[string]string{"team": "web"}},
+	}); len(events) != 0 {
+		t.Errorf("expected no events for a mismatched shared property, got %v", events)
+	}
+
+	// Updates merge keys, deleting those sent empty
+	if _, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{
+		EventId:           tagged.Id,
+		PrivateProperties: &proto.PropertyMap{Properties: map[string]string{"source": "", "status": "closed"}},
+	}); err != nil {
+		t.Fatalf("UpdateEvent() failed: %v", err)
+	}
+	events = list(&proto.ListEventsRequest{
+		PrivateProperty: &proto.PropertyMap{Properties: map[string]string{"status": "closed"}},
+	})
+	if len(events) != 1 {
+		t.Fatalf("expected the updated event, got %v", events)
+	}
+	want := map[string]string{"ticket": "OPS-12", "status": "closed"}
+	if got := events[0].GetPrivateProperties(); len(got) != len(want) || got["ticket"] != want["ticket"] || got["status"] != want["status"] {
+		t.Errorf("private properties = %v, want %v", got, want)
+	}
+	if got := events[0].GetSharedProperties(); got["team"] != "sre" {
+		t.Errorf("expected shared properties to be kept, got %v", got)
+	}
+}
+
+func TestClient_GetEvent_IncludeDeleted(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.SoftDelete(true)
+	server.AddEvent("primary", &gcalendar.Event{Id: "lunch", Summary: "Lunch"})
+	server.AddEvent("primary", &gcalendar.Event{
+		Id:         "standup",
+		Summary:    "Standup",
+		Start:      &gcalendar.EventDateTime{DateTime: "2024-07-01T09:00:00Z"},
+		End:        &gcalendar.EventDateTime{DateTime: "2024-07-01T09:30:00Z"},
+		Recurrence: []string{"RRULE:FREQ=DAILY;COUNT=3"},
+	})
+
+	ctx := context.Background()
+	client := newMockClient(t, server)
+
+	for _, id := range []string{"lunch", "standup_20240702T090000Z"} {
+		if err := client.DeleteEvent(ctx, &proto.DeleteEventRequest{EventId: id}); err != nil {
+			t.Fatalf("DeleteEvent(%s) failed: %v", id, err)
+		}
+
+		// Deleted events aren't found unless asked for
+		if _, err := client.GetEvent(ctx, &proto.GetEventRequest{EventId: id}); !errors.Is(err, calendar.ErrEventNotFound) {
+			t.Errorf("expected ErrEventNotFound for deleted %s, got %v", id, err)
+		}
+		tombstone, err := client.GetEvent(ctx, &proto.GetEventRequest{EventId: id, IncludeDeleted: ptr(true)})
+		if err != nil {
+			t.Fatalf("GetEvent(%s) with deleted events failed: %v", id, err)
+		}
+		if tombstone.Id != id || tombstone.Status != "cancelled" {
+			t.Errorf("expected the cancelled tombstone of %s, got %s (%s)", id, tombstone.Id, tombstone.Status)
+		}
+	}
+
+	// The rest of the series is still there
+	event, err := client.GetEvent(ctx, &proto.GetEventRequest{EventId: "standup_20240703T090000Z"})
+	if err != nil || event.Status == "cancelled" {
+		t.Errorf("expected the next instance unaffected, got %v, %v", event, err)
+	}
+}
+
+func TestClient_GetEventByICalUID(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.AddEvent("primary", &gcalendar.Event{Id: "a", Summary: "Imported A", ICalUID: "a-123@example.com"})
+	server.AddEvent("primary", &gcalendar.Event{Id: "b", Summary: "Imported B", ICalUID: "b-456@example.com"})
+	server.AddEvent("primary", &gcalendar.Event{Id: "series", Summary: "Weekly", ICalUID: "weekly@example.com", Recurrence: []string{"RRULE:FREQ=WEEKLY"}})
+	server.AddEvent("primary", &gcalendar.Event{Id: "series_20240708", Summary: "Weekly (moved)", ICalUID: "weekly@example.com", RecurringEventId: "series"})
+
+	ctx := context.Background()
+	client := newMockClient(t, server)
+
+	event, err := client.GetEventByICalUID(ctx, "", "b-456@example.com")
+	if err != nil {
+		t.Fatalf("GetEventByICalUID() failed: %v", err)
+	}
+	if event.Id != "b" || event.Summary != "Imported B" {
+		t.Errorf("expected event b, got %s (%s)", event.Id, event.Summary)
+	}
+	last, _ := server.LastRequest()
+	if got := last.Query.Get("iCalUID"); got != "b-456@example.com" {
+		t.Errorf("expected the lookup to filter by iCalUID, got %q", got)
+	}
+
+	// The series wins over its exceptions
+	if event, err := client.GetEventByICalUID(ctx, "primary", "weekly@example.com"); err != nil || event.Id != "series" {
+		t.Errorf("expected the recurring series, got %v, %v", event, err)
+	}
+
+	// Events created through the API get Google-style iCalUIDs
+	created, err := client.CreateEvent(ctx, &proto.AddEventRequest{Summary: "Created"})
+	if err != nil {
+		t.Fatalf("CreateEvent() failed: %v", err)
+	}
+	if event, err := client.GetEventByICalUID(ctx, "primary", created.ICalUID); err != nil || event.Id != created.Id {
+		t.Errorf("expected to find the created event by %q, got %v, %v", created.ICalUID, event, err)
+	}
+
+	if _, err := client.GetEventByICalUID(ctx, "primary", "missing@example.com"); !errors.Is(err, calendar.ErrEventNotFound) {
+		t.Errorf("expected ErrEventNotFound, got %v", err)
+	}
+}
+
+func TestClient_ImportEvent(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := newMockClient(t, server)
+
+	start := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	imported, err := client.ImportEvent(ctx, &proto.ImportEventRequest{
+		IcalUid:   "0f3c2a@legacy.example.com",
+		Summary:   "Quarterly review",
+		StartTime: timestamppb.New(start),
+		EndTime:   timestamppb.New(start.Add(time.Hour)),
+	})
+	if err != nil {
+		t.Fatalf("ImportEvent() failed: %v", err)
+	}
+	if imported.ICalUID != "0f3c2a@legacy.example.com" {
+		t.Errorf("expected the iCalUID kept, got %q", imported.ICalUID)
+	}
+	last, _ := server.LastRequest()
+	if last.Method != http.MethodPost || !strings.HasSuffix(last.Path, "/events/import") {
+		t.Errorf("expected an import request, got %s %s", last.Method, last.Path)
+	}
+
+	event, err := client.GetEventByICalUID(ctx, "primary", "0f3c2a@legacy.example.com")
+	if err != nil {
+		t.Fatalf("GetEventByICalUID() failed: %v", err)
+	}
+	if event.Id != imported.Id || event.Summary != "Quarterly review" || event.Start.DateTime != start.Format(time.RFC3339) {
+		t.Errorf("unexpected event fetched by iCalUID: %+v", event)
+	}
+
+	requests := len(server.Requests())
+	if _, err := client.ImportEvent(ctx, &proto.ImportEventRequest{Summary: "No UID"}); err == nil {
+		t.Error("expected an error importing without an iCalUID")
+	}
+	if len(server.Requests()) != requests {
+		t.Error("expected a missing iCalUID to be rejected before any request")
+	}
+}
+
+func TestListEvents_SurfacesMidStreamError(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	for _, id := range []string{"a", "b", "c"} {
+		server.AddEvent("primary", &gcalendar.Event{Id: id, Summary: "Event " + id})
+	}
+
+	// The first page succeeds; the request for the second one fails
+	var pages atomic.Int32
+	transport := &hookTransport{afterGet: func() {
+		if pages.Add(1) == 1 {
+			server.FailNext(1, http.StatusBadRequest)
+		}
+	}}
+	client, err := calendar.NewClient(context.Background(), &http.Client{Transport: transport}, calendar.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar client: %v", err)
+	}
+
+	ctx := context.Background()
+	svc := &calendarService{calendarClient: client}
+	output := filepath.Join(t.TempDir(), "events.json")
+	root := &cli.Command{
+		Name:     "cali",
+		Commands: proto.CalendarServiceCommandsFlat(ctx, svc, protocli.WithOutputFormats(protocli.JSON())),
+	}
+	err = root.Run(ctx, []string{"cali", "list-events", "--limit", "1", "--follow-pages", "--output", output})
+	if err == nil || !strings.Contains(err.Error(), "unable to retrieve events") {
+		t.Fatalf("expected the second page's error, got %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 1 {
+		t.Errorf("expected the first page's event before the error, got %q", data)
+	}
+}
+
+func TestListEvents_CancelledMidList(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	for _, id := range []string{"a", "b", "c"} {
+		server.AddEvent("primary", &gcalendar.Event{Id: id, Summary: "Event " + id})
+	}
+
+	// Cancel as the second page is requested, and stall it so the mock can
+	// only give up on it
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var pages atomic.Int32
+	server.OnRequest(func(r *http.Request) {
+		if r.Method == http.MethodGet && pages.Add(1) == 2 {
+			server.SetLatency(time.Minute)
+			cancel()
+		}
+	})
+
+	svc := &calendarService{calendarClient: newMockClient(t, server)}
+	output := filepath.Join(t.TempDir(), "events.json")
+	root := &cli.Command{
+		Name:     "cali",
+		Commands: proto.CalendarServiceCommandsFlat(ctx, svc, protocli.WithOutputFormats(protocli.JSON())),
+	}
+
+	start := time.Now()
+	err := root.Run(ctx, []string{"cali", "list-events", "--limit", "1", "--follow-pages", "--output", output})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the list to stop promptly, took %v", elapsed)
+	}
+	if got := pages.Load(); got != 2 {
+		t.Errorf("expected no pages after the cancelled one, got %d requests", got)
+	}
+}
+
+func TestListEvents_AnchorRoundTrip(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	base := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	for i, id := range []string{"a", "b", "c", "d", "e"} {
+		start := base.Add(time.Duration(i) * time.Hour)
+		server.AddEvent("primary", &gcalendar.Event{
+			Id:      id,
+			Summary: "Event " + id,
+			Start:   &gcalendar.EventDateTime{DateTime: start.Format(time.RFC3339)},
+			End:     &gcalendar.EventDateTime{DateTime: start.Add(30 * time.Minute).Format(time.RFC3339)},
+		})
+	}
+
+	ctx := context.Background()
+	svc := &calendarService{calendarClient: newMockClient(t, server)}
+	root := &cli.Command{
+		Name:     "cali",
+		Commands: proto.CalendarServiceCommandsFlat(ctx, svc, protocli.WithOutputFormats(protocli.JSON())),
+	}
+
+	// list runs one page and returns its event IDs and the anchor it ended with
+	list := func(args ...string) ([]string, string) {
+		t.Helper()
+		output := filepath.Join(t.TempDir(), "events.json")
+		if err := root.Run(ctx, append([]string{"cali", "list-events", "--limit", "3", "--output", output}, args...)); err != nil {
+			t.Fatalf("list-events failed: %v", err)
+		}
+		data, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatalf("failed to read output: %v", err)
+		}
+		var ids []string
+		var anchor string
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			var resp proto.ListEventsResponse
+			if err := protojson.Unmarshal([]byte(line), &resp); err != nil {
+				t.Fatalf("failed to decode output %q: %v", line, err)
+			}
+			if anchor != "" {
+				t.Errorf("expected the anchor on the last message only, got %q after it", line)
+			}
+			if resp.Event != nil {
+				ids = append(ids, resp.Event.Id)
+			}
+			anchor = resp.GetNextAnchor()
+		}
+		return ids, anchor
+	}
+
+	first, anchor := list()
+	if got := strings.Join(first, ","); got != "a,b,c" {
+		t.Errorf("first page = %q, want a,b,c", got)
+	}
+	if anchor == "" {
+		t.Fatal("expected a next anchor when more events exist")
+	}
+
+	second, anchor := list("--anchor", anchor)
+	if got := strings.Join(second, ","); got != "d,e" {
+		t.Errorf("second page = %q, want d,e", got)
+	}
+	if anchor != "" {
+		t.Errorf("expected no anchor after the last page, got %q", anchor)
+	}
+}
+
+func TestSearchEvents_CLI(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	base := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	add := func(id, summary, location string, day int) {
+		start := base.AddDate(0, 0, day)
+		server.AddEvent("primary", &gcalendar.Event{
+			Id:       id,
+			Summary:  summary,
+			Location: location,
+			Start:    &gcalendar.EventDateTime{DateTime: start.Format(time.RFC3339)},
+			End:      &gcalendar.EventDateTime{DateTime: start.Add(30 * time.Minute).Format(time.RFC3339)},
+		})
+	}
+	add("standup1", "Team standup", "Room 1", 0)
+	add("standup2", "Standup (remote)", "", 1)
+	add("review", "Design review", "Standup corner", 2)
+	add("lunch", "Lunch", "Cafe", 3)
+
+	ctx := context.Background()
+	svc := &calendarService{calendarClient: newMockClient(t, server)}
+	root := &cli.Command{
+		Name:     "cali",
+		Commands: proto.CalendarServiceCommandsFlat(ctx, svc, protocli.WithOutputFormats(protocli.JSON())),
+	}
+	search := func(args ...string) []string {
+		t.Helper()
+		output := filepath.Join(t.TempDir(), "events.json")
+		if err := root.Run(ctx, append([]string{"cali", "search", "--output", output}, args...)); err != nil {
+			t.Fatalf("search failed: %v", err)
+		}
+		data, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatalf("failed to read output: %v", err)
+		}
+		var ids []string
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			if line == "" {
+				continue
+			}
+			var event proto.Event
+			if err := protojson.Unmarshal([]byte(line), &event); err != nil {
+				t.Fatalf("failed to decode output %q: %v", line, err)
+			}
+			ids = append(ids, event.Id)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	// The query narrows the results, matching any text field
+	if got := strings.Join(search("--query", "standup"), ","); got != "review,standup1,standup2" {
+		t.Errorf("search standup = %q", got)
+	}
+	last, _ := server.LastRequest()
+	if last.Query.Get("q") != "standup" || last.Query.Get("singleEvents") != "true" {
+		t.Errorf("expected a q filter over single events, got %v", last.Query)
+	}
+
+	// A time window narrows it further, in start order
+	events, errs := svc.calendarClient.SearchEvents(ctx, &proto.SearchEventsRequest{
+		Query: "standup",
+		After: timestamppb.New(base.Add(time.Hour)),
+	})
+	var ids []string
+	for event := range events {
+		ids = append(ids, event.Id)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("SearchEvents() failed: %v", err)
+	}
+	if got := strings.Join(ids, ","); got != "standup2,review" {
+		t.Errorf("search standup after the first day = %q", got)
+	}
+
+	// As does a limit
+	if got := search("--query", "standup", "--limit", "1"); len(got) != 1 {
+		t.Errorf("expected one result with --limit 1, got %v", got)
+	}
+
+	if got := search("--query", "offsite"); len(got) != 0 {
+		t.Errorf("expected no matches, got %v", got)
+	}
+
+	// A blank query is rejected before reaching the API
+	requests := len(server.Requests())
+	if err := root.Run(ctx, []string{"cali", "search", "--query", " "}); err == nil {
+		t.Error("expected an error for a blank query")
+	}
+	if got := len(server.Requests()); got != requests {
+		t.Errorf("expected no request for a blank query, got %d", got-requests)
+	}
+}
+
+func TestClient_MaxAttendees(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	var attendees []*gcalendar.EventAttendee
+	for _, name := range []string{"ann", "bob", "cat", "dan", "eve"} {
+		attendees = append(attendees, &gcalendar.EventAttendee{Email: name + "@example.com"})
+	}
+	server.AddEvent("primary", &gcalendar.Event{Id: "allhands", Summary: "All hands", Attendees: attendees})
+
+	ctx := context.Background()
+	client := newMockClient(t, server, calendar.WithMaxAttendees(2))
+
+	respChan, errChan := client.ListEvents(ctx, &proto.ListEventsRequest{})
+	var events []*proto.Event
+	for resp := range respChan {
+		if resp.Event != nil {
+			events = append(events, resp.Event)
+		}
+	}
+	if err := <-errChan; err != nil {
+		t.Fatalf("ListEvents() failed: %v", err)
+	}
+	last, _ := server.LastRequest()
+	if got := last.Query.Get("maxAttendees"); got != "2" {
+		t.Errorf("expected maxAttendees=2 on the list, got %q", got)
+	}
+	if len(events) != 1 || strings.Join(events[0].Attendees, ",") != "ann@example.com,bob@example.com" {
+		t.Fatalf("expected the attendees to be truncated to 2, got %v", events)
+	}
+
+	event, err := client.GetEvent(ctx, &proto.GetEventRequest{EventId: "allhands"})
+	if err != nil {
+		t.Fatalf("GetEvent() failed: %v", err)
+	}
+	if len(event.Attendees) != 2 || !event.AttendeesOmitted {
+		t.Errorf("expected GetEvent to be truncated too, got %d attendees (omitted %v)", len(event.Attendees), event.AttendeesOmitted)
+	}
+
+	// Updates read and keep the full guest list
+	if _, err := client.UpdateEvent(ctx, &proto.UpdateEventRequest{EventId: "allhands", Summary: ptr("All hands (Q3)")}); err != nil {
+		t.Fatalf("UpdateEvent() failed: %v", err)
+	}
+	if got := server.GetEvents("primary")[0].Attendees; len(got) != 5 {
+		t.Errorf("expected the stored event to keep all 5 attendees, got %d", len(got))
+	}
+
+	// Without the option nothing is truncated
+	full, err := newMockClient(t, server).GetEvent(ctx, &proto.GetEventRequest{EventId: "allhands"})
+	if err != nil {
+		t.Fatalf("GetEvent() failed: %v", err)
+	}
+	if len(full.Attendees) != 5 || full.AttendeesOmitted {
+		t.Errorf("expected all 5 attendees, got %d", len(full.Attendees))
+	}
+}
+
+func TestImportICS_CLI(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc := &calendarService{calendarClient: newMockClient(t, server)}
+
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Example//EN",
+		"BEGIN:VEVENT",
+		"UID:standup@example.com",
+		"SUMMARY:Standup\\, daily",
+		"DESCRIPTION:Agenda:\\nupdates\\; blockers \\\\ notes that run long enough",
+		"  to be folded",
+		"LOCATION:Room 1",
+		"DTSTART;TZID=America/New_York:20240701T090000",
+		"DTEND;TZID=America/New_York:20240701T091500",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR",
+		`ATTENDEE;CN="Doe, Jane";ROLE=OPT-PARTICIPANT:mailto:jane@example.com`,
+		"ATTENDEE:mailto:bob@example.com",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"TRIGGER:-PT10M",
+		"SUMMARY:Not the event summary",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:offsite@example.com",
+		"SUMMARY:Offsite",
+		"DTSTART;VALUE=DATE:20240710",
+		"DTEND;VALUE=DATE:20240712",
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Review",
+		"DTSTART:20240702T150000Z",
+		"DURATION:PT45M",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	dir := t.TempDir()
+	input := filepath.Join(dir, "events.ics")
+	if err := os.WriteFile(input, []byte(ics), 0o600); err != nil {
+		t.Fatalf("failed to write ICS file: %v", err)
+	}
+
+	output := filepath.Join(dir, "imported.json")
+	root := &cli.Command{
+		Name:     "cali",
+		Commands: proto.CalendarServiceCommandsFlat(ctx, svc, protocli.WithOutputFormats(protocli.JSON())),
+	}
+	if err := root.Run(ctx, []string{"cali", "import-ics", "--file", input, "--output", output}); err != nil {
+		t.Fatalf("import-ics failed: %v", err)
+	}
+
+	events := server.GetEvents("primary")
+	if len(events) != 3 {
+		t.Fatalf("expected 3 imported events, got %d", len(events))
+	}
+	bySummary := map[string]*gcalendar.Event{}
+	for _, e := range events {
+		bySummary[e.Summary] = e
+	}
+
+	standup := bySummary["Standup, daily"]
+	if standup == nil {
+		t.Fatalf("expected the escaped summary to be unescaped, got %+v", events)
+	}
+	if want := "Agenda:\nupdates; blockers \\ notes that run long enough to be folded"; standup.Description != want {
+		t.Errorf("description = %q, want %q", standup.Description, want)
+	}
+	if standup.Location != "Room 1" {
+		t.Errorf("location = %q", standup.Location)
+	}
+	if standup.Start.DateTime == "" || standup.Start.TimeZone != "America/New_York" {
+		t.Errorf("expected a zoned start, got %+v", standup.Start)
+	}
+	if start, _ := time.Parse(time.RFC3339, standup.Start.DateTime); !start.Equal(time.Date(2024, 7, 1, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("start = %q, want 09:00 New York", standup.Start.DateTime)
+	}
+	if len(standup.Recurrence) != 1 || standup.Recurrence[0] != "RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR" {
+		t.Errorf("recurrence = %v", standup.Recurrence)
+	}
+	if len(standup.Attendees) != 2 ||
+		standup.Attendees[0].Email != "jane@example.com" || standup.Attendees[0].DisplayName != "Doe, Jane" || !standup.Attendees[0].Optional ||
+		standup.Attendees[1].Email != "bob@example.com" || standup.Attendees[1].Optional {
+		t.Errorf("unexpected attendees: %+v", standup.Attendees)
+	}
+
+	offsite := bySummary["Offsite"]
+	if offsite == nil || offsite.Start.Date != "2024-07-10" || offsite.End.Date != "2024-07-12" || offsite.Start.DateTime != "" {
+		t.Fatalf("expected an all-day offsite, got %+v", offsite)
+	}
+	if offsite.Transparency != "transparent" {
+		t.Errorf("transparency = %q, want transparent", offsite.Transparency)
+	}
+
+	review := bySummary["Review"]
+	if review == nil {
+		t.Fatal("expected the review event to be imported")
+	}
+	start, _ := time.Parse(time.RFC3339, review.Start.DateTime)
+	end, _ := time.Parse(time.RFC3339, review.End.DateTime)
+	if end.Sub(start) != 45*time.Minute {
+		t.Errorf("expected DURATION to set a 45 minute event, got %s to %s", review.Start.DateTime, review.End.DateTime)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected a response per event, got %q", data)
+	}
+	for _, line := range lines {
+		var resp proto.AddEventResponse
+		if err := protojson.Unmarshal([]byte(line), &resp); err != nil {
+			t.Fatalf("failed to decode output %q: %v", line, err)
+		}
+		if !resp.GetSuccess() || resp.GetEventId() == "" || resp.GetCalendarId() != "primary" {
+			t.Errorf("unexpected response: %v", &resp)
+		}
+	}
+
+	// A malformed file is rejected before anything is created
+	if err := os.WriteFile(input, []byte("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:No start\r\nEND:VEVENT\r\n"), 0o600); err != nil {
+		t.Fatalf("failed to write ICS file: %v", err)
+	}
+	if err := root.Run(ctx, []string{"cali", "import-ics", "--file", input}); err == nil {
+		t.Error("expected an error for an event without DTSTART")
+	}
+	if got := len(server.GetEvents("primary")); got != 3 {
+		t.Errorf("expected no new events, got %d", got-3)
+	}
+}
+
+func TestParseICS_SkipsInstanceOverrides(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:standup@example.com",
+		"SUMMARY:Standup",
+		"DTSTART:20240701T090000Z",
+		"DTEND:20240701T091500Z",
+		"RRULE:FREQ=DAILY;COUNT=5",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:standup@example.com",
+		"RECURRENCE-ID:20240703T090000Z",
+		"SUMMARY:Standup (moved)",
+		"DTSTART:20240703T100000Z",
+		"DTEND:20240703T101500Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	reqs, err := calendar.ParseICS(strings.NewReader(ics))
+	if err != nil {
+		t.Fatalf("ParseICS() failed: %v", err)
+	}
+	if len(reqs) != 1 || reqs[0].Summary != "Standup" || reqs[0].GetRecurrence() == nil {
+		t.Errorf("expected only the series, got %v", reqs)
+	}
+}
+
+func TestParseICS_EXRULE(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"SUMMARY:Standup",
+		"DTSTART:20240701T090000Z",
+		"RRULE:FREQ=DAILY",
+		"EXRULE:FREQ=WEEKLY;BYDAY=SA,SU",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	_, err := calendar.ParseICS(strings.NewReader(ics))
+	if err == nil || !strings.Contains(err.Error(), "EXRULE is not supported") {
+		t.Errorf("expected EXRULE to be rejected as unsupported, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drewfead/cali/proto"
+	"github.com/urfave/cli/v3"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// csvHeader names the columns written for each event
+var csvHeader = []string{"id", "summary", "start", "end", "location", "attendees"}
+
+// csvFormat renders events as CSV rows for spreadsheets. Streamed responses
+// are formatted one message at a time, so the header row is written before
+// the first row sent to each writer.
+type csvFormat struct {
+	mu      sync.Mutex
+	started map[io.Writer]bool
+}
+
+func newCSVFormat() *csvFormat {
+	return &csvFormat{started: map[io.Writer]bool{}}
+}
+
+func (f *csvFormat) Name() string {
+	return "csv"
+}
+
+func (f *csvFormat) Format(_ context.Context, _ *cli.Command, w io.Writer, msg protobuf.Message) error {
+	var event *proto.Event
+	switch m := msg.(type) {
+	case *proto.Event:
+		event = m
+	case *proto.GetEventResponse:
+		event = m.GetEvent()
+	case *proto.QuickAddResponse:
+		event = m.GetEvent()
+	case *proto.ListEventsResponse:
+		// The last message of a page may carry only next_anchor
+		event = m.GetEvent()
+		if event == nil {
+			return nil
+		}
+	default:
+		return fmt.Errorf("csv output is not supported for %s", msg.ProtoReflect().Descriptor().FullName())
+	}
+
+	f.mu.Lock()
+	header := !f.started[w]
+	f.started[w] = true
+	f.mu.Unlock()
+
+	// The CLI writes the delimiter (a newline) after each message, so the
+	// last row is left unterminated
+	var b strings.Builder
+	cw := csv.NewWriter(&b)
+	if header {
+		if err := cw.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+	if err := cw.Write(csvRow(event)); err != nil {
+		return err
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	_, err := io.WriteString(w, strings.TrimSuffix(b.String(), "\n"))
+	return err
+}
+
+// csvRow flattens event into the csvHeader columns. Attendees are their
+// emails joined with semicolons.
+func csvRow(event *proto.Event) []string {
+	attendees := event.GetAttendees()
+	if details := event.GetAttendeeDetails(); len(details) > 0 {
+		attendees = make([]string, 0, len(details))
+		for _, a := range details {
+			attendees = append(attendees, a.GetEmail())
+		}
+	}
+
+	return []string{
+		event.GetId(),
+		event.GetSummary(),
+		csvTime(event.GetStartTime()),
+		csvTime(event.GetEndTime()),
+		event.GetLocation(),
+		strings.Join(attendees, ";"),
+	}
+}
+
+// csvTime formats ts as RFC 3339, or "" if it is unset
+func csvTime(ts *timestamppb.Timestamp) string {
+	if ts == nil || !ts.IsValid() {
+		return ""
+	}
+	return ts.AsTime().Format(time.RFC3339)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drewfead/cali/pkg/googlecaltest"
+	"github.com/drewfead/cali/proto"
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/urfave/cli/v3"
+	gcalendar "google.golang.org/api/calendar/v3"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCSVFormat_GetEvent(t *testing.T) {
+	location := `Room "A", 2nd floor`
+	event := &proto.Event{
+		Id:        "evt1",
+		Summary:   "Planning, Q3",
+		StartTime: timestamppb.New(time.Date(2024, 7, 1, 13, 0, 0, 0, time.UTC)),
+		EndTime:   timestamppb.New(time.Date(2024, 7, 1, 14, 0, 0, 0, time.UTC)),
+		Location:  &location,
+		Attendees: []string{"jane@example.com"},
+		AttendeeDetails: []*proto.Attendee{
+			{Email: "jane@example.com"},
+			{Email: "bob@example.com"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := newCSVFormat().Format(context.Background(), nil, &buf, &proto.GetEventResponse{Event: event}); err != nil {
+		t.Fatalf("failed to render CSV: %v", err)
+	}
+
+	want := "id,summary,start,end,location,attendees\n" +
+		`evt1,"Planning, Q3",2024-07-01T13:00:00Z,2024-07-01T14:00:00Z,"Room ""A"", 2nd floor",jane@example.com;bob@example.com`
+	if got := buf.String(); got != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", got, want)
+	}
+
+	// Responses without events aren't supported
+	if err := newCSVFormat().Format(context.Background(), nil, &buf, &proto.RevokeResponse{}); err == nil {
+		t.Error("expected an error for a non-event response")
+	}
+}
+
+func TestCSVFormat_ListEvents_CLI(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour).UTC()
+	server.AddEvent("primary", &gcalendar.Event{
+		Id:       "a",
+		Summary:  "Standup",
+		Start:    &gcalendar.EventDateTime{DateTime: start.Format(time.RFC3339)},
+		End:      &gcalendar.EventDateTime{DateTime: start.Add(15 * time.Minute).Format(time.RFC3339)},
+		Location: "Room 1",
+		Attendees: []*gcalendar.EventAttendee{
+			{Email: "jane@example.com"},
+			{Email: "bob@example.com"},
+		},
+	})
+	server.AddEvent("primary", &gcalendar.Event{
+		Id:      "b",
+		Summary: "Review\nnotes",
+		Start:   &gcalendar.EventDateTime{DateTime: start.Add(time.Hour).Format(time.RFC3339)},
+		End:     &gcalendar.EventDateTime{DateTime: start.Add(2 * time.Hour).Format(time.RFC3339)},
+	})
+
+	ctx := context.Background()
+	svc := &calendarService{calendarClient: newMockClient(t, server)}
+
+	output := filepath.Join(t.TempDir(), "events.csv")
+	root := &cli.Command{
+		Name:     "cali",
+		Commands: proto.CalendarServiceCommandsFlat(ctx, svc, protocli.WithOutputFormats(newCSVFormat())),
+	}
+	if err := root.Run(ctx, []string{"cali", "list-events", "--future", "--format", "csv", "--output", output}); err != nil {
+		t.Fatalf("list-events failed: %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV %q: %v", data, err)
+	}
+
+	want := [][]string{
+		csvHeader,
+		{"a", "Standup", start.Format(time.RFC3339), start.Add(15 * time.Minute).Format(time.RFC3339), "Room 1", "jane@example.com;bob@example.com"},
+		{"b", "Review\nnotes", start.Add(time.Hour).Format(time.RFC3339), start.Add(2 * time.Hour).Format(time.RFC3339), "", ""},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("expected a header and %d rows, got %q", len(want)-1, data)
+	}
+	for i := range want {
+		if strings.Join(records[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("row %d = %q, want %q", i, records[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/drewfead/cali/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// parseAttendeeList parses the --attendees flag: either a comma-separated
+// list of emails, or a JSON array of Attendee objects for display names,
+// optional guests, and response statuses, e.g.
+//
+//	--attendees 'alice@example.com,bob@example.com'
+//	--attendees '[{"email":"alice@example.com","responseStatus":"accepted"}]'
+//
+// An empty JSON array yields an empty list, which removes every guest on update.
+func parseAttendeeList(value string) (*proto.AttendeeList, error) {
+	value = strings.TrimSpace(value)
+	list := &proto.AttendeeList{}
+
+	if strings.HasPrefix(value, "[") {
+		if err := protojson.Unmarshal([]byte(`{"attendees":`+value+`}`), list); err != nil {
+			return nil, fmt.Errorf("invalid attendees JSON: %w", err)
+		}
+		return list, nil
+	}
+
+	for _, email := range strings.Split(value, ",") {
+		email = strings.TrimSpace(email)
+		if email == "" {
+			continue
+		}
+		list.Attendees = append(list.Attendees, &proto.Attendee{Email: email})
+	}
+	return list, nil
+}
+
+// parseRecurrence parses the --recurrence flag: RRULE, RDATE, and EXDATE
+// lines separated by whitespace, e.g.
+//
+//	--recurrence 'RRULE:FREQ=WEEKLY;BYDAY=MO EXDATE:20240311T090000Z'
+//
+// Lines are validated when the event is sent.
+func parseRecurrence(value string) *proto.Recurrence {
+	return &proto.Recurrence{Rules: strings.Fields(value)}
+}
+
+// parseReminderList parses the --reminders flag: comma-separated
+// method:minutes pairs, or a JSON array of Reminder objects, e.g.
+//
+//	--reminders 'popup:10,email:60'
+//	--reminders '[{"method":"popup","minutes":10}]'
+//
+// An empty JSON array yields an empty list, which restores the calendar's
+// default reminders on update.
+func parseReminderList(value string) (*proto.ReminderList, error) {
+	value = strings.TrimSpace(value)
+	list := &proto.ReminderList{}
+
+	if strings.HasPrefix(value, "[") {
+		if err := protojson.Unmarshal([]byte(`{"reminders":`+value+`}`), list); err != nil {
+			return nil, fmt.Errorf("invalid reminders JSON: %w", err)
+		}
+		return list, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		method, minutes, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid reminder %q: expected method:minutes (e.g. popup:10)", pair)
+		}
+		n, err := strconv.ParseInt(minutes, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reminder %q: minutes must be a number", pair)
+		}
+		list.Reminders = append(list.Reminders, &proto.Reminder{Method: method, Minutes: int32(n)})
+	}
+	return list, nil
+}
+
+// parseAttachmentList parses the --attachments flag: either a
+// comma-separated list of file URLs, or a JSON array of Attachment objects
+// for titles and MIME types, e.g.
+//
+//	--attachments 'https://drive.google.com/open?id=abc'
+//	--attachments '[{"fileUrl":"https://drive.google.com/open?id=abc","title":"Agenda"}]'
+//
+// An empty JSON array yields an empty list, which removes every attachment on
+// update.
+func parseAttachmentList(value string) (*proto.AttachmentList, error) {
+	value = strings.TrimSpace(value)
+	list := &proto.AttachmentList{}
+
+	if strings.HasPrefix(value, "[") {
+		if err := protojson.Unmarshal([]byte(`{"attachments":`+value+`}`), list); err != nil {
+			return nil, fmt.Errorf("invalid attachments JSON: %w", err)
+		}
+		return list, nil
+	}
+
+	for _, fileURL := range strings.Split(value, ",") {
+		fileURL = strings.TrimSpace(fileURL)
+		if fileURL == "" {
+			continue
+		}
+		list.Attachments = append(list.Attachments, &proto.Attachment{FileUrl: fileURL})
+	}
+	return list, nil
+}
+
+// parsePropertyMap parses an extended properties flag such as
+// --private-properties: comma-separated key=value pairs, or a JSON object for
+// values containing commas, e.g.
+//
+//	--private-properties 'ticket=OPS-12,source=jira'
+//	--private-properties '{"note":"a, b"}'
+//
+// On update, an empty value (e.g. 'ticket=') deletes the key.
+func parsePropertyMap(value string) (*proto.PropertyMap, error) {
+	value = strings.TrimSpace(value)
+	props := &proto.PropertyMap{Properties: map[string]string{}}
+
+	if strings.HasPrefix(value, "{") {
+		if err := protojson.Unmarshal([]byte(`{"properties":`+value+`}`), props); err != nil {
+			return nil, fmt.Errorf("invalid properties JSON: %w", err)
+		}
+		return props, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(pair, "=")
+		if key = strings.TrimSpace(key); !ok || key == "" {
+			return nil, fmt.Errorf("invalid property %q: expected key=value", pair)
+		}
+		props.Properties[key] = strings.TrimSpace(val)
+	}
+	return props, nil
+}
+
+// parseCalendarIDList parses a calendar list flag such as --calendar: a
+// comma-separated list of calendar IDs, e.g.
+//
+//	--calendar 'primary,team@group.calendar.google.com'
+func parseCalendarIDList(value string) *proto.CalendarIdList {
+	list := &proto.CalendarIdList{}
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			list.Ids = append(list.Ids, id)
+		}
+	}
+	return list
+}
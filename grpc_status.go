@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/drewfead/cali/internal/calendar"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError attaches a gRPC status code to an error while keeping its
+// message and the errors it wraps
+type statusError struct {
+	err  error
+	code codes.Code
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+func (e *statusError) GRPCStatus() *status.Status {
+	return status.New(e.code, e.err.Error())
+}
+
+// grpcCode returns the gRPC status code for a calendar client error, or
+// Unknown if it isn't one the client classifies
+func grpcCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, calendar.ErrEventNotFound):
+		return codes.NotFound
+	case errors.Is(err, calendar.ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, calendar.ErrRateLimited):
+		return codes.ResourceExhausted
+	case errors.Is(err, calendar.ErrConcurrentModification):
+		return codes.FailedPrecondition
+	case errors.Is(err, calendar.ErrConflict):
+		return codes.AlreadyExists
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	}
+	return codes.Unknown
+}
+
+// withStatus gives err the gRPC status code matching its class, so gRPC
+// callers see more than Unknown. The class takes precedence over the code a
+// wrapped *googleapi.Error reports for itself (which, for example, treats
+// every 403 as PermissionDenied); unclassified errors are returned as they
+// are.
+func withStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	code := grpcCode(err)
+	if code == codes.Unknown {
+		return err
+	}
+	return &statusError{err: err, code: code}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drewfead/cali/internal/calendar"
+	"github.com/drewfead/cali/pkg/googlecaltest"
+	"github.com/drewfead/cali/proto"
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/urfave/cli/v3"
+	gcalendar "google.golang.org/api/calendar/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandlers_MapClientErrorsToStatusCodes(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.AddEvent("primary", &gcalendar.Event{Id: "standup", Summary: "Standup"})
+
+	ctx := context.Background()
+	svc := &calendarService{calendarClient: newMockClient(t, server)}
+
+	tests := []struct {
+		name   string
+		status int
+		want   codes.Code
+	}{
+		{name: "not found", status: http.StatusNotFound, want: codes.NotFound},
+		{name: "forbidden", status: http.StatusForbidden, want: codes.PermissionDenied},
+		{name: "rate limited", status: http.StatusTooManyRequests, want: codes.ResourceExhausted},
+		{name: "conflict", status: http.StatusConflict, want: codes.AlreadyExists},
+		// Other API errors keep the code the API error reports
+		{name: "unclassified", status: http.StatusBadRequest, want: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server.FailNext(1, tt.status)
+			_, err := svc.GetEvent(ctx, &proto.GetEventRequest{EventId: "standup"})
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("status.Code() = %v, want %v (err: %v)", got, tt.want, err)
+			}
+			if msg := status.Convert(err).Message(); !strings.HasPrefix(msg, "failed to get event: ") {
+				t.Errorf("expected the message kept, got %q", msg)
+			}
+		})
+	}
+
+	// The client's sentinels stay reachable through the status
+	_, err := svc.GetEvent(ctx, &proto.GetEventRequest{EventId: "missing"})
+	if status.Code(err) != codes.NotFound || !errors.Is(err, calendar.ErrEventNotFound) {
+		t.Errorf("expected NotFound wrapping ErrEventNotFound, got %v", err)
+	}
+
+	server.FailNext(1, http.StatusForbidden)
+	if _, err := svc.DeleteEvent(ctx, &proto.DeleteEventRequest{EventId: "standup"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied deleting, got %v (%v)", status.Code(err), err)
+	}
+}
+
+func TestHandlers_StatusForConcurrentModification(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.AddEvent("primary", &gcalendar.Event{Id: "shared", Summary: "Original"})
+
+	transport := &hookTransport{afterGet: func() {
+		server.AddEvent("primary", &gcalendar.Event{Id: "shared", Summary: "Edited elsewhere"})
+	}}
+	client, err := calendar.NewClient(context.Background(), &http.Client{Transport: transport}, calendar.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar client: %v", err)
+	}
+	svc := &calendarService{calendarClient: client}
+
+	resp, err := svc.UpdateEvent(context.Background(), &proto.UpdateEventRequest{EventId: "shared", Summary: ptr("Mine")})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v (%v)", status.Code(err), err)
+	}
+	if resp.GetSuccess() {
+		t.Error("expected an unsuccessful response")
+	}
+}
+
+func TestHandlers_StatusForDeadlineExceeded(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.AddEvent("primary", &gcalendar.Event{Id: "standup", Summary: "Standup"})
+	server.SetLatency(time.Second)
+
+	svc := &calendarService{calendarClient: newMockClient(t, server)}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := svc.GetEvent(ctx, &proto.GetEventRequest{EventId: "standup"}); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded, got %v (%v)", status.Code(err), err)
+	}
+}
+
+func TestListEvents_StatusFromStreamError(t *testing.T) {
+	server := googlecaltest.NewServer()
+	defer server.Close()
+
+	server.FailNext(1, http.StatusForbidden)
+
+	ctx := context.Background()
+	svc := &calendarService{calendarClient: newMockClient(t, server)}
+	root := &cli.Command{
+		Name:     "cali",
+		Commands: proto.CalendarServiceCommandsFlat(ctx, svc, protocli.WithOutputFormats(protocli.JSON())),
+	}
+
+	err := root.Run(ctx, []string{"cali", "list-events", "--output", filepath.Join(t.TempDir(), "out.json")})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied from the stream, got %v (%v)", status.Code(err), err)
+	}
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drewfead/cali/proto"
+	protocli "github.com/drewfead/proto-cli"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func renderICS(t *testing.T, event *proto.Event) string {
+	t.Helper()
+	format, err := protocli.TemplateFormat("ics", map[string]string{
+		"calendar.GetEventResponse": eventTemplateICS + getEventResponseTemplateICS,
+	}, icsFuncMap)
+	if err != nil {
+		t.Fatalf("failed to create ICS format: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := format.Format(context.Background(), nil, &buf, &proto.GetEventResponse{Event: event}); err != nil {
+		t.Fatalf("failed to render ICS: %v", err)
+	}
+	return buf.String()
+}
+
+func TestEventTemplateICS_Reminders(t *testing.T) {
+	ics := renderICS(t, &proto.Event{
+		Id:             "evt1",
+		CalendarId:     "primary",
+		Summary:        "Standup; daily, short",
+		OrganizerEmail: ptr("boss@example.com"),
+		Reminders: []*proto.Reminder{
+			{Method: "popup", Minutes: 10},
+			{Method: "email", Minutes: 1440},
+		},
+	})
+
+	popup := "BEGIN:VALARM\nACTION:DISPLAY\nTRIGGER:-PT10M\nDESCRIPTION:Standup\\; daily\\, short\nEND:VALARM"
+	if !strings.Contains(ics, popup) {
+		t.Errorf("expected a popup VALARM\n%s\nin\n%s", popup, ics)
+	}
+	email := "BEGIN:VALARM\nACTION:EMAIL\nTRIGGER:-P1D\nDESCRIPTION:Standup\\; daily\\, short\nSUMMARY:Standup\\; daily\\, short\nATTENDEE:mailto:boss@example.com\nEND:VALARM"
+	if !strings.Contains(ics, email) {
+		t.Errorf("expected an email VALARM\n%s\nin\n%s", email, ics)
+	}
+	if strings.Index(ics, "END:VALARM") > strings.Index(ics, "END:VEVENT") {
+		t.Errorf("expected the alarms inside the VEVENT:\n%s", ics)
+	}
+
+	if ics := renderICS(t, &proto.Event{Id: "evt2", Summary: "No reminders"}); strings.Contains(ics, "VALARM") {
+		t.Errorf("expected no VALARM without reminder overrides:\n%s", ics)
+	}
+}
+
+func TestICSTrigger(t *testing.T) {
+	tests := []struct {
+		minutes int32
+		want    string
+	}{
+		{0, "PT0S"},
+		{10, "-PT10M"},
+		{60, "-PT1H"},
+		{90, "-PT1H30M"},
+		{1440, "-P1D"},
+		{1510, "-P1DT1H10M"},
+	}
+	for _, tt := range tests {
+		if got := icsTrigger(tt.minutes); got != tt.want {
+			t.Errorf("icsTrigger(%d) = %q, want %q", tt.minutes, got, tt.want)
+		}
+	}
+}
+
+func TestEventTemplateICS_Recurrence(t *testing.T) {
+	ics := renderICS(t, &proto.Event{
+		Id:         "weekly",
+		CalendarId: "primary",
+		Summary:    "Weekly sync",
+		StartTime:  timestamppb.New(time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)),
+		EndTime:    timestamppb.New(time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC)),
+		Recurrence: []string{
+			"RRULE:FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10",
+			"EXDATE;TZID=America/New_York:20240311T090000,20240318T090000",
+			"",
+			"RDATE:20240316T090000Z",
+		},
+	})
+
+	// Lines are emitted verbatim (no ICS text escaping of ; or ,) right after
+	// the event's times
+	want := "DTEND:20240304T093000Z\n" +
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10\n" +
+		"EXDATE;TZID=America/New_York:20240311T090000,20240318T090000\n" +
+		"RDATE:20240316T090000Z\n"
+	if !strings.Contains(ics, want) {
+		t.Errorf("expected recurrence lines\n%s\nin\n%s", want, ics)
+	}
+	if strings.Contains(ics, "\n\n") {
+		t.Errorf("expected no blank lines:\n%s", ics)
+	}
+}
+
+func TestEventTemplateICS_Participants(t *testing.T) {
+	ics := renderICS(t, &proto.Event{
+		Id:             "meeting",
+		CalendarId:     "primary",
+		Summary:        "Planning",
+		OrganizerEmail: ptr("boss@example.com"),
+		OrganizerName:  ptr("Smith, Pat"),
+		Attendees:      []string{"alex@example.com", "sam@example.com"},
+		AttendeeDetails: []*proto.Attendee{
+			{Email: "alex@example.com", DisplayName: ptr(`Alex "AJ" Jones`), ResponseStatus: ptr("accepted")},
+			{Email: "sam@example.com", Optional: true, ResponseStatus: ptr("needsAction")},
+		},
+	})
+
+	for _, want := range []string{
+		"\nORGANIZER;CN=\"Smith, Pat\":mailto:boss@example.com\n",
+		"\nATTENDEE;CN=Alex AJ Jones;ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED:mailto:alex@example.com\n",
+		"\nATTENDEE;ROLE=OPT-PARTICIPANT;PARTSTAT=NEEDS-ACTION:mailto:sam@example.com\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("expected %q in\n%s", want, ics)
+		}
+	}
+	if n := strings.Count(ics, "ATTENDEE"); n != 2 {
+		t.Errorf("expected 2 ATTENDEE lines, got %d:\n%s", n, ics)
+	}
+
+	// Without details, attendees fall back to bare addresses
+	ics = renderICS(t, &proto.Event{Id: "plain", Attendees: []string{"alex@example.com"}, OrganizerEmail: ptr("boss@example.com")})
+	for _, want := range []string{"\nORGANIZER:mailto:boss@example.com\n", "\nATTENDEE:mailto:alex@example.com\n"} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("expected %q in\n%s", want, ics)
+		}
+	}
+}
+
+func TestEventTemplateICS_TimeZone(t *testing.T) {
+	start := time.Date(2024, 7, 1, 13, 0, 0, 0, time.UTC)
+	ics := renderICS(t, &proto.Event{
+		Id:         "zoned",
+		CalendarId: "primary",
+		Summary:    "Standup",
+		StartTime:  timestamppb.New(start),
+		EndTime:    timestamppb.New(start.Add(30 * time.Minute)),
+		TimeZone:   ptr("America/New_York"),
+	})
+
+	for _, want := range []string{
+		"\nDTSTART;TZID=America/New_York:20240701T090000\n",
+		"\nDTEND;TZID=America/New_York:20240701T093000\n",
+		"\nBEGIN:VTIMEZONE\nTZID:America/New_York\n" +
+			"BEGIN:DAYLIGHT\nDTSTART:20240310T020000\nTZOFFSETFROM:-0500\nTZOFFSETTO:-0400\nTZNAME:EDT\nRRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU\nEND:DAYLIGHT\n" +
+			"BEGIN:STANDARD\nDTSTART:20241103T020000\nTZOFFSETFROM:-0400\nTZOFFSETTO:-0500\nTZNAME:EST\nRRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1SU\nEND:STANDARD\n" +
+			"END:VTIMEZONE\nBEGIN:VEVENT\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("expected %q in\n%s", want, ics)
+		}
+	}
+
+	// Without a zone, times stay in UTC and no VTIMEZONE is emitted
+	ics = renderICS(t, &proto.Event{Id: "utc", StartTime: timestamppb.New(start)})
+	if !strings.Contains(ics, "\nDTSTART:20240701T130000Z\n") || strings.Contains(ics, "VTIMEZONE") {
+		t.Errorf("expected a UTC start and no VTIMEZONE:\n%s", ics)
+	}
+}
+
+func TestEventTemplateICS_AllDay(t *testing.T) {
+	ics := renderICS(t, &proto.Event{
+		Id:         "offsite",
+		CalendarId: "primary",
+		Summary:    "Offsite",
+		StartTime:  timestamppb.New(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)),
+		EndTime:    timestamppb.New(time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC)),
+		AllDay:     ptr(true),
+		TimeZone:   ptr("America/New_York"),
+	})
+
+	for _, want := range []string{"\nDTSTART;VALUE=DATE:20240701\n", "\nDTEND;VALUE=DATE:20240703\n"} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("expected %q in\n%s", want, ics)
+		}
+	}
+	if strings.Contains(ics, "VTIMEZONE") || strings.Contains(ics, "DTSTART:") || strings.Contains(ics, "DTEND:") {
+		t.Errorf("expected dates only and no VTIMEZONE:\n%s", ics)
+	}
+}
+
+func TestICSVTimezone(t *testing.T) {
+	ts := timestamppb.New(time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC))
+
+	// Last-Sunday rules
+	berlin := icsVTimezone("Europe/Berlin", ts)
+	for _, want := range []string{
+		"DTSTART:20240331T020000\nTZOFFSETFROM:+0100\nTZOFFSETTO:+0200\nTZNAME:CEST\nRRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU",
+		"DTSTART:20241027T030000\nTZOFFSETFROM:+0200\nTZOFFSETTO:+0100\nTZNAME:CET\nRRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU",
+	} {
+		if !strings.Contains(berlin, want) {
+			t.Errorf("expected %q in\n%s", want, berlin)
+		}
+	}
+
+	// No daylight saving time
+	tokyo := icsVTimezone("Asia/Tokyo", ts)
+	want := "BEGIN:VTIMEZONE\nTZID:Asia/Tokyo\nBEGIN:STANDARD\nDTSTART:19700101T000000\nTZOFFSETFROM:+0900\nTZOFFSETTO:+0900\nTZNAME:JST\nEND:STANDARD\nEND:VTIMEZONE"
+	if tokyo != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, tokyo)
+	}
+
+	for _, zone := range []string{"", "UTC", "Not/AZone"} {
+		if got := icsVTimezone(zone, ts); got != "" {
+			t.Errorf("expected no VTIMEZONE for %q, got\n%s", zone, got)
+		}
+	}
+}
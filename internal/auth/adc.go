@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// GetDefaultCredentialsClient creates an authenticated HTTP client from
+// Application Default Credentials, requesting the given scopes (full calendar
+// access if none)
+func GetDefaultCredentialsClient(ctx context.Context, scopes []string) (*http.Client, error) {
+	scopes, err := resolveScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := google.FindDefaultCredentials(ctx, scopes...)
+	if err != nil {
+		return nil, fmt.Errorf("application default credentials unavailable: %w", err)
+	}
+
+	return oauth2.NewClient(ctx, creds.TokenSource), nil
+}
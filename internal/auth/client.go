@@ -2,47 +2,123 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os/exec"
 	"runtime"
+	"sync"
+	"time"
 
 	"golang.org/x/oauth2"
 )
 
 const (
-	localServerPort = "8080"
-	callbackPath    = "/oauth2callback"
+	defaultCallbackPort = 8080
+	callbackPath        = "/oauth2callback"
+
+	// callbackShutdownTimeout bounds how long the callback server waits for
+	// the browser's request to finish once the flow is over
+	callbackShutdownTimeout = 5 * time.Second
 )
 
-// GetClient returns an authenticated HTTP client for Google Calendar API
-func GetClient(ctx context.Context, config *oauth2.Config, tokenPath string) (*http.Client, error) {
-	// Try to load existing token
-	tok, err := LoadToken(tokenPath)
-	if err == nil {
-		// Token loaded successfully, return client
-		return config.Client(ctx, tok), nil
+// FlowOption configures how an OAuth token is obtained and cached
+type FlowOption func(*flowOptions)
+
+type flowOptions struct {
+	callbackPort int
+	openBrowser  func(url string) error
+	device       bool
+	passphrase   string
+}
+
+func newFlowOptions(opts []FlowOption) flowOptions {
+	options := flowOptions{
+		callbackPort: defaultCallbackPort,
+		openBrowser:  openBrowser,
+	}
+	for _, opt := range opts {
+		opt(&options)
 	}
+	return options
+}
 
-	// Token not found, initiate OAuth flow
-	tok, err = GetTokenFromWeb(ctx, config)
-	if err != nil {
-		return nil, fmt.Errorf("unable to get token from web: %w", err)
+// WithCallbackPort sets the local port the browser is redirected to once the
+// user grants access. Port 0 binds any free port.
+func WithCallbackPort(port int) FlowOption {
+	return func(o *flowOptions) {
+		o.callbackPort = port
+	}
+}
+
+// WithBrowserOpener replaces the function used to open the authorization URL
+// (by default the platform's browser launcher)
+func WithBrowserOpener(open func(url string) error) FlowOption {
+	return func(o *flowOptions) {
+		o.openBrowser = open
 	}
+}
+
+// WithDeviceFlow authorizes with the device flow (see GetTokenFromDevice)
+// instead of a browser redirect to a local server
+func WithDeviceFlow() FlowOption {
+	return func(o *flowOptions) {
+		o.device = true
+	}
+}
 
-	// Save the token for future use
-	if err := SaveToken(tokenPath, tok); err != nil {
-		return nil, fmt.Errorf("unable to save token: %w", err)
+// WithTokenPassphrase encrypts the cached token at rest with a key derived
+// from passphrase (see SaveTokenWithPassphrase)
+func WithTokenPassphrase(passphrase string) FlowOption {
+	return func(o *flowOptions) {
+		o.passphrase = passphrase
 	}
+}
 
-	return config.Client(ctx, tok), nil
+// GetClient returns an authenticated HTTP client for Google Calendar API
+func GetClient(ctx context.Context, config *oauth2.Config, tokenPath string, opts ...FlowOption) (*http.Client, error) {
+	return cachedTokenClient(ctx, config, tokenPath, opts...)
+}
+
+// getNewToken asks the user to authorize cali with the configured flow
+func getNewToken(ctx context.Context, config *oauth2.Config, opts ...FlowOption) (*oauth2.Token, error) {
+	if newFlowOptions(opts).device {
+		return GetTokenFromDevice(ctx, config)
+	}
+
+	tok, err := GetTokenFromWeb(ctx, config, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get token from web: %w", err)
+	}
+	return tok, nil
 }
 
 // GetTokenFromWeb initiates browser-based OAuth flow
-func GetTokenFromWeb(ctx context.Context, config *oauth2.Config) (*oauth2.Token, error) {
+func GetTokenFromWeb(ctx context.Context, config *oauth2.Config, opts ...FlowOption) (*oauth2.Token, error) {
+	options := newFlowOptions(opts)
+
+	// Bind the callback port up front so a busy port fails fast and port 0
+	// resolves to the port actually assigned
+	listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", options.callbackPort))
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen for OAuth callback on port %d: %w", options.callbackPort, err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+
 	// Set redirect URL to local server
-	config.RedirectURL = fmt.Sprintf("http://localhost:%s%s", localServerPort, callbackPath)
+	config.RedirectURL = fmt.Sprintf("http://localhost:%d%s", port, callbackPath)
+
+	// A fresh, unguessable state ties the callback to this flow, so a forged
+	// redirect from another site can't inject its own authorization code
+	state, err := newState()
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
 
 	// Channel to receive authorization code
 	codeCh := make(chan string, 1)
@@ -51,38 +127,71 @@ func GetTokenFromWeb(ctx context.Context, config *oauth2.Config) (*oauth2.Token,
 	// Create HTTP server to receive callback
 	mux := http.NewServeMux()
 	server := &http.Server{
-		Addr:    ":" + localServerPort,
 		Handler: mux,
 	}
 
+	// Release the port however the flow ends, letting the browser's request
+	// finish even if ctx was cancelled
+	shutdown := sync.OnceFunc(func() {
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), callbackShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			slog.Warn("failed to shut down OAuth callback server", "error", err)
+		}
+		// Shutdown only closes the listener once Serve has taken it, which the
+		// flow can end before
+		listener.Close()
+	})
+	defer shutdown()
+
+	// Only the first outcome is waited for, so later ones are dropped
+	// rather than blocking their handler (and with it the shutdown)
+	report := func(err error) {
+		select {
+		case errCh <- err:
+		default:
+		}
+	}
+
 	// Handle OAuth callback
 	mux.HandleFunc(callbackPath, func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("state")), []byte(state)) != 1 {
+			report(fmt.Errorf("OAuth callback state mismatch (possible CSRF); try authorizing again"))
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Error: Invalid state parameter")
+			return
+		}
+
 		code := r.URL.Query().Get("code")
 		if code == "" {
-			errCh <- fmt.Errorf("no authorization code received")
+			report(fmt.Errorf("no authorization code received"))
 			fmt.Fprintf(w, "Error: No authorization code received")
 			return
 		}
 
-		codeCh <- code
+		select {
+		case codeCh <- code:
+		default:
+		}
 		fmt.Fprintf(w, "Authorization successful! You can close this window and return to the terminal.")
 	})
 
-	// Start server in background
+	// Serve on the listener bound above; binding can no longer fail, so any
+	// error here is the server failing mid-flow
 	go func() {
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			errCh <- fmt.Errorf("failed to start local server: %w", err)
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			report(fmt.Errorf("OAuth callback server failed: %w", err))
 		}
 	}()
 
 	// Generate authorization URL
-	authURL := config.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
+	authURL := config.AuthCodeURL(state, oauth2.AccessTypeOffline)
 
 	// Open browser
 	slog.Info("opening browser for authorization")
 	slog.Info("if the browser doesn't open automatically, visit this URL", "url", authURL)
 
-	if err := openBrowser(authURL); err != nil {
+	if err := options.openBrowser(authURL); err != nil {
 		slog.Warn("failed to open browser automatically", "error", err)
 	}
 
@@ -92,15 +201,11 @@ func GetTokenFromWeb(ctx context.Context, config *oauth2.Config) (*oauth2.Token,
 	case code = <-codeCh:
 		// Got authorization code
 	case err := <-errCh:
-		server.Shutdown(ctx)
 		return nil, err
 	case <-ctx.Done():
-		server.Shutdown(ctx)
 		return nil, ctx.Err()
 	}
-
-	// Shutdown server
-	server.Shutdown(ctx)
+	shutdown()
 
 	// Exchange authorization code for token
 	tok, err := config.Exchange(ctx, code)
@@ -111,6 +216,15 @@ func GetTokenFromWeb(ctx context.Context, config *oauth2.Config) (*oauth2.Token,
 	return tok, nil
 }
 
+// newState returns a random OAuth state parameter
+func newState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("unable to generate OAuth state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
 // openBrowser opens the specified URL in the default browser
 func openBrowser(url string) error {
 	var cmd *exec.Cmd
@@ -6,18 +6,23 @@ import (
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
-	"google.golang.org/api/calendar/v3"
 )
 
-// LoadConfig loads OAuth credentials from the specified file path
-func LoadConfig(credentialsPath string) (*oauth2.Config, error) {
+// LoadConfig loads OAuth credentials from the specified file path, requesting
+// the given scopes (full calendar access if none)
+func LoadConfig(credentialsPath string, scopes []string) (*oauth2.Config, error) {
+	scopes, err := resolveScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
 	b, err := os.ReadFile(credentialsPath)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read credentials file: %w", err)
 	}
 
 	// Parse the credentials file and create OAuth config
-	config, err := google.ConfigFromJSON(b, calendar.CalendarScope)
+	config, err := google.ConfigFromJSON(b, scopes...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse client secret file to config: %w", err)
 	}
@@ -5,29 +5,65 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/drewfead/cali/proto"
 	"golang.org/x/oauth2/google"
-	"google.golang.org/api/calendar/v3"
 )
 
 // GetClientFromConfig creates an authenticated HTTP client from typed config
 func GetClientFromConfig(ctx context.Context, cfg *proto.AuthConfig, tokenPath string) (*http.Client, error) {
+	// Explicitly requested ADC wins over any embedded credentials
+	if cfg.UseAdc {
+		return GetDefaultCredentialsClient(ctx, cfg.Scopes)
+	}
+
 	// Try service account first
 	if cfg.ServiceAccount != nil && cfg.ServiceAccount.ClientEmail != "" {
-		return GetServiceAccountClientFromConfig(ctx, cfg.ServiceAccount)
+		return GetServiceAccountClientFromConfig(ctx, cfg.ServiceAccount, cfg.Scopes)
 	}
 
 	// Fall back to OAuth
 	if cfg.OauthClient != nil && cfg.OauthClient.ClientId != "" {
-		return GetOAuthClientFromConfig(ctx, cfg.OauthClient, tokenPath)
+		var opts []FlowOption
+		if cfg.OauthCallbackPort != nil {
+			opts = append(opts, WithCallbackPort(int(cfg.GetOauthCallbackPort())))
+		}
+		if cfg.TokenPassphrase != "" {
+			opts = append(opts, WithTokenPassphrase(cfg.TokenPassphrase))
+		}
+		switch cfg.OauthFlow {
+		case "", "browser":
+		case "device":
+			opts = append(opts, WithDeviceFlow())
+		default:
+			return nil, fmt.Errorf("unknown OAuth flow %q (want browser or device)", cfg.OauthFlow)
+		}
+		return GetOAuthClientFromConfig(ctx, cfg.OauthClient, cfg.Scopes, tokenPath, opts...)
 	}
 
-	return nil, fmt.Errorf("no credentials configured (need service_account or oauth_client)")
+	// Fall back to the environment's credentials (e.g. GKE or Cloud Run)
+	client, err := GetDefaultCredentialsClient(ctx, cfg.Scopes)
+	if err != nil {
+		return nil, fmt.Errorf("no credentials configured (need service_account, oauth_client, or application default credentials): %w", err)
+	}
+	return client, nil
 }
 
-// GetServiceAccountClientFromConfig creates a service account client from typed config
-func GetServiceAccountClientFromConfig(ctx context.Context, creds *proto.ServiceAccountCredentials) (*http.Client, error) {
+// GetServiceAccountClientFromConfig creates a service account client from typed
+// config, requesting the given scopes (full calendar access if none)
+func GetServiceAccountClientFromConfig(ctx context.Context, creds *proto.ServiceAccountCredentials, scopes []string) (*http.Client, error) {
+	scopes, err := resolveScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Catch incomplete config here, where the missing field can be named,
+	// rather than deep inside JWT parsing or signing
+	if err := validateServiceAccount(creds); err != nil {
+		return nil, err
+	}
+
 	// Convert proto message to JSON that google.JWTConfigFromJSON expects
 	jsonData, err := serviceAccountToJSON(creds)
 	if err != nil {
@@ -35,16 +71,25 @@ func GetServiceAccountClientFromConfig(ctx context.Context, creds *proto.Service
 	}
 
 	// Create JWT config from the JSON
-	config, err := google.JWTConfigFromJSON(jsonData, calendar.CalendarScope)
+	config, err := google.JWTConfigFromJSON(jsonData, scopes...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse service account config: %w", err)
 	}
 
+	// With domain-wide delegation, tokens are issued on behalf of this user
+	config.Subject = creds.ImpersonateEmail
+
 	return config.Client(ctx), nil
 }
 
-// GetOAuthClientFromConfig creates an OAuth client from typed config
-func GetOAuthClientFromConfig(ctx context.Context, creds *proto.OAuthClientCredentials, tokenPath string) (*http.Client, error) {
+// GetOAuthClientFromConfig creates an OAuth client from typed config,
+// requesting the given scopes (full calendar access if none)
+func GetOAuthClientFromConfig(ctx context.Context, creds *proto.OAuthClientCredentials, scopes []string, tokenPath string, opts ...FlowOption) (*http.Client, error) {
+	scopes, err := resolveScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert proto message to JSON that google.ConfigFromJSON expects
 	jsonData, err := oauthClientToJSON(creds)
 	if err != nil {
@@ -52,30 +97,35 @@ func GetOAuthClientFromConfig(ctx context.Context, creds *proto.OAuthClientCrede
 	}
 
 	// Parse OAuth config
-	config, err := google.ConfigFromJSON(jsonData, calendar.CalendarScope)
+	config, err := google.ConfigFromJSON(jsonData, scopes...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse OAuth config: %w", err)
 	}
 
-	// Try to load existing token
-	tok, err := LoadToken(tokenPath)
-	if err == nil {
-		// Token loaded successfully
-		return config.Client(ctx, tok), nil
-	}
+	return cachedTokenClient(ctx, config, tokenPath, opts...)
+}
 
-	// Token not found, initiate OAuth flow
-	tok, err = GetTokenFromWeb(ctx, config)
-	if err != nil {
-		return nil, fmt.Errorf("unable to get token from web: %w", err)
+// validateServiceAccount checks that the fields needed to sign in as a
+// service account are set, naming the first one that isn't
+func validateServiceAccount(creds *proto.ServiceAccountCredentials) error {
+	required := []struct {
+		field string
+		value string
+	}{
+		{"type", creds.GetType()},
+		{"client_email", creds.GetClientEmail()},
+		{"private_key", creds.GetPrivateKey()},
+		{"token_uri", creds.GetTokenUri()},
 	}
-
-	// Save the token
-	if err := SaveToken(tokenPath, tok); err != nil {
-		return nil, fmt.Errorf("unable to save token: %w", err)
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			return fmt.Errorf("service account config is missing %s (copy it from the service account's JSON key file)", r.field)
+		}
 	}
-
-	return config.Client(ctx, tok), nil
+	if creds.Type != "service_account" {
+		return fmt.Errorf("service account config has type %q, want \"service_account\"", creds.Type)
+	}
+	return nil
 }
 
 // serviceAccountToJSON converts ServiceAccountCredentials proto to JSON
@@ -99,8 +149,19 @@ func serviceAccountToJSON(creds *proto.ServiceAccountCredentials) ([]byte, error
 
 // oauthClientToJSON converts OAuthClientCredentials proto to JSON
 func oauthClientToJSON(creds *proto.OAuthClientCredentials) ([]byte, error) {
-	// Create a map matching Google's expected JSON structure for desktop apps
-	installed := map[string]interface{}{
+	// Google wraps desktop app credentials under "installed" and web
+	// application credentials under "web"
+	clientType := creds.ClientType
+	switch clientType {
+	case "":
+		clientType = "installed"
+	case "installed", "web":
+	default:
+		return nil, fmt.Errorf("unknown OAuth client type %q (want installed or web)", clientType)
+	}
+
+	// Create a map matching Google's expected JSON structure
+	client := map[string]interface{}{
 		"client_id":                   creds.ClientId,
 		"project_id":                  creds.ProjectId,
 		"auth_uri":                    creds.AuthUri,
@@ -111,7 +172,7 @@ func oauthClientToJSON(creds *proto.OAuthClientCredentials) ([]byte, error) {
 	}
 
 	data := map[string]interface{}{
-		"installed": installed,
+		clientType: client,
 	}
 
 	return json.Marshal(data)
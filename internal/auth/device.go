@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// GetTokenFromDevice runs the OAuth device flow for machines without a
+// browser: it prints a URL and code to enter on another device, then polls
+// the token endpoint until the user approves, denies, or the code expires.
+// The OAuth client must be of the "TVs and Limited Input devices" type.
+func GetTokenFromDevice(ctx context.Context, config *oauth2.Config) (*oauth2.Token, error) {
+	// google.ConfigFromJSON only fills in the auth and token URLs
+	if config.Endpoint.DeviceAuthURL == "" {
+		config.Endpoint.DeviceAuthURL = google.Endpoint.DeviceAuthURL
+	}
+
+	da, err := config.DeviceAuth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start device authorization: %w", err)
+	}
+
+	slog.Info("to authorize cali, visit this URL on any device and enter the code",
+		"url", da.VerificationURI, "code", da.UserCode)
+
+	tok, err := config.DeviceAccessToken(ctx, da)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("device authorization timed out; try again: %w", err)
+		}
+		return nil, fmt.Errorf("device authorization failed: %w", err)
+	}
+
+	return tok, nil
+}
@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// ErrTokenLocked is returned when an encrypted token file can't be decrypted:
+// no passphrase was given, or it was the wrong one
+var ErrTokenLocked = errors.New("unable to decrypt token")
+
+// Encrypted token files start with this header, then the PBKDF2 salt, the
+// AES-GCM nonce, and the sealed token JSON. Plaintext token files are JSON
+// objects, so they can never start with it.
+var encryptedTokenHeader = []byte("cali-token-aesgcm-v1\n")
+
+const (
+	tokenSaltSize      = 16
+	tokenKeyIterations = 600_000
+	tokenKeySize       = 32 // AES-256
+)
+
+// isEncryptedToken reports whether data was written by sealToken
+func isEncryptedToken(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedTokenHeader)
+}
+
+// sealToken encrypts plaintext with a key derived from passphrase
+func sealToken(plaintext []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, tokenSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("unable to generate salt: %w", err)
+	}
+	gcm, err := tokenCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("unable to generate nonce: %w", err)
+	}
+
+	out := append([]byte{}, encryptedTokenHeader...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	// The header is authenticated too, so it can't be swapped for another version's
+	return gcm.Seal(out, nonce, plaintext, encryptedTokenHeader), nil
+}
+
+// openToken decrypts data written by sealToken
+func openToken(data []byte, passphrase string) ([]byte, error) {
+	data = data[len(encryptedTokenHeader):]
+	if len(data) < tokenSaltSize {
+		return nil, fmt.Errorf("encrypted token is truncated")
+	}
+	salt, data := data[:tokenSaltSize], data[tokenSaltSize:]
+	gcm, err := tokenCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("encrypted token is truncated")
+	}
+	nonce, sealed := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, sealed, encryptedTokenHeader)
+	if err != nil {
+		return nil, fmt.Errorf("%w (wrong passphrase or corrupted file)", ErrTokenLocked)
+	}
+	return plaintext, nil
+}
+
+// tokenCipher derives an AES-256-GCM cipher from passphrase and salt
+func tokenCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, tokenKeyIterations, tokenKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive token key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create token cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"fmt"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// knownScopes are the Google Calendar scopes cali may request
+var knownScopes = map[string]bool{
+	calendar.CalendarScope:                     true,
+	calendar.CalendarReadonlyScope:             true,
+	calendar.CalendarEventsScope:               true,
+	calendar.CalendarEventsReadonlyScope:       true,
+	calendar.CalendarCalendarlistReadonlyScope: true,
+	calendar.CalendarFreebusyScope:             true,
+	calendar.CalendarEventsOwnedScope:          true,
+	calendar.CalendarEventsOwnedReadonlyScope:  true,
+	calendar.CalendarSettingsReadonlyScope:     true,
+	calendar.CalendarCalendarsReadonlyScope:    true,
+	calendar.CalendarCalendarlistScope:         true,
+	calendar.CalendarCalendarsScope:            true,
+	calendar.CalendarEventsFreebusyScope:       true,
+	calendar.CalendarEventsPublicReadonlyScope: true,
+	calendar.CalendarAclsScope:                 true,
+	calendar.CalendarAclsReadonlyScope:         true,
+	calendar.CalendarAppCreatedScope:           true,
+}
+
+// resolveScopes validates the requested scopes, defaulting to full calendar
+// access when none are given
+func resolveScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return []string{calendar.CalendarScope}, nil
+	}
+	for _, scope := range scopes {
+		if !knownScopes[scope] {
+			return nil, fmt.Errorf("unknown OAuth scope %q", scope)
+		}
+	}
+	return scopes, nil
+}
@@ -7,11 +7,16 @@ import (
 	"os"
 
 	"golang.org/x/oauth2/google"
-	"google.golang.org/api/calendar/v3"
 )
 
-// GetServiceAccountClient creates an authenticated HTTP client using a service account
-func GetServiceAccountClient(ctx context.Context, keyPath string) (*http.Client, error) {
+// GetServiceAccountClient creates an authenticated HTTP client using a service
+// account, requesting the given scopes (full calendar access if none)
+func GetServiceAccountClient(ctx context.Context, keyPath string, scopes []string) (*http.Client, error) {
+	scopes, err := resolveScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
 	data, err := os.ReadFile(keyPath)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read service account key: %w", err)
@@ -27,7 +32,7 @@ func GetServiceAccountClient(ctx context.Context, keyPath string) (*http.Client,
 	}
 
 	// Create JWT config from service account JSON
-	config, err := google.JWTConfigFromJSON(data, calendar.CalendarScope)
+	config, err := google.JWTConfigFromJSON(data, scopes...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse service account key: %w", err)
 	}
@@ -1,26 +1,50 @@
 package auth
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
+	"strings"
 
 	"golang.org/x/oauth2"
 )
 
-const tokenFilePermMode = 0o600
+const (
+	tokenFilePermMode = 0o600
+	revokeURL         = "https://oauth2.googleapis.com/revoke"
+)
 
 // LoadToken loads an OAuth token from the specified file path
 func LoadToken(tokenPath string) (*oauth2.Token, error) {
-	f, err := os.Open(tokenPath)
+	return LoadTokenWithPassphrase(tokenPath, "")
+}
+
+// LoadTokenWithPassphrase loads an OAuth token from the specified file path,
+// decrypting it with passphrase if it was saved encrypted. Plaintext token
+// files load regardless of the passphrase.
+func LoadTokenWithPassphrase(tokenPath, passphrase string) (*oauth2.Token, error) {
+	data, err := os.ReadFile(tokenPath)
 	if err != nil {
 		return nil, fmt.Errorf("unable to open token file: %w", err)
 	}
-	defer f.Close()
+
+	if isEncryptedToken(data) {
+		if passphrase == "" {
+			return nil, fmt.Errorf("%w: %s is encrypted; set token_passphrase to load it", ErrTokenLocked, tokenPath)
+		}
+		data, err = openToken(data, passphrase)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	tok := &oauth2.Token{}
-	err = json.NewDecoder(f).Decode(tok)
-	if err != nil {
+	if err := json.Unmarshal(data, tok); err != nil {
 		return nil, fmt.Errorf("unable to decode token: %w", err)
 	}
 
@@ -29,16 +53,137 @@ func LoadToken(tokenPath string) (*oauth2.Token, error) {
 
 // SaveToken saves an OAuth token to the specified file path with restricted permissions
 func SaveToken(tokenPath string, token *oauth2.Token) error {
+	return SaveTokenWithPassphrase(tokenPath, token, "")
+}
+
+// SaveTokenWithPassphrase saves an OAuth token to the specified file path with
+// restricted permissions, encrypting it with AES-GCM under a key derived from
+// passphrase unless passphrase is empty
+func SaveTokenWithPassphrase(tokenPath string, token *oauth2.Token, passphrase string) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("unable to encode token: %w", err)
+	}
+	if passphrase != "" {
+		data, err = sealToken(data, passphrase)
+		if err != nil {
+			return fmt.Errorf("unable to encrypt token: %w", err)
+		}
+	}
+
 	f, err := os.OpenFile(tokenPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, tokenFilePermMode)
 	if err != nil {
 		return fmt.Errorf("unable to create token file: %w", err)
 	}
 	defer f.Close()
 
-	err = json.NewEncoder(f).Encode(token)
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("unable to write token: %w", err)
+	}
+
+	return nil
+}
+
+// cachedTokenClient returns a client authorized with the token cached at
+// tokenPath, refreshing it if it has expired, or runs the configured flow to
+// get (and cache) a new one if there is none
+func cachedTokenClient(ctx context.Context, config *oauth2.Config, tokenPath string, opts ...FlowOption) (*http.Client, error) {
+	options := newFlowOptions(opts)
+
+	// Try to load existing token
+	tok, err := LoadTokenWithPassphrase(tokenPath, options.passphrase)
+	if err == nil {
+		// Token loaded successfully; refresh it first if it has expired
+		tok, err = ensureFreshToken(ctx, config, tokenPath, options.passphrase, tok)
+		if err != nil {
+			return nil, err
+		}
+		return config.Client(ctx, tok), nil
+	}
+	if errors.Is(err, ErrTokenLocked) {
+		// Don't overwrite a token that's merely encrypted with another passphrase
+		return nil, err
+	}
+
+	// Token not found, initiate OAuth flow
+	tok, err = getNewToken(ctx, config, opts...)
 	if err != nil {
-		return fmt.Errorf("unable to encode token: %w", err)
+		return nil, err
+	}
+
+	// Save the token for future use
+	if err := SaveTokenWithPassphrase(tokenPath, tok, options.passphrase); err != nil {
+		return nil, fmt.Errorf("unable to save token: %w", err)
 	}
 
+	return config.Client(ctx, tok), nil
+}
+
+// ensureFreshToken returns tok if it is still valid. An expired token is
+// refreshed through config and the result saved back to tokenPath (encrypted
+// with passphrase, if set); one with no refresh token can't be, so the user
+// must re-authenticate.
+func ensureFreshToken(ctx context.Context, config *oauth2.Config, tokenPath, passphrase string, tok *oauth2.Token) (*oauth2.Token, error) {
+	if tok.Valid() {
+		return tok, nil
+	}
+	if tok.RefreshToken == "" {
+		return nil, fmt.Errorf("OAuth token in %s has expired and has no refresh token; delete it and run cali again to re-authenticate", tokenPath)
+	}
+
+	refreshed, err := config.TokenSource(ctx, tok).Token()
+	if err != nil {
+		return nil, fmt.Errorf("unable to refresh expired OAuth token (delete %s and run cali again to re-authenticate): %w", tokenPath, err)
+	}
+	if err := SaveTokenWithPassphrase(tokenPath, refreshed, passphrase); err != nil {
+		return nil, fmt.Errorf("unable to save refreshed token: %w", err)
+	}
+	return refreshed, nil
+}
+
+// RevokeToken revokes tok with Google, invalidating both its access and
+// refresh tokens, then deletes the token file at tokenPath. A token Google no
+// longer recognizes (already revoked or expired) is just deleted. Requests use
+// the *http.Client in ctx under oauth2.HTTPClient, if any.
+func RevokeToken(ctx context.Context, tok *oauth2.Token, tokenPath string) error {
+	// Revoking the refresh token also revokes the access tokens issued from it
+	token := tok.RefreshToken
+	if token == "" {
+		token = tok.AccessToken
+	}
+	if token == "" {
+		return fmt.Errorf("token in %s has nothing to revoke", tokenPath)
+	}
+
+	client := http.DefaultClient
+	if c, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok {
+		client = c
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, revokeURL, strings.NewReader(url.Values{"token": {token}}.Encode()))
+	if err != nil {
+		return fmt.Errorf("unable to build revocation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to revoke token: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(body, &e) != nil || e.Error != "invalid_token" {
+			return fmt.Errorf("unable to revoke token: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+		}
+	}
+
+	if err := os.Remove(tokenPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("token revoked but unable to delete %s: %w", tokenPath, err)
+	}
 	return nil
 }
@@ -0,0 +1,187 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drewfead/cali/proto"
+	"google.golang.org/api/calendar/v3"
+)
+
+// defaultBulkConcurrency is the number of in-flight requests used by bulk
+// operations when no concurrency is specified
+const defaultBulkConcurrency = 4
+
+// BulkOptions controls how a bulk operation applies a batch of requests
+type BulkOptions struct {
+	// Concurrency bounds the number of requests in flight (defaults to 4)
+	Concurrency int
+	// StopOnError stops dispatching further requests after the first failure
+	StopOnError bool
+}
+
+// BulkResult is the outcome of a single request within a bulk operation
+type BulkResult struct {
+	Index   int             // position of the request in the input slice
+	Event   *calendar.Event // resulting event (nil on error or when skipped)
+	Err     error           // request error, if any
+	Skipped bool            // true if the request was never attempted (StopOnError or cancellation)
+}
+
+// BulkUpdate applies each update request with bounded concurrency and returns
+// one result per request, in input order. Individual failures do not stop the
+// batch unless StopOnError is set, in which case requests not yet dispatched
+// are reported as skipped.
+func (c *Client) BulkUpdate(ctx context.Context, reqs []*proto.UpdateEventRequest, opts BulkOptions) []BulkResult {
+	return runBulk(ctx, len(reqs), opts, func(ctx context.Context, i int) (*calendar.Event, error) {
+		return c.UpdateEvent(ctx, reqs[i])
+	})
+}
+
+// CreateEvents creates each event with bounded concurrency over the client's
+// single authenticated session and returns one result per request, in input
+// order. Failures and cancellation behave as in BulkUpdate: requests not yet
+// dispatched when ctx is cancelled are reported as skipped.
+func (c *Client) CreateEvents(ctx context.Context, reqs []*proto.AddEventRequest, opts BulkOptions) []BulkResult {
+	return runBulk(ctx, len(reqs), opts, func(ctx context.Context, i int) (*calendar.Event, error) {
+		return c.CreateEvent(ctx, reqs[i])
+	})
+}
+
+// ErrUnfilteredDelete is returned by DeleteEvents when the filter would match
+// every event in the calendar without ConfirmAll being set
+var ErrUnfilteredDelete = errors.New("refusing to delete every event without ConfirmAll")
+
+// DeleteFilter selects the events removed by DeleteEvents
+type DeleteFilter struct {
+	After  time.Time // only events ending after this (zero for no lower bound)
+	Before time.Time // only events starting before this (zero for no upper bound)
+	Query  string    // free-text search, as in SearchEvents
+	// ConfirmAll allows deleting every event when no other filter is set
+	ConfirmAll bool
+	// Concurrency bounds the number of deletes in flight (defaults to 4)
+	Concurrency int
+}
+
+// DeleteSummary is the outcome of DeleteEvents
+type DeleteSummary struct {
+	Matched int              // events the filter selected
+	Deleted int              // events deleted successfully
+	Failed  map[string]error // per-event errors, by event ID
+}
+
+// DeleteEvents deletes every event of a calendar matching filter, with
+// bounded concurrency, and reports how many were deleted along with the
+// error for each event that wasn't. Recurring series are matched and deleted
+// as a whole. A filter with no window or query is rejected with
+// ErrUnfilteredDelete unless ConfirmAll is set, to guard against wiping a
+// calendar by accident.
+func (c *Client) DeleteEvents(ctx context.Context, calendarID string, filter DeleteFilter) (*DeleteSummary, error) {
+	query := strings.TrimSpace(filter.Query)
+	if filter.After.IsZero() && filter.Before.IsZero() && query == "" && !filter.ConfirmAll {
+		return nil, ErrUnfilteredDelete
+	}
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+
+	// Only IDs are needed to delete
+	call := c.service.Events.List(calendarID).Fields("nextPageToken", "items(id)")
+	if !filter.After.IsZero() {
+		call = call.TimeMin(filter.After.Format(time.RFC3339))
+	}
+	if !filter.Before.IsZero() {
+		call = call.TimeMax(filter.Before.Format(time.RFC3339))
+	}
+	if query != "" {
+		call = call.Q(query)
+	}
+
+	listCtx, cancel := c.withTimeout(ctx)
+	var ids []string
+	err := c.retry(listCtx, func() error {
+		// Start over on retry so pages aren't collected twice
+		ids = nil
+		return call.Pages(listCtx, func(page *calendar.Events) error {
+			for _, event := range page.Items {
+				ids = append(ids, event.Id)
+			}
+			return nil
+		})
+	})
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve events: %w", err)
+	}
+
+	results := runBulk(ctx, len(ids), BulkOptions{Concurrency: filter.Concurrency}, func(ctx context.Context, i int) (*calendar.Event, error) {
+		id := ids[i]
+		return nil, c.DeleteEvent(ctx, &proto.DeleteEventRequest{EventId: id, CalendarId: &calendarID})
+	})
+
+	summary := &DeleteSummary{Matched: len(ids), Failed: make(map[string]error)}
+	for _, result := range results {
+		switch {
+		case result.Skipped:
+			summary.Failed[ids[result.Index]] = fmt.Errorf("not attempted: %w", ctx.Err())
+		case result.Err != nil:
+			summary.Failed[ids[result.Index]] = result.Err
+		default:
+			summary.Deleted++
+		}
+	}
+	return summary, nil
+}
+
+// runBulk calls do for each of n requests, at most opts.Concurrency at a time
+func runBulk(ctx context.Context, n int, opts BulkOptions, do func(ctx context.Context, i int) (*calendar.Event, error)) []BulkResult {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+
+	results := make([]BulkResult, n)
+	for i := range results {
+		results[i] = BulkResult{Index: i, Skipped: true}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+dispatch:
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case sem <- struct{}{}:
+		}
+
+		// Re-check after acquiring a slot, since a failure may have cancelled the batch meanwhile
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			event, err := do(ctx, i)
+			results[i] = BulkResult{Index: i, Event: event, Err: err}
+			if err != nil && opts.StopOnError {
+				cancel()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	return results
+}
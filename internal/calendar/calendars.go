@@ -0,0 +1,68 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewfead/cali/proto"
+	"google.golang.org/api/calendar/v3"
+)
+
+// validAccessRoles are the minAccessRole values Google accepts
+var validAccessRoles = map[string]bool{
+	"freeBusyReader": true,
+	"reader":         true,
+	"writer":         true,
+	"owner":          true,
+}
+
+// ListCalendars returns every calendar on the authenticated user's calendar
+// list, optionally only those the user has at least min_access_role on.
+func (c *Client) ListCalendars(ctx context.Context, req *proto.ListCalendarsRequest) ([]*calendar.CalendarListEntry, error) {
+	call := c.service.CalendarList.List().Context(ctx)
+	if role := req.GetMinAccessRole(); role != "" {
+		if !validAccessRoles[role] {
+			return nil, fmt.Errorf("invalid min_access_role %q: expected freeBusyReader, reader, writer, or owner", role)
+		}
+		call = call.MinAccessRole(role)
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var entries []*calendar.CalendarListEntry
+	err := c.retry(ctx, func() error {
+		// Start over on retry so pages aren't collected twice
+		entries = nil
+		return call.Pages(ctx, func(page *calendar.CalendarList) error {
+			entries = append(entries, page.Items...)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list calendars: %w", err)
+	}
+	return entries, nil
+}
+
+// Ping makes the cheapest authenticated call there is, reading one entry of
+// the calendar list, to check that the client's credentials work and the API
+// is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	err := c.retry(ctx, func() error {
+		_, err := c.service.CalendarList.List().MaxResults(1).Fields("items(id)").Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unable to reach the calendar API: %w", err)
+	}
+	return nil
+}
+
+// Endpoint returns the base URL of the Calendar API the client calls
+func (c *Client) Endpoint() string {
+	return c.service.BasePath
+}
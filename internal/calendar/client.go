@@ -2,41 +2,252 @@ package calendar
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/drewfead/cali/proto"
+	"golang.org/x/oauth2"
 	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
+	protobuf "google.golang.org/protobuf/proto"
 )
 
+// ErrConcurrentModification is returned by UpdateEvent when the event changed
+// between being read and written, so the update was not applied.
+var ErrConcurrentModification = errors.New("event was modified concurrently")
+
 // Client wraps the Google Calendar API service
 type Client struct {
 	service *calendar.Service
+
+	// defaultEventDuration is the length of created events that have no end
+	// time (DefaultEventDuration when zero)
+	defaultEventDuration time.Duration
+
+	// authenticatedEmail caches the result of AuthenticatedEmail
+	identityMu         sync.Mutex
+	authenticatedEmail string
+
+	// retryPolicy governs retries of rate-limited and failed calls
+	retryPolicy RetryPolicy
+
+	// defaultTimeout bounds calls whose context has no deadline (none when zero)
+	defaultTimeout time.Duration
+
+	// dryRun logs writes and returns synthetic results instead of sending them
+	dryRun bool
+
+	// maxAttendeesAllowed rejects creates and updates inviting more
+	// attendees than this (no limit when zero)
+	maxAttendeesAllowed int
+
+	// maxAttendees caps the attendees returned per event by reads (no cap
+	// when zero)
+	maxAttendees int64
+}
+
+// ClientOption configures a Client created by NewClient
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	endpoint            string
+	retryPolicy         RetryPolicy
+	defaultTimeout      time.Duration
+	maxAttendees        int
+	maxAttendeesAllowed int
+	dryRun              bool
+	appName             string
+	transport           http.RoundTripper
+}
+
+// WithEndpoint points the client at a different API endpoint, such as a mock
+// server in tests. An empty endpoint keeps the default.
+func WithEndpoint(endpoint string) ClientOption {
+	return func(o *clientOptions) {
+		o.endpoint = endpoint
+	}
+}
+
+// WithRetry retries calls that fail with a 429 or 5xx according to policy.
+// Without it, every call is attempted once.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(o *clientOptions) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithDefaultTimeout bounds each call, retries included, to d when the
+// caller's context has no deadline of its own. ListEvents applies it to each
+// page. Without it, calls wait as long as their context allows.
+func WithDefaultTimeout(d time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.defaultTimeout = d
+	}
+}
+
+// WithMaxAttendees caps the attendees returned for each event by GetEvent,
+// GetEventByICalUID, ListEvents, and SearchEvents at n, which keeps responses
+// for very large meetings small. Google then lists only the first attendees
+// (always including the signed-in user) and sets AttendeesOmitted. Updates
+// still read the full list. Zero or less means no cap.
+func WithMaxAttendees(n int) ClientOption {
+	return func(o *clientOptions) {
+		o.maxAttendees = n
+	}
+}
+
+// WithMaxAttendeesAllowed rejects creates and updates whose attendee list
+// would exceed n before they reach the API, enforcing a local invite policy.
+// Unlike WithMaxAttendees it limits what is sent rather than what is read.
+// Zero or less means no limit.
+func WithMaxAttendeesAllowed(n int) ClientOption {
+	return func(o *clientOptions) {
+		o.maxAttendeesAllowed = n
+	}
+}
+
+// WithApplicationName identifies the client to the API by adding name to
+// the User-Agent of every request, so its traffic can be told apart on the
+// server. It defaults to "cali/<version>".
+func WithApplicationName(name string) ClientOption {
+	return func(o *clientOptions) {
+		o.appName = name
+	}
+}
+
+// WithTransport sends the client's requests through rt, such as a
+// transport with a proxy, custom CAs, or instrumentation. When the HTTP
+// client passed to NewClient is authenticated with OAuth, rt carries the
+// requests after credentials are added to them, so authentication is kept;
+// otherwise rt replaces the client's transport.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(o *clientOptions) {
+		o.transport = rt
+	}
+}
+
+// withTransport returns a copy of httpClient that sends requests through rt,
+// keeping any OAuth transport in front of it
+func withTransport(httpClient *http.Client, rt http.RoundTripper) *http.Client {
+	wrapped := *httpClient
+	if authed, ok := httpClient.Transport.(*oauth2.Transport); ok {
+		wrapped.Transport = &oauth2.Transport{Source: authed.Source, Base: rt}
+	} else {
+		wrapped.Transport = rt
+	}
+	return &wrapped
+}
+
+// defaultApplicationName is "cali/" followed by the module version cali was
+// built at, or "dev" for builds from a working tree
+func defaultApplicationName() string {
+	version := "dev"
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		version = info.Main.Version
+	}
+	return "cali/" + version
 }
 
 // NewClient creates a new Google Calendar API client.
-// Optionally accepts an endpoint URL for testing with mock servers.
-func NewClient(ctx context.Context, httpClient *http.Client, endpoint ...string) (*Client, error) {
-	opts := []option.ClientOption{option.WithHTTPClient(httpClient)}
+func NewClient(ctx context.Context, httpClient *http.Client, opts ...ClientOption) (*Client, error) {
+	var options clientOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.transport != nil {
+		httpClient = withTransport(httpClient, options.transport)
+	}
+
+	serviceOpts := []option.ClientOption{option.WithHTTPClient(httpClient)}
 
 	// Add endpoint override if provided
-	if len(endpoint) > 0 && endpoint[0] != "" {
-		opts = append(opts, option.WithEndpoint(endpoint[0]))
+	if options.endpoint != "" {
+		serviceOpts = append(serviceOpts, option.WithEndpoint(options.endpoint))
 	}
 
-	srv, err := calendar.NewService(ctx, opts...)
+	srv, err := calendar.NewService(ctx, serviceOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create Calendar service: %w", err)
 	}
 
+	// option.WithUserAgent only applies to transports the library builds
+	// itself, so set the fragment the service adds to each request directly
+	srv.UserAgent = options.appName
+	if srv.UserAgent == "" {
+		srv.UserAgent = defaultApplicationName()
+	}
+
 	return &Client{
-		service: srv,
+		service:             srv,
+		retryPolicy:         options.retryPolicy,
+		defaultTimeout:      options.defaultTimeout,
+		maxAttendees:        int64(max(options.maxAttendees, 0)),
+		maxAttendeesAllowed: max(options.maxAttendeesAllowed, 0),
+		dryRun:              options.dryRun,
 	}, nil
 }
 
+// withTimeout applies the client's default timeout to ctx unless ctx already
+// has a deadline. The returned cancel func must always be called.
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.defaultTimeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.defaultTimeout)
+}
+
+// SetDefaultEventDuration sets the length of events created with a start but
+// no end time. Non-positive durations restore DefaultEventDuration.
+func (c *Client) SetDefaultEventDuration(d time.Duration) {
+	c.defaultEventDuration = d
+}
+
+// SelfLink returns the API URL of an event, which can be used to re-fetch it
+// later. It is relative to the client's endpoint, so it points at the mock
+// server when one is in use.
+func (c *Client) SelfLink(calendarID, eventID string) string {
+	return fmt.Sprintf("%s/calendars/%s/events/%s",
+		strings.TrimSuffix(c.service.BasePath, "/"), url.PathEscape(calendarID), url.PathEscape(eventID))
+}
+
+// validateOutgoing checks an event about to be sent to Google, catching the
+// mistakes Google would otherwise reject with a 400
+func (c *Client) validateOutgoing(event *calendar.Event) error {
+	if err := ValidateRecurrence(event); err != nil {
+		return err
+	}
+	if err := ValidateEventTimes(event); err != nil {
+		return err
+	}
+	if err := ValidateAttendees(event); err != nil {
+		return err
+	}
+	if err := ValidateAttendeeCount(event, c.maxAttendeesAllowed); err != nil {
+		return err
+	}
+	if err := ValidateReminders(event); err != nil {
+		return err
+	}
+	if err := ValidateVisibility(event); err != nil {
+		return err
+	}
+	return ValidateColorID(event)
+}
+
 // CreateEvent creates a new event in the specified calendar
 func (c *Client) CreateEvent(ctx context.Context, req *proto.AddEventRequest) (*calendar.Event, error) {
 	// Default to primary calendar if not specified
@@ -45,11 +256,40 @@ func (c *Client) CreateEvent(ctx context.Context, req *proto.AddEventRequest) (*
 		calendarID = *req.CalendarId
 	}
 
+	if err := ValidateTimeZone(req.GetTimeZone()); err != nil {
+		return nil, err
+	}
+	if err := ValidateSendUpdates(req.GetSendUpdates()); err != nil {
+		return nil, err
+	}
+
 	// Convert proto request to Calendar API event
-	event := MapProtoToEvent(req)
+	defaultDuration := c.defaultEventDuration
+	if defaultDuration <= 0 {
+		defaultDuration = DefaultEventDuration
+	}
+	event := MapProtoToEventWithDuration(req, defaultDuration)
+	if err := c.validateOutgoing(event); err != nil {
+		return nil, err
+	}
+
+	if c.dryRun {
+		return dryRunCreate("create", calendarID, event), nil
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 
 	// Create the event
-	createdEvent, err := c.service.Events.Insert(calendarID, event).Context(ctx).Do()
+	createdEvent, err := c.retryInsert(ctx, calendarID, event, func() (*calendar.Event, error) {
+		// Version 1 lets Google act on conference create requests
+		call := c.service.Events.Insert(calendarID, event).
+			ConferenceDataVersion(1).SupportsAttachments(true).Context(ctx)
+		if req.SendUpdates != nil && *req.SendUpdates != "" {
+			call = call.SendUpdates(*req.SendUpdates)
+		}
+		return call.Do()
+	})
 	if err != nil {
 		return nil, fmt.Errorf("unable to create event: %w", err)
 	}
@@ -65,17 +305,79 @@ func (c *Client) UpdateEvent(ctx context.Context, req *proto.UpdateEventRequest)
 		calendarID = *req.CalendarId
 	}
 
+	if err := ValidateTimeZone(req.GetTimeZone()); err != nil {
+		return nil, err
+	}
+	if err := ValidateSendUpdates(req.GetSendUpdates()); err != nil {
+		return nil, err
+	}
+	if err := ValidateEditScope(req.GetEditScope()); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
 	// First, get the existing event
-	existingEvent, err := c.service.Events.Get(calendarID, req.EventId).Context(ctx).Do()
+	var existingEvent *calendar.Event
+	err := c.retry(ctx, func() (err error) {
+		existingEvent, err = c.service.Events.Get(calendarID, req.EventId).Context(ctx).Do()
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("unable to get event: %w", err)
 	}
 
+	// The edit scope only changes what's updated when event_id names an
+	// instance of a recurring event
+	switch req.GetEditScope() {
+	case proto.EditScope_EDIT_SCOPE_SINGLE:
+		if len(existingEvent.Recurrence) > 0 {
+			return nil, fmt.Errorf("edit_scope single needs the ID of one instance, but %s is a whole recurring series", req.EventId)
+		}
+	case proto.EditScope_EDIT_SCOPE_ALL, proto.EditScope_EDIT_SCOPE_FOLLOWING:
+		if existingEvent.RecurringEventId != "" {
+			return c.updateRecurring(ctx, calendarID, req, existingEvent)
+		}
+	}
+
+	return c.applyUpdate(ctx, calendarID, req, existingEvent)
+}
+
+// applyUpdate applies req to existingEvent, the event it names as last read,
+// and patches the changes onto the server copy if it hasn't changed since.
+func (c *Client) applyUpdate(ctx context.Context, calendarID string, req *proto.UpdateEventRequest, existingEvent *calendar.Event) (*calendar.Event, error) {
 	// Apply updates from the request
 	updatedEvent := MapProtoUpdateToEvent(req, existingEvent)
+	if err := c.validateOutgoing(updatedEvent); err != nil {
+		return nil, err
+	}
+
+	if c.dryRun {
+		slog.Info("dry run: not sending update", "calendar_id", calendarID, "event_id", req.EventId)
+		return updatedEvent, nil
+	}
 
-	// Update the event
-	result, err := c.service.Events.Update(calendarID, req.EventId, updatedEvent).Context(ctx).Do()
+	// Patch only the fields the request changes, so anything the mapper
+	// doesn't handle (e.g. colorId) keeps its current value on the server
+	patch := MapProtoUpdateToPatch(req, updatedEvent)
+	var result *calendar.Event
+	err := c.retry(ctx, func() (err error) {
+		call := c.service.Events.Patch(calendarID, req.EventId, patch).SupportsAttachments(true).Context(ctx)
+		if req.SendUpdates != nil && *req.SendUpdates != "" {
+			call = call.SendUpdates(*req.SendUpdates)
+		}
+		// Only apply the update if the event is still the version we read
+		if existingEvent.Etag != "" {
+			call.Header().Set("If-Match", existingEvent.Etag)
+		}
+		result, err = call.Do()
+		return err
+	})
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusPreconditionFailed {
+		return nil, fmt.Errorf("unable to update event %s: %w", req.EventId, ErrConcurrentModification)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("unable to update event: %w", err)
 	}
@@ -83,7 +385,57 @@ func (c *Client) UpdateEvent(ctx context.Context, req *proto.UpdateEventRequest)
 	return result, nil
 }
 
-// GetEvent retrieves a single event by ID
+// UpdateEventFull replaces an event with the complete event given, in a
+// single request. Unlike UpdateEvent it doesn't read the event first, so it
+// saves a round-trip but can't detect concurrent modifications, and any field
+// left unset in event is cleared. Settings proto.Event has no field for (see
+// MapProtoEventToEvent) reset to Google's defaults, so use UpdateEvent to
+// change events that rely on them. The calendar defaults to event's calendar,
+// then primary.
+func (c *Client) UpdateEventFull(ctx context.Context, calendarID string, event *proto.Event) (*calendar.Event, error) {
+	if event.GetId() == "" {
+		return nil, fmt.Errorf("an event ID is required")
+	}
+	if calendarID == "" {
+		calendarID = event.GetCalendarId()
+	}
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+
+	if err := ValidateTimeZone(event.GetTimeZone()); err != nil {
+		return nil, err
+	}
+
+	replacement := MapProtoEventToEvent(event)
+	if err := c.validateOutgoing(replacement); err != nil {
+		return nil, err
+	}
+
+	if c.dryRun {
+		slog.Info("dry run: not sending update", "calendar_id", calendarID, "event_id", event.Id)
+		replacement.HtmlLink = dryRunHTMLLink(calendarID, event.Id)
+		return replacement, nil
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var result *calendar.Event
+	err := c.retry(ctx, func() (err error) {
+		result, err = c.service.Events.Update(calendarID, event.Id, replacement).SupportsAttachments(true).Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to update event: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetEvent retrieves a single event by ID. Google keeps deleted events, and
+// cancelled instances of a series, as tombstones with status "cancelled";
+// GetEvent reports them as ErrEventNotFound unless IncludeDeleted is set.
 func (c *Client) GetEvent(ctx context.Context, req *proto.GetEventRequest) (*calendar.Event, error) {
 	// Default to primary calendar if not specified
 	calendarID := "primary"
@@ -91,13 +443,154 @@ func (c *Client) GetEvent(ctx context.Context, req *proto.GetEventRequest) (*cal
 		calendarID = *req.CalendarId
 	}
 
-	event, err := c.service.Events.Get(calendarID, req.EventId).Context(ctx).Do()
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var event *calendar.Event
+	err := c.retry(ctx, func() (err error) {
+		call := c.service.Events.Get(calendarID, req.EventId)
+		if c.maxAttendees > 0 {
+			call = call.MaxAttendees(c.maxAttendees)
+		}
+		event, err = call.Context(ctx).Do()
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("unable to get event: %w", err)
 	}
+	if event.Status == "cancelled" && !req.GetIncludeDeleted() {
+		return nil, fmt.Errorf("event %s was deleted: %w", req.EventId, ErrEventNotFound)
+	}
+	return event, nil
+}
+
+// GetEventByICalUID retrieves the event with the given iCalUID, the identifier
+// other calendar systems key events by. A recurring series' exceptions share
+// its iCalUID, so the series itself is returned in preference to them.
+func (c *Client) GetEventByICalUID(ctx context.Context, calendarID, iCalUID string) (*calendar.Event, error) {
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+	if iCalUID == "" {
+		return nil, fmt.Errorf("iCalUID is required")
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var events *calendar.Events
+	err := c.retry(ctx, func() (err error) {
+		call := c.service.Events.List(calendarID).ICalUID(iCalUID)
+		if c.maxAttendees > 0 {
+			call = call.MaxAttendees(c.maxAttendees)
+		}
+		events, err = call.Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve events: %w", err)
+	}
+	if len(events.Items) == 0 {
+		return nil, fmt.Errorf("no event with iCalUID %q: %w", iCalUID, ErrEventNotFound)
+	}
+
+	for _, event := range events.Items {
+		if event.RecurringEventId == "" {
+			return event, nil
+		}
+	}
+	return events.Items[0], nil
+}
+
+// QuickAdd creates an event from a natural-language description such as
+// "Lunch tomorrow at noon", leaving Google to parse the summary and times
+func (c *Client) QuickAdd(ctx context.Context, req *proto.QuickAddRequest) (*calendar.Event, error) {
+	// Default to primary calendar if not specified
+	calendarID := "primary"
+	if req.CalendarId != nil && *req.CalendarId != "" {
+		calendarID = *req.CalendarId
+	}
+
+	if strings.TrimSpace(req.Text) == "" {
+		return nil, fmt.Errorf("quick add text is required")
+	}
+
+	// Without Google to parse the text, the whole text becomes the summary
+	if c.dryRun {
+		return dryRunCreate("quick add", calendarID, &calendar.Event{Summary: req.Text}), nil
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	// Quick add can't be given an ID to make a repeat detectable, so only
+	// rate limits, which Google rejects before creating anything, are retried
+	var event *calendar.Event
+	err := c.retryWhen(ctx, isRateLimited, func() (err error) {
+		event, err = c.service.Events.QuickAdd(calendarID, req.Text).Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to quick add event: %w", err)
+	}
 	return event, nil
 }
 
+// ImportEvent adds a copy of an event from another calendar. Unlike
+// CreateEvent it keeps the event's iCalUID, and importing an iCalUID the
+// calendar already has updates that event rather than adding another.
+func (c *Client) ImportEvent(ctx context.Context, req *proto.ImportEventRequest) (*calendar.Event, error) {
+	// Default to primary calendar if not specified
+	calendarID := "primary"
+	if req.CalendarId != nil && *req.CalendarId != "" {
+		calendarID = *req.CalendarId
+	}
+
+	if strings.TrimSpace(req.IcalUid) == "" {
+		return nil, fmt.Errorf("iCalUID is required")
+	}
+	if err := ValidateTimeZone(req.GetTimeZone()); err != nil {
+		return nil, err
+	}
+
+	defaultDuration := c.defaultEventDuration
+	if defaultDuration <= 0 {
+		defaultDuration = DefaultEventDuration
+	}
+	event := MapProtoToEventWithDuration(&proto.AddEventRequest{
+		Summary:     req.Summary,
+		Description: req.Description,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		Location:    req.Location,
+		TimeZone:    req.TimeZone,
+		AllDay:      req.AllDay,
+		Attendees:   req.Attendees,
+		Recurrence:  req.Recurrence,
+	}, defaultDuration)
+	event.ICalUID = req.IcalUid
+	if err := c.validateOutgoing(event); err != nil {
+		return nil, err
+	}
+
+	if c.dryRun {
+		return dryRunCreate("import", calendarID, event), nil
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var imported *calendar.Event
+	err := c.retry(ctx, func() (err error) {
+		imported, err = c.service.Events.Import(calendarID, event).Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to import event: %w", err)
+	}
+	return imported, nil
+}
+
 // DeleteEvent deletes an event from the specified calendar
 func (c *Client) DeleteEvent(ctx context.Context, req *proto.DeleteEventRequest) error {
 	// Default to primary calendar if not specified
@@ -106,8 +599,26 @@ func (c *Client) DeleteEvent(ctx context.Context, req *proto.DeleteEventRequest)
 		calendarID = *req.CalendarId
 	}
 
+	if err := ValidateSendUpdates(req.GetSendUpdates()); err != nil {
+		return err
+	}
+
+	if c.dryRun {
+		slog.Info("dry run: not sending delete", "calendar_id", calendarID, "event_id", req.EventId)
+		return nil
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
 	// Delete the event
-	err := c.service.Events.Delete(calendarID, req.EventId).Context(ctx).Do()
+	err := c.retry(ctx, func() error {
+		call := c.service.Events.Delete(calendarID, req.EventId).Context(ctx)
+		if req.SendUpdates != nil && *req.SendUpdates != "" {
+			call = call.SendUpdates(*req.SendUpdates)
+		}
+		return call.Do()
+	})
 	if err != nil {
 		return fmt.Errorf("unable to delete event: %w", err)
 	}
@@ -115,7 +626,20 @@ func (c *Client) DeleteEvent(ctx context.Context, req *proto.DeleteEventRequest)
 	return nil
 }
 
-// ListEvents returns a channel that streams events from the specified calendar with pagination support
+// propertyFilters formats extended property filters as the key=value
+// constraints Google expects, in a stable order
+func propertyFilters(props map[string]string) []string {
+	filters := make([]string, 0, len(props))
+	for key, value := range props {
+		filters = append(filters, key+"="+value)
+	}
+	sort.Strings(filters)
+	return filters
+}
+
+// ListEvents returns a channel that streams events from the specified calendar with pagination support.
+// By default it streams one page and ends with a next_anchor if more remain; with follow_pages it
+// streams every page until the results are exhausted or ctx is cancelled.
 func (c *Client) ListEvents(ctx context.Context, req *proto.ListEventsRequest) (<-chan *proto.ListEventsResponse, <-chan error) {
 	responseChan := make(chan *proto.ListEventsResponse)
 	errChan := make(chan error, 1)
@@ -133,38 +657,13 @@ func (c *Client) ListEvents(ctx context.Context, req *proto.ListEventsRequest) (
 		slog.Debug("listing events", "calendar_id", calendarID)
 
 		// Build the events list call
-		call := c.service.Events.List(calendarID).Context(ctx).SingleEvents(true)
-
-		// Apply time filters based on flags
-		// Priority: explicit after/before > boolean flags (future/past) > default (all events)
-		// Note: Check for non-zero timestamps, not just IsValid(), since protobuf creates zero-value timestamps
-		hasExplicitTimes := (req.After != nil && req.After.IsValid() && req.After.AsTime().Unix() > 0) ||
-			(req.Before != nil && req.Before.IsValid() && req.Before.AsTime().Unix() > 0)
-		hasTimeFilter := false
-
-		if hasExplicitTimes {
-			// Use explicit after/before timestamps
-			if req.After != nil && req.After.IsValid() && req.After.AsTime().Unix() > 0 {
-				call = call.TimeMin(req.After.AsTime().Format("2006-01-02T15:04:05Z07:00"))
-				hasTimeFilter = true
-			}
-			if req.Before != nil && req.Before.IsValid() && req.Before.AsTime().Unix() > 0 {
-				call = call.TimeMax(req.Before.AsTime().Format("2006-01-02T15:04:05Z07:00"))
-				hasTimeFilter = true
-			}
-		} else if req.Future != nil && *req.Future {
-			// Future events (after now)
-			call = call.TimeMin(time.Now().Format("2006-01-02T15:04:05Z07:00"))
-			hasTimeFilter = true
-		} else if req.Past != nil && *req.Past {
-			// Past events (before now)
-			call = call.TimeMax(time.Now().Format("2006-01-02T15:04:05Z07:00"))
-			hasTimeFilter = true
-		}
-		// else: no time filter (all events)
-
-		// Only use orderBy when we have a time filter (required by Google Calendar API)
-		if hasTimeFilter {
+		call, after, before := filteredListCall(c.service, calendarID, req)
+		call = call.Context(ctx)
+
+		// Only use orderBy when we have a time filter, and never for masters
+		// (Google only allows orderBy=startTime with singleEvents=true)
+		hasTimeFilter := !after.IsZero() || !before.IsZero()
+		if hasTimeFilter && (req.SingleEvents == nil || *req.SingleEvents) {
 			call = call.OrderBy("startTime")
 		}
 
@@ -178,40 +677,148 @@ func (c *Client) ListEvents(ctx context.Context, req *proto.ListEventsRequest) (
 			call = call.PageToken(*req.Anchor)
 		}
 
-		// Fetch one page of results
-		events, err := call.Do()
-		if err != nil {
-			slog.Error("failed to retrieve events", "error", err, "calendar_id", calendarID)
-			errChan <- fmt.Errorf("unable to retrieve events: %w", err)
-			return
+		// Cap the attendees listed per event, if configured
+		if c.maxAttendees > 0 {
+			call = call.MaxAttendees(c.maxAttendees)
 		}
 
-		slog.Debug("retrieved events", "count", len(events.Items), "has_next_page", events.NextPageToken != "")
+		// Follow NextPageToken internally when asked, otherwise stop after one
+		// page and hand the caller a next_anchor
+		followPages := req.FollowPages != nil && *req.FollowPages
+
+		for {
+			// Fetch one page of results, bounding each page by the default timeout
+			var events *calendar.Events
+			pageCtx, cancel := c.withTimeout(ctx)
+			err := c.retry(pageCtx, func() (err error) {
+				events, err = call.Context(pageCtx).Do()
+				return err
+			})
+			cancel()
+			if err != nil {
+				slog.Error("failed to retrieve events", "error", err, "calendar_id", calendarID)
+				errChan <- fmt.Errorf("unable to retrieve events: %w", err)
+				return
+			}
+
+			slog.Debug("retrieved events", "count", len(events.Items), "has_next_page", events.NextPageToken != "")
+
+			// Stream events to channel
+			for _, event := range events.Items {
+				select {
+				case <-ctx.Done():
+					errChan <- ctx.Err()
+					return
+				case responseChan <- &proto.ListEventsResponse{
+					Event: MapEventToProto(event, calendarID),
+				}:
+				}
+			}
 
-		// Stream events to channel
-		for _, event := range events.Items {
-			select {
-			case <-ctx.Done():
-				errChan <- ctx.Err()
+			if events.NextPageToken == "" {
 				return
-			case responseChan <- &proto.ListEventsResponse{
-				Event: MapEventToProto(event, calendarID),
-			}:
 			}
-		}
+			if followPages {
+				if err := ctx.Err(); err != nil {
+					errChan <- err
+					return
+				}
+				call = call.PageToken(events.NextPageToken)
+				continue
+			}
 
-		// Send final message with next_anchor if there are more results
-		if events.NextPageToken != "" {
+			// Send final message with next_anchor since there are more results
 			select {
 			case <-ctx.Done():
 				errChan <- ctx.Err()
-				return
 			case responseChan <- &proto.ListEventsResponse{
 				NextAnchor: &events.NextPageToken,
 			}:
 			}
+			return
 		}
 	}()
 
 	return responseChan, errChan
 }
+
+// filteredListCall builds an events list call applying req's filters (time
+// window, single events, and extended properties), but none of its paging.
+// It also returns the bounds of the time window, each zero when unbounded.
+func filteredListCall(service *calendar.Service, calendarID string, req *proto.ListEventsRequest) (call *calendar.EventsListCall, after, before time.Time) {
+	// Expand recurring events into instances unless the caller asked for
+	// the recurring masters themselves
+	singleEvents := req.SingleEvents == nil || *req.SingleEvents
+	call = service.Events.List(calendarID).SingleEvents(singleEvents)
+
+	// Apply time filters based on flags
+	// Priority: explicit after/before > boolean flags (future/past) > default (all events)
+	// Note: Check for non-zero timestamps, not just IsValid(), since protobuf creates zero-value timestamps
+	hasAfter := req.After != nil && req.After.IsValid() && req.After.AsTime().Unix() > 0
+	hasBefore := req.Before != nil && req.Before.IsValid() && req.Before.AsTime().Unix() > 0
+	switch {
+	case hasAfter || hasBefore:
+		if hasAfter {
+			after = req.After.AsTime()
+		}
+		if hasBefore {
+			before = req.Before.AsTime()
+		}
+	case req.Future != nil && *req.Future:
+		// Future events (after now)
+		after = time.Now()
+	case req.Past != nil && *req.Past:
+		// Past events (before now)
+		before = time.Now()
+	}
+	// else: no time filter (all events)
+	if !after.IsZero() {
+		call = call.TimeMin(after.Format(time.RFC3339))
+	}
+	if !before.IsZero() {
+		call = call.TimeMax(before.Format(time.RFC3339))
+	}
+
+	// Filter by extended properties (events must match every pair)
+	if filters := propertyFilters(req.PrivateProperty.GetProperties()); len(filters) > 0 {
+		call = call.PrivateExtendedProperty(filters...)
+	}
+	if filters := propertyFilters(req.SharedProperty.GetProperties()); len(filters) > 0 {
+		call = call.SharedExtendedProperty(filters...)
+	}
+
+	return call, after, before
+}
+
+// ListEventsSeq returns an iterator over the events ListEvents lists, for
+// use in a range loop. Every page is followed. A failure is yielded once, as
+// the final pair with a nil event, and breaking out of the loop early stops
+// the listing.
+func (c *Client) ListEventsSeq(ctx context.Context, req *proto.ListEventsRequest) iter.Seq2[*proto.Event, error] {
+	return func(yield func(*proto.Event, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// Follow pages without changing the caller's request
+		req := protobuf.Clone(req).(*proto.ListEventsRequest)
+		followPages := true
+		req.FollowPages = &followPages
+
+		responseChan, errChan := c.ListEvents(ctx, req)
+		for resp := range responseChan {
+			if resp.Event == nil {
+				continue
+			}
+			if !yield(resp.Event, nil) {
+				// Stop the producer and let it close its channels
+				cancel()
+				for range responseChan {
+				}
+				return
+			}
+		}
+		if err := <-errChan; err != nil {
+			yield(nil, err)
+		}
+	}
+}
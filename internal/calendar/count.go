@@ -0,0 +1,59 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/drewfead/cali/proto"
+	"google.golang.org/api/calendar/v3"
+)
+
+// countPageSize is the largest page Google returns, so by default counting
+// takes as few requests as possible
+const countPageSize = 2500
+
+// EventCount is the outcome of CountEvents
+type EventCount struct {
+	Count  int       // events matching the request
+	After  time.Time // start of the window counted (zero for no lower bound)
+	Before time.Time // end of the window counted (zero for no upper bound)
+}
+
+// CountEvents counts the events ListEvents would list for req, following
+// every page but fetching only event IDs. The request's filters apply as in
+// ListEvents, and its limit sets the page size (default 2500); anchor and
+// follow_pages are ignored. Future and past are resolved against the current
+// time, as reported in the result.
+func (c *Client) CountEvents(ctx context.Context, req *proto.ListEventsRequest) (*EventCount, error) {
+	// Default to primary calendar if not specified
+	calendarID := "primary"
+	if req.CalendarId != nil && *req.CalendarId != "" {
+		calendarID = *req.CalendarId
+	}
+
+	call, after, before := filteredListCall(c.service, calendarID, req)
+	pageSize := int64(countPageSize)
+	if req.Limit != nil && *req.Limit > 0 {
+		pageSize = int64(*req.Limit)
+	}
+	call = call.MaxResults(pageSize).Fields("nextPageToken", "items(id)")
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	count := 0
+	err := c.retry(ctx, func() error {
+		// Start over on retry so pages aren't counted twice
+		count = 0
+		return call.Pages(ctx, func(page *calendar.Events) error {
+			count += len(page.Items)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to count events: %w", err)
+	}
+
+	return &EventCount{Count: count, After: after, Before: before}, nil
+}
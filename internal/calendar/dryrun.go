@@ -0,0 +1,49 @@
+package calendar
+
+import (
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// WithDryRun makes the client log writes (creates, quick adds, updates, and
+// deletes) instead of sending them, returning a synthetic result as if each
+// had succeeded. Reads, including the read UpdateEvent makes first, still go
+// to the API, so a dry run exercises the same validation as a real one.
+func WithDryRun(enabled bool) ClientOption {
+	return func(o *clientOptions) {
+		o.dryRun = enabled
+	}
+}
+
+// dryRunCreate returns the event a create of event in calendarID would
+// plausibly return, with a fake ID derived from its content (or its
+// idempotency key) so repeated dry runs report the same ID. An imported
+// event keeps its iCalUID.
+func dryRunCreate(operation, calendarID string, event *calendar.Event) *calendar.Event {
+	created := *event
+	if created.Id == "" {
+		h := fnv.New64a()
+		fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s", calendarID, event.Summary, eventTimeKey(event.Start), eventTimeKey(event.End))
+		// Event IDs may only use base32hex characters, which hex digits are
+		created.Id = fmt.Sprintf("dryrun%016x", h.Sum64())
+	}
+	created.Status = "confirmed"
+	if created.ICalUID == "" {
+		created.ICalUID = created.Id + "@google.com"
+	}
+	created.HtmlLink = dryRunHTMLLink(calendarID, created.Id)
+
+	slog.Info("dry run: not sending "+operation, "calendar_id", calendarID, "event_id", created.Id, "summary", created.Summary)
+	return &created
+}
+
+// dryRunHTMLLink builds a Google Calendar link in the format Google uses,
+// whose eid is the event and calendar IDs encoded together.
+func dryRunHTMLLink(calendarID, eventID string) string {
+	eid := base64.RawURLEncoding.EncodeToString([]byte(eventID + " " + calendarID))
+	return "https://www.google.com/calendar/event?eid=" + eid
+}
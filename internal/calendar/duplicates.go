@@ -0,0 +1,118 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/drewfead/cali/proto"
+	"google.golang.org/api/calendar/v3"
+)
+
+// FindDuplicates lists the events of a calendar between start and end (either
+// may be zero for an open range) and groups likely duplicates. Two events are
+// considered duplicates if they share a summary, start, and end, or if they
+// share an iCalUID without being instances of the same recurring series.
+// Only clusters of two or more events are returned, ordered by the position
+// of their first event in the listing.
+func (c *Client) FindDuplicates(ctx context.Context, calendarID string, start, end time.Time) ([][]*proto.Event, error) {
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+
+	call := c.service.Events.List(calendarID).Context(ctx).SingleEvents(true)
+	if !start.IsZero() {
+		call = call.TimeMin(start.Format(time.RFC3339))
+	}
+	if !end.IsZero() {
+		call = call.TimeMax(end.Format(time.RFC3339))
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var events []*calendar.Event
+	err := c.retry(ctx, func() error {
+		// Start over on retry so pages aren't collected twice
+		events = nil
+		return call.Pages(ctx, func(page *calendar.Events) error {
+			events = append(events, page.Items...)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve events: %w", err)
+	}
+
+	// Union events that match on either key, so a cluster links events that
+	// are duplicates by content and by iCalUID transitively
+	parent := make([]int, len(events))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(i, j int) {
+		if ri, rj := find(i), find(j); ri != rj {
+			parent[rj] = ri
+		}
+	}
+
+	byContent := make(map[string]int)
+	byICalUID := make(map[string]int)
+	for i, event := range events {
+		key := fmt.Sprintf("%s\x00%s\x00%s", event.Summary, eventTimeKey(event.Start), eventTimeKey(event.End))
+		if first, ok := byContent[key]; ok {
+			union(first, i)
+		} else {
+			byContent[key] = i
+		}
+
+		// Instances of one recurring series legitimately share an iCalUID
+		if event.ICalUID != "" && event.RecurringEventId == "" {
+			if first, ok := byICalUID[event.ICalUID]; ok {
+				union(first, i)
+			} else {
+				byICalUID[event.ICalUID] = i
+			}
+		}
+	}
+
+	var order []int
+	members := make(map[int][]*proto.Event)
+	for i, event := range events {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			order = append(order, root)
+		}
+		members[root] = append(members[root], MapEventToProto(event, calendarID))
+	}
+
+	var clusters [][]*proto.Event
+	for _, root := range order {
+		if len(members[root]) > 1 {
+			clusters = append(clusters, members[root])
+		}
+	}
+	return clusters, nil
+}
+
+// eventTimeKey normalizes an event time for comparison, so the same instant
+// written in different zones matches
+func eventTimeKey(dt *calendar.EventDateTime) string {
+	if dt == nil {
+		return ""
+	}
+	if dt.DateTime != "" {
+		if t, err := time.Parse(time.RFC3339, dt.DateTime); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+		return dt.DateTime
+	}
+	return dt.Date
+}
@@ -0,0 +1,265 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drewfead/cali/proto"
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ValidateEditScope checks that editScope is one UpdateEvent understands,
+// since an open enum can carry any number over the wire.
+func ValidateEditScope(editScope proto.EditScope) error {
+	switch editScope {
+	case proto.EditScope_EDIT_SCOPE_UNSPECIFIED, proto.EditScope_EDIT_SCOPE_SINGLE,
+		proto.EditScope_EDIT_SCOPE_FOLLOWING, proto.EditScope_EDIT_SCOPE_ALL:
+		return nil
+	}
+	return fmt.Errorf("invalid edit_scope %d: expected single, following, or all", editScope)
+}
+
+// updateRecurring applies req, an update to the instance of a recurring
+// series, to the whole series or to the instance and those after it, as its
+// edit scope says.
+func (c *Client) updateRecurring(ctx context.Context, calendarID string, req *proto.UpdateEventRequest, instance *calendar.Event) (*calendar.Event, error) {
+	var master *calendar.Event
+	err := c.retry(ctx, func() (err error) {
+		master, err = c.service.Events.Get(calendarID, instance.RecurringEventId).Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get recurring event: %w", err)
+	}
+
+	splitAt, ok := eventInstant(instance.OriginalStartTime)
+	if !ok {
+		return nil, fmt.Errorf("unable to update series %s: instance %s has no original start time", master.Id, instance.Id)
+	}
+	seriesStart, ok := eventInstant(master.Start)
+	if !ok {
+		return nil, fmt.Errorf("unable to update series %s: it has no start time", master.Id)
+	}
+
+	// Changing the first instance and those after it changes them all
+	if req.GetEditScope() == proto.EditScope_EDIT_SCOPE_ALL || !splitAt.After(seriesStart) {
+		// Times move the series by as much as they move the instance
+		seriesReq := protobuf.Clone(req).(*proto.UpdateEventRequest)
+		seriesReq.EventId = master.Id
+		seriesReq.StartTime = shiftTimestamp(req.StartTime, instance.Start, master.Start)
+		seriesReq.EndTime = shiftTimestamp(req.EndTime, instance.End, master.End)
+		return c.applyUpdate(ctx, calendarID, seriesReq, master)
+	}
+	return c.splitSeries(ctx, calendarID, req, master, splitAt)
+}
+
+// splitSeries ends master's series just before splitAt and starts a new
+// series there carrying the update, so the update changes only the
+// instances from splitAt on. The new series is created first and removed
+// again if the old one can't be ended, so a failure never drops instances.
+func (c *Client) splitSeries(ctx context.Context, calendarID string, req *proto.UpdateEventRequest, master *calendar.Event, splitAt time.Time) (*calendar.Event, error) {
+	allDay := master.Start.Date != ""
+
+	// A COUNT limits the whole series, so the new one gets what's left of it
+	before := 0
+	if recurrenceCount(master.Recurrence) > 0 {
+		err := c.retry(ctx, func() error {
+			before = 0
+			return c.service.Events.Instances(calendarID, master.Id).
+				TimeMax(splitAt.Format(time.RFC3339)).ShowDeleted(true).Context(ctx).
+				Pages(ctx, func(page *calendar.Events) error {
+					// timeMax bounds starts, so check the original starts too
+					for _, item := range page.Items {
+						if t, ok := eventInstant(item.OriginalStartTime); ok && t.Before(splitAt) {
+							before++
+						}
+					}
+					return nil
+				})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to count instances of series %s: %w", master.Id, err)
+		}
+	}
+	ended, continued := splitRecurrence(master.Recurrence, splitAt, allDay, before)
+
+	// The new series starts with the instance and lasts as long as the old
+	seriesStart, _ := eventInstant(master.Start)
+	seriesEnd, ok := eventInstant(master.End)
+	if !ok {
+		seriesEnd = seriesStart
+	}
+	series := *master
+	series.Id = ""
+	series.ICalUID = ""
+	series.Etag = ""
+	series.HtmlLink = ""
+	series.Created = ""
+	series.Updated = ""
+	series.Sequence = 0
+	series.Recurrence = continued
+	if allDay {
+		series.Start = &calendar.EventDateTime{Date: splitAt.Format("2006-01-02")}
+		series.End = &calendar.EventDateTime{Date: splitAt.Add(seriesEnd.Sub(seriesStart)).Format("2006-01-02")}
+	} else {
+		loc, _ := eventLocation(&master.Start.TimeZone)
+		series.Start = &calendar.EventDateTime{DateTime: splitAt.In(loc).Format(time.RFC3339), TimeZone: master.Start.TimeZone}
+		series.End = &calendar.EventDateTime{DateTime: splitAt.Add(seriesEnd.Sub(seriesStart)).In(loc).Format(time.RFC3339), TimeZone: master.End.TimeZone}
+	}
+
+	updatedSeries := MapProtoUpdateToEvent(req, &series)
+	if err := c.validateOutgoing(updatedSeries); err != nil {
+		return nil, err
+	}
+
+	if c.dryRun {
+		slog.Info("dry run: not ending series", "calendar_id", calendarID, "event_id", master.Id, "recurrence", ended)
+		return dryRunCreate("new series", calendarID, updatedSeries), nil
+	}
+
+	// Start the new series before ending the old one, so a failure leaves
+	// the original series whole rather than missing its later instances
+	created, err := c.retryInsert(ctx, calendarID, updatedSeries, func() (*calendar.Event, error) {
+		call := c.service.Events.Insert(calendarID, updatedSeries).SupportsAttachments(true).Context(ctx)
+		if req.SendUpdates != nil && *req.SendUpdates != "" {
+			call = call.SendUpdates(*req.SendUpdates)
+		}
+		return call.Do()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the rest of series %s: %w", master.Id, err)
+	}
+
+	// End the old series, but only if it's still the version we read
+	err = c.retry(ctx, func() error {
+		call := c.service.Events.Patch(calendarID, master.Id, &calendar.Event{Recurrence: ended}).Context(ctx)
+		if req.SendUpdates != nil && *req.SendUpdates != "" {
+			call = call.SendUpdates(*req.SendUpdates)
+		}
+		if master.Etag != "" {
+			call.Header().Set("If-Match", master.Etag)
+		}
+		_, err := call.Do()
+		return err
+	})
+	if err != nil {
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusPreconditionFailed {
+			err = ErrConcurrentModification
+		}
+		err = fmt.Errorf("unable to end series %s: %w", master.Id, err)
+
+		// Take the new series back out so its instances aren't doubled up
+		delErr := c.retry(ctx, func() error {
+			call := c.service.Events.Delete(calendarID, created.Id).Context(ctx)
+			if req.SendUpdates != nil && *req.SendUpdates != "" {
+				call = call.SendUpdates(*req.SendUpdates)
+			}
+			return call.Do()
+		})
+		if delErr != nil {
+			return nil, fmt.Errorf("%w; also unable to remove new series %s: %v", err, created.Id, delErr)
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+// splitRecurrence returns the recurrence lines of a series ended just before
+// splitAt, and of the series continuing from it. before is how many
+// instances precede splitAt, which a COUNT in the continuing series excludes.
+// RDATE and EXDATE lines are kept in both.
+func splitRecurrence(recurrence []string, splitAt time.Time, allDay bool, before int) (ended, continued []string) {
+	until := splitAt.Add(-time.Second).UTC().Format("20060102T150405Z")
+	if allDay {
+		until = splitAt.AddDate(0, 0, -1).Format("20060102")
+	}
+
+	for _, line := range recurrence {
+		body, ok := strings.CutPrefix(line, "RRULE:")
+		if !ok {
+			ended = append(ended, line)
+			continued = append(continued, line)
+			continue
+		}
+
+		var endedParts, continuedParts []string
+		for _, part := range strings.Split(body, ";") {
+			key, value, _ := strings.Cut(part, "=")
+			switch key {
+			case "COUNT":
+				n, _ := strconv.Atoi(value)
+				continuedParts = append(continuedParts, "COUNT="+strconv.Itoa(max(n-before, 1)))
+			case "UNTIL":
+				continuedParts = append(continuedParts, part)
+			default:
+				endedParts = append(endedParts, part)
+				continuedParts = append(continuedParts, part)
+			}
+		}
+		endedParts = append(endedParts, "UNTIL="+until)
+		ended = append(ended, "RRULE:"+strings.Join(endedParts, ";"))
+		continued = append(continued, "RRULE:"+strings.Join(continuedParts, ";"))
+	}
+	return ended, continued
+}
+
+// recurrenceCount returns the COUNT of the series' RRULE, or 0 if it has none
+func recurrenceCount(recurrence []string) int {
+	for _, line := range recurrence {
+		body, ok := strings.CutPrefix(line, "RRULE:")
+		if !ok {
+			continue
+		}
+		for _, part := range strings.Split(body, ";") {
+			if value, ok := strings.CutPrefix(part, "COUNT="); ok {
+				n, _ := strconv.Atoi(value)
+				return n
+			}
+		}
+	}
+	return 0
+}
+
+// shiftTimestamp moves t, a new time for from, by as much as to differs from
+// from, or returns t unchanged if either can't be read.
+func shiftTimestamp(t *timestamppb.Timestamp, from, to *calendar.EventDateTime) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	fromTime, ok := eventInstant(from)
+	if !ok {
+		return t
+	}
+	toTime, ok := eventInstant(to)
+	if !ok {
+		return t
+	}
+	return timestamppb.New(t.AsTime().Add(toTime.Sub(fromTime)))
+}
+
+// eventInstant parses an EventDateTime, reading an all-day date as midnight
+// UTC
+func eventInstant(dt *calendar.EventDateTime) (time.Time, bool) {
+	if dt == nil {
+		return time.Time{}, false
+	}
+	if dt.DateTime != "" {
+		t, err := time.Parse(time.RFC3339, dt.DateTime)
+		return t, err == nil
+	}
+	if dt.Date != "" {
+		t, err := time.Parse("2006-01-02", dt.Date)
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,77 @@
+package calendar
+
+import (
+	"errors"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+)
+
+// Errors classifying failed API calls. Client methods wrap the underlying
+// *googleapi.Error so callers can test for either with errors.Is and
+// errors.As.
+var (
+	// ErrEventNotFound means the event (or its calendar) doesn't exist or was
+	// deleted. GetEventByICalUID also returns it when no event has the
+	// requested iCalUID.
+	ErrEventNotFound = errors.New("event not found")
+	// ErrForbidden means the caller may not access the calendar or event
+	ErrForbidden = errors.New("permission denied")
+	// ErrRateLimited means a usage limit was hit (after any retries)
+	ErrRateLimited = errors.New("rate limited")
+	// ErrConflict means the write clashes with an existing resource, such as
+	// an event with the same ID
+	ErrConflict = errors.New("conflict")
+)
+
+// rateLimitReasons are the 403 reasons Google uses for usage limits rather
+// than missing permissions
+var rateLimitReasons = map[string]bool{
+	"rateLimitExceeded":     true,
+	"userRateLimitExceeded": true,
+	"quotaExceeded":         true,
+	"dailyLimitExceeded":    true,
+}
+
+// classifiedError is an API error paired with the sentinel describing it
+type classifiedError struct {
+	err  error
+	kind error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// classifyError wraps an API error with the sentinel for its status code,
+// leaving its message unchanged. Other errors are returned as they are.
+func classifyError(err error) error {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	var kind error
+	switch apiErr.Code {
+	case http.StatusNotFound, http.StatusGone:
+		kind = ErrEventNotFound
+	case http.StatusForbidden:
+		kind = ErrForbidden
+		for _, item := range apiErr.Errors {
+			if rateLimitReasons[item.Reason] {
+				kind = ErrRateLimited
+			}
+		}
+	case http.StatusTooManyRequests:
+		kind = ErrRateLimited
+	case http.StatusConflict:
+		kind = ErrConflict
+	default:
+		return err
+	}
+	return &classifiedError{err: err, kind: kind}
+}
@@ -0,0 +1,113 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/drewfead/cali/proto"
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// FreeBusy reports when each requested calendar (primary by default) is busy
+// between after and before. Results follow the order of the request, with
+// each calendar's busy periods sorted and merged so none overlap. Calendars
+// Google couldn't query carry the error reasons instead.
+func (c *Client) FreeBusy(ctx context.Context, req *proto.FreeBusyRequest) ([]*proto.FreeBusyCalendar, error) {
+	// Zero-value timestamps count as unset
+	if req.After == nil || !req.After.IsValid() || req.After.AsTime().Unix() <= 0 ||
+		req.Before == nil || !req.Before.IsValid() || req.Before.AsTime().Unix() <= 0 {
+		return nil, fmt.Errorf("free/busy queries require both after and before")
+	}
+	timeMin, timeMax := req.After.AsTime(), req.Before.AsTime()
+	if !timeMax.After(timeMin) {
+		return nil, fmt.Errorf("invalid free/busy range: before (%s) must be after after (%s)",
+			timeMax.Format(time.RFC3339), timeMin.Format(time.RFC3339))
+	}
+
+	calendarIDs := req.GetCalendar().GetIds()
+	if len(calendarIDs) == 0 {
+		calendarIDs = []string{"primary"}
+	}
+
+	query := &calendar.FreeBusyRequest{
+		TimeMin: timeMin.Format(time.RFC3339),
+		TimeMax: timeMax.Format(time.RFC3339),
+	}
+	for _, id := range calendarIDs {
+		query.Items = append(query.Items, &calendar.FreeBusyRequestItem{Id: id})
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var resp *calendar.FreeBusyResponse
+	err := c.retry(ctx, func() (err error) {
+		resp, err = c.service.Freebusy.Query(query).Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to query free/busy: %w", err)
+	}
+
+	var results []*proto.FreeBusyCalendar
+	for _, id := range calendarIDs {
+		result := &proto.FreeBusyCalendar{CalendarId: id}
+		cal, ok := resp.Calendars[id]
+		if !ok {
+			result.Errors = []string{"notFound"}
+			results = append(results, result)
+			continue
+		}
+		for _, e := range cal.Errors {
+			result.Errors = append(result.Errors, e.Reason)
+		}
+		busy, err := mergeBusyPeriods(cal.Busy)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read busy periods of %s: %w", id, err)
+		}
+		result.Busy = busy
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// mergeBusyPeriods sorts busy periods by start and coalesces any that overlap
+// or touch.
+func mergeBusyPeriods(periods []*calendar.TimePeriod) ([]*proto.BusyPeriod, error) {
+	type interval struct{ start, end time.Time }
+
+	intervals := make([]interval, 0, len(periods))
+	for _, period := range periods {
+		start, err := time.Parse(time.RFC3339, period.Start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid busy start %q: %w", period.Start, err)
+		}
+		end, err := time.Parse(time.RFC3339, period.End)
+		if err != nil {
+			return nil, fmt.Errorf("invalid busy end %q: %w", period.End, err)
+		}
+		intervals = append(intervals, interval{start, end})
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].start.Before(intervals[j].start) })
+
+	var merged []*proto.BusyPeriod
+	var last *interval
+	for i := range intervals {
+		if last != nil && !intervals[i].start.After(last.end) {
+			if intervals[i].end.After(last.end) {
+				last.end = intervals[i].end
+				merged[len(merged)-1].End = timestamppb.New(last.end)
+			}
+			continue
+		}
+		last = &intervals[i]
+		merged = append(merged, &proto.BusyPeriod{
+			Start: timestamppb.New(last.start),
+			End:   timestamppb.New(last.end),
+		})
+	}
+	return merged, nil
+}
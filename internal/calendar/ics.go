@@ -0,0 +1,355 @@
+package calendar
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drewfead/cali/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// icsLine is one unfolded content line: NAME;PARAM=value:VALUE (RFC 5545 3.1)
+type icsLine struct {
+	name   string
+	params map[string]string
+	value  string
+	raw    string
+}
+
+// ParseICS reads an iCalendar stream and maps each VEVENT to an
+// AddEventRequest. It understands SUMMARY, DESCRIPTION, LOCATION, DTSTART,
+// DTEND or DURATION (timed, TZID, or all-day VALUE=DATE), RRULE, RDATE,
+// EXDATE, ATTENDEE, TRANSP, CLASS, and UID; other properties and nested
+// components such as VALARM are ignored. An event with an EXRULE, which
+// Google doesn't support, is an error. Events with a RECURRENCE-ID, changes to
+// one instance of a series, are skipped with a warning, leaving the instance
+// as its series has it.
+func ParseICS(r io.Reader) ([]*proto.AddEventRequest, error) {
+	lines, err := unfoldICS(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		reqs     []*proto.AddEventRequest
+		current  *icsEvent
+		depth    int // nesting inside the current VEVENT (e.g. VALARM)
+		startRow int
+	)
+	for i, raw := range lines {
+		if raw == "" {
+			continue
+		}
+		line, err := parseICSLine(raw)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		switch {
+		case line.name == "BEGIN" && strings.EqualFold(line.value, "VEVENT") && current == nil:
+			current, startRow = &icsEvent{}, i+1
+		case current == nil:
+			// Outside a VEVENT (VCALENDAR, VTIMEZONE, ...)
+		case line.name == "BEGIN":
+			depth++
+		case line.name == "END" && depth > 0:
+			depth--
+		case line.name == "END" && strings.EqualFold(line.value, "VEVENT") && current.recurrenceID != "":
+			// An override of one instance shares its series' UID, so importing
+			// it would add a standalone copy, or collide with the series
+			slog.Warn("skipping changed instance of a recurring event; it is imported unchanged with its series",
+				"line", startRow, "uid", current.uid, "recurrence_id", current.recurrenceID)
+			current = nil
+		case line.name == "END" && strings.EqualFold(line.value, "VEVENT"):
+			req, err := current.request()
+			if err != nil {
+				return nil, fmt.Errorf("event starting on line %d: %w", startRow, err)
+			}
+			reqs = append(reqs, req)
+			current = nil
+		case depth == 0:
+			current.set(line)
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("event starting on line %d: missing END:VEVENT", startRow)
+	}
+
+	return reqs, nil
+}
+
+// unfoldICS splits r into content lines, joining folded continuation lines
+// (those starting with a space or tab) onto the line before
+func unfoldICS(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += text[1:]
+			continue
+		}
+		lines = append(lines, text)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read ICS: %w", err)
+	}
+	return lines, nil
+}
+
+// parseICSLine splits a content line into its name, parameters, and value.
+// Parameter values may be double-quoted to contain ; : and ,
+func parseICSLine(raw string) (icsLine, error) {
+	line := icsLine{params: map[string]string{}, raw: raw}
+
+	inQuotes := false
+	nameEnd, valueStart := -1, -1
+	for i, r := range raw {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case r == ';' && nameEnd < 0:
+			nameEnd = i
+		case r == ':':
+			valueStart = i + 1
+		}
+		if valueStart >= 0 {
+			break
+		}
+	}
+	if valueStart < 0 {
+		return line, fmt.Errorf("malformed content line %q", raw)
+	}
+	if nameEnd < 0 {
+		nameEnd = valueStart - 1
+	}
+	line.name = strings.ToUpper(raw[:nameEnd])
+	line.value = raw[valueStart:]
+
+	// Parameters between the name and the value
+	params := raw[nameEnd : valueStart-1]
+	for params != "" {
+		params = params[1:] // leading ';'
+		eq := strings.IndexByte(params, '=')
+		if eq < 0 {
+			return line, fmt.Errorf("malformed parameter in %q", raw)
+		}
+		key := strings.ToUpper(params[:eq])
+		rest := params[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return line, fmt.Errorf("unterminated quoted parameter in %q", raw)
+			}
+			value, rest = rest[1:end+1], rest[end+2:]
+		} else if semi := strings.IndexByte(rest, ';'); semi >= 0 {
+			value, rest = rest[:semi], rest[semi:]
+		} else {
+			value, rest = rest, ""
+		}
+		line.params[key] = value
+		params = rest
+	}
+
+	return line, nil
+}
+
+// icsUnescape reverses TEXT value escaping (the inverse of icsEscape)
+func icsUnescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default: // \\ \; \,
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// icsEvent collects a VEVENT's properties until END:VEVENT
+type icsEvent struct {
+	req      proto.AddEventRequest
+	start    *icsLine
+	end      *icsLine
+	duration string
+	uid      string
+	exrule   bool // has an EXRULE, which Google doesn't support
+	// recurrenceID marks the event as an override of one instance of the
+	// series with the same UID
+	recurrenceID string
+}
+
+func (e *icsEvent) set(line icsLine) {
+	switch line.name {
+	case "SUMMARY":
+		e.req.Summary = icsUnescape(line.value)
+	case "DESCRIPTION":
+		description := icsUnescape(line.value)
+		e.req.Description = &description
+	case "LOCATION":
+		location := icsUnescape(line.value)
+		e.req.Location = &location
+	case "DTSTART":
+		e.start = &line
+	case "DTEND":
+		e.end = &line
+	case "DURATION":
+		e.duration = line.value
+	case "UID":
+		e.uid = line.value
+	case "RECURRENCE-ID":
+		e.recurrenceID = line.value
+	case "EXRULE":
+		e.exrule = true
+	case "RRULE", "RDATE", "EXDATE":
+		// Already RFC 5545 lines, which is what Google expects
+		if e.req.Recurrence == nil {
+			e.req.Recurrence = &proto.Recurrence{}
+		}
+		e.req.Recurrence.Rules = append(e.req.Recurrence.Rules, line.raw)
+	case "ATTENDEE":
+		email := strings.TrimSpace(line.value)
+		if len(email) >= len("mailto:") && strings.EqualFold(email[:len("mailto:")], "mailto:") {
+			email = email[len("mailto:"):]
+		}
+		attendee := &proto.Attendee{
+			Email:    email,
+			Optional: strings.EqualFold(line.params["ROLE"], "OPT-PARTICIPANT"),
+		}
+		if cn := line.params["CN"]; cn != "" {
+			attendee.DisplayName = &cn
+		}
+		if e.req.Attendees == nil {
+			e.req.Attendees = &proto.AttendeeList{}
+		}
+		e.req.Attendees.Attendees = append(e.req.Attendees.Attendees, attendee)
+	case "TRANSP":
+		blocks := !strings.EqualFold(line.value, "TRANSPARENT")
+		e.req.BlocksTime = &blocks
+	case "CLASS":
+		switch visibility := strings.ToLower(line.value); visibility {
+		case "public", "private", "confidential":
+			e.req.Visibility = &visibility
+		}
+	}
+}
+
+// request maps the collected properties onto an AddEventRequest
+func (e *icsEvent) request() (*proto.AddEventRequest, error) {
+	if e.start == nil {
+		return nil, fmt.Errorf("missing DTSTART")
+	}
+	if e.exrule {
+		return nil, fmt.Errorf("EXRULE is not supported by Google Calendar; list the excluded dates with EXDATE instead")
+	}
+	req := &e.req
+
+	start, allDay, zone, err := parseICSDateTime(*e.start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DTSTART: %w", err)
+	}
+	req.StartTime = timestamppb.New(start)
+	if allDay {
+		req.AllDay = &allDay
+	}
+	if zone != "" {
+		req.TimeZone = &zone
+	}
+
+	switch {
+	case e.end != nil:
+		end, _, _, err := parseICSDateTime(*e.end)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DTEND: %w", err)
+		}
+		req.EndTime = timestamppb.New(end)
+	case e.duration != "":
+		d, err := parseICSDuration(e.duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DURATION: %w", err)
+		}
+		req.EndTime = timestamppb.New(start.Add(d))
+	}
+
+	// Google event IDs only allow base32hex characters, so derive one from
+	// the UID; re-importing the same file then fails on events it already
+	// created instead of duplicating them
+	if e.uid != "" {
+		sum := sha256.Sum256([]byte(e.uid))
+		key := "ics" + hex.EncodeToString(sum[:16])
+		req.IdempotencyKey = &key
+	}
+
+	return req, nil
+}
+
+// parseICSDateTime parses a DTSTART or DTEND: a date (VALUE=DATE, all-day),
+// a UTC date-time ending in Z, or a local date-time in its TZID zone (UTC if
+// it has none). It returns the IANA zone name for zoned times.
+func parseICSDateTime(line icsLine) (t time.Time, allDay bool, zone string, err error) {
+	value := strings.TrimSpace(line.value)
+	if strings.EqualFold(line.params["VALUE"], "DATE") || len(value) == len("20060102") {
+		t, err = time.Parse("20060102", value)
+		return t, true, "", err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse("20060102T150405Z", value)
+		return t, false, "", err
+	}
+
+	loc := time.UTC
+	if tzid := line.params["TZID"]; tzid != "" {
+		l, err := time.LoadLocation(tzid)
+		if err != nil {
+			return t, false, "", fmt.Errorf("unknown TZID %q", tzid)
+		}
+		loc, zone = l, tzid
+	}
+	t, err = time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, zone, err
+}
+
+var icsDurationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseICSDuration parses an RFC 5545 duration such as PT1H30M or P1D
+func parseICSDuration(s string) (time.Duration, error) {
+	m := icsDurationPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("malformed duration %q", s)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return 0, fmt.Errorf("malformed duration %q", s)
+		}
+		d += time.Duration(n) * unit
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
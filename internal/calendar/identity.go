@@ -0,0 +1,38 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// AuthenticatedEmail returns the email of the user the client is authenticated
+// as, which Google reports as the ID of the primary calendar. The result is
+// cached for the lifetime of the client; failures are not cached.
+func (c *Client) AuthenticatedEmail(ctx context.Context) (string, error) {
+	c.identityMu.Lock()
+	defer c.identityMu.Unlock()
+
+	if c.authenticatedEmail != "" {
+		return c.authenticatedEmail, nil
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var entry *calendar.CalendarListEntry
+	err := c.retry(ctx, func() (err error) {
+		entry, err = c.service.CalendarList.Get("primary").Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve authenticated user: %w", err)
+	}
+	if entry.Id == "" {
+		return "", fmt.Errorf("unable to resolve authenticated user: primary calendar has no ID")
+	}
+
+	c.authenticatedEmail = entry.Id
+	return c.authenticatedEmail, nil
+}
@@ -1,6 +1,10 @@
 package calendar
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/drewfead/cali/proto"
@@ -8,8 +12,19 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// DefaultEventDuration is the length of events created without an end time,
+// unless the client is configured otherwise
+const DefaultEventDuration = time.Hour
+
 // MapProtoToEvent converts a proto AddEventRequest to a Google Calendar Event
 func MapProtoToEvent(req *proto.AddEventRequest) *calendar.Event {
+	return MapProtoToEventWithDuration(req, DefaultEventDuration)
+}
+
+// MapProtoToEventWithDuration converts a proto AddEventRequest to a Google
+// Calendar Event, ending events that have no end time defaultDuration after
+// their start
+func MapProtoToEventWithDuration(req *proto.AddEventRequest, defaultDuration time.Duration) *calendar.Event {
 	event := &calendar.Event{
 		Summary: req.Summary,
 	}
@@ -50,61 +65,245 @@ func MapProtoToEvent(req *proto.AddEventRequest) *calendar.Event {
 		}
 	}
 
+	// Set attendees if provided
+	if req.Attendees != nil {
+		event.Attendees = MapProtoToAttendees(req.Attendees.Attendees)
+	}
+
+	// Make the event recurring if rules are provided
+	if req.Recurrence != nil {
+		event.Recurrence = req.Recurrence.Rules
+	}
+
+	// Override the calendar's default reminders if provided; otherwise leave
+	// Reminders unset so Google applies the defaults
+	if req.Reminders != nil && len(req.Reminders.Reminders) > 0 {
+		event.Reminders = MapProtoToReminders(req.Reminders.Reminders)
+	}
+
+	// Attach files if provided
+	if req.Attachments != nil {
+		event.Attachments = MapProtoToAttachments(req.Attachments.Attachments)
+	}
+
+	// Ask Google to create a Meet link; the request ID makes retries of the
+	// same insert reuse one conference
+	if req.AddConference != nil && *req.AddConference {
+		event.ConferenceData = &calendar.ConferenceData{
+			CreateRequest: &calendar.CreateConferenceRequest{
+				RequestId:             newConferenceRequestID(),
+				ConferenceSolutionKey: &calendar.ConferenceSolutionKey{Type: "hangoutsMeet"},
+			},
+		}
+	}
+
+	// Set visibility if provided (Google defaults to the calendar's setting)
+	if req.Visibility != nil && *req.Visibility != "" {
+		event.Visibility = *req.Visibility
+	}
+
+	// Set color if provided (Google defaults to the calendar's color)
+	if req.ColorId != nil && *req.ColorId != "" {
+		event.ColorId = *req.ColorId
+	}
+
+	// Set extended properties if provided
+	if private, shared := req.PrivateProperties.GetProperties(), req.SharedProperties.GetProperties(); len(private) > 0 || len(shared) > 0 {
+		event.ExtendedProperties = &calendar.EventExtendedProperties{Private: private, Shared: shared}
+	}
+
+	// Set event type if provided (Google defaults to "default")
+	if req.EventType != nil && *req.EventType != "" {
+		event.EventType = *req.EventType
+	}
+
 	// Always explicitly set transparency (Google Calendar API defaults may differ)
-	// If blocks_time is true, event is "opaque" (blocks time)
-	// If blocks_time is false, event is "transparent" (doesn't block time)
-	if req.BlocksTime != nil && *req.BlocksTime {
-		event.Transparency = "opaque"
+	event.Transparency = defaultTransparency(req.BlocksTime, event.EventType)
+
+	// Express times in the requested zone (UTC unless set)
+	loc, zone := eventLocation(req.TimeZone)
+
+	// All-day events use dates only; others get date-times
+	if req.AllDay != nil && *req.AllDay {
+		event.Start, event.End = allDayRange(req.StartTime, req.EndTime, loc)
 	} else {
-		event.Transparency = "transparent"
+		event.Start, event.End = timedRange(req.StartTime, req.EndTime, defaultDuration, loc, zone)
 	}
 
+	return event
+}
+
+// newConferenceRequestID returns a random ID for a conference create request
+func newConferenceRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// timedRange returns the start and end of a timed event in loc. The start
+// defaults to the next hour and the end to defaultDuration after the start.
+func timedRange(start, end *timestamppb.Timestamp, defaultDuration time.Duration, loc *time.Location, zone string) (*calendar.EventDateTime, *calendar.EventDateTime) {
 	// Determine start time
 	var startTime time.Time
-	if req.StartTime != nil {
-		startTime = req.StartTime.AsTime()
+	if start != nil {
+		startTime = start.AsTime()
 	} else {
 		// Default to current time rounded to next hour
-		now := time.Now()
+		now := time.Now().In(loc)
 		startTime = now.Add(time.Hour - time.Duration(now.Minute())*time.Minute - time.Duration(now.Second())*time.Second)
 	}
 
 	// Determine end time
 	var endTime time.Time
-	if req.EndTime != nil {
-		endTime = req.EndTime.AsTime()
+	if end != nil {
+		endTime = end.AsTime()
 	} else {
-		// Default to 1 hour after start time
-		endTime = startTime.Add(time.Hour)
+		// Default to the configured duration after start time
+		endTime = startTime.Add(defaultDuration)
 	}
 
 	// Set event times in RFC3339 format
-	event.Start = &calendar.EventDateTime{
-		DateTime: startTime.Format(time.RFC3339),
-		TimeZone: "UTC",
+	return eventDateTimeIn(startTime, loc, zone), eventDateTimeIn(endTime, loc, zone)
+}
+
+// allDayRange returns the dates of an all-day event, taken in loc, starting
+// today if start is unset. Google treats the end date as exclusive, so an end
+// on or before the start date becomes the day after the start.
+func allDayRange(start, end *timestamppb.Timestamp, loc *time.Location) (*calendar.EventDateTime, *calendar.EventDateTime) {
+	startDay := time.Now().In(loc)
+	if start != nil {
+		startDay = start.AsTime().In(loc)
+	}
+	startDay = dateOf(startDay)
+
+	endDay := startDay.AddDate(0, 0, 1)
+	if end != nil {
+		if d := dateOf(end.AsTime().In(loc)); d.After(startDay) {
+			endDay = d
+		}
 	}
 
-	event.End = &calendar.EventDateTime{
-		DateTime: endTime.Format(time.RFC3339),
-		TimeZone: "UTC",
+	return &calendar.EventDateTime{Date: startDay.Format("2006-01-02")},
+		&calendar.EventDateTime{Date: endDay.Format("2006-01-02")}
+}
+
+// allDayUpdate returns the dates of an all-day event after an update: new
+// times are taken in loc and unset ones keep the existing edge's date (a
+// timed edge gives the date it falls on). An end on or before the start
+// date becomes the day after the start.
+func allDayUpdate(start, end *timestamppb.Timestamp, existingStart, existingEnd *calendar.EventDateTime, loc *time.Location) (*calendar.EventDateTime, *calendar.EventDateTime) {
+	startDay, ok := edgeDay(start, existingStart, loc)
+	if !ok {
+		startDay = dateOf(time.Now().In(loc))
+	}
+	endDay, ok := edgeDay(end, existingEnd, loc)
+	if !ok || !endDay.After(startDay) {
+		endDay = startDay.AddDate(0, 0, 1)
 	}
 
-	return event
+	return &calendar.EventDateTime{Date: startDay.Format("2006-01-02")},
+		&calendar.EventDateTime{Date: endDay.Format("2006-01-02")}
 }
 
-// MapProtoUpdateToEvent applies updates from UpdateEventRequest to an existing event
+// edgeDay returns the date t falls on in loc, or the date of the existing
+// edge if t is unset
+func edgeDay(t *timestamppb.Timestamp, existing *calendar.EventDateTime, loc *time.Location) (time.Time, bool) {
+	switch {
+	case t != nil:
+		return dateOf(t.AsTime().In(loc)), true
+	case existing == nil:
+		return time.Time{}, false
+	case existing.Date != "":
+		d, err := time.Parse("2006-01-02", existing.Date)
+		return d, err == nil
+	default:
+		d, err := time.Parse(time.RFC3339, existing.DateTime)
+		return dateOf(d), err == nil
+	}
+}
+
+// dateOf returns midnight UTC on t's calendar date
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// eventLocation resolves an optional IANA time zone name, defaulting to UTC.
+// Names are checked by ValidateTimeZone before mapping; unknown ones fall
+// back to UTC here.
+func eventLocation(timeZone *string) (*time.Location, string) {
+	if timeZone == nil || *timeZone == "" {
+		return time.UTC, "UTC"
+	}
+	loc, err := time.LoadLocation(*timeZone)
+	if err != nil {
+		return time.UTC, "UTC"
+	}
+	return loc, *timeZone
+}
+
+// eventDateTimeIn formats t as a Google Calendar date-time in loc, labelled
+// with the zone's IANA name
+func eventDateTimeIn(t time.Time, loc *time.Location, zone string) *calendar.EventDateTime {
+	return &calendar.EventDateTime{
+		DateTime: t.In(loc).Format(time.RFC3339),
+		TimeZone: zone,
+	}
+}
+
+// ValidateTimeZone checks that name is an IANA time zone Google will accept.
+// An empty name is valid and means UTC.
+func ValidateTimeZone(name string) error {
+	if name == "" {
+		return nil
+	}
+	if name == "Local" {
+		return fmt.Errorf("invalid time zone %q: use an IANA name such as America/New_York", name)
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("invalid time zone %q: %w", name, err)
+	}
+	return nil
+}
+
+// defaultTransparency resolves an event's transparency. An explicit blocks_time
+// is authoritative; otherwise the default depends on the event type:
+//
+//	blocks_time  event_type                   transparency
+//	true         any                          opaque
+//	false        any                          transparent
+//	unset        outOfOffice, focusTime       opaque
+//	unset        default, workingLocation, "" transparent
+func defaultTransparency(blocksTime *bool, eventType string) string {
+	if blocksTime != nil {
+		if *blocksTime {
+			return "opaque"
+		}
+		return "transparent"
+	}
+
+	switch eventType {
+	case "outOfOffice", "focusTime":
+		return "opaque"
+	default:
+		return "transparent"
+	}
+}
+
+// MapProtoUpdateToEvent applies updates from UpdateEventRequest to an existing event.
+// An unset (nil) field leaves the event's value unchanged, while a text field
+// set to the empty string clears it.
 func MapProtoUpdateToEvent(req *proto.UpdateEventRequest, existingEvent *calendar.Event) *calendar.Event {
 	// Start with the existing event
 	event := existingEvent
 
-	// Update optional fields only if provided
-	if req.Summary != nil && *req.Summary != "" {
+	// Update optional fields only if provided ("" clears them)
+	if req.Summary != nil {
 		event.Summary = *req.Summary
 	}
-	if req.Description != nil && *req.Description != "" {
+	if req.Description != nil {
 		event.Description = *req.Description
 	}
-	if req.Location != nil && *req.Location != "" {
+	if req.Location != nil {
 		event.Location = *req.Location
 	}
 
@@ -132,6 +331,46 @@ func MapProtoUpdateToEvent(req *proto.UpdateEventRequest, existingEvent *calenda
 		}
 	}
 
+	// Replace the guest list if provided (an empty list removes every guest)
+	if req.Attendees != nil {
+		event.Attendees = MapProtoToAttendees(req.Attendees.Attendees)
+	}
+
+	// Replace the recurrence rules if provided
+	if req.Recurrence != nil {
+		event.Recurrence = req.Recurrence.Rules
+	}
+
+	// Replace the reminder overrides if provided (an empty list restores the
+	// calendar's defaults)
+	if req.Reminders != nil {
+		event.Reminders = MapProtoToReminders(req.Reminders.Reminders)
+	}
+
+	// Replace the attachments if provided (an empty list removes them all)
+	if req.Attachments != nil {
+		event.Attachments = MapProtoToAttachments(req.Attachments.Attachments)
+	}
+
+	// Update visibility if provided
+	if req.Visibility != nil && *req.Visibility != "" {
+		event.Visibility = *req.Visibility
+	}
+
+	// Update color if provided ("" restores the calendar's color)
+	if req.ColorId != nil {
+		event.ColorId = *req.ColorId
+	}
+
+	// Merge extended properties if provided (an empty value deletes the key)
+	if req.PrivateProperties != nil || req.SharedProperties != nil {
+		if event.ExtendedProperties == nil {
+			event.ExtendedProperties = &calendar.EventExtendedProperties{}
+		}
+		event.ExtendedProperties.Private = mergeProperties(event.ExtendedProperties.Private, req.PrivateProperties.GetProperties())
+		event.ExtendedProperties.Shared = mergeProperties(event.ExtendedProperties.Shared, req.SharedProperties.GetProperties())
+	}
+
 	// Update transparency if provided
 	if req.BlocksTime != nil {
 		if *req.BlocksTime {
@@ -141,27 +380,341 @@ func MapProtoUpdateToEvent(req *proto.UpdateEventRequest, existingEvent *calenda
 		}
 	}
 
-	// Update start time if provided
-	if req.StartTime != nil {
-		startTime := req.StartTime.AsTime()
-		event.Start = &calendar.EventDateTime{
-			DateTime: startTime.Format(time.RFC3339),
-			TimeZone: "UTC",
+	// Express updated times in the requested zone (UTC unless set)
+	loc, zone := eventLocation(req.TimeZone)
+
+	// All-day events stay all-day unless req says otherwise, so new times
+	// become dates rather than date-times Google would reject beside them
+	allDay := event.Start != nil && event.Start.Date != ""
+	if req.AllDay != nil {
+		allDay = *req.AllDay
+	}
+	if allDay {
+		if req.StartTime != nil || req.EndTime != nil || req.AllDay != nil {
+			event.Start, event.End = allDayUpdate(req.StartTime, req.EndTime, event.Start, event.End, loc)
+		}
+	} else {
+		// Update start time if provided
+		if req.StartTime != nil {
+			event.Start = eventDateTimeIn(req.StartTime.AsTime(), loc, zone)
+		}
+
+		// Update end time if provided
+		if req.EndTime != nil {
+			event.End = eventDateTimeIn(req.EndTime.AsTime(), loc, zone)
 		}
 	}
 
-	// Update end time if provided
-	if req.EndTime != nil {
-		endTime := req.EndTime.AsTime()
-		event.End = &calendar.EventDateTime{
-			DateTime: endTime.Format(time.RFC3339),
-			TimeZone: "UTC",
+	// Move unchanged times into a newly requested zone too (all-day dates
+	// have no zone to change)
+	if req.TimeZone != nil && *req.TimeZone != "" {
+		for _, dt := range []*calendar.EventDateTime{event.Start, event.End} {
+			if dt == nil || dt.DateTime == "" {
+				continue
+			}
+			if t, err := time.Parse(time.RFC3339, dt.DateTime); err == nil {
+				dt.DateTime = t.In(loc).Format(time.RFC3339)
+				dt.TimeZone = zone
+			}
 		}
 	}
 
 	return event
 }
 
+// MapProtoUpdateToPatch builds the body of an Events.Patch call from an event
+// that MapProtoUpdateToEvent has already applied req to, carrying only the
+// fields req sets. Cleared values (empty strings, lists, false) are sent
+// explicitly, since PATCH keeps the current value of any field left out.
+func MapProtoUpdateToPatch(req *proto.UpdateEventRequest, updated *calendar.Event) *calendar.Event {
+	patch := &calendar.Event{}
+	force := func(field string) {
+		patch.ForceSendFields = append(patch.ForceSendFields, field)
+	}
+
+	if req.Summary != nil {
+		patch.Summary = updated.Summary
+		force("Summary")
+	}
+	if req.Description != nil {
+		patch.Description = updated.Description
+		force("Description")
+	}
+	if req.Location != nil {
+		patch.Location = updated.Location
+		force("Location")
+	}
+
+	if req.GuestsCanSeeOtherGuests != nil {
+		patch.GuestsCanSeeOtherGuests = updated.GuestsCanSeeOtherGuests
+	}
+	if req.GuestsCanModify != nil {
+		patch.GuestsCanModify = updated.GuestsCanModify
+		force("GuestsCanModify")
+	}
+	if req.GuestsCanInviteOthers != nil {
+		patch.GuestsCanInviteOthers = updated.GuestsCanInviteOthers
+	}
+
+	if req.SourceTitle != nil || req.SourceUrl != nil {
+		source := *updated.Source
+		if source.Title == "" && source.Url == "" {
+			// Nothing left of the source, so remove it
+			patch.NullFields = append(patch.NullFields, "Source")
+		} else {
+			source.ForceSendFields = append(source.ForceSendFields, "Title", "Url")
+			patch.Source = &source
+		}
+	}
+	if req.Attendees != nil {
+		patch.Attendees = updated.Attendees
+		force("Attendees")
+	}
+	if req.Recurrence != nil {
+		patch.Recurrence = updated.Recurrence
+		force("Recurrence")
+	}
+	if req.Reminders != nil {
+		reminders := *updated.Reminders
+		// Google merges reminders, so overrides left over from before must be
+		// nulled or it rejects them alongside the defaults
+		if reminders.UseDefault {
+			reminders.NullFields = append(reminders.NullFields, "Overrides")
+		}
+		patch.Reminders = &reminders
+	}
+	if req.Attachments != nil {
+		patch.Attachments = updated.Attachments
+		force("Attachments")
+	}
+	if req.Visibility != nil && *req.Visibility != "" {
+		patch.Visibility = updated.Visibility
+	}
+	if req.ColorId != nil {
+		patch.ColorId = updated.ColorId
+		force("ColorId")
+	}
+	if req.PrivateProperties != nil || req.SharedProperties != nil {
+		// Google merges the property maps, so deleted keys are sent as null
+		patch.ExtendedProperties = &calendar.EventExtendedProperties{
+			Private: patchProperties(req.PrivateProperties.GetProperties()),
+			Shared:  patchProperties(req.SharedProperties.GetProperties()),
+		}
+		for key, value := range req.PrivateProperties.GetProperties() {
+			if value == "" {
+				patch.ExtendedProperties.NullFields = append(patch.ExtendedProperties.NullFields, "Private."+key)
+			}
+		}
+		for key, value := range req.SharedProperties.GetProperties() {
+			if value == "" {
+				patch.ExtendedProperties.NullFields = append(patch.ExtendedProperties.NullFields, "Shared."+key)
+			}
+		}
+	}
+	if req.BlocksTime != nil {
+		patch.Transparency = updated.Transparency
+	}
+
+	// A new time zone or all_day setting moves both ends, and a new all-day
+	// start can push the end along with it
+	zoneChanged := req.TimeZone != nil && *req.TimeZone != ""
+	allDay := updated.Start != nil && updated.Start.Date != ""
+	if req.StartTime != nil || zoneChanged || req.AllDay != nil || (allDay && req.EndTime != nil) {
+		patch.Start = patchDateTime(updated.Start)
+	}
+	if req.EndTime != nil || zoneChanged || req.AllDay != nil || (allDay && req.StartTime != nil) {
+		patch.End = patchDateTime(updated.End)
+	}
+
+	return patch
+}
+
+// mergeProperties applies updates to props, deleting keys whose new value is
+// empty, and returns the result (nil once empty)
+func mergeProperties(props, updates map[string]string) map[string]string {
+	for key, value := range updates {
+		if value == "" {
+			delete(props, key)
+			continue
+		}
+		if props == nil {
+			props = map[string]string{}
+		}
+		props[key] = value
+	}
+	if len(props) == 0 {
+		return nil
+	}
+	return props
+}
+
+// patchProperties returns the properties in updates that are set rather than
+// deleted, or nil if there are none
+func patchProperties(updates map[string]string) map[string]string {
+	var props map[string]string
+	for key, value := range updates {
+		if value == "" {
+			continue
+		}
+		if props == nil {
+			props = map[string]string{}
+		}
+		props[key] = value
+	}
+	return props
+}
+
+// patchDateTime copies dt for a patch, nulling whichever of the all-day date
+// or the timed fields it no longer has so Google doesn't merge the two
+func patchDateTime(dt *calendar.EventDateTime) *calendar.EventDateTime {
+	if dt == nil {
+		return nil
+	}
+	patched := *dt
+	if patched.DateTime != "" && patched.Date == "" {
+		patched.NullFields = append(patched.NullFields, "Date")
+	}
+	if patched.Date != "" && patched.DateTime == "" {
+		patched.NullFields = append(patched.NullFields, "DateTime", "TimeZone")
+	}
+	return &patched
+}
+
+// ValidateEventTimes checks that the event's start and end each set exactly one
+// of Date (all-day) or DateTime, and the same one, which Google otherwise
+// rejects with a 400.
+// The mappers never produce both, but imported events can.
+func ValidateEventTimes(event *calendar.Event) error {
+	for _, edge := range []struct {
+		name string
+		dt   *calendar.EventDateTime
+	}{
+		{"start", event.Start},
+		{"end", event.End},
+	} {
+		if edge.dt == nil {
+			continue
+		}
+		if edge.dt.Date != "" && edge.dt.DateTime != "" {
+			return fmt.Errorf("invalid event %s: both date (%s) and dateTime (%s) are set; use date for all-day events or dateTime otherwise",
+				edge.name, edge.dt.Date, edge.dt.DateTime)
+		}
+		if edge.dt.Date == "" && edge.dt.DateTime == "" {
+			return fmt.Errorf("invalid event %s: one of date or dateTime must be set", edge.name)
+		}
+	}
+	if event.Start != nil && event.End != nil && (event.Start.Date == "") != (event.End.Date == "") {
+		return fmt.Errorf("invalid event times: start and end must both be dates (all-day) or both be dateTimes; set both start and end when changing all_day")
+	}
+	return nil
+}
+
+// validResponseStatuses are the RSVP states Google accepts for attendees
+var validResponseStatuses = map[string]bool{
+	"needsAction": true,
+	"declined":    true,
+	"tentative":   true,
+	"accepted":    true,
+}
+
+// ValidateAttendees checks that every attendee has an email and, if set, a
+// response status Google recognizes.
+func ValidateAttendees(event *calendar.Event) error {
+	for i, attendee := range event.Attendees {
+		if attendee.Email == "" {
+			return fmt.Errorf("invalid attendee %d: email is required", i+1)
+		}
+		if attendee.ResponseStatus != "" && !validResponseStatuses[attendee.ResponseStatus] {
+			return fmt.Errorf("invalid attendee %s: unknown response status %q (expected needsAction, declined, tentative, or accepted)",
+				attendee.Email, attendee.ResponseStatus)
+		}
+	}
+	return nil
+}
+
+// ValidateAttendeeCount checks that the event invites at most limit
+// attendees. A non-positive limit allows any number.
+func ValidateAttendeeCount(event *calendar.Event, limit int) error {
+	if limit > 0 && len(event.Attendees) > limit {
+		return fmt.Errorf("invalid attendees: %d invited, but at most %d are allowed (max_attendees_allowed)", len(event.Attendees), limit)
+	}
+	return nil
+}
+
+// validVisibilities are the visibility values Google accepts
+var validVisibilities = map[string]bool{
+	"default":      true,
+	"public":       true,
+	"private":      true,
+	"confidential": true,
+}
+
+// ValidateVisibility checks that the event's visibility, if set, is one
+// Google accepts.
+func ValidateVisibility(event *calendar.Event) error {
+	if event.Visibility != "" && !validVisibilities[event.Visibility] {
+		return fmt.Errorf("invalid visibility %q: expected default, public, private, or confidential", event.Visibility)
+	}
+	return nil
+}
+
+// maxEventColorID is the highest of Google's event color IDs, which run from 1
+const maxEventColorID = 11
+
+// ValidateColorID checks that the event's color, if set, is one of Google's
+// event color IDs ("1" to "11").
+func ValidateColorID(event *calendar.Event) error {
+	if event.ColorId == "" {
+		return nil
+	}
+	if id, err := strconv.Atoi(event.ColorId); err != nil || id < 1 || id > maxEventColorID || strconv.Itoa(id) != event.ColorId {
+		return fmt.Errorf("invalid color_id %q: expected an event color from 1 to %d", event.ColorId, maxEventColorID)
+	}
+	return nil
+}
+
+// validSendUpdates are the sendUpdates values Google accepts
+var validSendUpdates = map[string]bool{
+	"none":         true,
+	"externalOnly": true,
+	"all":          true,
+}
+
+// ValidateSendUpdates checks that sendUpdates, if set, is one Google accepts.
+func ValidateSendUpdates(sendUpdates string) error {
+	if sendUpdates != "" && !validSendUpdates[sendUpdates] {
+		return fmt.Errorf("invalid send_updates %q: expected none, externalOnly, or all", sendUpdates)
+	}
+	return nil
+}
+
+// maxReminderOverrides and maxReminderMinutes are Google's limits on reminder
+// overrides (minutes up to four weeks)
+const (
+	maxReminderOverrides = 5
+	maxReminderMinutes   = 40320
+)
+
+// ValidateReminders checks an event's reminder overrides against Google's
+// limits: at most five, each popup or email, 0 to 40320 minutes before.
+func ValidateReminders(event *calendar.Event) error {
+	if event.Reminders == nil {
+		return nil
+	}
+	if len(event.Reminders.Overrides) > maxReminderOverrides {
+		return fmt.Errorf("invalid reminders: at most %d overrides are allowed, got %d", maxReminderOverrides, len(event.Reminders.Overrides))
+	}
+	for _, override := range event.Reminders.Overrides {
+		if override.Method != "popup" && override.Method != "email" {
+			return fmt.Errorf("invalid reminder method %q: expected popup or email", override.Method)
+		}
+		if override.Minutes < 0 || override.Minutes > maxReminderMinutes {
+			return fmt.Errorf("invalid reminder of %d minutes: must be between 0 and %d", override.Minutes, maxReminderMinutes)
+		}
+	}
+	return nil
+}
+
 // MapEventToProto converts a Google Calendar Event to a proto Event
 func MapEventToProto(event *calendar.Event, calendarID string) *proto.Event {
 	protoEvent := &proto.Event{
@@ -184,6 +737,26 @@ func MapEventToProto(event *calendar.Event, calendarID string) *proto.Event {
 	if event.Transparency != "" {
 		protoEvent.Transparency = &event.Transparency
 	}
+	if event.Visibility != "" {
+		protoEvent.Visibility = &event.Visibility
+	}
+	if event.ColorId != "" {
+		protoEvent.ColorId = &event.ColorId
+	}
+	if event.ExtendedProperties != nil {
+		protoEvent.PrivateProperties = event.ExtendedProperties.Private
+		protoEvent.SharedProperties = event.ExtendedProperties.Shared
+	}
+	if event.EventType != "" {
+		protoEvent.EventType = &event.EventType
+	}
+
+	// Carry the guest permissions, so writing the event back keeps them
+	if event.GuestsCanModify {
+		protoEvent.GuestsCanModify = &event.GuestsCanModify
+	}
+	protoEvent.GuestsCanInviteOthers = event.GuestsCanInviteOthers
+	protoEvent.GuestsCanSeeOtherGuests = event.GuestsCanSeeOtherGuests
 
 	// Extract organizer information
 	if event.Organizer != nil {
@@ -226,11 +799,16 @@ func MapEventToProto(event *calendar.Event, calendarID string) *proto.Event {
 			if t, err := time.Parse(time.RFC3339, event.Start.DateTime); err == nil {
 				protoEvent.StartTime = timestamppb.New(t)
 			}
+			if event.Start.TimeZone != "" {
+				protoEvent.TimeZone = &event.Start.TimeZone
+			}
 		} else if event.Start.Date != "" {
 			// All-day event - parse date only
 			if t, err := time.Parse("2006-01-02", event.Start.Date); err == nil {
 				protoEvent.StartTime = timestamppb.New(t)
 			}
+			allDay := true
+			protoEvent.AllDay = &allDay
 		}
 	}
 
@@ -248,14 +826,191 @@ func MapEventToProto(event *calendar.Event, calendarID string) *proto.Event {
 		}
 	}
 
-	// Extract attendee emails
+	// Recurrence rules are only present on recurring masters
+	if len(event.Recurrence) > 0 {
+		protoEvent.Recurrence = event.Recurrence
+	}
+
+	// Extract reminder overrides
+	if event.Reminders != nil && !event.Reminders.UseDefault {
+		for _, override := range event.Reminders.Overrides {
+			protoEvent.Reminders = append(protoEvent.Reminders, &proto.Reminder{
+				Method:  override.Method,
+				Minutes: int32(override.Minutes),
+			})
+		}
+	}
+
+	// Extract attachments
+	for _, attachment := range event.Attachments {
+		protoEvent.Attachments = append(protoEvent.Attachments, MapAttachmentToProto(attachment))
+	}
+
+	// Extract attendee emails, plus the structured details
 	if event.Attendees != nil {
 		for _, attendee := range event.Attendees {
 			if attendee.Email != "" {
 				protoEvent.Attendees = append(protoEvent.Attendees, attendee.Email)
+				protoEvent.AttendeeDetails = append(protoEvent.AttendeeDetails, MapAttendeeToProto(attendee))
 			}
 		}
 	}
 
 	return protoEvent
 }
+
+// MapProtoEventToEvent converts a complete proto Event back to a Google
+// Calendar Event, for replacing an event wholesale. Read-only fields (the
+// link, organizer, and conference details) are left unset, as are settings
+// proto.Event doesn't carry, such as anyoneCanAddSelf, privateCopy, and the
+// out-of-office, focus time, and working location properties.
+func MapProtoEventToEvent(protoEvent *proto.Event) *calendar.Event {
+	event := &calendar.Event{
+		Id:                      protoEvent.Id,
+		Summary:                 protoEvent.Summary,
+		Description:             protoEvent.GetDescription(),
+		Location:                protoEvent.GetLocation(),
+		Status:                  protoEvent.GetStatus(),
+		Transparency:            protoEvent.GetTransparency(),
+		Visibility:              protoEvent.GetVisibility(),
+		ColorId:                 protoEvent.GetColorId(),
+		Recurrence:              protoEvent.Recurrence,
+		Reminders:               MapProtoToReminders(protoEvent.Reminders),
+		Attachments:             MapProtoToAttachments(protoEvent.Attachments),
+		EventType:               protoEvent.GetEventType(),
+		GuestsCanModify:         protoEvent.GetGuestsCanModify(),
+		GuestsCanInviteOthers:   protoEvent.GuestsCanInviteOthers,
+		GuestsCanSeeOtherGuests: protoEvent.GuestsCanSeeOtherGuests,
+	}
+
+	if protoEvent.GetSourceTitle() != "" || protoEvent.GetSourceUrl() != "" {
+		event.Source = &calendar.EventSource{Title: protoEvent.GetSourceTitle(), Url: protoEvent.GetSourceUrl()}
+	}
+	if len(protoEvent.PrivateProperties) > 0 || len(protoEvent.SharedProperties) > 0 {
+		event.ExtendedProperties = &calendar.EventExtendedProperties{
+			Private: protoEvent.PrivateProperties,
+			Shared:  protoEvent.SharedProperties,
+		}
+	}
+
+	// Prefer the structured attendees, which keep names and flags
+	if len(protoEvent.AttendeeDetails) > 0 {
+		event.Attendees = MapProtoToAttendees(protoEvent.AttendeeDetails)
+	} else {
+		for _, email := range protoEvent.Attendees {
+			event.Attendees = append(event.Attendees, &calendar.EventAttendee{Email: email})
+		}
+	}
+
+	// All-day dates were read as midnight UTC
+	if protoEvent.GetAllDay() {
+		event.Start, event.End = allDayRange(protoEvent.StartTime, protoEvent.EndTime, time.UTC)
+	} else {
+		loc, zone := eventLocation(protoEvent.TimeZone)
+		event.Start, event.End = timedRange(protoEvent.StartTime, protoEvent.EndTime, DefaultEventDuration, loc, zone)
+	}
+
+	return event
+}
+
+// MapAttendeeToProto converts a Google Calendar attendee to a proto Attendee
+func MapAttendeeToProto(attendee *calendar.EventAttendee) *proto.Attendee {
+	protoAttendee := &proto.Attendee{
+		Email:    attendee.Email,
+		Optional: attendee.Optional,
+	}
+	if attendee.DisplayName != "" {
+		protoAttendee.DisplayName = &attendee.DisplayName
+	}
+	if attendee.ResponseStatus != "" {
+		protoAttendee.ResponseStatus = &attendee.ResponseStatus
+	}
+	return protoAttendee
+}
+
+// MapProtoToAttendee converts a proto Attendee to a Google Calendar attendee
+func MapProtoToAttendee(attendee *proto.Attendee) *calendar.EventAttendee {
+	eventAttendee := &calendar.EventAttendee{
+		Email:    attendee.Email,
+		Optional: attendee.Optional,
+	}
+	if attendee.DisplayName != nil {
+		eventAttendee.DisplayName = *attendee.DisplayName
+	}
+	if attendee.ResponseStatus != nil {
+		eventAttendee.ResponseStatus = *attendee.ResponseStatus
+	}
+	return eventAttendee
+}
+
+// MapCalendarListEntryToProto converts a Google Calendar calendarList entry
+// to a proto Calendar
+func MapCalendarListEntryToProto(entry *calendar.CalendarListEntry) *proto.Calendar {
+	summary := entry.Summary
+	if entry.SummaryOverride != "" {
+		// The user's own name for the calendar takes precedence
+		summary = entry.SummaryOverride
+	}
+	return &proto.Calendar{
+		Id:         entry.Id,
+		Summary:    summary,
+		AccessRole: entry.AccessRole,
+		Primary:    entry.Primary,
+	}
+}
+
+// MapAttachmentToProto converts a Google Calendar attachment to a proto Attachment
+func MapAttachmentToProto(attachment *calendar.EventAttachment) *proto.Attachment {
+	protoAttachment := &proto.Attachment{FileUrl: attachment.FileUrl}
+	if attachment.Title != "" {
+		protoAttachment.Title = &attachment.Title
+	}
+	if attachment.MimeType != "" {
+		protoAttachment.MimeType = &attachment.MimeType
+	}
+	return protoAttachment
+}
+
+// MapProtoToAttachments converts proto Attachments to Google Calendar attachments
+func MapProtoToAttachments(attachments []*proto.Attachment) []*calendar.EventAttachment {
+	var eventAttachments []*calendar.EventAttachment
+	for _, attachment := range attachments {
+		eventAttachments = append(eventAttachments, &calendar.EventAttachment{
+			FileUrl:  attachment.FileUrl,
+			Title:    attachment.GetTitle(),
+			MimeType: attachment.GetMimeType(),
+		})
+	}
+	return eventAttachments
+}
+
+// MapProtoToReminders converts proto Reminders to Google Calendar reminder
+// overrides. With no reminders, the calendar's defaults are used instead.
+func MapProtoToReminders(reminders []*proto.Reminder) *calendar.EventReminders {
+	if len(reminders) == 0 {
+		return &calendar.EventReminders{UseDefault: true}
+	}
+	eventReminders := &calendar.EventReminders{
+		UseDefault: false,
+		// UseDefault must be sent explicitly, since false is omitted by default
+		ForceSendFields: []string{"UseDefault"},
+	}
+	for _, reminder := range reminders {
+		eventReminders.Overrides = append(eventReminders.Overrides, &calendar.EventReminder{
+			Method:  reminder.Method,
+			Minutes: int64(reminder.Minutes),
+			// Zero minutes (at the start time) is meaningful
+			ForceSendFields: []string{"Minutes"},
+		})
+	}
+	return eventReminders
+}
+
+// MapProtoToAttendees converts proto Attendees to Google Calendar attendees
+func MapProtoToAttendees(attendees []*proto.Attendee) []*calendar.EventAttendee {
+	var eventAttendees []*calendar.EventAttendee
+	for _, attendee := range attendees {
+		eventAttendees = append(eventAttendees, MapProtoToAttendee(attendee))
+	}
+	return eventAttendees
+}
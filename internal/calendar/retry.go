@@ -0,0 +1,159 @@
+package calendar
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/base32"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+)
+
+// RetryPolicy controls how calls that fail with a rate limit (429) or server
+// error (5xx) are retried. Other errors are returned immediately.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry; it doubles on each
+	// subsequent attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff between attempts (no cap when zero). A
+	// server's Retry-After is honored even when it is longer.
+	MaxDelay time.Duration
+	// Jitter is the fraction of each delay, from 0 to 1, that is randomized
+	// to spread out retries from concurrent callers.
+	Jitter float64
+}
+
+// DefaultRetryPolicy retries transient failures a few times over roughly
+// seven seconds.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   time.Second,
+	MaxDelay:    8 * time.Second,
+	Jitter:      0.5,
+}
+
+// retry runs call until it succeeds, fails with a non-retryable error, runs
+// out of attempts, or ctx is cancelled. The final error is classified (see
+// classifyError).
+func (c *Client) retry(ctx context.Context, call func() error) error {
+	return c.retryWhen(ctx, isRetryable, call)
+}
+
+// retryWhen is retry with retryable deciding which errors are retried.
+func (c *Client) retryWhen(ctx context.Context, retryable func(error) bool, call func() error) error {
+	policy := c.retryPolicy
+	for attempt := 1; ; attempt++ {
+		err := call()
+		if err == nil || attempt >= policy.MaxAttempts || !retryable(err) {
+			return classifyError(err)
+		}
+
+		delay := policy.backoff(attempt)
+		if after, ok := retryAfter(err); ok {
+			delay = after
+		}
+		slog.Debug("retrying calendar API call", "attempt", attempt, "delay", delay, "error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the delay after the given (1-based) failed attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 && delay > 0 {
+		jitter := time.Duration(min(p.Jitter, 1) * float64(delay))
+		delay -= time.Duration(rand.Int64N(int64(jitter) + 1))
+	}
+	return delay
+}
+
+// isRetryable reports whether err is a rate limit or server error.
+func isRetryable(err error) bool {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.Code == http.StatusTooManyRequests || apiErr.Code >= 500
+}
+
+// isRateLimited reports whether err is a rate limit, which Google returns
+// before doing any work, so even a call that isn't idempotent can be retried.
+func isRateLimited(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusTooManyRequests
+}
+
+// retryInsert is retry for insert, a call creating event in calendarID.
+// Inserts aren't idempotent: a server error can come after Google has stored
+// the event, and a plain retry would store it again. So event is first given
+// an ID if it has none, making a repeat conflict instead, and a conflict on a
+// retry fetches the event the earlier attempt created.
+func (c *Client) retryInsert(ctx context.Context, calendarID string, event *calendar.Event, insert func() (*calendar.Event, error)) (*calendar.Event, error) {
+	if event.Id == "" {
+		event.Id = newEventID()
+	}
+
+	var created *calendar.Event
+	attempt := 0
+	err := c.retry(ctx, func() (err error) {
+		attempt++
+		created, err = insert()
+		var apiErr *googleapi.Error
+		if attempt > 1 && errors.As(err, &apiErr) && apiErr.Code == http.StatusConflict {
+			created, err = c.service.Events.Get(calendarID, event.Id).Context(ctx).Do()
+		}
+		return err
+	})
+	return created, err
+}
+
+// newEventID returns a random event ID in the base32hex alphabet Google
+// requires of event IDs.
+func newEventID() string {
+	b := make([]byte, 16)
+	crand.Read(b)
+	return strings.ToLower(base32.HexEncoding.WithPadding(base32.NoPadding).EncodeToString(b))
+}
+
+// retryAfter returns the wait requested by an error's Retry-After header,
+// given either in seconds or as an HTTP date.
+func retryAfter(err error) (time.Duration, bool) {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return 0, false
+	}
+	value := apiErr.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
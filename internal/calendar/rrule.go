@@ -0,0 +1,113 @@
+package calendar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// validFrequencies are the FREQ values defined by RFC 5545
+var validFrequencies = map[string]bool{
+	"SECONDLY": true,
+	"MINUTELY": true,
+	"HOURLY":   true,
+	"DAILY":    true,
+	"WEEKLY":   true,
+	"MONTHLY":  true,
+	"YEARLY":   true,
+}
+
+// validWeekdays are the two-letter weekday codes accepted by BYDAY
+var validWeekdays = map[string]bool{
+	"MO": true, "TU": true, "WE": true, "TH": true, "FR": true, "SA": true, "SU": true,
+}
+
+// ValidateRRULE checks a recurrence rule (with or without the "RRULE:" prefix)
+// before it is sent to Google, which otherwise rejects mistakes with an opaque
+// 400. The returned error names the offending token.
+func ValidateRRULE(rule string) error {
+	body := strings.TrimPrefix(rule, "RRULE:")
+	if body == "" {
+		return fmt.Errorf("invalid RRULE %q: empty rule", rule)
+	}
+
+	hasFreq := false
+	for _, part := range strings.Split(body, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || key == "" || value == "" {
+			return fmt.Errorf("invalid RRULE %q: malformed part %q (expected KEY=VALUE)", rule, part)
+		}
+
+		switch key {
+		case "FREQ":
+			if !validFrequencies[value] {
+				return fmt.Errorf("invalid RRULE %q: unknown FREQ %q", rule, value)
+			}
+			hasFreq = true
+		case "COUNT", "INTERVAL":
+			if n, err := strconv.Atoi(value); err != nil || n < 1 {
+				return fmt.Errorf("invalid RRULE %q: %s must be a positive integer, got %q", rule, key, value)
+			}
+		case "UNTIL":
+			if !validUntil(value) {
+				return fmt.Errorf("invalid RRULE %q: UNTIL %q is not a date (YYYYMMDD) or UTC date-time (YYYYMMDDTHHMMSSZ)", rule, value)
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				// Strip an optional ordinal prefix such as "1MO" or "-1FR"
+				code := strings.TrimLeft(day, "+-0123456789")
+				if !validWeekdays[code] {
+					return fmt.Errorf("invalid RRULE %q: unknown BYDAY weekday %q", rule, day)
+				}
+			}
+		}
+	}
+
+	if !hasFreq {
+		return fmt.Errorf("invalid RRULE %q: missing FREQ", rule)
+	}
+	return nil
+}
+
+// validUntil reports whether value is in one of the UNTIL forms Google accepts:
+// a date or a UTC date-time (floating local times are rejected)
+func validUntil(value string) bool {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// recurrencePrefixes are the recurrence line types Google accepts; RDATE and
+// EXDATE may carry parameters such as TZID before the colon
+var recurrencePrefixes = []string{"RRULE:", "RDATE:", "RDATE;", "EXDATE:", "EXDATE;"}
+
+// ValidateRecurrence checks every recurrence line of an outgoing event: each
+// must be an RRULE, RDATE, or EXDATE, and RRULEs must be well formed.
+func ValidateRecurrence(event *calendar.Event) error {
+	for _, line := range event.Recurrence {
+		if !hasRecurrencePrefix(line) {
+			return fmt.Errorf("invalid recurrence %q: must start with RRULE:, RDATE:, or EXDATE:", line)
+		}
+		if strings.HasPrefix(line, "RRULE:") {
+			if err := ValidateRRULE(line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func hasRecurrencePrefix(line string) bool {
+	for _, prefix := range recurrencePrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
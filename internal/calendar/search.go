@@ -0,0 +1,101 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/drewfead/cali/proto"
+	"google.golang.org/api/calendar/v3"
+)
+
+// maxSearchPageSize is the largest page Google returns for an events list
+const maxSearchPageSize = 2500
+
+// SearchEvents returns a channel that streams the events of a calendar
+// matching req.Query, which Google matches against the summary, description,
+// location, attendees, and other text fields. Recurring events are expanded
+// into instances, in start order when a time window is given. Every page is
+// followed until the results (or req.Limit events) are exhausted.
+func (c *Client) SearchEvents(ctx context.Context, req *proto.SearchEventsRequest) (<-chan *proto.Event, <-chan error) {
+	responseChan := make(chan *proto.Event)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(responseChan)
+		defer close(errChan)
+
+		query := strings.TrimSpace(req.Query)
+		if query == "" {
+			errChan <- fmt.Errorf("a search query is required")
+			return
+		}
+
+		calendarID := "primary"
+		if req.CalendarId != nil && *req.CalendarId != "" {
+			calendarID = *req.CalendarId
+		}
+
+		call := c.service.Events.List(calendarID).Q(query).SingleEvents(true)
+		hasTimeFilter := false
+		if req.After != nil && req.After.IsValid() && req.After.AsTime().Unix() > 0 {
+			call = call.TimeMin(req.After.AsTime().Format(time.RFC3339))
+			hasTimeFilter = true
+		}
+		if req.Before != nil && req.Before.IsValid() && req.Before.AsTime().Unix() > 0 {
+			call = call.TimeMax(req.Before.AsTime().Format(time.RFC3339))
+			hasTimeFilter = true
+		}
+		if hasTimeFilter {
+			call = call.OrderBy("startTime")
+		}
+
+		// Cap the attendees listed per event, if configured
+		if c.maxAttendees > 0 {
+			call = call.MaxAttendees(c.maxAttendees)
+		}
+
+		// Don't fetch more than the limit needs
+		remaining := int(req.GetLimit())
+		if remaining > 0 {
+			call = call.MaxResults(int64(min(remaining, maxSearchPageSize)))
+		}
+
+		for {
+			// Fetch one page of results, bounding each page by the default timeout
+			var events *calendar.Events
+			pageCtx, cancel := c.withTimeout(ctx)
+			err := c.retry(pageCtx, func() (err error) {
+				events, err = call.Context(pageCtx).Do()
+				return err
+			})
+			cancel()
+			if err != nil {
+				errChan <- fmt.Errorf("unable to search events: %w", err)
+				return
+			}
+
+			for _, event := range events.Items {
+				select {
+				case <-ctx.Done():
+					errChan <- ctx.Err()
+					return
+				case responseChan <- MapEventToProto(event, calendarID):
+				}
+				if req.GetLimit() > 0 {
+					if remaining--; remaining == 0 {
+						return
+					}
+				}
+			}
+
+			if events.NextPageToken == "" {
+				return
+			}
+			call = call.PageToken(events.NextPageToken)
+		}
+	}()
+
+	return responseChan, errChan
+}
@@ -52,6 +52,24 @@ func GetTokenPath() (string, error) {
 	return filepath.Join(configDir, tokenFile), nil
 }
 
+// GetTokenPathForProfile returns the path to the OAuth token file for a named
+// auth profile (~/.config/cali/token-{name}.json). The empty profile uses the
+// default token file.
+func GetTokenPathForProfile(name string) (string, error) {
+	if name == "" {
+		return GetTokenPath()
+	}
+	if name != filepath.Base(name) || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid profile name %q", name)
+	}
+
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "token-"+name+".json"), nil
+}
+
 // EnsureConfigDir creates the configuration directory if it doesn't exist
 func EnsureConfigDir() error {
 	configDir, err := GetConfigDir()
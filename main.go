@@ -4,17 +4,20 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
 	"text/template"
 	"time"
+	_ "time/tzdata" // --time-zone must resolve even where the OS lacks zoneinfo
 
 	"github.com/drewfead/cali/internal/auth"
 	"github.com/drewfead/cali/internal/calendar"
 	"github.com/drewfead/cali/internal/config"
 	"github.com/drewfead/cali/proto"
 	protocli "github.com/drewfead/proto-cli"
+	"github.com/urfave/cli/v3"
 	protobuf "google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -33,6 +36,7 @@ type calendarService struct {
 	calendarClient *calendar.Client // Google Calendar API client (initialized lazily)
 	ctx            context.Context
 	cfg            *proto.CaliConfig
+	profile        string // auth profile selected with --profile, "" for the default
 }
 
 // newCalendarService creates a calendar service with lazy initialization.
@@ -64,42 +68,52 @@ func initializeGoogleCalendar(ctx context.Context, svc *calendarService, cfg *pr
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	// Check if we have auth config
-	if cfg.Auth == nil {
-		return fmt.Errorf("no auth configuration found")
-	}
-
-	// Determine token path (use config or default)
-	tokenPath := cfg.Auth.OauthTokenPath
-	if tokenPath == "" {
-		defaultPath, _ := config.GetTokenPath()
-		tokenPath = defaultPath
+	authCfg, tokenPath, err := profileAuth(cfg, svc.profile)
+	if err != nil {
+		return err
 	}
 
 	// Get authenticated HTTP client from typed config
-	httpClient, err := auth.GetClientFromConfig(ctx, cfg.Auth, tokenPath)
+	httpClient, err := auth.GetClientFromConfig(ctx, authCfg, tokenPath)
 	if err != nil {
 		return fmt.Errorf("failed to get authenticated client: %w", err)
 	}
 
 	// Determine auth mode for logging
-	if cfg.Auth.ServiceAccount != nil && cfg.Auth.ServiceAccount.ClientEmail != "" {
+	switch authMode(authCfg) {
+	case authModeServiceAccount:
 		slog.Info("using service account authentication", "mode", "automated")
-	} else {
+	case authModeOAuth:
 		slog.Info("using OAuth user authentication", "mode", "interactive")
+	default:
+		slog.Info("using application default credentials", "mode", "automated")
 	}
 
-	// Create Calendar API client with optional endpoint override
-	var calendarClient *calendar.Client
-	if cfg.ApiEndpoint != "" {
-		calendarClient, err = calendar.NewClient(ctx, httpClient, cfg.ApiEndpoint)
-	} else {
-		calendarClient, err = calendar.NewClient(ctx, httpClient)
+	if cfg.MaxAttendeesAllowed < 0 {
+		return fmt.Errorf("invalid max_attendees_allowed %d: must be zero (no limit) or positive", cfg.MaxAttendeesAllowed)
 	}
+
+	// Create Calendar API client with optional endpoint override, retrying
+	// rate-limited and failed calls and enforcing the configured cap on
+	// invitees
+	calendarClient, err := calendar.NewClient(ctx, httpClient,
+		calendar.WithEndpoint(cfg.ApiEndpoint),
+		calendar.WithRetry(calendar.DefaultRetryPolicy),
+		calendar.WithMaxAttendeesAllowed(int(cfg.MaxAttendeesAllowed)),
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create calendar client: %w", err)
 	}
 
+	// Apply the configured default length for events given only a start
+	if cfg.DefaultEventDuration != "" {
+		d, err := time.ParseDuration(cfg.DefaultEventDuration)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid default_event_duration %q: must be a positive Go duration such as \"30m\"", cfg.DefaultEventDuration)
+		}
+		calendarClient.SetDefaultEventDuration(d)
+	}
+
 	svc.calendarClient = calendarClient
 	return nil
 }
@@ -131,7 +145,7 @@ func (s *calendarService) AddEvent(ctx context.Context, req *proto.AddEventReque
 		return &proto.AddEventResponse{
 			Success: false,
 			Message: fmt.Sprintf("Failed to create event in Google Calendar: %v", err),
-		}, err
+		}, withStatus(err)
 	}
 
 	// Validate that the event was actually created
@@ -157,6 +171,7 @@ func (s *calendarService) AddEvent(ctx context.Context, req *proto.AddEventReque
 		Message:    fmt.Sprintf("Event '%s' added successfully to Google Calendar", req.Summary),
 		HtmlLink:   event.HtmlLink,
 		CalendarId: calendarID,
+		SelfLink:   s.calendarClient.SelfLink(calendarID, event.Id),
 	}, nil
 }
 
@@ -175,7 +190,7 @@ func (s *calendarService) UpdateEvent(ctx context.Context, req *proto.UpdateEven
 		return &proto.UpdateEventResponse{
 			Success: false,
 			Message: fmt.Sprintf("Failed to update event in Google Calendar: %v", err),
-		}, err
+		}, withStatus(err)
 	}
 
 	// Use calendar_id from request, default to "primary"
@@ -208,7 +223,7 @@ func (s *calendarService) DeleteEvent(ctx context.Context, req *proto.DeleteEven
 		return &proto.DeleteEventResponse{
 			Success: false,
 			Message: fmt.Sprintf("Failed to delete event from Google Calendar: %v", err),
-		}, err
+		}, withStatus(err)
 	}
 
 	// Use calendar_id from request, default to "primary"
@@ -233,7 +248,7 @@ func (s *calendarService) GetEvent(ctx context.Context, req *proto.GetEventReque
 	// Get event via Google Calendar API
 	event, err := s.calendarClient.GetEvent(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get event: %w", err)
+		return nil, withStatus(fmt.Errorf("failed to get event: %w", err))
 	}
 
 	// Validate that the event was retrieved
@@ -256,6 +271,83 @@ func (s *calendarService) GetEvent(ctx context.Context, req *proto.GetEventReque
 	}, nil
 }
 
+func (s *calendarService) QuickAdd(ctx context.Context, req *proto.QuickAddRequest) (*proto.QuickAddResponse, error) {
+	// Lazily initialize calendar client on first use
+	if err := s.ensureInitialized(ctx); err != nil {
+		return nil, fmt.Errorf("failed to initialize calendar client: %w", err)
+	}
+
+	// Create event via Google Calendar API
+	event, err := s.calendarClient.QuickAdd(ctx, req)
+	if err != nil {
+		return nil, withStatus(fmt.Errorf("failed to quick add event: %w", err))
+	}
+
+	// Validate that the event was actually created
+	if event == nil || event.Id == "" {
+		slog.Error("quick added event has no ID", "calendar_id", req.CalendarId)
+		return nil, fmt.Errorf("created event is missing ID")
+	}
+
+	// Use calendar_id from request, default to "primary"
+	calendarID := "primary"
+	if req.CalendarId != nil && *req.CalendarId != "" {
+		calendarID = *req.CalendarId
+	}
+
+	slog.Info("event quick added successfully", "event_id", event.Id, "calendar_id", calendarID)
+
+	return &proto.QuickAddResponse{
+		Event: calendar.MapEventToProto(event, calendarID),
+	}, nil
+}
+
+func (s *calendarService) ImportEvent(ctx context.Context, req *proto.ImportEventRequest) (*proto.AddEventResponse, error) {
+	// Lazily initialize calendar client on first use
+	if err := s.ensureInitialized(ctx); err != nil {
+		return &proto.AddEventResponse{
+			Success: false,
+			Message: "Google Calendar not configured - see AUTHENTICATION.md",
+		}, err
+	}
+
+	// Use calendar_id from request, default to "primary"
+	calendarID := "primary"
+	if req.CalendarId != nil && *req.CalendarId != "" {
+		calendarID = *req.CalendarId
+	}
+
+	// Import event via Google Calendar API
+	event, err := s.calendarClient.ImportEvent(ctx, req)
+	if err != nil {
+		slog.Error("failed to import event", "error", err, "calendar_id", calendarID, "ical_uid", req.IcalUid)
+		return &proto.AddEventResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to import event into Google Calendar: %v", err),
+		}, withStatus(err)
+	}
+
+	// Validate that the event was actually imported
+	if event == nil || event.Id == "" {
+		slog.Error("imported event has no ID", "calendar_id", calendarID)
+		return &proto.AddEventResponse{
+			Success: false,
+			Message: "Event import succeeded but returned event has no ID",
+		}, fmt.Errorf("imported event is missing ID")
+	}
+
+	slog.Info("event imported successfully", "event_id", event.Id, "ical_uid", event.ICalUID, "calendar_id", calendarID)
+
+	return &proto.AddEventResponse{
+		EventId:    event.Id,
+		Success:    true,
+		Message:    fmt.Sprintf("Event '%s' imported successfully into Google Calendar", req.Summary),
+		HtmlLink:   event.HtmlLink,
+		CalendarId: calendarID,
+		SelfLink:   s.calendarClient.SelfLink(calendarID, event.Id),
+	}, nil
+}
+
 func (s *calendarService) ListEvents(req *proto.ListEventsRequest, stream proto.CalendarService_ListEventsServer) error {
 	// Lazily initialize calendar client on first use
 	if err := s.ensureInitialized(stream.Context()); err != nil {
@@ -265,40 +357,251 @@ func (s *calendarService) ListEvents(req *proto.ListEventsRequest, stream proto.
 	// Get response channel from calendar client
 	responseChan, errChan := s.calendarClient.ListEvents(stream.Context(), req)
 
-	// Stream responses back to client
-	for {
-		select {
-		case response, ok := <-responseChan:
-			if !ok {
-				// Channel closed, check for errors
-				select {
-				case err := <-errChan:
-					if err != nil {
-						return err
-					}
-				default:
-				}
-				// Successfully completed
-				return nil
-			}
-
-			// Send response (contains either an event or next_anchor)
-			if err := stream.Send(response); err != nil {
-				return fmt.Errorf("failed to send response: %w", err)
-			}
-
-		case err := <-errChan:
-			if err != nil {
-				return err
-			}
-
-		case <-stream.Context().Done():
-			return stream.Context().Err()
+	// Stream responses back to client until the producer closes the channel,
+	// whether it finished, failed, or saw the context cancelled
+	for response := range responseChan {
+		// Send response (contains either an event or next_anchor)
+		if err := stream.Send(response); err != nil {
+			return fmt.Errorf("failed to send response: %w", err)
+		}
+	}
+
+	// The producer closes errChan when it's done, so this blocks only until
+	// any error it hit has been delivered
+	if err := <-errChan; err != nil {
+		return withStatus(err)
+	}
+	return nil
+}
+
+// SearchEvents implements the SearchEvents RPC.
+func (s *calendarService) SearchEvents(req *proto.SearchEventsRequest, stream proto.CalendarService_SearchEventsServer) error {
+	// Lazily initialize calendar client on first use
+	if err := s.ensureInitialized(stream.Context()); err != nil {
+		return fmt.Errorf("failed to initialize calendar client: %w", err)
+	}
+
+	eventChan, errChan := s.calendarClient.SearchEvents(stream.Context(), req)
+	for event := range eventChan {
+		if err := stream.Send(event); err != nil {
+			return fmt.Errorf("failed to send response: %w", err)
+		}
+	}
+
+	// The producer closes errChan when it's done, so this blocks only until
+	// any error it hit has been delivered
+	if err := <-errChan; err != nil {
+		return withStatus(err)
+	}
+	return nil
+}
+
+func (s *calendarService) ListCalendars(req *proto.ListCalendarsRequest, stream proto.CalendarService_ListCalendarsServer) error {
+	// Lazily initialize calendar client on first use
+	if err := s.ensureInitialized(stream.Context()); err != nil {
+		return fmt.Errorf("failed to initialize calendar client: %w", err)
+	}
+
+	entries, err := s.calendarClient.ListCalendars(stream.Context(), req)
+	if err != nil {
+		return withStatus(fmt.Errorf("failed to list calendars: %w", err))
+	}
+
+	for _, entry := range entries {
+		if err := stream.Send(calendar.MapCalendarListEntryToProto(entry)); err != nil {
+			return fmt.Errorf("failed to send response: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *calendarService) FreeBusy(req *proto.FreeBusyRequest, stream proto.CalendarService_FreeBusyServer) error {
+	// Lazily initialize calendar client on first use
+	if err := s.ensureInitialized(stream.Context()); err != nil {
+		return fmt.Errorf("failed to initialize calendar client: %w", err)
+	}
+
+	calendars, err := s.calendarClient.FreeBusy(stream.Context(), req)
+	if err != nil {
+		return withStatus(fmt.Errorf("failed to query free/busy: %w", err))
+	}
+
+	for _, cal := range calendars {
+		if err := stream.Send(cal); err != nil {
+			return fmt.Errorf("failed to send response: %w", err)
+		}
+	}
+	return nil
+}
+
+// ImportICS implements the ImportICS RPC. Every event in the file is
+// attempted; one that fails to parse stops the import before anything is
+// created, while API failures are reported per event.
+func (s *calendarService) ImportICS(req *proto.ImportICSRequest, stream proto.CalendarService_ImportICSServer) error {
+	ctx := stream.Context()
+
+	var r io.Reader = os.Stdin
+	if req.File != "" && req.File != "-" {
+		f, err := os.Open(req.File)
+		if err != nil {
+			return fmt.Errorf("failed to open ICS file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	events, err := calendar.ParseICS(r)
+	if err != nil {
+		return fmt.Errorf("failed to parse ICS file: %w", err)
+	}
+
+	if err := s.ensureInitialized(ctx); err != nil {
+		return err
+	}
+
+	calendarID := "primary"
+	if req.CalendarId != nil && *req.CalendarId != "" {
+		calendarID = *req.CalendarId
+	}
+
+	failed := 0
+	for _, event := range events {
+		event.CalendarId = &calendarID
+		resp := &proto.AddEventResponse{CalendarId: calendarID}
+
+		created, err := s.calendarClient.CreateEvent(ctx, event)
+		if err != nil {
+			failed++
+			resp.Message = fmt.Sprintf("Failed to import '%s': %v", event.Summary, err)
+		} else {
+			resp.EventId = created.Id
+			resp.Success = true
+			resp.Message = fmt.Sprintf("Event '%s' imported", event.Summary)
+			resp.HtmlLink = created.HtmlLink
+			resp.SelfLink = s.calendarClient.SelfLink(calendarID, created.Id)
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to import %d of %d events", failed, len(events))
+	}
+	return nil
+}
+
+// Revoke implements the Revoke RPC. It doesn't initialize the calendar client,
+// so it never starts an OAuth flow just to disconnect.
+func (s *calendarService) Revoke(ctx context.Context, req *proto.RevokeRequest) (*proto.RevokeResponse, error) {
+	authCfg, tokenPath, err := profileAuth(s.cfg, s.profile)
+	if err != nil {
+		return &proto.RevokeResponse{
+			Success: false,
+			Message: "Invalid profile",
+		}, err
+	}
+
+	tok, err := auth.LoadTokenWithPassphrase(tokenPath, authCfg.TokenPassphrase)
+	if err != nil {
+		return &proto.RevokeResponse{
+			Success:   false,
+			Message:   "No stored OAuth token to revoke",
+			TokenPath: tokenPath,
+		}, fmt.Errorf("no stored OAuth token to revoke: %w", err)
+	}
+
+	if err := auth.RevokeToken(ctx, tok, tokenPath); err != nil {
+		return &proto.RevokeResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to revoke token: %v", err),
+			TokenPath: tokenPath,
+		}, err
+	}
+
+	// Force re-authentication on the next call
+	s.calendarClient = nil
+
+	return &proto.RevokeResponse{
+		Success:   true,
+		Message:   "Revoked access and deleted the stored token",
+		TokenPath: tokenPath,
+	}, nil
+}
+
+func (s *calendarService) Ping(ctx context.Context, req *proto.PingRequest) (*proto.PingResponse, error) {
+	resp := &proto.PingResponse{}
+	if authCfg, _, err := profileAuth(s.cfg, s.profile); err == nil {
+		resp.AuthMode = authMode(authCfg)
+	}
+
+	// Lazily initialize calendar client on first use
+	if err := s.ensureInitialized(ctx); err != nil {
+		resp.Message = fmt.Sprintf("Failed to authenticate: %v", err)
+		return resp, fmt.Errorf("failed to initialize calendar client: %w", err)
+	}
+	resp.Endpoint = s.calendarClient.Endpoint()
+
+	if err := s.calendarClient.Ping(ctx); err != nil {
+		resp.Message = fmt.Sprintf("Failed to reach the calendar API: %v", err)
+		return resp, withStatus(err)
+	}
+
+	resp.Success = true
+	resp.Message = "Authenticated and reached the calendar API"
+	return resp, nil
+}
+
+func (s *calendarService) FindDuplicates(req *proto.FindDuplicatesRequest, stream proto.CalendarService_FindDuplicatesServer) error {
+	// Lazily initialize calendar client on first use
+	if err := s.ensureInitialized(stream.Context()); err != nil {
+		return fmt.Errorf("failed to initialize calendar client: %w", err)
+	}
+
+	calendarID := "primary"
+	if req.CalendarId != nil && *req.CalendarId != "" {
+		calendarID = *req.CalendarId
+	}
+
+	// Zero-value timestamps mean an open-ended range
+	var start, end time.Time
+	if req.After != nil && req.After.IsValid() && req.After.AsTime().Unix() > 0 {
+		start = req.After.AsTime()
+	}
+	if req.Before != nil && req.Before.IsValid() && req.Before.AsTime().Unix() > 0 {
+		end = req.Before.AsTime()
+	}
+
+	clusters, err := s.calendarClient.FindDuplicates(stream.Context(), calendarID, start, end)
+	if err != nil {
+		return withStatus(fmt.Errorf("failed to find duplicates: %w", err))
+	}
+
+	for _, cluster := range clusters {
+		if err := stream.Send(&proto.DuplicateCluster{Events: cluster}); err != nil {
+			return fmt.Errorf("failed to send response: %w", err)
 		}
 	}
+	return nil
 }
 
 // ICS format helper functions
+// icsFuncMap holds the helper functions available to the ICS templates
+var icsFuncMap = template.FuncMap{
+	"icsTime":      icsTimestamp,
+	"icsDateTime":  icsDateTime,
+	"icsDate":      icsDate,
+	"icsVTimezone": icsVTimezone,
+	"icsEscape":    icsEscape,
+	"icsParam":     icsParam,
+	"icsPartstat":  icsPartstat,
+	"icsTrigger":   icsTrigger,
+	"icsAction":    icsAction,
+	"now":          icsNow,
+	"upper":        strings.ToUpper,
+}
+
 func icsTimestamp(ts *timestamppb.Timestamp) string {
 	if ts == nil || !ts.IsValid() {
 		return ""
@@ -316,10 +619,124 @@ func icsEscape(s string) string {
 	return s
 }
 
+// icsParam formats s as a property parameter value (e.g. a CN display name).
+// Parameter values can't be backslash-escaped like text, so double quotes and
+// line breaks are dropped and values containing ; : or , are quoted (RFC 5545).
+func icsParam(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '"' || r == '\r' || r == '\n' {
+			return -1
+		}
+		return r
+	}, s)
+	if strings.ContainsAny(s, ";:,") {
+		return `"` + s + `"`
+	}
+	return s
+}
+
+// icsPartstat maps an attendee's response status to an ATTENDEE PARTSTAT
+func icsPartstat(status string) string {
+	switch status {
+	case "accepted":
+		return "ACCEPTED"
+	case "declined":
+		return "DECLINED"
+	case "tentative":
+		return "TENTATIVE"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// icsTrigger formats a reminder's minutes before the start as a VALARM
+// TRIGGER duration, e.g. 10 -> -PT10M, 90 -> -PT1H30M, 1440 -> -P1D
+func icsTrigger(minutes int32) string {
+	if minutes <= 0 {
+		return "PT0S"
+	}
+	days, hours, mins := minutes/(24*60), minutes/60%24, minutes%60
+
+	var b strings.Builder
+	b.WriteString("-P")
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if hours > 0 || mins > 0 {
+		b.WriteString("T")
+		if hours > 0 {
+			fmt.Fprintf(&b, "%dH", hours)
+		}
+		if mins > 0 {
+			fmt.Fprintf(&b, "%dM", mins)
+		}
+	}
+	return b.String()
+}
+
+// icsAction maps a reminder method to a VALARM ACTION
+func icsAction(method string) string {
+	if method == "email" {
+		return "EMAIL"
+	}
+	return "DISPLAY"
+}
+
 func icsNow() string {
 	return time.Now().UTC().Format("20060102T150405Z")
 }
 
+// Auth modes reported by Ping
+const (
+	authModeADC            = "adc"
+	authModeServiceAccount = "service_account"
+	authModeOAuth          = "oauth"
+)
+
+// authMode names the kind of credentials authCfg authenticates with, picked
+// in the same order auth.GetClientFromConfig tries them
+func authMode(authCfg *proto.AuthConfig) string {
+	switch {
+	case authCfg.GetUseAdc():
+		return authModeADC
+	case authCfg.GetServiceAccount().GetClientEmail() != "":
+		return authModeServiceAccount
+	case authCfg.GetOauthClient().GetClientId() != "":
+		return authModeOAuth
+	default:
+		return authModeADC
+	}
+}
+
+// profileAuth returns the auth settings for the named profile (the top-level
+// auth block for "") and where its OAuth token is cached. A profile not
+// listed under profiles is an error, so a typo can't start a sign-in under
+// the wrong account. Without any auth block, application default credentials
+// are used.
+func profileAuth(cfg *proto.CaliConfig, profile string) (*proto.AuthConfig, string, error) {
+	authCfg := cfg.GetAuth()
+	if profile != "" {
+		p, ok := cfg.GetProfiles()[profile]
+		if !ok {
+			return nil, "", fmt.Errorf("unknown profile %q", profile)
+		}
+		authCfg = p
+	}
+	if authCfg == nil {
+		authCfg = &proto.AuthConfig{}
+	}
+
+	tokenPath := authCfg.GetOauthTokenPath()
+	if tokenPath == "" {
+		defaultPath, err := config.GetTokenPathForProfile(profile)
+		if err != nil {
+			return nil, "", err
+		}
+		tokenPath = defaultPath
+	}
+	return authCfg, tokenPath, nil
+}
+
 func main() {
 	ctx := context.Background()
 
@@ -351,6 +768,53 @@ func main() {
 		return timestamppb.New(t), nil
 	}
 
+	attendeeListDeserializer := func(ctx context.Context, flags protocli.FlagContainer) (protobuf.Message, error) {
+		// Leave attendees unset (nil) when the flag isn't given
+		if flags.String() == "" {
+			return nil, nil
+		}
+		return parseAttendeeList(flags.String())
+	}
+
+	propertyMapDeserializer := func(ctx context.Context, flags protocli.FlagContainer) (protobuf.Message, error) {
+		// Leave properties unset (nil) when the flag isn't given
+		if flags.String() == "" {
+			return nil, nil
+		}
+		return parsePropertyMap(flags.String())
+	}
+
+	recurrenceDeserializer := func(ctx context.Context, flags protocli.FlagContainer) (protobuf.Message, error) {
+		// Leave recurrence unset (nil) when the flag isn't given
+		if flags.String() == "" {
+			return nil, nil
+		}
+		return parseRecurrence(flags.String()), nil
+	}
+
+	reminderListDeserializer := func(ctx context.Context, flags protocli.FlagContainer) (protobuf.Message, error) {
+		// Leave reminders unset (nil) when the flag isn't given
+		if flags.String() == "" {
+			return nil, nil
+		}
+		return parseReminderList(flags.String())
+	}
+
+	attachmentListDeserializer := func(ctx context.Context, flags protocli.FlagContainer) (protobuf.Message, error) {
+		// Leave attachments unset (nil) when the flag isn't given
+		if flags.String() == "" {
+			return nil, nil
+		}
+		return parseAttachmentList(flags.String())
+	}
+
+	calendarIDListDeserializer := func(ctx context.Context, flags protocli.FlagContainer) (protobuf.Message, error) {
+		// Leave calendars unset (nil) when the flag isn't given
+		if flags.String() == "" {
+			return nil, nil
+		}
+		return parseCalendarIDList(flags.String()), nil
+	}
 
 	// Create ICS format for calendar events (templates loaded from embedded files)
 	// Response templates use {{template "event" ...}} to reuse event template definition
@@ -359,14 +823,7 @@ func main() {
 		"calendar.Event":              eventTemplateICS,
 		"calendar.ListEventsResponse": eventTemplateICS + listEventsResponseTemplateICS,
 		"calendar.GetEventResponse":   eventTemplateICS + getEventResponseTemplateICS,
-	}
-
-	// Build function map with helper functions
-	icsFuncMap := template.FuncMap{
-		"icsTime":   icsTimestamp,
-		"icsEscape": icsEscape,
-		"now":       icsNow,
-		"upper":     strings.ToUpper,
+		"calendar.QuickAddResponse":   eventTemplateICS + getEventResponseTemplateICS,
 	}
 
 	icsFormat, err := protocli.TemplateFormat("ics", icsTemplates, icsFuncMap)
@@ -385,8 +842,15 @@ func main() {
 			protocli.JSON(),
 			protocli.YAML(),
 			icsFormat,
+			newCSVFormat(),
 		),
 		protocli.WithFlagDeserializer("google.protobuf.Timestamp", timestampDeserializer),
+		protocli.WithFlagDeserializer("calendar.AttendeeList", attendeeListDeserializer),
+		protocli.WithFlagDeserializer("calendar.Recurrence", recurrenceDeserializer),
+		protocli.WithFlagDeserializer("calendar.ReminderList", reminderListDeserializer),
+		protocli.WithFlagDeserializer("calendar.AttachmentList", attachmentListDeserializer),
+		protocli.WithFlagDeserializer("calendar.CalendarIdList", calendarIDListDeserializer),
+		protocli.WithFlagDeserializer("calendar.PropertyMap", propertyMapDeserializer),
 	)
 
 	// Create root command with config support
@@ -399,6 +863,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Add hand-written commands that don't map onto a single RPC
+	rootCmd.Commands = append(rootCmd.Commands, bulkUpdateCommand(svc))
+
+	// --profile picks the credentials and token before any command runs
+	rootCmd.Flags = append(rootCmd.Flags, &cli.StringFlag{
+		Name:    "profile",
+		Usage:   "auth profile to use (see profiles in config.example.yaml)",
+		Sources: cli.EnvVars("CALI_PROFILE"),
+	})
+	before := rootCmd.Before
+	rootCmd.Before = func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+		svc.profile = cmd.String("profile")
+		if before != nil {
+			return before(ctx, cmd)
+		}
+		return ctx, nil
+	}
+
 	if err := rootCmd.Run(ctx, os.Args); err != nil {
 		slog.Error("command failed", "error", err)
 		os.Exit(1)
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/drewfead/cali/internal/calendar"
 	"github.com/drewfead/cali/proto"
+	gcalendar "google.golang.org/api/calendar/v3"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -161,3 +164,767 @@ func TestMapProtoToEvent_PartialSource(t *testing.T) {
 		})
 	}
 }
+
+func TestMapProtoToEvent_TransparencyByEventType(t *testing.T) {
+	tests := []struct {
+		name       string
+		eventType  *string
+		blocksTime *bool
+		want       string
+	}{
+		{name: "unset type, unset blocks_time", want: "transparent"},
+		{name: "default", eventType: ptr("default"), want: "transparent"},
+		{name: "outOfOffice", eventType: ptr("outOfOffice"), want: "opaque"},
+		{name: "focusTime", eventType: ptr("focusTime"), want: "opaque"},
+		{name: "workingLocation", eventType: ptr("workingLocation"), want: "transparent"},
+		{name: "outOfOffice with explicit blocks_time false", eventType: ptr("outOfOffice"), blocksTime: ptr(false), want: "transparent"},
+		{name: "default with explicit blocks_time true", eventType: ptr("default"), blocksTime: ptr(true), want: "opaque"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &proto.AddEventRequest{
+				Summary:    "Typed Event",
+				EventType:  tt.eventType,
+				BlocksTime: tt.blocksTime,
+			}
+
+			event := calendar.MapProtoToEvent(req)
+
+			if event.Transparency != tt.want {
+				t.Errorf("expected transparency %q, got %q", tt.want, event.Transparency)
+			}
+			if tt.eventType != nil && event.EventType != *tt.eventType {
+				t.Errorf("expected event type %q, got %q", *tt.eventType, event.EventType)
+			}
+		})
+	}
+}
+
+func TestValidateEventTimes(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   *gcalendar.EventDateTime
+		end     *gcalendar.EventDateTime
+		wantErr string
+	}{
+		{
+			name:  "timed",
+			start: &gcalendar.EventDateTime{DateTime: "2024-03-01T09:00:00Z"},
+			end:   &gcalendar.EventDateTime{DateTime: "2024-03-01T10:00:00Z"},
+		},
+		{
+			name:  "all-day",
+			start: &gcalendar.EventDateTime{Date: "2024-03-01"},
+			end:   &gcalendar.EventDateTime{Date: "2024-03-02"},
+		},
+		{
+			name:    "start sets both",
+			start:   &gcalendar.EventDateTime{Date: "2024-03-01", DateTime: "2024-03-01T09:00:00Z"},
+			end:     &gcalendar.EventDateTime{DateTime: "2024-03-01T10:00:00Z"},
+			wantErr: "invalid event start: both date",
+		},
+		{
+			name:    "end sets both",
+			start:   &gcalendar.EventDateTime{Date: "2024-03-01"},
+			end:     &gcalendar.EventDateTime{Date: "2024-03-02", DateTime: "2024-03-02T00:00:00Z"},
+			wantErr: "invalid event end: both date",
+		},
+		{
+			name:    "end sets neither",
+			start:   &gcalendar.EventDateTime{Date: "2024-03-01"},
+			end:     &gcalendar.EventDateTime{TimeZone: "UTC"},
+			wantErr: "invalid event end: one of date or dateTime must be set",
+		},
+		{
+			name:    "mixed kinds",
+			start:   &gcalendar.EventDateTime{Date: "2024-03-01"},
+			end:     &gcalendar.EventDateTime{DateTime: "2024-03-01T10:00:00Z"},
+			wantErr: "start and end must both be dates",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := calendar.ValidateEventTimes(&gcalendar.Event{Start: tt.start, End: tt.end})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestMapProtoToEvent_DefaultDuration(t *testing.T) {
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		duration time.Duration
+		wantEnd  string
+	}{
+		{name: "30 minutes", duration: 30 * time.Minute, wantEnd: "2024-03-01T09:30:00Z"},
+		{name: "45 minutes", duration: 45 * time.Minute, wantEnd: "2024-03-01T09:45:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := calendar.MapProtoToEventWithDuration(&proto.AddEventRequest{
+				Summary:   "Short Meeting",
+				StartTime: timestamppb.New(start),
+			}, tt.duration)
+
+			if event.End.DateTime != tt.wantEnd {
+				t.Errorf("expected end %s, got %s", tt.wantEnd, event.End.DateTime)
+			}
+		})
+	}
+
+	// An explicit end time always wins
+	event := calendar.MapProtoToEventWithDuration(&proto.AddEventRequest{
+		Summary:   "Explicit End",
+		StartTime: timestamppb.New(start),
+		EndTime:   timestamppb.New(start.Add(2 * time.Hour)),
+	}, 30*time.Minute)
+	if event.End.DateTime != "2024-03-01T11:00:00Z" {
+		t.Errorf("expected explicit end to be kept, got %s", event.End.DateTime)
+	}
+
+	// The default remains one hour
+	event = calendar.MapProtoToEvent(&proto.AddEventRequest{Summary: "Default", StartTime: timestamppb.New(start)})
+	if event.End.DateTime != "2024-03-01T10:00:00Z" {
+		t.Errorf("expected one hour default, got %s", event.End.DateTime)
+	}
+}
+
+func TestMapAttendees_Optional(t *testing.T) {
+	event := &gcalendar.Event{
+		Id:      "planning",
+		Summary: "Planning",
+		Attendees: []*gcalendar.EventAttendee{
+			{Email: "lead@example.com", DisplayName: "Lead"},
+			{Email: "fyi@example.com", Optional: true},
+		},
+	}
+
+	protoEvent := calendar.MapEventToProto(event, "primary")
+
+	if len(protoEvent.AttendeeDetails) != 2 {
+		t.Fatalf("expected 2 attendee details, got %d", len(protoEvent.AttendeeDetails))
+	}
+	required, optional := protoEvent.AttendeeDetails[0], protoEvent.AttendeeDetails[1]
+	if required.Optional || required.GetDisplayName() != "Lead" {
+		t.Errorf("expected required attendee 'Lead', got %+v", required)
+	}
+	if !optional.Optional || optional.DisplayName != nil {
+		t.Errorf("expected optional attendee without display name, got %+v", optional)
+	}
+	if len(protoEvent.Attendees) != 2 {
+		t.Errorf("expected attendee emails to still be populated, got %v", protoEvent.Attendees)
+	}
+
+	// And back again
+	for i, attendee := range protoEvent.AttendeeDetails {
+		roundTripped := calendar.MapProtoToAttendee(attendee)
+		original := event.Attendees[i]
+		if roundTripped.Email != original.Email || roundTripped.Optional != original.Optional || roundTripped.DisplayName != original.DisplayName {
+			t.Errorf("attendee %d did not round-trip: got %+v, want %+v", i, roundTripped, original)
+		}
+	}
+}
+
+func TestMapAttendees_ResponseStatusRoundTrip(t *testing.T) {
+	accepted, declined := "accepted", "declined"
+	alice := "Alice"
+	req := &proto.AddEventRequest{
+		Summary: "Review",
+		Attendees: &proto.AttendeeList{Attendees: []*proto.Attendee{
+			{Email: "alice@example.com", DisplayName: &alice, ResponseStatus: &accepted},
+			{Email: "bob@example.com", Optional: true, ResponseStatus: &declined},
+		}},
+	}
+
+	event := calendar.MapProtoToEvent(req)
+	if len(event.Attendees) != 2 {
+		t.Fatalf("expected 2 attendees, got %d", len(event.Attendees))
+	}
+	if got := event.Attendees[0]; got.Email != "alice@example.com" || got.DisplayName != "Alice" || got.ResponseStatus != "accepted" {
+		t.Errorf("unexpected first attendee: %+v", got)
+	}
+	if got := event.Attendees[1]; got.Email != "bob@example.com" || !got.Optional || got.ResponseStatus != "declined" {
+		t.Errorf("unexpected second attendee: %+v", got)
+	}
+	if err := calendar.ValidateAttendees(event); err != nil {
+		t.Errorf("expected valid attendees, got %v", err)
+	}
+
+	protoEvent := calendar.MapEventToProto(event, "primary")
+	if len(protoEvent.AttendeeDetails) != 2 {
+		t.Fatalf("expected 2 attendee details, got %d", len(protoEvent.AttendeeDetails))
+	}
+	for i, want := range req.Attendees.Attendees {
+		got := protoEvent.AttendeeDetails[i]
+		if got.Email != want.Email || got.GetResponseStatus() != want.GetResponseStatus() ||
+			got.GetDisplayName() != want.GetDisplayName() || got.Optional != want.Optional {
+			t.Errorf("attendee %d did not round-trip: got %+v, want %+v", i, got, want)
+		}
+	}
+}
+
+func TestMapProtoUpdateToEvent_ReplacesAttendees(t *testing.T) {
+	existing := &gcalendar.Event{
+		Attendees: []*gcalendar.EventAttendee{{Email: "old@example.com"}},
+	}
+
+	// Unset keeps the current guests
+	event := calendar.MapProtoUpdateToEvent(&proto.UpdateEventRequest{}, existing)
+	if len(event.Attendees) != 1 {
+		t.Fatalf("expected attendees to be kept, got %v", event.Attendees)
+	}
+
+	event = calendar.MapProtoUpdateToEvent(&proto.UpdateEventRequest{
+		Attendees: &proto.AttendeeList{Attendees: []*proto.Attendee{{Email: "new@example.com"}}},
+	}, existing)
+	if len(event.Attendees) != 1 || event.Attendees[0].Email != "new@example.com" {
+		t.Errorf("expected attendees to be replaced, got %v", event.Attendees)
+	}
+
+	// An empty list removes every guest
+	event = calendar.MapProtoUpdateToEvent(&proto.UpdateEventRequest{Attendees: &proto.AttendeeList{}}, existing)
+	if len(event.Attendees) != 0 {
+		t.Errorf("expected attendees to be cleared, got %v", event.Attendees)
+	}
+}
+
+func TestMapProtoUpdateToEvent_ClearsFields(t *testing.T) {
+	existing := &gcalendar.Event{
+		Summary:     "Planning",
+		Description: "Agenda",
+		Location:    "Room 1",
+	}
+
+	// An empty string clears the field; unset fields are preserved
+	event := calendar.MapProtoUpdateToEvent(&proto.UpdateEventRequest{Description: ptr("")}, existing)
+	if event.Description != "" {
+		t.Errorf("expected description to be cleared, got %q", event.Description)
+	}
+	if event.Summary != "Planning" || event.Location != "Room 1" {
+		t.Errorf("expected unset fields to be preserved, got summary %q, location %q", event.Summary, event.Location)
+	}
+
+	event = calendar.MapProtoUpdateToEvent(&proto.UpdateEventRequest{Location: ptr("")}, event)
+	if event.Location != "" || event.Summary != "Planning" {
+		t.Errorf("expected only location to be cleared, got %+v", event)
+	}
+}
+
+func TestValidateAttendees(t *testing.T) {
+	tests := []struct {
+		name      string
+		attendees []*gcalendar.EventAttendee
+		wantErr   string
+	}{
+		{name: "valid", attendees: []*gcalendar.EventAttendee{{Email: "a@example.com", ResponseStatus: "tentative"}}},
+		{name: "missing email", attendees: []*gcalendar.EventAttendee{{DisplayName: "Nobody"}}, wantErr: "email is required"},
+		{name: "unknown status", attendees: []*gcalendar.EventAttendee{{Email: "a@example.com", ResponseStatus: "maybe"}}, wantErr: "unknown response status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := calendar.ValidateAttendees(&gcalendar.Event{Attendees: tt.attendees})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseAttendeeList(t *testing.T) {
+	list, err := parseAttendeeList("alice@example.com, bob@example.com")
+	if err != nil {
+		t.Fatalf("failed to parse email list: %v", err)
+	}
+	if len(list.Attendees) != 2 || list.Attendees[1].Email != "bob@example.com" {
+		t.Errorf("unexpected attendees from email list: %v", list.Attendees)
+	}
+
+	list, err = parseAttendeeList(`[{"email":"alice@example.com","displayName":"Alice","responseStatus":"accepted"},{"email":"bob@example.com","optional":true}]`)
+	if err != nil {
+		t.Fatalf("failed to parse JSON list: %v", err)
+	}
+	if len(list.Attendees) != 2 || list.Attendees[0].GetResponseStatus() != "accepted" || !list.Attendees[1].Optional {
+		t.Errorf("unexpected attendees from JSON list: %v", list.Attendees)
+	}
+
+	if _, err := parseAttendeeList(`[{"email":`); err == nil {
+		t.Error("expected an error for malformed JSON")
+	}
+}
+
+func TestMapRecurrence_Weekly(t *testing.T) {
+	weekly := []string{"RRULE:FREQ=WEEKLY;BYDAY=TU;COUNT=10", "EXDATE:20240312T150000Z"}
+	start := time.Date(2024, 3, 5, 15, 0, 0, 0, time.UTC)
+	req := &proto.AddEventRequest{
+		Summary:    "Weekly sync",
+		StartTime:  timestamppb.New(start),
+		Recurrence: &proto.Recurrence{Rules: weekly},
+	}
+
+	event := calendar.MapProtoToEvent(req)
+	if strings.Join(event.Recurrence, "\n") != strings.Join(weekly, "\n") {
+		t.Errorf("expected recurrence %v, got %v", weekly, event.Recurrence)
+	}
+	if err := calendar.ValidateRecurrence(event); err != nil {
+		t.Errorf("expected a valid weekly recurrence, got %v", err)
+	}
+
+	protoEvent := calendar.MapEventToProto(event, "primary")
+	if strings.Join(protoEvent.Recurrence, "\n") != strings.Join(weekly, "\n") {
+		t.Errorf("expected recurrence to round-trip, got %v", protoEvent.Recurrence)
+	}
+
+	// Updates replace the rules only when given
+	updated := calendar.MapProtoUpdateToEvent(&proto.UpdateEventRequest{}, event)
+	if len(updated.Recurrence) != 2 {
+		t.Errorf("expected recurrence to be kept, got %v", updated.Recurrence)
+	}
+	updated = calendar.MapProtoUpdateToEvent(&proto.UpdateEventRequest{
+		Recurrence: &proto.Recurrence{Rules: []string{"RRULE:FREQ=WEEKLY;BYDAY=TH"}},
+	}, event)
+	if len(updated.Recurrence) != 1 || updated.Recurrence[0] != "RRULE:FREQ=WEEKLY;BYDAY=TH" {
+		t.Errorf("expected recurrence to be replaced, got %v", updated.Recurrence)
+	}
+}
+
+func TestMapReminders_Overrides(t *testing.T) {
+	req := &proto.AddEventRequest{
+		Summary: "Dentist",
+		Reminders: &proto.ReminderList{Reminders: []*proto.Reminder{
+			{Method: "popup", Minutes: 10},
+			{Method: "email", Minutes: 1440},
+		}},
+	}
+
+	event := calendar.MapProtoToEvent(req)
+	if event.Reminders == nil || event.Reminders.UseDefault {
+		t.Fatalf("expected reminder overrides without defaults, got %+v", event.Reminders)
+	}
+	if len(event.Reminders.Overrides) != 2 {
+		t.Fatalf("expected 2 overrides, got %d", len(event.Reminders.Overrides))
+	}
+	if got := event.Reminders.Overrides[1]; got.Method != "email" || got.Minutes != 1440 {
+		t.Errorf("unexpected second override: %+v", got)
+	}
+	if err := calendar.ValidateReminders(event); err != nil {
+		t.Errorf("expected valid reminders, got %v", err)
+	}
+
+	// useDefault=false must reach Google, or the overrides are ignored
+	data, err := json.Marshal(event.Reminders)
+	if err != nil {
+		t.Fatalf("failed to marshal reminders: %v", err)
+	}
+	if !strings.Contains(string(data), `"useDefault":false`) {
+		t.Errorf("expected useDefault=false to be sent, got %s", data)
+	}
+
+	protoEvent := calendar.MapEventToProto(event, "primary")
+	if len(protoEvent.Reminders) != 2 {
+		t.Fatalf("expected 2 reminders to round-trip, got %d", len(protoEvent.Reminders))
+	}
+	for i, want := range req.Reminders.Reminders {
+		if got := protoEvent.Reminders[i]; got.Method != want.Method || got.Minutes != want.Minutes {
+			t.Errorf("reminder %d did not round-trip: got %+v, want %+v", i, got, want)
+		}
+	}
+}
+
+func TestMapReminders_DefaultsWhenUnset(t *testing.T) {
+	event := calendar.MapProtoToEvent(&proto.AddEventRequest{Summary: "Lunch"})
+	if event.Reminders != nil {
+		t.Errorf("expected reminders to be left unset for calendar defaults, got %+v", event.Reminders)
+	}
+
+	// On update, an empty list restores the defaults
+	existing := &gcalendar.Event{Reminders: &gcalendar.EventReminders{
+		Overrides: []*gcalendar.EventReminder{{Method: "popup", Minutes: 5}},
+	}}
+	updated := calendar.MapProtoUpdateToEvent(&proto.UpdateEventRequest{Reminders: &proto.ReminderList{}}, existing)
+	if updated.Reminders == nil || !updated.Reminders.UseDefault || len(updated.Reminders.Overrides) != 0 {
+		t.Errorf("expected default reminders after clearing, got %+v", updated.Reminders)
+	}
+	if protoEvent := calendar.MapEventToProto(updated, "primary"); len(protoEvent.Reminders) != 0 {
+		t.Errorf("expected no reminder overrides when defaults apply, got %v", protoEvent.Reminders)
+	}
+}
+
+func TestValidateReminders(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides []*gcalendar.EventReminder
+		wantErr   string
+	}{
+		{name: "valid", overrides: []*gcalendar.EventReminder{{Method: "popup", Minutes: 0}, {Method: "email", Minutes: 40320}}},
+		{name: "unknown method", overrides: []*gcalendar.EventReminder{{Method: "sms", Minutes: 10}}, wantErr: "invalid reminder method"},
+		{name: "too far ahead", overrides: []*gcalendar.EventReminder{{Method: "popup", Minutes: 40321}}, wantErr: "between 0 and 40320"},
+		{name: "too many", overrides: []*gcalendar.EventReminder{
+			{Method: "popup", Minutes: 1}, {Method: "popup", Minutes: 2}, {Method: "popup", Minutes: 3},
+			{Method: "popup", Minutes: 4}, {Method: "popup", Minutes: 5}, {Method: "popup", Minutes: 6},
+		}, wantErr: "at most 5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := calendar.ValidateReminders(&gcalendar.Event{Reminders: &gcalendar.EventReminders{Overrides: tt.overrides}})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseReminderList(t *testing.T) {
+	list, err := parseReminderList("popup:10, email:60")
+	if err != nil {
+		t.Fatalf("failed to parse reminder pairs: %v", err)
+	}
+	if len(list.Reminders) != 2 || list.Reminders[1].Method != "email" || list.Reminders[1].Minutes != 60 {
+		t.Errorf("unexpected reminders: %v", list.Reminders)
+	}
+
+	list, err = parseReminderList(`[{"method":"popup","minutes":15}]`)
+	if err != nil {
+		t.Fatalf("failed to parse JSON reminders: %v", err)
+	}
+	if len(list.Reminders) != 1 || list.Reminders[0].Minutes != 15 {
+		t.Errorf("unexpected reminders from JSON: %v", list.Reminders)
+	}
+
+	for _, bad := range []string{"popup", "popup:soon"} {
+		if _, err := parseReminderList(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestMapProtoToEvent_TimeZone(t *testing.T) {
+	start := time.Date(2024, 7, 1, 13, 0, 0, 0, time.UTC)
+	zone := "America/New_York"
+	event := calendar.MapProtoToEvent(&proto.AddEventRequest{
+		Summary:   "Standup",
+		StartTime: timestamppb.New(start),
+		TimeZone:  &zone,
+	})
+
+	if event.Start.TimeZone != zone || event.End.TimeZone != zone {
+		t.Errorf("expected start and end in %s, got %q and %q", zone, event.Start.TimeZone, event.End.TimeZone)
+	}
+	if event.Start.DateTime != "2024-07-01T09:00:00-04:00" {
+		t.Errorf("expected start formatted in EDT, got %s", event.Start.DateTime)
+	}
+	if event.End.DateTime != "2024-07-01T10:00:00-04:00" {
+		t.Errorf("expected end formatted in EDT, got %s", event.End.DateTime)
+	}
+
+	// Unset keeps UTC
+	event = calendar.MapProtoToEvent(&proto.AddEventRequest{Summary: "Standup", StartTime: timestamppb.New(start)})
+	if event.Start.TimeZone != "UTC" || event.Start.DateTime != "2024-07-01T13:00:00Z" {
+		t.Errorf("expected UTC by default, got %s in %s", event.Start.DateTime, event.Start.TimeZone)
+	}
+}
+
+func TestMapEventToProto_TimeZone(t *testing.T) {
+	event := calendar.MapEventToProto(&gcalendar.Event{
+		Id:    "zoned",
+		Start: &gcalendar.EventDateTime{DateTime: "2024-07-01T09:00:00-04:00", TimeZone: "America/New_York"},
+		End:   &gcalendar.EventDateTime{DateTime: "2024-07-01T10:00:00-04:00", TimeZone: "America/New_York"},
+	}, "primary")
+	if event.GetTimeZone() != "America/New_York" {
+		t.Errorf("expected time zone America/New_York, got %q", event.GetTimeZone())
+	}
+
+	// All-day events have no zone
+	event = calendar.MapEventToProto(&gcalendar.Event{
+		Id:    "all-day",
+		Start: &gcalendar.EventDateTime{Date: "2024-07-01"},
+		End:   &gcalendar.EventDateTime{Date: "2024-07-02"},
+	}, "primary")
+	if event.TimeZone != nil {
+		t.Errorf("expected no time zone for an all-day event, got %q", event.GetTimeZone())
+	}
+}
+
+func TestMapProtoUpdateToEvent_TimeZone(t *testing.T) {
+	existing := &gcalendar.Event{
+		Start: &gcalendar.EventDateTime{DateTime: "2024-01-15T17:00:00Z", TimeZone: "UTC"},
+		End:   &gcalendar.EventDateTime{DateTime: "2024-01-15T18:00:00Z", TimeZone: "UTC"},
+	}
+	zone := "Europe/Berlin"
+	newEnd := time.Date(2024, 1, 15, 18, 30, 0, 0, time.UTC)
+
+	event := calendar.MapProtoUpdateToEvent(&proto.UpdateEventRequest{
+		EndTime:  timestamppb.New(newEnd),
+		TimeZone: &zone,
+	}, existing)
+
+	if event.Start.DateTime != "2024-01-15T18:00:00+01:00" || event.Start.TimeZone != zone {
+		t.Errorf("expected unchanged start moved to %s, got %s in %s", zone, event.Start.DateTime, event.Start.TimeZone)
+	}
+	if event.End.DateTime != "2024-01-15T19:30:00+01:00" || event.End.TimeZone != zone {
+		t.Errorf("expected new end in %s, got %s in %s", zone, event.End.DateTime, event.End.TimeZone)
+	}
+}
+
+func TestValidateTimeZone(t *testing.T) {
+	for _, valid := range []string{"", "UTC", "America/New_York", "Asia/Kolkata"} {
+		if err := calendar.ValidateTimeZone(valid); err != nil {
+			t.Errorf("expected %q to be valid, got %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"Mars/Olympus_Mons", "Local", "EST5EDT/Nope"} {
+		if err := calendar.ValidateTimeZone(invalid); err == nil {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestMapProtoToEvent_AllDay(t *testing.T) {
+	allDay := true
+	start := time.Date(2024, 12, 25, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		end       *timestamppb.Timestamp
+		timeZone  *string
+		wantStart string
+		wantEnd   string
+	}{
+		{name: "single day", wantStart: "2024-12-25", wantEnd: "2024-12-26"},
+		{name: "multi-day with exclusive end", end: timestamppb.New(time.Date(2024, 12, 27, 0, 0, 0, 0, time.UTC)), wantStart: "2024-12-25", wantEnd: "2024-12-27"},
+		{name: "end on the start date", end: timestamppb.New(start.Add(time.Hour)), wantStart: "2024-12-25", wantEnd: "2024-12-26"},
+		{name: "dates taken in the time zone", timeZone: ptr("Pacific/Auckland"), wantStart: "2024-12-26", wantEnd: "2024-12-27"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := calendar.MapProtoToEvent(&proto.AddEventRequest{
+				Summary:   "Holiday",
+				StartTime: timestamppb.New(start),
+				EndTime:   tt.end,
+				TimeZone:  tt.timeZone,
+				AllDay:    &allDay,
+			})
+
+			if event.Start.Date != tt.wantStart || event.End.Date != tt.wantEnd {
+				t.Errorf("expected %s to %s, got %s to %s", tt.wantStart, tt.wantEnd, event.Start.Date, event.End.Date)
+			}
+			if event.Start.DateTime != "" || event.End.DateTime != "" || event.Start.TimeZone != "" || event.End.TimeZone != "" {
+				t.Errorf("expected dates only, got start %+v and end %+v", event.Start, event.End)
+			}
+			if err := calendar.ValidateEventTimes(event); err != nil {
+				t.Errorf("expected valid all-day times, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMapVisibility(t *testing.T) {
+	for _, visibility := range []string{"default", "public", "private", "confidential"} {
+		t.Run(visibility, func(t *testing.T) {
+			event := calendar.MapProtoToEvent(&proto.AddEventRequest{Summary: "Event", Visibility: ptr(visibility)})
+			if event.Visibility != visibility {
+				t.Errorf("expected visibility %q, got %q", visibility, event.Visibility)
+			}
+			if err := calendar.ValidateVisibility(event); err != nil {
+				t.Errorf("expected %q to be valid, got %v", visibility, err)
+			}
+			if got := calendar.MapEventToProto(event, "primary").GetVisibility(); got != visibility {
+				t.Errorf("expected visibility %q to round-trip, got %q", visibility, got)
+			}
+
+			updated := calendar.MapProtoUpdateToEvent(&proto.UpdateEventRequest{Visibility: ptr(visibility)}, &gcalendar.Event{})
+			if updated.Visibility != visibility {
+				t.Errorf("expected updated visibility %q, got %q", visibility, updated.Visibility)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		event := calendar.MapProtoToEvent(&proto.AddEventRequest{Summary: "Event", Visibility: ptr("secret")})
+		err := calendar.ValidateVisibility(event)
+		if err == nil || !strings.Contains(err.Error(), `invalid visibility "secret"`) {
+			t.Errorf("expected an invalid visibility error, got %v", err)
+		}
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		event := calendar.MapProtoToEvent(&proto.AddEventRequest{Summary: "Event"})
+		if event.Visibility != "" {
+			t.Errorf("expected visibility to be left to the calendar, got %q", event.Visibility)
+		}
+	})
+}
+
+func TestMapColorID(t *testing.T) {
+	event := calendar.MapProtoToEvent(&proto.AddEventRequest{Summary: "Event", ColorId: ptr("11")})
+	if event.ColorId != "11" {
+		t.Errorf("expected color %q, got %q", "11", event.ColorId)
+	}
+	if err := calendar.ValidateColorID(event); err != nil {
+		t.Errorf("expected color 11 to be valid, got %v", err)
+	}
+	if got := calendar.MapEventToProto(event, "primary").GetColorId(); got != "11" {
+		t.Errorf("expected color to round-trip, got %q", got)
+	}
+
+	updated := calendar.MapProtoUpdateToEvent(&proto.UpdateEventRequest{ColorId: ptr("3")}, &gcalendar.Event{ColorId: "11"})
+	if updated.ColorId != "3" {
+		t.Errorf("expected updated color %q, got %q", "3", updated.ColorId)
+	}
+	if cleared := calendar.MapProtoUpdateToEvent(&proto.UpdateEventRequest{ColorId: ptr("")}, updated); cleared.ColorId != "" {
+		t.Errorf("expected color to be cleared, got %q", cleared.ColorId)
+	}
+
+	for _, colorID := range []string{"0", "12", "-1", "blue", "05"} {
+		t.Run("invalid "+colorID, func(t *testing.T) {
+			event := calendar.MapProtoToEvent(&proto.AddEventRequest{Summary: "Event", ColorId: ptr(colorID)})
+			err := calendar.ValidateColorID(event)
+			if err == nil || !strings.Contains(err.Error(), "invalid color_id") {
+				t.Errorf("expected an invalid color error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMapProtoToEvent_AddConference(t *testing.T) {
+	event := calendar.MapProtoToEvent(&proto.AddEventRequest{Summary: "Sync", AddConference: ptr(true)})
+	if event.ConferenceData == nil || event.ConferenceData.CreateRequest == nil {
+		t.Fatal("expected a conference create request")
+	}
+	create := event.ConferenceData.CreateRequest
+	if create.ConferenceSolutionKey.Type != "hangoutsMeet" {
+		t.Errorf("expected hangoutsMeet, got %q", create.ConferenceSolutionKey.Type)
+	}
+	if create.RequestId == "" {
+		t.Error("expected a request ID")
+	}
+
+	other := calendar.MapProtoToEvent(&proto.AddEventRequest{Summary: "Sync", AddConference: ptr(true)})
+	if other.ConferenceData.CreateRequest.RequestId == create.RequestId {
+		t.Error("expected each request to get a fresh request ID")
+	}
+
+	for _, req := range []*proto.AddEventRequest{
+		{Summary: "Sync"},
+		{Summary: "Sync", AddConference: ptr(false)},
+	} {
+		if event := calendar.MapProtoToEvent(req); event.ConferenceData != nil {
+			t.Errorf("expected no conference data for %v, got %+v", req, event.ConferenceData)
+		}
+	}
+}
+
+func TestMapAttachments_DriveFile(t *testing.T) {
+	req := &proto.AddEventRequest{
+		Summary: "Planning",
+		Attachments: &proto.AttachmentList{Attachments: []*proto.Attachment{{
+			FileUrl:  "https://drive.google.com/open?id=1a2b3c",
+			Title:    ptr("Agenda"),
+			MimeType: ptr("application/vnd.google-apps.document"),
+		}}},
+	}
+
+	event := calendar.MapProtoToEvent(req)
+	if len(event.Attachments) != 1 {
+		t.Fatalf("expected 1 attachment, got %d", len(event.Attachments))
+	}
+	if got := event.Attachments[0]; got.FileUrl != "https://drive.google.com/open?id=1a2b3c" || got.Title != "Agenda" {
+		t.Errorf("unexpected attachment: %+v", got)
+	}
+
+	protoEvent := calendar.MapEventToProto(event, "primary")
+	if len(protoEvent.Attachments) != 1 {
+		t.Fatalf("expected 1 attachment to round-trip, got %d", len(protoEvent.Attachments))
+	}
+	want := req.Attachments.Attachments[0]
+	if got := protoEvent.Attachments[0]; got.FileUrl != want.FileUrl || got.GetTitle() != want.GetTitle() || got.GetMimeType() != want.GetMimeType() {
+		t.Errorf("attachment did not round-trip: got %v, want %v", got, want)
+	}
+
+	// On update, an empty list removes every attachment
+	updated := calendar.MapProtoUpdateToEvent(&proto.UpdateEventRequest{Attachments: &proto.AttachmentList{}}, event)
+	if len(updated.Attachments) != 0 {
+		t.Errorf("expected attachments to be removed, got %v", updated.Attachments)
+	}
+}
+
+func TestParseAttachmentList(t *testing.T) {
+	list, err := parseAttachmentList("https://example.com/a.pdf, https://example.com/b.pdf")
+	if err != nil {
+		t.Fatalf("failed to parse attachment URLs: %v", err)
+	}
+	if len(list.Attachments) != 2 || list.Attachments[1].FileUrl != "https://example.com/b.pdf" {
+		t.Errorf("unexpected attachments: %v", list.Attachments)
+	}
+
+	list, err = parseAttachmentList(`[{"fileUrl":"https://example.com/a.pdf","mimeType":"application/pdf"}]`)
+	if err != nil {
+		t.Fatalf("failed to parse JSON attachments: %v", err)
+	}
+	if len(list.Attachments) != 1 || list.Attachments[0].GetMimeType() != "application/pdf" {
+		t.Errorf("unexpected attachments from JSON: %v", list.Attachments)
+	}
+
+	if _, err := parseAttachmentList(`[{"fileUrl":`); err == nil {
+		t.Error("expected an error for malformed JSON")
+	}
+}
+
+func TestParsePropertyMap(t *testing.T) {
+	props, err := parsePropertyMap(" ticket=OPS-12, source = jira ,,status=")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"ticket": "OPS-12", "source": "jira", "status": ""}
+	if len(props.Properties) != len(want) {
+		t.Fatalf("properties = %v, want %v", props.Properties, want)
+	}
+	for key, value := range want {
+		if got, ok := props.Properties[key]; !ok || got != value {
+			t.Errorf("property %q = %q, want %q", key, got, value)
+		}
+	}
+
+	props, err = parsePropertyMap(`{"note":"a, b"}`)
+	if err != nil || props.Properties["note"] != "a, b" {
+		t.Errorf("expected JSON properties to parse, got %v, %v", props, err)
+	}
+
+	for _, value := range []string{"ticket", "=OPS-12", `{"note":`} {
+		if _, err := parsePropertyMap(value); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+}
+
+func TestParseCalendarIDList(t *testing.T) {
+	list := parseCalendarIDList(" primary, team@group.calendar.google.com ,,")
+	if got := strings.Join(list.Ids, "|"); got != "primary|team@group.calendar.google.com" {
+		t.Errorf("unexpected calendar IDs: %q", got)
+	}
+}
@@ -0,0 +1,53 @@
+package googlecaltest
+
+import (
+	"net/http"
+	"strings"
+)
+
+// RequireAuth makes the server reject any request whose Authorization header
+// is not "Bearer <expectedToken>" with a 401, as Google does for missing or
+// invalid credentials. An empty token turns the check off again, which is the
+// default.
+func (s *Server) RequireAuth(expectedToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requiredToken = expectedToken
+}
+
+// LastAuthToken returns the bearer token sent with the most recent request,
+// or "" if it had none. Tokens are captured whether or not auth is required.
+func (s *Server) LastAuthToken() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastAuthToken
+}
+
+// authorize captures r's bearer token and reports whether r may proceed,
+// writing a 401 if the server requires a different token.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) bool {
+	token := bearerToken(r)
+
+	s.mu.Lock()
+	s.lastAuthToken = token
+	required := s.requiredToken
+	s.mu.Unlock()
+
+	if required == "" || token == required {
+		return true
+	}
+	w.Header().Set("WWW-Authenticate", `Bearer realm="https://accounts.google.com/"`)
+	writeAPIError(w, http.StatusUnauthorized, "authError",
+		"Request had invalid authentication credentials. Expected OAuth 2 access token, login cookie or other valid authentication credential.")
+	return false
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>"
+// header, returning "" for other schemes or no header.
+func bearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,153 @@
+package googlecaltest
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// defaultCurrentUser is the email of the authenticated user until a test sets
+// one with SetCurrentUser.
+const defaultCurrentUser = "user@example.com"
+
+// accessRoleRank orders access roles for minAccessRole filtering.
+var accessRoleRank = map[string]int{
+	"freeBusyReader": 1,
+	"reader":         2,
+	"writer":         3,
+	"owner":          4,
+}
+
+// handleCalendarList routes /users/me/calendarList requests.
+func (s *Server) handleCalendarList(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path[strings.Index(r.URL.Path, "/users/me/calendarList")+len("/users/me/calendarList"):]
+	calendarID := strings.Trim(path, "/")
+
+	if r.Method != http.MethodGet {
+		writeAPIError(w, http.StatusNotImplemented, "notImplemented", "unsupported calendarList operation")
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if calendarID == "" {
+		s.listCalendars(w, r)
+		return
+	}
+
+	if entry := s.calendarEntry(calendarID); entry != nil {
+		s.writeJSON(w, r, entry)
+		return
+	}
+	writeAPIError(w, http.StatusNotFound, "notFound", "calendar not found")
+}
+
+// listCalendars handles GET /users/me/calendarList: the primary calendar
+// first, then those added with AddCalendar in ID order. Callers must hold
+// s.mu.
+func (s *Server) listCalendars(w http.ResponseWriter, r *http.Request) {
+	minAccessRole := r.URL.Query().Get("minAccessRole")
+	if minAccessRole != "" && accessRoleRank[minAccessRole] == 0 {
+		writeAPIError(w, http.StatusBadRequest, "invalidParameter", "invalid minAccessRole: "+minAccessRole)
+		return
+	}
+
+	entries := []*calendar.CalendarListEntry{s.primaryCalendar()}
+	ids := make([]string, 0, len(s.calendars))
+	for id := range s.calendars {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		entries = append(entries, s.calendars[id])
+	}
+
+	list := &calendar.CalendarList{Kind: "calendar#calendarList"}
+	for _, entry := range entries {
+		if accessRoleRank[entry.AccessRole] >= accessRoleRank[minAccessRole] {
+			list.Items = append(list.Items, entry)
+		}
+	}
+	s.writeJSON(w, r, list)
+}
+
+// calendarAvailable reports whether event requests may act on calendarID:
+// always in lenient mode, and only for known calendars with StrictCalendars.
+func (s *Server) calendarAvailable(calendarID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.strictCalendars || calendarID == "primary" || calendarID == s.currentUser {
+		return true
+	}
+	if _, ok := s.calendars[calendarID]; ok {
+		return true
+	}
+	_, ok := s.events[calendarID]
+	return ok
+}
+
+// calendarEntry returns the calendarList entry for calendarID, or nil if it
+// is neither the primary calendar nor one added with AddCalendar. Callers must
+// hold s.mu.
+func (s *Server) calendarEntry(calendarID string) *calendar.CalendarListEntry {
+	// The primary calendar's ID is the authenticated user's email
+	if calendarID == "primary" || calendarID == s.currentUser {
+		return s.primaryCalendar()
+	}
+	return s.calendars[calendarID]
+}
+
+// calendarLocation returns the time zone set for calendarID with
+// AddCalendar, or UTC if it has none or isn't a known calendar. Callers must
+// hold s.mu.
+func (s *Server) calendarLocation(calendarID string) *time.Location {
+	if entry := s.calendarEntry(calendarID); entry != nil && entry.TimeZone != "" {
+		if loc, err := time.LoadLocation(entry.TimeZone); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// primaryCalendar returns the calendarList entry of the current user's
+// primary calendar. Callers must hold s.mu.
+func (s *Server) primaryCalendar() *calendar.CalendarListEntry {
+	return &calendar.CalendarListEntry{
+		Kind:       "calendar#calendarListEntry",
+		Id:         s.currentUser,
+		Summary:    s.currentUser,
+		Primary:    true,
+		AccessRole: "owner",
+	}
+}
+
+// AddCalendar adds a calendar to the current user's calendar list. The entry
+// needs an ID; its summary defaults to the ID and its access role to owner.
+// Adding an existing ID replaces that entry.
+func (s *Server) AddCalendar(entry *calendar.CalendarListEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *entry
+	stored.Kind = "calendar#calendarListEntry"
+	if stored.Summary == "" {
+		stored.Summary = stored.Id
+	}
+	if stored.AccessRole == "" {
+		stored.AccessRole = "owner"
+	}
+	s.calendars[stored.Id] = &stored
+}
+
+// SetCurrentUser sets the email of the authenticated user, which the server
+// reports as the ID of the primary calendar (defaults to user@example.com).
+func (s *Server) SetCurrentUser(email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.currentUser = email
+}
@@ -0,0 +1,90 @@
+package googlecaltest
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// applyConferenceData mimics how Google treats conference data on insert:
+// with conferenceDataVersion=1 a pending create request is fulfilled with a
+// fake Meet conference, and with version 0 conference data is ignored.
+func applyConferenceData(event *calendar.Event, version string) {
+	if event.ConferenceData == nil {
+		return
+	}
+	if version != "1" {
+		event.ConferenceData = nil
+		return
+	}
+
+	create := event.ConferenceData.CreateRequest
+	if create == nil || create.ConferenceSolutionKey == nil || create.ConferenceSolutionKey.Type != "hangoutsMeet" {
+		return
+	}
+
+	code := meetCode(event.Id)
+	event.ConferenceData = &calendar.ConferenceData{
+		ConferenceId: code,
+		ConferenceSolution: &calendar.ConferenceSolution{
+			Key:  &calendar.ConferenceSolutionKey{Type: "hangoutsMeet"},
+			Name: "Google Meet",
+		},
+		CreateRequest: &calendar.CreateConferenceRequest{
+			RequestId:             create.RequestId,
+			ConferenceSolutionKey: create.ConferenceSolutionKey,
+			Status:                &calendar.ConferenceRequestStatus{StatusCode: "success"},
+		},
+		EntryPoints: []*calendar.EntryPoint{{
+			EntryPointType: "video",
+			Uri:            "https://meet.google.com/" + code,
+			Label:          "meet.google.com/" + code,
+		}},
+	}
+	event.HangoutLink = "https://meet.google.com/" + code
+}
+
+// validateConferenceDataVersion checks a conferenceDataVersion parameter,
+// which Google accepts only as 0 or 1 (unset means 0).
+func validateConferenceDataVersion(version string) error {
+	switch version {
+	case "", "0", "1":
+		return nil
+	}
+	return fmt.Errorf("invalid conferenceDataVersion %q: must be 0 or 1", version)
+}
+
+// updateConferenceData mimics how Google treats conference data on update:
+// with version 0 any conference data sent is ignored and the event keeps its
+// existing conference; with version 1 the sent data replaces it, and a new
+// create request is fulfilled as on insert.
+func updateConferenceData(existing, updated *calendar.Event, version string) {
+	if version != "1" {
+		updated.ConferenceData = existing.ConferenceData
+		updated.HangoutLink = existing.HangoutLink
+		return
+	}
+	if updated.ConferenceData == nil {
+		updated.HangoutLink = ""
+		return
+	}
+	applyConferenceData(updated, version)
+}
+
+// meetCode derives a stable Meet-style code (e.g. "abc-defg-hij") from seed.
+func meetCode(seed string) string {
+	h := fnv.New64a()
+	h.Write([]byte(seed))
+	n := h.Sum64()
+
+	code := make([]byte, 0, 12)
+	for i := 0; i < 10; i++ {
+		if i == 3 || i == 7 {
+			code = append(code, '-')
+		}
+		code = append(code, byte('a'+n%26))
+		n /= 26
+	}
+	return string(code)
+}
@@ -8,10 +8,20 @@
 // The mock server supports the following Google Calendar API operations:
 //
 //   - Insert Event: POST /calendars/{calendarId}/events
+//   - Quick Add Event: POST /calendars/{calendarId}/events/quickAdd (summary is the text)
+//   - Import Event: POST /calendars/{calendarId}/events/import (keeps the iCalUID;
+//     re-importing one replaces the earlier event)
 //   - List Events: GET /calendars/{calendarId}/events (with pagination, time filters, sorting)
 //   - Get Event: GET /calendars/{calendarId}/events/{eventId}
-//   - Update Event: PUT/PATCH /calendars/{calendarId}/events/{eventId}
+//   - List Instances: GET /calendars/{calendarId}/events/{eventId}/instances
+//   - Update Event: PUT /calendars/{calendarId}/events/{eventId} (full replace)
+//   - Patch Event: PATCH /calendars/{calendarId}/events/{eventId} (merges sent fields)
 //   - Delete Event: DELETE /calendars/{calendarId}/events/{eventId}
+//   - Watch Events: POST /calendars/{calendarId}/events/watch (webhook channels)
+//   - Stop Channel: POST /channels/stop
+//   - Query Free/Busy: POST /freeBusy (merged busy blocks from opaque events)
+//   - List CalendarList: GET /users/me/calendarList (primary plus AddCalendar entries, with minAccessRole)
+//   - Get CalendarList Entry: GET /users/me/calendarList/{calendarId}
 //
 // # Basic Usage
 //
@@ -45,19 +55,94 @@
 //	    Summary: "Existing Event",
 //	})
 //
+//	// Or seed from a JSON fixture of {calendarId: {eventId: event}}, and
+//	// dump the current state with SaveFixture
+//	err := server.LoadFixture(f)
+//
 //	// Get all events for assertions
 //	events := server.GetEvents("primary")
 //
+//	// Inspect exactly what the client sent
+//	last, _ := server.LastRequest()
+//	all := server.Requests()
+//
 //	// Clear all data between tests
 //	server.Reset()
 //
+//	// Or roll back to a seeded baseline
+//	restore := server.Snapshot()
+//	defer restore()
+//
 // # Features
 //
 //   - Thread-safe: Uses mutex for concurrent access
-//   - Pagination: Supports maxResults and pageToken query parameters
-//   - Time filtering: Supports timeMin and timeMax query parameters
+//   - Pagination: Supports maxResults (default 250, capped at 2500) and
+//     pageToken query parameters; page tokens are opaque and malformed or
+//     tampered ones fail with 400
+//   - Time filtering: Supports timeMin and timeMax query parameters; all-day
+//     events match any window overlapping their dates, which are days in the
+//     request's timeZone or else the calendar's (UTC unless set with
+//     AddCalendar)
+//   - Change filtering: Supports updatedMin against each event's Updated time
+//   - Search: Supports q, matched case-insensitively against summary,
+//     description, location, and attendee emails and names
 //   - Sorting: Supports orderBy=startTime with singleEvents=true
-//   - Multiple calendars: Each calendar ID maintains separate event storage
+//   - Recurrence: Expands RRULE series into instances when singleEvents=true,
+//     applying time filters to each instance rather than the master
+//   - Exceptions: Stored instance overrides (RecurringEventId + OriginalStartTime)
+//     replace their generated occurrence, or remove it when cancelled.
+//     Occurrences can be fetched, updated, and deleted by instance ID
+//     ({masterId}_{originalStart}); updating one stores it as an override, and
+//     deleting one stores a cancelled override
+//   - Soft delete: SoftDelete(true) keeps cancelled tombstones, listed only
+//     with showDeleted=true
+//   - List metadata: Event lists carry the calendar's summary, timeZone
+//     (UTC unless set with AddCalendar), and accessRole
+//   - Incremental sync: Full lists return a nextSyncToken; passing it back as
+//     syncToken returns only events changed since (deletions as cancelled), and
+//     invalid or expired tokens (e.g. after Reset) fail with 410 Gone
+//   - Attendee limits: maxAttendees truncates each returned event's guest
+//     list, keeping the current user and setting attendeesOmitted; stored
+//     events are unchanged
+//   - Time zones: timeZone renders returned date-times in the given zone and
+//     labels them with it; stored events are unchanged
+//   - Partial responses: Honors the fields parameter (e.g. "items(id,summary)"),
+//     omitting unselected fields from the JSON response
+//   - Forward compatibility: InjectResponseField adds unknown fields to every
+//     response to prove clients ignore them
+//   - Preconditions: Updates and deletes with an If-Match header that doesn't
+//     match the event's current ETag fail with 412 "conditionNotMet"
+//   - Time range validation: Inserts and updates whose end precedes their
+//     start fail with a 400 "invalid" error
+//   - Client-chosen IDs: Inserts keep an event ID the client sets, failing
+//     with 409 "duplicate" if the calendar already has it
+//   - Conferences: Inserts and updates with conferenceDataVersion=1 fulfil a
+//     hangoutsMeet create request with a fake Meet link; with version 0 sent
+//     conference data is ignored (updates keep the existing conference), and
+//     other versions fail with 400, as Google does
+//   - Attachments: Stored and echoed back unchanged
+//   - Push notifications: Watched calendars POST Google-style notifications
+//     (a "sync" message on creation, then "exists" on each change) to the
+//     channel address
+//   - Structured errors: Failures return Google's JSON error envelope, so the
+//     client library surfaces them as *googleapi.Error
+//   - Transient failures: FailNext and FailNextWithRetryAfter fail upcoming
+//     requests with a status such as 429 or 503, for testing client retries
+//   - Latency: SetLatency delays every request, ending early if the client
+//     gives up, for testing client timeouts
+//   - Request hooks: OnRequest runs a function as each request arrives, for
+//     cancelling a client's context at a precise point mid-operation
+//   - Dropped connections: DropAfterItems and DropAfterBytes cut responses off
+//     mid-body and close the connection, so clients see an unexpected EOF
+//   - Authentication: Unchecked by default; RequireAuth rejects requests
+//     without the expected bearer token with a 401, and LastAuthToken reports
+//     the token each request carried
+//   - Current user: SetCurrentUser sets the authenticated user's email, reported
+//     as the primary calendar's ID
+//   - Multiple calendars: Each calendar ID maintains separate event storage;
+//     StrictCalendars makes requests for unregistered calendars fail with 404
+//     instead of acting on an empty calendar
 //   - Automatic ID generation: Assigns sequential IDs to new events
-//   - Metadata: Sets Created, Updated, Status, and HtmlLink fields
+//   - Metadata: Sets Created, Updated, Status, Etag, and HtmlLink fields;
+//     SetClock freezes the time Created and Updated are stamped with
 package googlecaltest
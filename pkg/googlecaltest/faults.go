@@ -0,0 +1,170 @@
+package googlecaltest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// FailNext makes the next n requests fail with the given HTTP status and a
+// Google-style error body, before they reach any handler. Statuses map to
+// Google's reasons: 429 is rateLimitExceeded, 5xx is backendError, and
+// anything else is badRequest. Use it to exercise client retry logic.
+func (s *Server) FailNext(n, status int) {
+	s.FailNextWithRetryAfter(n, status, -1)
+}
+
+// FailNextWithRetryAfter is FailNext with a Retry-After header of retryAfter,
+// rounded down to whole seconds, on each failure. A negative retryAfter omits
+// the header.
+func (s *Server) FailNextWithRetryAfter(n, status int, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failRemaining = n
+	s.failStatus = status
+	s.failRetryAfter = retryAfter
+}
+
+// injectFailure writes a queued failure, if any, reporting whether it did.
+func (s *Server) injectFailure(w http.ResponseWriter) bool {
+	s.mu.Lock()
+	if s.failRemaining <= 0 {
+		s.mu.Unlock()
+		return false
+	}
+	s.failRemaining--
+	status, retryAfter := s.failStatus, s.failRetryAfter
+	s.mu.Unlock()
+
+	reason := "badRequest"
+	switch {
+	case status == http.StatusForbidden:
+		reason = "forbidden"
+	case status == http.StatusNotFound:
+		reason = "notFound"
+	case status == http.StatusConflict:
+		reason = "duplicate"
+	case status == http.StatusTooManyRequests:
+		reason = "rateLimitExceeded"
+	case status >= 500:
+		reason = "backendError"
+	}
+	if retryAfter >= 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter/time.Second)))
+	}
+	writeAPIError(w, status, reason, http.StatusText(status))
+	return true
+}
+
+// SetLatency delays every subsequent request by d before it is handled,
+// simulating a slow or hung network so client timeouts can be tested. The
+// delay ends early if the client gives up on the request. Zero disables it.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// injectLatency waits out the configured latency, reporting false if the
+// client went away first.
+func (s *Server) injectLatency(r *http.Request) bool {
+	s.mu.RLock()
+	latency := s.latency
+	s.mu.RUnlock()
+	if latency <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(latency)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+// OnRequest registers hook to run as each request arrives, before any latency
+// or failure is injected. It runs on the request's handler goroutine, so a
+// test can cancel its client's context at an exact point (say, when the
+// second page of a list is requested) and, with SetLatency, have the handler
+// give up on the request rather than answer it. A nil hook removes it.
+func (s *Server) OnRequest(hook func(r *http.Request)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onRequest = hook
+}
+
+// runRequestHook calls the OnRequest hook, if any.
+func (s *Server) runRequestHook(r *http.Request) {
+	s.mu.RLock()
+	hook := s.onRequest
+	s.mu.RUnlock()
+	if hook != nil {
+		hook(r)
+	}
+}
+
+// DropAfterBytes makes every subsequent response close its connection after
+// n bytes of the body have been written, simulating a connection dropped
+// mid-stream. The full Content-Length is still announced, so clients see an
+// unexpected EOF rather than a short but valid body. A negative n disables it.
+func (s *Server) DropAfterBytes(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropAfterBytes = n
+}
+
+// DropAfterItems lets list responses through intact until n events have been
+// served in total; the first list response that would exceed n is cut off
+// mid-body and its connection closed, as are all later ones. This simulates a
+// connection lost after the first pages of a multi-page list. A negative n
+// disables it.
+func (s *Server) DropAfterItems(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropAfterItems = n
+	s.itemsServed.Store(0)
+}
+
+// writeBody writes a successful response body, truncating it if a drop is
+// configured. items is the number of events the body lists. Callers must
+// hold s.mu.
+func (s *Server) writeBody(w http.ResponseWriter, body []byte, items int) {
+	cut := -1
+	if s.dropAfterBytes >= 0 && len(body) > s.dropAfterBytes {
+		cut = s.dropAfterBytes
+	}
+	if s.dropAfterItems >= 0 && items > 0 {
+		if served := s.itemsServed.Add(int64(items)); served > int64(s.dropAfterItems) {
+			if half := len(body) / 2; cut < 0 || half < cut {
+				cut = half
+			}
+		}
+	}
+
+	if cut < 0 {
+		w.Write(body)
+		return
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body[:cut])
+	dropConnection(w)
+}
+
+// dropConnection flushes what has been written and closes the underlying
+// connection without finishing the response.
+func dropConnection(w http.ResponseWriter) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		return
+	}
+	if conn, _, err := hj.Hijack(); err == nil {
+		conn.Close()
+	}
+}
@@ -0,0 +1,199 @@
+package googlecaltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// fieldMask is a parsed partial-response selector. Each key maps to the mask
+// for its children, with nil selecting the whole value.
+type fieldMask map[string]fieldMask
+
+// parseFields parses the fields query parameter, e.g.
+// "nextPageToken,items(id,summary,start/dateTime)".
+func parseFields(fields string) (fieldMask, error) {
+	p := &fieldsParser{input: fields}
+	mask, err := p.parseList()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.input) {
+		return nil, fmt.Errorf("invalid field selection %q: unexpected %q at offset %d", fields, p.input[p.pos], p.pos)
+	}
+	return mask, nil
+}
+
+type fieldsParser struct {
+	input string
+	pos   int
+}
+
+// parseList parses comma-separated selectors up to a closing paren or the end.
+func (p *fieldsParser) parseList() (fieldMask, error) {
+	mask := fieldMask{}
+	for {
+		name, sub, err := p.parseItem()
+		if err != nil {
+			return nil, err
+		}
+		mask.merge(name, sub)
+
+		if p.pos >= len(p.input) || p.input[p.pos] != ',' {
+			return mask, nil
+		}
+		p.pos++
+	}
+}
+
+// parseItem parses "name", "name/item", or "name(list)".
+func (p *fieldsParser) parseItem() (string, fieldMask, error) {
+	start := p.pos
+	for p.pos < len(p.input) && !strings.ContainsRune(",/()", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	name := strings.TrimSpace(p.input[start:p.pos])
+	if name == "" {
+		return "", nil, fmt.Errorf("invalid field selection %q: empty field name at offset %d", p.input, start)
+	}
+
+	if p.pos >= len(p.input) {
+		return name, nil, nil
+	}
+	switch p.input[p.pos] {
+	case '/':
+		p.pos++
+		child, sub, err := p.parseItem()
+		if err != nil {
+			return "", nil, err
+		}
+		return name, fieldMask{child: sub}, nil
+	case '(':
+		p.pos++
+		sub, err := p.parseList()
+		if err != nil {
+			return "", nil, err
+		}
+		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
+			return "", nil, fmt.Errorf("invalid field selection %q: missing ')'", p.input)
+		}
+		p.pos++
+		return name, sub, nil
+	}
+	return name, nil, nil
+}
+
+// merge adds a selector to the mask; selecting a whole value wins over any
+// partial selection of the same key.
+func (m fieldMask) merge(name string, sub fieldMask) {
+	existing, ok := m[name]
+	switch {
+	case !ok:
+		m[name] = sub
+	case existing == nil || sub == nil:
+		m[name] = nil
+	default:
+		for child, childSub := range sub {
+			existing.merge(child, childSub)
+		}
+	}
+}
+
+// apply projects a decoded JSON value onto the mask. Arrays are projected
+// element-wise, as Google does for selectors like items(id).
+func (m fieldMask) apply(value any) any {
+	if m == nil {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any)
+		for name, sub := range m {
+			if child, ok := v[name]; ok {
+				out[name] = sub.apply(child)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, elem := range v {
+			out[i] = m.apply(elem)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// writeJSON writes v as the JSON response body, honoring the fields query
+// parameter for partial responses and adding any injected extra fields.
+// Callers must hold s.mu.
+func (s *Server) writeJSON(w http.ResponseWriter, r *http.Request, v any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	items := 0
+	if list, ok := v.(*calendar.Events); ok {
+		items = len(list.Items)
+	}
+
+	fields := r.URL.Query().Get("fields")
+	if fields == "" && len(s.extraFields) == 0 {
+		s.encode(w, v, items)
+		return
+	}
+
+	// Round-trip through a generic value so it can be walked by JSON name
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "backendError", fmt.Sprintf("failed to encode response: %v", err))
+		return
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "backendError", fmt.Sprintf("failed to encode response: %v", err))
+		return
+	}
+
+	if fields != "" {
+		mask, err := parseFields(fields)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "invalidParameter", err.Error())
+			return
+		}
+		generic = mask.apply(generic)
+	}
+
+	injectFields(generic, s.extraFields)
+	s.encode(w, generic, items)
+}
+
+// encode writes v as JSON through writeBody. Callers must hold s.mu.
+func (s *Server) encode(w http.ResponseWriter, v any, items int) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "backendError", fmt.Sprintf("failed to encode response: %v", err))
+		return
+	}
+	s.writeBody(w, buf.Bytes(), items)
+}
+
+// injectFields adds extra to a decoded response object and to each of its
+// items, so both list and single-resource decoding see the unknown fields.
+func injectFields(value any, extra map[string]any) {
+	obj, ok := value.(map[string]any)
+	if !ok {
+		return
+	}
+	for key, v := range extra {
+		obj[key] = v
+	}
+	if items, ok := obj["items"].([]any); ok {
+		for _, item := range items {
+			injectFields(item, extra)
+		}
+	}
+}
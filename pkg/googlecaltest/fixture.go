@@ -0,0 +1,84 @@
+package googlecaltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// LoadFixture adds the events in a JSON fixture of the form
+//
+//	{"primary": {"event1": {"summary": "Standup", ...}}}
+//
+// to the server, keeping their IDs (an event's id defaults to its key) and
+// bumping the ID counter past any "eventN" IDs so later inserts don't
+// collide. Existing events with the same ID are replaced.
+func (s *Server) LoadFixture(r io.Reader) error {
+	var fixture map[string]map[string]*calendar.Event
+	if err := json.NewDecoder(r).Decode(&fixture); err != nil {
+		return fmt.Errorf("failed to decode fixture: %w", err)
+	}
+
+	// Validate everything before touching the store
+	for calendarID, calEvents := range fixture {
+		for eventID, event := range calEvents {
+			if event == nil {
+				return fmt.Errorf("invalid fixture: event %q in calendar %q is null", eventID, calendarID)
+			}
+			if event.Id != "" && event.Id != eventID {
+				return fmt.Errorf("invalid fixture: event %q in calendar %q has mismatched id %q", eventID, calendarID, event.Id)
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for calendarID, calEvents := range fixture {
+		if s.events[calendarID] == nil {
+			s.events[calendarID] = make(map[string]*calendar.Event)
+		}
+		for eventID, event := range calEvents {
+			event.Id = eventID
+			s.events[calendarID][eventID] = event
+			s.recordChange(calendarID, eventID)
+
+			if n, ok := generatedIDNumber(eventID); ok && n >= s.nextID {
+				s.nextID = n + 1
+			}
+		}
+	}
+	return nil
+}
+
+// SaveFixture writes the server's events as a JSON fixture that LoadFixture
+// can read back.
+func (s *Server) SaveFixture(w io.Writer) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s.events); err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+	return nil
+}
+
+// generatedIDNumber reports the counter value of an ID in the "eventN" form
+// the server assigns.
+func generatedIDNumber(eventID string) (int, bool) {
+	digits, ok := strings.CutPrefix(eventID, "event")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(digits)
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,140 @@
+package googlecaltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// busyInterval is a span of time a calendar is busy, as computed by freeBusy.
+type busyInterval struct {
+	start, end time.Time
+}
+
+// queryFreeBusy handles POST /freeBusy. Each requested calendar reports the
+// merged intervals, clipped to the query window, in which its opaque,
+// non-cancelled events (recurring instances included) overlap the window.
+// Unknown calendars report a notFound error instead.
+func (s *Server) queryFreeBusy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, http.StatusMethodNotAllowed, "httpMethodNotAllowed", "method not allowed")
+		return
+	}
+
+	var req calendar.FreeBusyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "parseError", fmt.Sprintf("invalid JSON: %v", err))
+		return
+	}
+	timeMin, minErr := time.Parse(time.RFC3339, req.TimeMin)
+	timeMax, maxErr := time.Parse(time.RFC3339, req.TimeMax)
+	if minErr != nil || maxErr != nil {
+		writeAPIError(w, http.StatusBadRequest, "required", "timeMin and timeMax must be RFC 3339 timestamps")
+		return
+	}
+	if !timeMax.After(timeMin) {
+		writeAPIError(w, http.StatusBadRequest, "timeRangeEmpty", "The specified time range is empty.")
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	resp := &calendar.FreeBusyResponse{
+		Kind:      "calendar#freeBusy",
+		TimeMin:   req.TimeMin,
+		TimeMax:   req.TimeMax,
+		Calendars: make(map[string]calendar.FreeBusyCalendar),
+	}
+	for _, item := range req.Items {
+		if !s.knownCalendar(item.Id) {
+			resp.Calendars[item.Id] = calendar.FreeBusyCalendar{
+				Errors: []*calendar.Error{{Domain: "global", Reason: "notFound"}},
+			}
+			continue
+		}
+		busy := []*calendar.TimePeriod{}
+		for _, interval := range s.busyIntervals(item.Id, timeMin, timeMax) {
+			busy = append(busy, &calendar.TimePeriod{
+				Start: interval.start.UTC().Format(time.RFC3339),
+				End:   interval.end.UTC().Format(time.RFC3339),
+			})
+		}
+		resp.Calendars[item.Id] = calendar.FreeBusyCalendar{Busy: busy}
+	}
+
+	s.writeJSON(w, r, resp)
+}
+
+// knownCalendar reports whether calendarID is the primary calendar, on the
+// calendar list, or holds events. Callers must hold s.mu.
+func (s *Server) knownCalendar(calendarID string) bool {
+	return calendarID == "primary" || calendarID == s.currentUser ||
+		s.calendars[calendarID] != nil || s.events[calendarID] != nil
+}
+
+// busyIntervals returns the merged, sorted intervals within [timeMin,
+// timeMax) in which the calendar's events block time. Callers must hold s.mu.
+func (s *Server) busyIntervals(calendarID string, timeMin, timeMax time.Time) []busyInterval {
+	calEvents := s.events[calendarID]
+	exceptions := collectExceptions(calEvents)
+
+	var occurrences []*calendar.Event
+	for _, evt := range calEvents {
+		if _, isException := exceptions[instanceKey(evt.RecurringEventId, evt.OriginalStartTime)]; isException && calEvents[evt.RecurringEventId] != nil {
+			// Surfaced through its series' expansion below
+			continue
+		}
+		if len(evt.Recurrence) > 0 {
+			occurrences = append(occurrences, applyExceptions(expandEvent(evt, timeMin, timeMax), exceptions, false)...)
+			continue
+		}
+		occurrences = append(occurrences, evt)
+	}
+
+	var intervals []busyInterval
+	for _, evt := range occurrences {
+		if evt.Status == "cancelled" || evt.Transparency == "transparent" {
+			continue
+		}
+		start, ok := eventDateTime(evt.Start)
+		if !ok {
+			continue
+		}
+		end, endOK := eventDateTime(evt.End)
+		if !endOK || !end.After(start) {
+			if evt.Start.Date == "" {
+				// Zero-length timed events block nothing
+				continue
+			}
+			end = start.AddDate(0, 0, 1)
+		}
+		if !end.After(timeMin) || !start.Before(timeMax) {
+			continue
+		}
+		if start.Before(timeMin) {
+			start = timeMin
+		}
+		if end.After(timeMax) {
+			end = timeMax
+		}
+		intervals = append(intervals, busyInterval{start: start, end: end})
+	}
+
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].start.Before(intervals[j].start) })
+	var merged []busyInterval
+	for _, interval := range intervals {
+		if n := len(merged); n > 0 && !interval.start.After(merged[n-1].end) {
+			if interval.end.After(merged[n-1].end) {
+				merged[n-1].end = interval.end
+			}
+			continue
+		}
+		merged = append(merged, interval)
+	}
+	return merged
+}
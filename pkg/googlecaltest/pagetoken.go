@@ -0,0 +1,33 @@
+package googlecaltest
+
+import (
+	"encoding/base64"
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"strings"
+)
+
+// encodePageToken returns an opaque token for the page starting at index. The
+// token carries a checksum so tampered or made-up tokens can be rejected, as
+// Google does, instead of silently paging from an arbitrary offset.
+func encodePageToken(index int) string {
+	payload := strconv.Itoa(index)
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(fmt.Sprintf("%s.%08x", payload, crc32.ChecksumIEEE([]byte("page:"+payload)))))
+}
+
+// decodePageToken returns the start index encoded in token.
+func decodePageToken(token string) (int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid page token %q", token)
+	}
+
+	payload, checksum, ok := strings.Cut(string(data), ".")
+	index, err := strconv.Atoi(payload)
+	if !ok || err != nil || index < 0 || checksum != fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte("page:"+payload))) {
+		return 0, fmt.Errorf("invalid page token %q", token)
+	}
+	return index, nil
+}
@@ -0,0 +1,74 @@
+package googlecaltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// RecordedRequest is a request received by the mock server, captured so tests
+// can assert on exactly what a client sent.
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	// Header holds the request's headers, such as User-Agent
+	Header http.Header
+	// Body is the decoded JSON request body, or nil if the request had none
+	// (or it was not a JSON object)
+	Body map[string]any
+	// RawBody is the request body as received
+	RawBody []byte
+}
+
+// record captures r and restores its body for the handlers that follow.
+func (s *Server) record(r *http.Request) {
+	recorded := RecordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+	}
+
+	if r.Body != nil {
+		data, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err == nil && len(data) > 0 {
+			recorded.RawBody = data
+			var body map[string]any
+			if json.Unmarshal(data, &body) == nil {
+				recorded.Body = body
+			}
+		}
+		r.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, recorded)
+}
+
+// Requests returns every request received since the server started or was
+// last Reset, in arrival order.
+func (s *Server) Requests() []RecordedRequest {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	requests := make([]RecordedRequest, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+// LastRequest returns the most recently received request, or false if none
+// has been received.
+func (s *Server) LastRequest() (RecordedRequest, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.requests) == 0 {
+		return RecordedRequest{}, false
+	}
+	return s.requests[len(s.requests)-1], true
+}
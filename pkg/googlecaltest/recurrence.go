@@ -0,0 +1,318 @@
+package googlecaltest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// maxRecurrenceInstances bounds the expansion of open-ended series (no COUNT or
+// UNTIL) when the list request does not supply a timeMax. It counts instances
+// from the start of the request's window, so late windows aren't starved.
+const maxRecurrenceInstances = 1000
+
+// rrule is the subset of an RFC 5545 recurrence rule understood by the mock.
+type rrule struct {
+	freq     string
+	interval int
+	count    int
+	until    time.Time
+	byDay    []time.Weekday
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// parseRRule parses an "RRULE:..." recurrence line.
+func parseRRule(line string) (*rrule, error) {
+	body, ok := strings.CutPrefix(line, "RRULE:")
+	if !ok {
+		return nil, fmt.Errorf("not an RRULE: %q", line)
+	}
+
+	rule := &rrule{interval: 1}
+	for _, part := range strings.Split(body, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("malformed RRULE part %q", part)
+		}
+
+		switch key {
+		case "FREQ":
+			rule.freq = value
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", value)
+			}
+			rule.interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid COUNT %q", value)
+			}
+			rule.count = n
+		case "UNTIL":
+			t, err := parseICalTime(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid UNTIL %q: %w", value, err)
+			}
+			rule.until = t
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day, ok := weekdayCodes[code]
+				if !ok {
+					return nil, fmt.Errorf("unsupported BYDAY value %q", code)
+				}
+				rule.byDay = append(rule.byDay, day)
+			}
+		}
+	}
+
+	switch rule.freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+	default:
+		return nil, fmt.Errorf("unsupported FREQ %q", rule.freq)
+	}
+
+	return rule, nil
+}
+
+// parseICalTime parses the date and date-time forms used by UNTIL.
+func parseICalTime(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time format")
+}
+
+// occurrences returns the start times produced by the rule from start, skipping
+// those before from and stopping at the rule's COUNT/UNTIL, at limit (if
+// non-zero), or after maxRecurrenceInstances, whichever comes first. Skipped
+// occurrences still count toward COUNT but not toward the cap.
+func (r *rrule) occurrences(start, from, limit time.Time) []time.Time {
+	var out []time.Time
+	seen := 0
+
+	emit := func(t time.Time) bool {
+		if t.Before(start) {
+			return true
+		}
+		if !r.until.IsZero() && t.After(r.until) {
+			return false
+		}
+		if !limit.IsZero() && !t.Before(limit) {
+			return false
+		}
+		seen++
+		if r.count > 0 && seen > r.count {
+			return false
+		}
+		if t.Before(from) {
+			return true
+		}
+		out = append(out, t)
+		return len(out) < maxRecurrenceInstances
+	}
+
+	for i := 0; ; i++ {
+		switch r.freq {
+		case "DAILY":
+			if !emit(start.AddDate(0, 0, i*r.interval)) {
+				return out
+			}
+		case "WEEKLY":
+			if len(r.byDay) == 0 {
+				if !emit(start.AddDate(0, 0, 7*i*r.interval)) {
+					return out
+				}
+				continue
+			}
+			// Walk each matching weekday within the period's week (weeks start on Monday)
+			weekStart := start.AddDate(0, 0, -((int(start.Weekday())+6)%7)+7*i*r.interval)
+			for offset := 0; offset < 7; offset++ {
+				day := weekStart.AddDate(0, 0, offset)
+				for _, wd := range r.byDay {
+					if day.Weekday() == wd && !emit(day) {
+						return out
+					}
+				}
+			}
+		case "MONTHLY":
+			// Months without the start's day (say, the 31st) are skipped, as
+			// RFC 5545 and Google do, rather than rolled into the next month
+			if t := start.AddDate(0, i*r.interval, 0); t.Day() == start.Day() && !emit(t) {
+				return out
+			}
+		case "YEARLY":
+			// Likewise years without Feb 29 for a leap-day series
+			if t := start.AddDate(i*r.interval, 0, 0); t.Day() == start.Day() && !emit(t) {
+				return out
+			}
+		}
+	}
+}
+
+// expandEvent returns the instances of a recurring master event whose starts
+// fall before limit (or the rule's own bounds when limit is zero), leaving
+// out those that end well before from. Events without an RRULE are returned
+// unchanged.
+func expandEvent(master *calendar.Event, from, limit time.Time) []*calendar.Event {
+	var rule *rrule
+	for _, line := range master.Recurrence {
+		if r, err := parseRRule(line); err == nil {
+			rule = r
+			break
+		}
+	}
+	if rule == nil || master.Start == nil {
+		return []*calendar.Event{master}
+	}
+
+	allDay := master.Start.DateTime == ""
+	start, ok := eventDateTime(master.Start)
+	if !ok {
+		return []*calendar.Event{master}
+	}
+	end, ok := eventDateTime(master.End)
+	if !ok {
+		end = start
+	}
+	duration := end.Sub(start)
+
+	// Generate occurrences in the event's own zone so DST transitions keep wall-clock time
+	if loc, err := time.LoadLocation(master.Start.TimeZone); err == nil && master.Start.TimeZone != "" {
+		start = start.In(loc)
+	}
+
+	// Keep instances that overlap from, with a day's slack since all-day
+	// dates are read in the list's zone rather than UTC
+	if !from.IsZero() {
+		from = from.Add(-duration - 24*time.Hour)
+	}
+
+	var instances []*calendar.Event
+	for _, occurrence := range rule.occurrences(start, from, limit) {
+		instance := *master
+		instance.Recurrence = nil
+		instance.RecurringEventId = master.Id
+		if allDay {
+			instance.Id = fmt.Sprintf("%s_%s", master.Id, occurrence.Format("20060102"))
+			instance.Start = &calendar.EventDateTime{Date: occurrence.Format("2006-01-02")}
+			instance.End = &calendar.EventDateTime{Date: occurrence.Add(duration).Format("2006-01-02")}
+			instance.OriginalStartTime = &calendar.EventDateTime{Date: occurrence.Format("2006-01-02")}
+		} else {
+			instance.Id = fmt.Sprintf("%s_%s", master.Id, occurrence.UTC().Format("20060102T150405Z"))
+			instance.Start = &calendar.EventDateTime{
+				DateTime: occurrence.Format(time.RFC3339),
+				TimeZone: master.Start.TimeZone,
+			}
+			instance.End = &calendar.EventDateTime{
+				DateTime: occurrence.Add(duration).Format(time.RFC3339),
+				TimeZone: master.Start.TimeZone,
+			}
+			instance.OriginalStartTime = &calendar.EventDateTime{
+				DateTime: occurrence.Format(time.RFC3339),
+				TimeZone: master.Start.TimeZone,
+			}
+		}
+		instances = append(instances, &instance)
+	}
+
+	return instances
+}
+
+// generatedInstance returns the occurrence of a stored series whose instance
+// ID ("{masterId}_{originalStart}") is eventID, as expandEvent generates it,
+// or nil if no series has such an occurrence.
+func generatedInstance(events map[string]*calendar.Event, eventID string) *calendar.Event {
+	i := strings.LastIndex(eventID, "_")
+	if i < 0 {
+		return nil
+	}
+	master := events[eventID[:i]]
+	if master == nil || len(master.Recurrence) == 0 {
+		return nil
+	}
+	originalStart, err := parseICalTime(eventID[i+1:])
+	if err != nil {
+		return nil
+	}
+	for _, instance := range expandEvent(master, originalStart, originalStart.Add(time.Second)) {
+		if instance.Id == eventID {
+			return instance
+		}
+	}
+	return nil
+}
+
+// eventDateTime parses an EventDateTime into a time, treating all-day dates as
+// midnight UTC.
+func eventDateTime(dt *calendar.EventDateTime) (time.Time, bool) {
+	if dt == nil {
+		return time.Time{}, false
+	}
+	if dt.DateTime != "" {
+		t, err := time.Parse(time.RFC3339, dt.DateTime)
+		return t, err == nil
+	}
+	if dt.Date != "" {
+		t, err := time.Parse("2006-01-02", dt.Date)
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
+
+// instanceKey identifies a single occurrence of a series by its master ID and
+// original start time, normalized to UTC so differently-zoned representations
+// of the same instant match.
+func instanceKey(recurringEventID string, originalStart *calendar.EventDateTime) string {
+	t, ok := eventDateTime(originalStart)
+	if recurringEventID == "" || !ok {
+		return ""
+	}
+	return recurringEventID + "/" + t.UTC().Format(time.RFC3339)
+}
+
+// collectExceptions indexes stored instance overrides (events carrying a
+// RecurringEventId and OriginalStartTime) by their instance key.
+func collectExceptions(events map[string]*calendar.Event) map[string]*calendar.Event {
+	exceptions := make(map[string]*calendar.Event)
+	for _, evt := range events {
+		if key := instanceKey(evt.RecurringEventId, evt.OriginalStartTime); key != "" {
+			exceptions[key] = evt
+		}
+	}
+	return exceptions
+}
+
+// applyExceptions substitutes stored overrides for the matching generated
+// instances, dropping instances whose override is cancelled unless showDeleted
+// is set.
+func applyExceptions(instances []*calendar.Event, exceptions map[string]*calendar.Event, showDeleted bool) []*calendar.Event {
+	var out []*calendar.Event
+	for _, instance := range instances {
+		override, ok := exceptions[instanceKey(instance.RecurringEventId, instance.OriginalStartTime)]
+		if !ok {
+			out = append(out, instance)
+			continue
+		}
+		if override.Status == "cancelled" && !showDeleted {
+			continue
+		}
+		out = append(out, override)
+	}
+	return out
+}
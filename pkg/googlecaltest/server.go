@@ -5,31 +5,113 @@ package googlecaltest
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/api/calendar/v3"
 )
 
+// Page size limits applied by listEvents and listInstances, matching the
+// Google Calendar API
+const (
+	defaultMaxResults = 250
+	maxMaxResults     = 2500
+)
+
 // Server is a mock Google Calendar API server for testing.
 type Server struct {
 	*httptest.Server
-	mu       sync.RWMutex
-	events   map[string]map[string]*calendar.Event // calendarID -> eventID -> event
-	nextID   int
-	baseTime time.Time
+	mu     sync.RWMutex
+	events map[string]map[string]*calendar.Event // calendarID -> eventID -> event
+
+	// nextID numbers the next generated "eventN" ID; allocatedIDs counts the
+	// IDs generated, which nextID doesn't once fixtures move it past theirs
+	nextID       int
+	allocatedIDs int
+
+	// clock stamps Created/Updated times and expires channels (time.Now
+	// unless a test freezes it with SetClock)
+	clock func() time.Time
+
+	// softDelete marks deleted events as cancelled instead of removing them
+	softDelete bool
+
+	// strictCalendars rejects event requests for calendars that were never
+	// registered with a 404, instead of treating them as empty
+	strictCalendars bool
+
+	// changeSeq is bumped on every mutation and backs syncToken-based
+	// incremental sync: changedAt records each event's latest change, and
+	// tombstones keep hard-deleted events so syncs can report them
+	changeSeq  int64
+	minSyncSeq int64                                 // tokens older than this have expired (set by Reset)
+	changedAt  map[string]map[string]int64           // calendarID -> eventID -> changeSeq
+	tombstones map[string]map[string]*calendar.Event // calendarID -> eventID -> cancelled copy
+
+	// requests records every request received, for assertions
+	requests []RecordedRequest
+
+	// extraFields are unknown fields added to every response, for testing
+	// forward compatibility
+	extraFields map[string]any
+
+	// currentUser is the authenticated user's email (the primary calendar ID)
+	currentUser string
+
+	// calendars are the calendarList entries besides the primary calendar
+	calendars map[string]*calendar.CalendarListEntry
+
+	// dropAfterBytes and dropAfterItems simulate connections dropped
+	// mid-response (negative when disabled); itemsServed counts the events
+	// listed so far, updated under a read lock
+	dropAfterBytes int
+	dropAfterItems int
+	itemsServed    atomic.Int64
+
+	// failRemaining requests will fail with failStatus (and a Retry-After
+	// of failRetryAfter unless negative)
+	failRemaining  int
+	failStatus     int
+	failRetryAfter time.Duration
+
+	// latency delays every request before it is handled
+	latency time.Duration
+
+	// onRequest, when set, is called as each request arrives
+	onRequest func(r *http.Request)
+
+	// requiredToken, when set, is the bearer token every request must carry;
+	// lastAuthToken is the token the most recent request sent
+	requiredToken string
+	lastAuthToken string
+
+	// channels are the registered push-notification channels, by channel ID
+	channels    map[string]*watchChannel
+	nextChannel int
 }
 
 // NewServer creates a new mock Google Calendar API server.
 func NewServer() *Server {
 	s := &Server{
-		events:   make(map[string]map[string]*calendar.Event),
-		nextID:   1,
-		baseTime: time.Now(),
+		events:         make(map[string]map[string]*calendar.Event),
+		nextID:         1,
+		clock:          time.Now,
+		changedAt:      make(map[string]map[string]int64),
+		tombstones:     make(map[string]map[string]*calendar.Event),
+		channels:       make(map[string]*watchChannel),
+		currentUser:    defaultCurrentUser,
+		calendars:      make(map[string]*calendar.CalendarListEntry),
+		dropAfterBytes: -1,
+		dropAfterItems: -1,
 	}
 
 	mux := http.NewServeMux()
@@ -41,9 +123,36 @@ func NewServer() *Server {
 
 // handleRequest routes all requests.
 func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
+	s.record(r)
+	s.runRequestHook(r)
+	if !s.injectLatency(r) {
+		return
+	}
+	if s.injectFailure(w) {
+		return
+	}
+	if !s.authorize(w, r) {
+		return
+	}
+
+	if strings.HasSuffix(r.URL.Path, "/channels/stop") {
+		s.stopChannel(w, r)
+		return
+	}
+
+	if strings.HasSuffix(r.URL.Path, "/freeBusy") {
+		s.queryFreeBusy(w, r)
+		return
+	}
+
+	if strings.Contains(r.URL.Path, "/users/me/calendarList") {
+		s.handleCalendarList(w, r)
+		return
+	}
+
 	// Check if this is a calendar events request
 	if !strings.Contains(r.URL.Path, "/calendars/") || !strings.Contains(r.URL.Path, "/events") {
-		http.Error(w, "unsupported endpoint", http.StatusNotFound)
+		writeAPIError(w, http.StatusNotFound, "notFound", "unsupported endpoint")
 		return
 	}
 	s.handleCalendars(w, r)
@@ -57,7 +166,7 @@ func (s *Server) handleCalendars(w http.ResponseWriter, r *http.Request) {
 	// Find the calendars section
 	idx := strings.Index(path, "/calendars/")
 	if idx == -1 {
-		http.Error(w, "invalid path: missing /calendars/", http.StatusBadRequest)
+		writeAPIError(w, http.StatusBadRequest, "badRequest", "invalid path: missing /calendars/")
 		return
 	}
 
@@ -66,7 +175,7 @@ func (s *Server) handleCalendars(w http.ResponseWriter, r *http.Request) {
 	parts := strings.Split(strings.Trim(path, "/"), "/")
 
 	if len(parts) < 2 {
-		http.Error(w, fmt.Sprintf("invalid path: expected at least calendarId/resource, got %v", parts), http.StatusBadRequest)
+		writeAPIError(w, http.StatusBadRequest, "badRequest", fmt.Sprintf("invalid path: expected at least calendarId/resource, got %v", parts))
 		return
 	}
 
@@ -74,7 +183,15 @@ func (s *Server) handleCalendars(w http.ResponseWriter, r *http.Request) {
 	resource := parts[1]
 
 	if resource != "events" {
-		http.Error(w, "unsupported resource", http.StatusNotImplemented)
+		writeAPIError(w, http.StatusNotImplemented, "notImplemented", "unsupported resource")
+		return
+	}
+	if strings.TrimSpace(calendarID) == "" {
+		writeAPIError(w, http.StatusBadRequest, "invalid", "invalid calendar ID: must not be empty")
+		return
+	}
+	if !s.calendarAvailable(calendarID) {
+		writeAPIError(w, http.StatusNotFound, "notFound", "calendar not found: "+calendarID)
 		return
 	}
 
@@ -87,8 +204,20 @@ func (s *Server) handleCalendars(w http.ResponseWriter, r *http.Request) {
 		case http.MethodPost:
 			s.insertEvent(w, r, calendarID)
 		default:
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeAPIError(w, http.StatusMethodNotAllowed, "httpMethodNotAllowed", "method not allowed")
 		}
+	} else if len(parts) == 3 && parts[2] == "quickAdd" && r.Method == http.MethodPost {
+		// /calendars/{calendarId}/events/quickAdd
+		s.quickAddEvent(w, r, calendarID)
+	} else if len(parts) == 3 && parts[2] == "import" && r.Method == http.MethodPost {
+		// /calendars/{calendarId}/events/import
+		s.importEvent(w, r, calendarID)
+	} else if len(parts) == 3 && parts[2] == "watch" && r.Method == http.MethodPost {
+		// /calendars/{calendarId}/events/watch
+		s.watchEvents(w, r, calendarID)
+	} else if len(parts) == 4 && parts[3] == "instances" && r.Method == http.MethodGet {
+		// /calendars/{calendarId}/events/{eventId}/instances
+		s.listInstances(w, r, calendarID, parts[2])
 	} else if len(parts) == 3 {
 		// /calendars/{calendarId}/events/{eventId}
 		eventID := parts[2]
@@ -100,42 +229,176 @@ func (s *Server) handleCalendars(w http.ResponseWriter, r *http.Request) {
 		case http.MethodDelete:
 			s.deleteEvent(w, r, calendarID, eventID)
 		default:
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeAPIError(w, http.StatusMethodNotAllowed, "httpMethodNotAllowed", "method not allowed")
 		}
 	} else {
-		http.Error(w, "invalid path", http.StatusBadRequest)
+		writeAPIError(w, http.StatusBadRequest, "badRequest", "invalid path")
 	}
 }
 
+// apiError mirrors the error envelope returned by the Google APIs, which
+// googleapi.CheckResponse decodes into a *googleapi.Error.
+type apiError struct {
+	Error apiErrorBody `json:"error"`
+}
+
+type apiErrorBody struct {
+	Code    int              `json:"code"`
+	Message string           `json:"message"`
+	Errors  []apiErrorDetail `json:"errors"`
+}
+
+type apiErrorDetail struct {
+	Domain  string `json:"domain"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// writeAPIError writes a Google-style JSON error response.
+func writeAPIError(w http.ResponseWriter, status int, reason, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(apiError{Error: apiErrorBody{
+		Code:    status,
+		Message: message,
+		Errors:  []apiErrorDetail{{Domain: "global", Reason: reason, Message: message}},
+	}})
+}
+
 // insertEvent handles POST /calendars/{calendarId}/events
 func (s *Server) insertEvent(w http.ResponseWriter, r *http.Request, calendarID string) {
 	var event calendar.Event
 	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
-		http.Error(w, fmt.Sprintf("invalid JSON: %v", err), http.StatusBadRequest)
+		writeAPIError(w, http.StatusBadRequest, "parseError", fmt.Sprintf("invalid JSON: %v", err))
+		return
+	}
+	if err := validateTimeRange(&event); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid", err.Error())
+		return
+	}
+	conferenceVersion := r.URL.Query().Get("conferenceDataVersion")
+	if err := validateConferenceDataVersion(conferenceVersion); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid", err.Error())
 		return
 	}
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Generate event ID
-	event.Id = fmt.Sprintf("event%d", s.nextID)
-	s.nextID++
+	// Keep a client-chosen ID, as Google does, or generate one
+	if event.Id != "" {
+		if _, exists := s.events[calendarID][event.Id]; exists {
+			writeAPIError(w, http.StatusConflict, "duplicate", "The requested identifier already exists.")
+			return
+		}
+	} else {
+		event.Id = s.generateID()
+	}
 
 	// Set metadata
 	event.Status = "confirmed"
-	event.Created = time.Now().Format(time.RFC3339)
+	if event.ICalUID == "" {
+		event.ICalUID = event.Id + "@google.com"
+	}
+	event.Created = s.clock().Format(time.RFC3339)
 	event.Updated = event.Created
 	event.HtmlLink = fmt.Sprintf("https://calendar.google.com/event?eid=%s", event.Id)
+	applyConferenceData(&event, conferenceVersion)
 
 	// Store event
 	if s.events[calendarID] == nil {
 		s.events[calendarID] = make(map[string]*calendar.Event)
 	}
 	s.events[calendarID][event.Id] = &event
+	s.recordChange(calendarID, event.Id)
+	event.Etag = s.etag()
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(event)
+	s.writeJSON(w, r, event)
+}
+
+// importEvent handles POST /calendars/{calendarId}/events/import. Unlike
+// insert it keeps the event's iCalUID, which is required, and importing an
+// iCalUID the calendar already has replaces that event under its existing ID.
+func (s *Server) importEvent(w http.ResponseWriter, r *http.Request, calendarID string) {
+	var event calendar.Event
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "parseError", fmt.Sprintf("invalid JSON: %v", err))
+		return
+	}
+	if event.ICalUID == "" {
+		writeAPIError(w, http.StatusBadRequest, "required", "Missing iCalUID.")
+		return
+	}
+	if err := validateTimeRange(&event); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var existing *calendar.Event
+	for _, evt := range s.events[calendarID] {
+		if evt.ICalUID == event.ICalUID && evt.RecurringEventId == "" {
+			existing = evt
+			break
+		}
+	}
+	if existing != nil {
+		event.Id = existing.Id
+		event.Created = existing.Created
+	} else {
+		event.Id = s.generateID()
+		event.Created = s.clock().Format(time.RFC3339)
+	}
+
+	if event.Status == "" {
+		event.Status = "confirmed"
+	}
+	event.Updated = s.clock().Format(time.RFC3339)
+	event.HtmlLink = fmt.Sprintf("https://calendar.google.com/event?eid=%s", event.Id)
+
+	if s.events[calendarID] == nil {
+		s.events[calendarID] = make(map[string]*calendar.Event)
+	}
+	s.events[calendarID][event.Id] = &event
+	s.recordChange(calendarID, event.Id)
+	event.Etag = s.etag()
+
+	s.writeJSON(w, r, event)
+}
+
+// quickAddEvent handles POST /calendars/{calendarId}/events/quickAdd. Rather
+// than parsing natural language, it creates an untimed event whose summary is
+// the text.
+func (s *Server) quickAddEvent(w http.ResponseWriter, r *http.Request, calendarID string) {
+	text := r.URL.Query().Get("text")
+	if text == "" {
+		writeAPIError(w, http.StatusBadRequest, "required", "Required parameter: text")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event := &calendar.Event{
+		Id:      s.generateID(),
+		Summary: text,
+		Status:  "confirmed",
+		Created: s.clock().Format(time.RFC3339),
+	}
+	event.Updated = event.Created
+	event.ICalUID = event.Id + "@google.com"
+	event.HtmlLink = fmt.Sprintf("https://calendar.google.com/event?eid=%s", event.Id)
+
+	if s.events[calendarID] == nil {
+		s.events[calendarID] = make(map[string]*calendar.Event)
+	}
+	s.events[calendarID][event.Id] = event
+	s.recordChange(calendarID, event.Id)
+	event.Etag = s.etag()
+
+	s.writeJSON(w, r, event)
 }
 
 // listEvents handles GET /calendars/{calendarId}/events
@@ -146,37 +409,123 @@ func (s *Server) listEvents(w http.ResponseWriter, r *http.Request, calendarID s
 	query := r.URL.Query()
 	timeMin := query.Get("timeMin")
 	timeMax := query.Get("timeMax")
+	updatedMin := query.Get("updatedMin")
+	q := query.Get("q")
+	privateProps := query["privateExtendedProperty"]
+	sharedProps := query["sharedExtendedProperty"]
+	iCalUID := query.Get("iCalUID")
+	maxAttendees, err := parseMaxAttendees(query)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid", err.Error())
+		return
+	}
+	loc, err := parseTimeZone(query)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid", err.Error())
+		return
+	}
+	// All-day dates are days in the requested zone, or the calendar's own
+	dayZone := loc
+	if dayZone == nil {
+		dayZone = s.calendarLocation(calendarID)
+	}
 	maxResults := query.Get("maxResults")
 	pageToken := query.Get("pageToken")
 	singleEvents := query.Get("singleEvents")
 	orderBy := query.Get("orderBy")
+	showDeleted := query.Get("showDeleted") == "true"
+
+	// Extended property filters must each be propertyName=value
+	for _, filter := range append(append([]string{}, privateProps...), sharedProps...) {
+		if key, _, ok := strings.Cut(filter, "="); !ok || key == "" {
+			writeAPIError(w, http.StatusBadRequest, "invalid", fmt.Sprintf("invalid extended property filter %q: expected propertyName=value", filter))
+			return
+		}
+	}
+
+	// Incremental sync returns only what changed since the token, deletions
+	// included; stale or unknown tokens require a full resync, as with Google
+	var syncSince int64
+	syncing := query.Get("syncToken") != ""
+	if syncing {
+		seq, ok := s.parseSyncToken(query.Get("syncToken"))
+		if !ok {
+			writeAPIError(w, http.StatusGone, "fullSyncRequired", "sync token is no longer valid, a full sync is required")
+			return
+		}
+		syncSince = seq
+		showDeleted = true
+	}
 
 	// Get all events for calendar
 	calEvents := s.events[calendarID]
 	if calEvents == nil {
 		calEvents = make(map[string]*calendar.Event)
 	}
+	if syncing {
+		calEvents = make(map[string]*calendar.Event)
+		for _, evt := range s.changedSince(calendarID, syncSince) {
+			calEvents[evt.Id] = evt
+		}
+	}
 
-	// Convert to slice for filtering/sorting
-	var events []*calendar.Event
+	// Convert to slice for filtering/sorting, expanding recurring events into
+	// their instances when singleEvents=true. Stored exceptions replace (or, when
+	// cancelled, remove) the generated instance they override.
+	var candidates []*calendar.Event
+	exceptions := collectExceptions(calEvents)
 	for _, evt := range calEvents {
-		// Apply time filters
-		if timeMin != "" && evt.Start != nil && evt.Start.DateTime != "" {
-			if evt.Start.DateTime < timeMin {
-				continue
-			}
+		if singleEvents != "true" {
+			candidates = append(candidates, evt)
+			continue
+		}
+		if _, isException := exceptions[instanceKey(evt.RecurringEventId, evt.OriginalStartTime)]; isException && calEvents[evt.RecurringEventId] != nil {
+			// Surfaced through its series' expansion below
+			continue
+		}
+		if len(evt.Recurrence) > 0 {
+			from, limit := expansionWindow(timeMin, timeMax)
+			candidates = append(candidates, applyExceptions(expandEvent(evt, from, limit), exceptions, showDeleted)...)
+			continue
 		}
-		if timeMax != "" && evt.Start != nil && evt.Start.DateTime != "" {
-			if evt.Start.DateTime > timeMax {
+		candidates = append(candidates, evt)
+	}
+
+	// Apply time filters to the expanded set so instances are bounded by their
+	// own start rather than the master's
+	var events []*calendar.Event
+	for _, evt := range candidates {
+		// Cancelled events (tombstones) are only returned when asked for
+		if evt.Status == "cancelled" && !showDeleted {
+			continue
+		}
+		if !syncing && !inTimeRange(evt, timeMin, timeMax, dayZone) {
+			continue
+		}
+		// updatedMin keeps only events modified at or after the cutoff
+		if updatedMin != "" {
+			minUpdated, err := time.Parse(time.RFC3339, updatedMin)
+			updated, updatedErr := time.Parse(time.RFC3339, evt.Updated)
+			if err == nil && updatedErr == nil && updated.Before(minUpdated) {
 				continue
 			}
 		}
+		if q != "" && !matchesQuery(evt, q) {
+			continue
+		}
+		if !matchesProperties(evt, privateProps, sharedProps) {
+			continue
+		}
+		if iCalUID != "" && evt.ICalUID != iCalUID {
+			continue
+		}
 		events = append(events, evt)
 	}
 
-	// Sort events
+	// Sort events, breaking start time ties by ID so events sharing a start
+	// come back in the same order on every request
 	if orderBy == "startTime" && singleEvents == "true" {
-		sort.Slice(events, func(i, j int) bool {
+		sort.SliceStable(events, func(i, j int) bool {
 			iTime := ""
 			jTime := ""
 			if events[i].Start != nil {
@@ -191,43 +540,339 @@ func (s *Server) listEvents(w http.ResponseWriter, r *http.Request, calendarID s
 					jTime = events[j].Start.Date
 				}
 			}
-			return iTime < jTime
+			if iTime != jTime {
+				return iTime < jTime
+			}
+			return events[i].Id < events[j].Id
+		})
+	} else {
+		// Map iteration order is random, so fall back to ID order to keep
+		// page boundaries stable across requests
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].Id < events[j].Id
 		})
 	}
 
 	// Handle pagination
+	pagedEvents, next, err := paginate(events, pageToken, maxResults)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid", err.Error())
+		return
+	}
+
+	// Shape copies of the events for output, leaving the stored ones intact
+	if maxAttendees > 0 || loc != nil {
+		shaped := make([]*calendar.Event, len(pagedEvents))
+		for i, evt := range pagedEvents {
+			if maxAttendees > 0 {
+				evt = s.limitAttendees(evt, maxAttendees)
+			}
+			if loc != nil {
+				evt = inTimeZone(evt, loc)
+			}
+			shaped[i] = evt
+		}
+		pagedEvents = shaped
+	}
+
+	// Build response
+	resp := s.eventsResponse(calendarID, pagedEvents)
+	if loc != nil {
+		resp.TimeZone = loc.String()
+	}
+
+	// Add next page token if there are more results; the last page carries the
+	// token for the next incremental sync instead
+	if next > 0 {
+		resp.NextPageToken = encodePageToken(next)
+	} else {
+		resp.NextSyncToken = s.syncToken()
+	}
+
+	s.writeJSON(w, r, resp)
+}
+
+// expansionWindow returns the bounds to expand recurring series within for a
+// list request's timeMin and timeMax, either of which may be empty. The limit
+// is just past timeMax so an instance starting exactly at it is kept.
+func expansionWindow(timeMin, timeMax string) (from, limit time.Time) {
+	if t, err := time.Parse(time.RFC3339, timeMin); err == nil {
+		from = t
+	}
+	if t, err := time.Parse(time.RFC3339, timeMax); err == nil {
+		limit = t.Add(time.Nanosecond)
+	}
+	return from, limit
+}
+
+// inTimeRange reports whether evt falls within a list request's timeMin and
+// timeMax, either of which may be empty. Timed events are bounded by their
+// start; all-day events cover whole days from midnight in dayZone, so they
+// match any window that overlaps that span.
+func inTimeRange(evt *calendar.Event, timeMin, timeMax string, dayZone *time.Location) bool {
+	if evt.Start != nil && evt.Start.DateTime != "" {
+		start, ok := eventDateTime(evt.Start)
+		if ok && timeMin != "" {
+			if minTime, err := time.Parse(time.RFC3339, timeMin); err == nil && start.Before(minTime) {
+				return false
+			}
+		}
+		if ok && timeMax != "" {
+			if maxTime, err := time.Parse(time.RFC3339, timeMax); err == nil && start.After(maxTime) {
+				return false
+			}
+		}
+	} else if evt.Start != nil && evt.Start.Date != "" {
+		start, ok := dateInZone(evt.Start, dayZone)
+		end, endOK := dateInZone(evt.End, dayZone)
+		if !endOK || !end.After(start) {
+			end = start.AddDate(0, 0, 1)
+		}
+		if ok && timeMin != "" {
+			if minTime, err := time.Parse(time.RFC3339, timeMin); err == nil && !end.After(minTime) {
+				return false
+			}
+		}
+		if ok && timeMax != "" {
+			if maxTime, err := time.Parse(time.RFC3339, timeMax); err == nil && !start.Before(maxTime) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// paginate returns the page of events a list request's pageToken and
+// maxResults select, and the index the next page starts at (zero on the last
+// page). Like Google, pages default to 250 results and are capped at 2500.
+func paginate(events []*calendar.Event, pageToken, maxResults string) ([]*calendar.Event, int, error) {
 	startIdx := 0
 	if pageToken != "" {
-		// Simple pagination: token is the start index
-		fmt.Sscanf(pageToken, "%d", &startIdx)
+		idx, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, 0, err
+		}
+		startIdx = idx
 	}
 
-	maxRes := len(events)
+	maxRes := defaultMaxResults
 	if maxResults != "" {
-		fmt.Sscanf(maxResults, "%d", &maxRes)
+		n, err := strconv.Atoi(maxResults)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid maxResults %q", maxResults)
+		}
+		maxRes = min(max(n, 1), maxMaxResults)
 	}
 
-	endIdx := startIdx + maxRes
-	if endIdx > len(events) {
-		endIdx = len(events)
+	startIdx = min(startIdx, len(events))
+	endIdx := min(startIdx+maxRes, len(events))
+	if endIdx == len(events) {
+		return events[startIdx:endIdx], 0, nil
 	}
+	return events[startIdx:endIdx], endIdx, nil
+}
 
-	pagedEvents := events[startIdx:endIdx]
-
-	// Build response
+// eventsResponse wraps items in an events list response carrying the
+// calendar's title, time zone, and the caller's access role, as Google does.
+// Calendars without metadata are described by their ID, in UTC, with owner
+// access. Callers must hold s.mu.
+func (s *Server) eventsResponse(calendarID string, items []*calendar.Event) *calendar.Events {
 	resp := &calendar.Events{
-		Kind:    "calendar#events",
-		Summary: calendarID,
-		Items:   pagedEvents,
+		Kind:       "calendar#events",
+		Summary:    calendarID,
+		TimeZone:   "UTC",
+		AccessRole: "owner",
+		Items:      items,
+	}
+	if entry := s.calendarEntry(calendarID); entry != nil {
+		resp.Summary = entry.Summary
+		resp.AccessRole = entry.AccessRole
+		if entry.TimeZone != "" {
+			resp.TimeZone = entry.TimeZone
+		}
 	}
+	return resp
+}
 
-	// Add next page token if there are more results
-	if endIdx < len(events) {
-		resp.NextPageToken = fmt.Sprintf("%d", endIdx)
+// matchesQuery reports whether q appears, case-insensitively, in the event's
+// summary, description, location, or any attendee's email or display name.
+func matchesQuery(evt *calendar.Event, q string) bool {
+	q = strings.ToLower(q)
+	fields := []string{evt.Summary, evt.Description, evt.Location}
+	for _, attendee := range evt.Attendees {
+		fields = append(fields, attendee.Email, attendee.DisplayName)
 	}
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), q) {
+			return true
+		}
+	}
+	return false
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+// matchesProperties reports whether the event's extended properties include
+// every privateExtendedProperty and sharedExtendedProperty filter, each given
+// as propertyName=value.
+func matchesProperties(evt *calendar.Event, private, shared []string) bool {
+	var privateProps, sharedProps map[string]string
+	if evt.ExtendedProperties != nil {
+		privateProps, sharedProps = evt.ExtendedProperties.Private, evt.ExtendedProperties.Shared
+	}
+	for _, set := range []struct {
+		filters []string
+		props   map[string]string
+	}{{private, privateProps}, {shared, sharedProps}} {
+		for _, filter := range set.filters {
+			key, value, _ := strings.Cut(filter, "=")
+			if got, ok := set.props[key]; !ok || got != value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// parseMaxAttendees reads the maxAttendees query parameter, returning 0 when
+// it is absent.
+func parseMaxAttendees(query url.Values) (int, error) {
+	value := query.Get("maxAttendees")
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid maxAttendees %q", value)
+	}
+	return n, nil
+}
+
+// limitAttendees returns evt unchanged if it has at most n attendees.
+// Otherwise it returns a copy listing only the first n, with AttendeesOmitted
+// set as Google does. The signed-in user's own entry (Self, or the current
+// user's email) is always kept, displacing the last of the others if needed.
+// Callers must hold s.mu.
+func (s *Server) limitAttendees(evt *calendar.Event, n int) *calendar.Event {
+	if len(evt.Attendees) <= n {
+		return evt
+	}
+
+	isSelf := func(a *calendar.EventAttendee) bool {
+		return a.Self || strings.EqualFold(a.Email, s.currentUser)
+	}
+	room := n
+	if slices.ContainsFunc(evt.Attendees, isSelf) {
+		room--
+	}
+
+	// Keep the user and the first others that fit, in their original order
+	kept := make([]*calendar.EventAttendee, 0, n)
+	selfKept := false
+	for _, attendee := range evt.Attendees {
+		switch {
+		case !selfKept && isSelf(attendee):
+			selfKept = true
+		case room > 0:
+			room--
+		default:
+			continue
+		}
+		kept = append(kept, attendee)
+	}
+
+	limited := *evt
+	limited.Attendees = kept
+	limited.AttendeesOmitted = true
+	return &limited
+}
+
+// parseTimeZone reads the timeZone query parameter, returning nil when it is
+// unset and an error if it isn't an IANA time zone name.
+func parseTimeZone(query url.Values) (*time.Location, error) {
+	name := query.Get("timeZone")
+	if name == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "Local" {
+		return nil, fmt.Errorf("invalid timeZone %q", name)
+	}
+	return loc, nil
+}
+
+// dateInZone reads an all-day date as midnight in loc
+func dateInZone(dt *calendar.EventDateTime, loc *time.Location) (time.Time, bool) {
+	if dt == nil || dt.Date == "" {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation("2006-01-02", dt.Date, loc)
+	return t, err == nil
+}
+
+// inTimeZone returns a copy of evt with its start, end, and original start
+// date-times rendered in loc and labelled with its name. All-day dates are
+// left as they are.
+func inTimeZone(evt *calendar.Event, loc *time.Location) *calendar.Event {
+	convert := func(dt *calendar.EventDateTime) *calendar.EventDateTime {
+		if dt == nil || dt.DateTime == "" {
+			return dt
+		}
+		t, err := time.Parse(time.RFC3339, dt.DateTime)
+		if err != nil {
+			return dt
+		}
+		converted := *dt
+		converted.DateTime = t.In(loc).Format(time.RFC3339)
+		converted.TimeZone = loc.String()
+		return &converted
+	}
+
+	rendered := *evt
+	rendered.Start = convert(evt.Start)
+	rendered.End = convert(evt.End)
+	rendered.OriginalStartTime = convert(evt.OriginalStartTime)
+	return &rendered
+}
+
+// listInstances handles GET /calendars/{calendarId}/events/{eventId}/instances
+func (s *Server) listInstances(w http.ResponseWriter, r *http.Request, calendarID, eventID string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	calEvents := s.events[calendarID]
+	if calEvents == nil {
+		writeAPIError(w, http.StatusNotFound, "notFound", "calendar not found")
+		return
+	}
+
+	master := calEvents[eventID]
+	if master == nil || len(master.Recurrence) == 0 {
+		writeAPIError(w, http.StatusNotFound, "notFound", "recurring event not found")
+		return
+	}
+
+	// Window and page the instances as listEvents does
+	query := r.URL.Query()
+	timeMin, timeMax := query.Get("timeMin"), query.Get("timeMax")
+	from, limit := expansionWindow(timeMin, timeMax)
+	dayZone := s.calendarLocation(calendarID)
+	var instances []*calendar.Event
+	for _, instance := range applyExceptions(expandEvent(master, from, limit), collectExceptions(calEvents), query.Get("showDeleted") == "true") {
+		if inTimeRange(instance, timeMin, timeMax, dayZone) {
+			instances = append(instances, instance)
+		}
+	}
+
+	page, next, err := paginate(instances, query.Get("pageToken"), query.Get("maxResults"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid", err.Error())
+		return
+	}
+	resp := s.eventsResponse(calendarID, page)
+	if next > 0 {
+		resp.NextPageToken = encodePageToken(next)
+	}
+
+	s.writeJSON(w, r, resp)
 }
 
 // getEvent handles GET /calendars/{calendarId}/events/{eventId}
@@ -237,53 +882,187 @@ func (s *Server) getEvent(w http.ResponseWriter, r *http.Request, calendarID, ev
 
 	calEvents := s.events[calendarID]
 	if calEvents == nil {
-		http.Error(w, "calendar not found", http.StatusNotFound)
+		writeAPIError(w, http.StatusNotFound, "notFound", "calendar not found")
 		return
 	}
 
 	event := calEvents[eventID]
 	if event == nil {
-		http.Error(w, "event not found", http.StatusNotFound)
+		event = generatedInstance(calEvents, eventID)
+	}
+	if event == nil {
+		writeAPIError(w, http.StatusNotFound, "notFound", "event not found")
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(event)
+	maxAttendees, err := parseMaxAttendees(r.URL.Query())
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid", err.Error())
+		return
+	}
+	loc, err := parseTimeZone(r.URL.Query())
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid", err.Error())
+		return
+	}
+	if maxAttendees > 0 {
+		event = s.limitAttendees(event, maxAttendees)
+	}
+	if loc != nil {
+		event = inTimeZone(event, loc)
+	}
+
+	s.writeJSON(w, r, event)
 }
 
 // updateEvent handles PUT/PATCH /calendars/{calendarId}/events/{eventId}
 func (s *Server) updateEvent(w http.ResponseWriter, r *http.Request, calendarID, eventID string) {
+	conferenceVersion := r.URL.Query().Get("conferenceDataVersion")
+	if err := validateConferenceDataVersion(conferenceVersion); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid", err.Error())
+		return
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	calEvents := s.events[calendarID]
 	if calEvents == nil {
-		http.Error(w, "calendar not found", http.StatusNotFound)
+		writeAPIError(w, http.StatusNotFound, "notFound", "calendar not found")
 		return
 	}
 
+	// Updating an occurrence of a series stores it as an exception
 	existing := calEvents[eventID]
 	if existing == nil {
-		http.Error(w, "event not found", http.StatusNotFound)
+		existing = generatedInstance(calEvents, eventID)
+	}
+	if existing == nil {
+		writeAPIError(w, http.StatusNotFound, "notFound", "event not found")
+		return
+	}
+	if !etagMatches(r, existing) {
+		writeAPIError(w, http.StatusPreconditionFailed, "conditionNotMet", "Precondition Failed")
 		return
 	}
 
+	// PUT replaces the whole event; PATCH merges only the fields present in
+	// the body, so omitted fields keep their current values
 	var updates calendar.Event
-	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
-		http.Error(w, fmt.Sprintf("invalid JSON: %v", err), http.StatusBadRequest)
+	var err error
+	if r.Method == http.MethodPatch {
+		updates, err = patchEvent(existing, r.Body)
+	} else {
+		err = json.NewDecoder(r.Body).Decode(&updates)
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "parseError", fmt.Sprintf("invalid JSON: %v", err))
+		return
+	}
+	if err := validateTimeRange(&updates); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid", err.Error())
 		return
 	}
 
 	// Preserve ID and metadata
 	updates.Id = eventID
 	updates.Created = existing.Created
-	updates.Updated = time.Now().Format(time.RFC3339)
+	updates.Updated = s.clock().Format(time.RFC3339)
 	updates.HtmlLink = existing.HtmlLink
+	if existing.RecurringEventId != "" {
+		updates.RecurringEventId = existing.RecurringEventId
+		updates.OriginalStartTime = existing.OriginalStartTime
+	}
+	updateConferenceData(existing, &updates, conferenceVersion)
 
 	calEvents[eventID] = &updates
+	s.recordChange(calendarID, eventID)
+	updates.Etag = s.etag()
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(updates)
+	s.writeJSON(w, r, updates)
+}
+
+// etagMatches reports whether a request's If-Match precondition, if any,
+// holds for the event's current ETag.
+func etagMatches(r *http.Request, event *calendar.Event) bool {
+	match := r.Header.Get("If-Match")
+	return match == "" || match == "*" || match == event.Etag
+}
+
+// validateTimeRange rejects events whose end precedes their start, comparing
+// only like with like (both dateTime or both date), as Google does.
+func validateTimeRange(event *calendar.Event) error {
+	if event.Start == nil || event.End == nil {
+		return nil
+	}
+	var start, end time.Time
+	var startErr, endErr error
+	switch {
+	case event.Start.DateTime != "" && event.End.DateTime != "":
+		start, startErr = time.Parse(time.RFC3339, event.Start.DateTime)
+		end, endErr = time.Parse(time.RFC3339, event.End.DateTime)
+	case event.Start.Date != "" && event.End.Date != "":
+		start, startErr = time.Parse("2006-01-02", event.Start.Date)
+		end, endErr = time.Parse("2006-01-02", event.End.Date)
+	default:
+		return nil
+	}
+	if startErr == nil && endErr == nil && end.Before(start) {
+		return fmt.Errorf("the specified time range is empty: end (%s) is before start (%s)",
+			end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// patchEvent overlays the fields present in body onto a copy of existing,
+// merging nested objects (such as extendedProperties) key by key. A field sent
+// as null is cleared, as with Google's PATCH.
+func patchEvent(existing *calendar.Event, body io.Reader) (calendar.Event, error) {
+	var patch json.RawMessage
+	if err := json.NewDecoder(body).Decode(&patch); err != nil {
+		return calendar.Event{}, err
+	}
+
+	current, err := json.Marshal(existing)
+	if err != nil {
+		return calendar.Event{}, err
+	}
+	data, err := mergePatch(current, patch)
+	if err != nil {
+		return calendar.Event{}, err
+	}
+
+	var event calendar.Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		return calendar.Event{}, err
+	}
+	return event, nil
+}
+
+// mergePatch applies patch to current as a JSON merge patch (RFC 7396):
+// objects merge recursively, null removes a key, and anything else replaces
+// the current value.
+func mergePatch(current, patch json.RawMessage) (json.RawMessage, error) {
+	var patchFields map[string]json.RawMessage
+	if json.Unmarshal(patch, &patchFields) != nil || patchFields == nil {
+		return patch, nil
+	}
+	var merged map[string]json.RawMessage
+	if json.Unmarshal(current, &merged) != nil || merged == nil {
+		merged = map[string]json.RawMessage{}
+	}
+	for key, value := range patchFields {
+		if string(value) == "null" {
+			delete(merged, key)
+			continue
+		}
+		next, err := mergePatch(merged[key], value)
+		if err != nil {
+			return nil, err
+		}
+		merged[key] = next
+	}
+	return json.Marshal(merged)
 }
 
 // deleteEvent handles DELETE /calendars/{calendarId}/events/{eventId}
@@ -293,25 +1072,135 @@ func (s *Server) deleteEvent(w http.ResponseWriter, r *http.Request, calendarID,
 
 	calEvents := s.events[calendarID]
 	if calEvents == nil {
-		http.Error(w, "calendar not found", http.StatusNotFound)
+		writeAPIError(w, http.StatusNotFound, "notFound", "calendar not found")
 		return
 	}
 
-	if calEvents[eventID] == nil {
-		http.Error(w, "event not found", http.StatusNotFound)
+	existing := calEvents[eventID]
+	if existing == nil {
+		existing = generatedInstance(calEvents, eventID)
+	}
+	if existing == nil {
+		writeAPIError(w, http.StatusNotFound, "notFound", "event not found")
+		return
+	}
+	if !etagMatches(r, existing) {
+		writeAPIError(w, http.StatusPreconditionFailed, "conditionNotMet", "Precondition Failed")
 		return
 	}
 
-	delete(calEvents, eventID)
+	// Occurrences of a series are always cancelled rather than removed, so the
+	// series doesn't generate them again
+	if s.softDelete || existing.RecurringEventId != "" {
+		// Keep a tombstone, as Google does, so showDeleted listings can see it
+		if existing.Status == "cancelled" {
+			writeAPIError(w, http.StatusGone, "deleted", "resource has been deleted")
+			return
+		}
+		existing.Status = "cancelled"
+		existing.Updated = s.clock().Format(time.RFC3339)
+		calEvents[eventID] = existing
+		s.recordChange(calendarID, eventID)
+		existing.Etag = s.etag()
+	} else {
+		delete(calEvents, eventID)
+		s.recordRemoval(calendarID, existing)
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// Reset clears all events from the server.
+// SoftDelete controls whether deletes remove events (the default) or mark them
+// as cancelled tombstones that are listed only with showDeleted=true.
+func (s *Server) SoftDelete(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.softDelete = enabled
+}
+
+// SetClock sets the function the server reads the current time from when it
+// stamps Created and Updated times and expires watch channels, so tests can
+// freeze or advance time. A nil clock restores time.Now.
+func (s *Server) SetClock(clock func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if clock == nil {
+		clock = time.Now
+	}
+	s.clock = clock
+}
+
+// StrictCalendars sets whether event requests for unknown calendars fail with
+// a 404 "notFound", as Google does, rather than acting on an empty calendar
+// (the default). Known calendars are primary (or the current user's email),
+// those added with AddCalendar, and any that AddEvent or LoadFixture has put
+// events in.
+func (s *Server) StrictCalendars(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.strictCalendars = enabled
+}
+
+// InjectResponseField adds an arbitrary field to every JSON response (and to
+// each item of list responses), simulating fields a newer API version might
+// return, so tests can prove clients ignore them.
+func (s *Server) InjectResponseField(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.extraFields == nil {
+		s.extraFields = make(map[string]any)
+	}
+	s.extraFields[key] = value
+}
+
+// AllocatedIDCount returns how many IDs the server has generated (for inserts,
+// and AddEvent calls without an ID) since it started or was last Reset. IDs
+// loaded from fixtures aren't counted.
+func (s *Server) AllocatedIDCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.allocatedIDs
+}
+
+// generateID returns the next "eventN" event ID. Callers must hold s.mu.
+func (s *Server) generateID() string {
+	id := fmt.Sprintf("event%d", s.nextID)
+	s.nextID++
+	s.allocatedIDs++
+	return id
+}
+
+// Reset returns the server to the state NewServer leaves it in: it clears
+// all events, calendars, channels, and recorded requests, and undoes every
+// setting, including faults, latency, auth, SoftDelete, StrictCalendars,
+// SetClock, and SetCurrentUser. Sync tokens issued before it expire.
 func (s *Server) Reset() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.events = make(map[string]map[string]*calendar.Event)
 	s.nextID = 1
+	s.allocatedIDs = 0
+	s.changedAt = make(map[string]map[string]int64)
+	s.tombstones = make(map[string]map[string]*calendar.Event)
+	s.requests = nil
+	s.extraFields = nil
+	s.dropAfterBytes = -1
+	s.dropAfterItems = -1
+	s.itemsServed.Store(0)
+	s.failRemaining = 0
+	s.latency = 0
+	s.onRequest = nil
+	s.requiredToken = ""
+	s.lastAuthToken = ""
+	s.channels = make(map[string]*watchChannel)
+	s.calendars = make(map[string]*calendar.CalendarListEntry)
+	s.softDelete = false
+	s.strictCalendars = false
+	s.clock = time.Now
+	s.currentUser = defaultCurrentUser
+
+	// Expire every token issued so far
+	s.changeSeq++
+	s.minSyncSeq = s.changeSeq
 }
 
 // GetEvents returns all events for a calendar (for test assertions).
@@ -332,17 +1221,34 @@ func (s *Server) GetEvents(calendarID string) []*calendar.Event {
 }
 
 // AddEvent adds a pre-configured event to the server (for test setup).
+// Events with RecurringEventId and OriginalStartTime set are treated as
+// exceptions to that series: when listing with singleEvents=true they replace
+// the matching generated instance, or remove it if their Status is "cancelled".
 func (s *Server) AddEvent(calendarID string, event *calendar.Event) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	// Instance overrides take the ID Google assigns to the occurrence they replace
+	if event.Id == "" && event.RecurringEventId != "" && event.OriginalStartTime != nil {
+		if t, ok := eventDateTime(event.OriginalStartTime); ok {
+			if event.OriginalStartTime.DateTime != "" {
+				event.Id = fmt.Sprintf("%s_%s", event.RecurringEventId, t.UTC().Format("20060102T150405Z"))
+			} else {
+				event.Id = fmt.Sprintf("%s_%s", event.RecurringEventId, t.Format("20060102"))
+			}
+		}
+	}
+
 	if event.Id == "" {
-		event.Id = fmt.Sprintf("event%d", s.nextID)
-		s.nextID++
+		event.Id = s.generateID()
 	}
 
 	if s.events[calendarID] == nil {
 		s.events[calendarID] = make(map[string]*calendar.Event)
 	}
 	s.events[calendarID][event.Id] = event
+	s.recordChange(calendarID, event.Id)
+	if event.Etag == "" {
+		event.Etag = s.etag()
+	}
 }
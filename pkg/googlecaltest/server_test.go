@@ -1,12 +1,22 @@
 package googlecaltest
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"golang.org/x/oauth2"
 	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 )
 
@@ -266,3 +276,2558 @@ func TestMockServer_Reset(t *testing.T) {
 		t.Errorf("expected 0 events after reset, got %d", len(events.Items))
 	}
 }
+
+func TestMockServer_ListEventsExpandsRecurringWithinWindow(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	// Daily series that started last week
+	thisWeek := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	lastWeek := thisWeek.AddDate(0, 0, -7)
+	server.AddEvent("primary", &calendar.Event{
+		Id:      "standup",
+		Summary: "Daily Standup",
+		Start: &calendar.EventDateTime{
+			DateTime: lastWeek.Add(9 * time.Hour).Format(time.RFC3339),
+		},
+		End: &calendar.EventDateTime{
+			DateTime: lastWeek.Add(9*time.Hour + 15*time.Minute).Format(time.RFC3339),
+		},
+		Recurrence: []string{"RRULE:FREQ=DAILY"},
+	})
+
+	// List a window covering this week only
+	events, err := svc.Events.List("primary").
+		SingleEvents(true).
+		OrderBy("startTime").
+		TimeMin(thisWeek.Format(time.RFC3339)).
+		TimeMax(thisWeek.AddDate(0, 0, 7).Format(time.RFC3339)).
+		Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+
+	if len(events.Items) != 7 {
+		t.Fatalf("expected 7 instances within the window, got %d", len(events.Items))
+	}
+
+	for i, item := range events.Items {
+		if item.RecurringEventId != "standup" {
+			t.Errorf("instance %d: expected recurringEventId 'standup', got %q", i, item.RecurringEventId)
+		}
+		if len(item.Recurrence) != 0 {
+			t.Errorf("instance %d: expected no recurrence on expanded instance", i)
+		}
+		want := thisWeek.AddDate(0, 0, i).Add(9 * time.Hour).Format(time.RFC3339)
+		if item.Start.DateTime != want {
+			t.Errorf("instance %d: expected start %s, got %s", i, want, item.Start.DateTime)
+		}
+	}
+}
+
+func TestRRule_SkipsMissingDays(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		want  []string
+	}{
+		{
+			name:  "monthly on the 31st",
+			rule:  "RRULE:FREQ=MONTHLY;COUNT=4",
+			start: time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC),
+			want:  []string{"2024-01-31", "2024-03-31", "2024-05-31", "2024-07-31"},
+		},
+		{
+			name:  "yearly on Feb 29",
+			rule:  "RRULE:FREQ=YEARLY;COUNT=2",
+			start: time.Date(2024, time.February, 29, 9, 0, 0, 0, time.UTC),
+			want:  []string{"2024-02-29", "2028-02-29"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("parseRRule() failed: %v", err)
+			}
+			var got []string
+			for _, occurrence := range rule.occurrences(tt.start, time.Time{}, time.Time{}) {
+				got = append(got, occurrence.Format("2006-01-02"))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("occurrences = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMockServer_RecurrenceExceptions(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	start := time.Date(2024, time.January, 15, 9, 0, 0, 0, time.UTC)
+	server.AddEvent("primary", &calendar.Event{
+		Id:      "standup",
+		Summary: "Daily Standup",
+		Start: &calendar.EventDateTime{
+			DateTime: start.Format(time.RFC3339),
+		},
+		End: &calendar.EventDateTime{
+			DateTime: start.Add(15 * time.Minute).Format(time.RFC3339),
+		},
+		Recurrence: []string{"RRULE:FREQ=DAILY;COUNT=5"},
+	})
+
+	// Cancel the second occurrence
+	server.AddEvent("primary", &calendar.Event{
+		RecurringEventId: "standup",
+		OriginalStartTime: &calendar.EventDateTime{
+			DateTime: start.AddDate(0, 0, 1).Format(time.RFC3339),
+		},
+		Status: "cancelled",
+	})
+
+	// Modify the third occurrence
+	server.AddEvent("primary", &calendar.Event{
+		RecurringEventId: "standup",
+		OriginalStartTime: &calendar.EventDateTime{
+			DateTime: start.AddDate(0, 0, 2).Format(time.RFC3339),
+		},
+		Summary: "Standup (moved)",
+		Status:  "confirmed",
+		Start: &calendar.EventDateTime{
+			DateTime: start.AddDate(0, 0, 2).Add(time.Hour).Format(time.RFC3339),
+		},
+		End: &calendar.EventDateTime{
+			DateTime: start.AddDate(0, 0, 2).Add(time.Hour + 15*time.Minute).Format(time.RFC3339),
+		},
+	})
+
+	events, err := svc.Events.List("primary").SingleEvents(true).OrderBy("startTime").Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+
+	if len(events.Items) != 4 {
+		t.Fatalf("expected 4 instances after cancelling one, got %d", len(events.Items))
+	}
+
+	cancelledID := "standup_" + start.AddDate(0, 0, 1).Format("20060102T150405Z")
+	modifiedID := "standup_" + start.AddDate(0, 0, 2).Format("20060102T150405Z")
+	foundModified := false
+	for _, item := range events.Items {
+		if item.Id == cancelledID {
+			t.Errorf("expected cancelled instance %s to be omitted", cancelledID)
+		}
+		if item.Id == modifiedID {
+			foundModified = true
+			if item.Summary != "Standup (moved)" {
+				t.Errorf("expected modified instance summary 'Standup (moved)', got %q", item.Summary)
+			}
+		}
+	}
+	if !foundModified {
+		t.Errorf("expected modified instance %s in results", modifiedID)
+	}
+
+	// The instances endpoint applies the same exceptions
+	instances, err := svc.Events.Instances("primary", "standup").Do()
+	if err != nil {
+		t.Fatalf("failed to list instances: %v", err)
+	}
+	if len(instances.Items) != 4 {
+		t.Errorf("expected 4 instances from instances endpoint, got %d", len(instances.Items))
+	}
+}
+
+func TestMockServer_InstanceByID(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	server.AddEvent("primary", &calendar.Event{
+		Id:         "weekly",
+		Summary:    "Weekly",
+		Start:      &calendar.EventDateTime{DateTime: "2024-01-01T09:00:00Z"},
+		End:        &calendar.EventDateTime{DateTime: "2024-01-01T10:00:00Z"},
+		Recurrence: []string{"RRULE:FREQ=WEEKLY;COUNT=3"},
+	})
+
+	instance, err := svc.Events.Get("primary", "weekly_20240108T090000Z").Do()
+	if err != nil {
+		t.Fatalf("failed to get instance: %v", err)
+	}
+	if instance.RecurringEventId != "weekly" || instance.Start.DateTime != "2024-01-08T09:00:00Z" {
+		t.Errorf("unexpected instance: %+v", instance)
+	}
+
+	// Patching the instance stores it as an exception
+	if _, err := svc.Events.Patch("primary", instance.Id, &calendar.Event{Summary: "Skipped"}).Do(); err != nil {
+		t.Fatalf("failed to patch instance: %v", err)
+	}
+	events, err := svc.Events.List("primary").SingleEvents(true).OrderBy("startTime").Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	var summaries []string
+	for _, e := range events.Items {
+		summaries = append(summaries, e.Summary)
+	}
+	if got := strings.Join(summaries, ","); got != "Weekly,Skipped,Weekly" {
+		t.Errorf("instances = %q, want Weekly,Skipped,Weekly", got)
+	}
+
+	// Deleting an occurrence cancels it, even without soft deletes
+	if err := svc.Events.Delete("primary", "weekly_20240115T090000Z").Do(); err != nil {
+		t.Fatalf("failed to delete instance: %v", err)
+	}
+	events, err = svc.Events.List("primary").SingleEvents(true).OrderBy("startTime").Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(events.Items) != 2 {
+		t.Errorf("expected the deleted instance gone from the list, got %d instances", len(events.Items))
+	}
+	cancelled, err := svc.Events.Get("primary", "weekly_20240115T090000Z").Do()
+	if err != nil || cancelled.Status != "cancelled" {
+		t.Errorf("expected the deleted instance kept as cancelled, got %v, %v", cancelled, err)
+	}
+
+	// IDs of occurrences the series doesn't have aren't found
+	var apiErr *googleapi.Error
+	for _, id := range []string{"weekly_20240109T090000Z", "weekly_20240122T090000Z", "weekly_junk"} {
+		if _, err := svc.Events.Get("primary", id).Do(); !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound {
+			t.Errorf("expected 404 for %s, got %v", id, err)
+		}
+	}
+}
+
+func TestMockServer_SoftDeleteShowDeleted(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	server.SoftDelete(true)
+
+	server.AddEvent("primary", &calendar.Event{Id: "keep", Summary: "Kept Event"})
+	server.AddEvent("primary", &calendar.Event{Id: "drop", Summary: "Deleted Event"})
+
+	if err := svc.Events.Delete("primary", "drop").Do(); err != nil {
+		t.Fatalf("failed to delete event: %v", err)
+	}
+
+	// Without showDeleted the tombstone is hidden
+	events, err := svc.Events.List("primary").Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(events.Items) != 1 || events.Items[0].Id != "keep" {
+		t.Errorf("expected only 'keep' without showDeleted, got %d items", len(events.Items))
+	}
+
+	// With showDeleted the tombstone is returned as cancelled
+	events, err = svc.Events.List("primary").ShowDeleted(true).Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(events.Items) != 2 {
+		t.Fatalf("expected 2 items with showDeleted, got %d", len(events.Items))
+	}
+	for _, item := range events.Items {
+		if item.Id == "drop" && item.Status != "cancelled" {
+			t.Errorf("expected deleted event status 'cancelled', got %q", item.Status)
+		}
+	}
+
+	// Hard delete remains the default
+	server.SoftDelete(false)
+	if err := svc.Events.Delete("primary", "keep").Do(); err != nil {
+		t.Fatalf("failed to delete event: %v", err)
+	}
+	events, err = svc.Events.List("primary").ShowDeleted(true).Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	for _, item := range events.Items {
+		if item.Id == "keep" {
+			t.Error("expected hard-deleted event to be gone even with showDeleted")
+		}
+	}
+}
+
+func TestMockServer_SyncToken(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	server.AddEvent("primary", &calendar.Event{Id: "existing", Summary: "Existing Event"})
+	server.AddEvent("primary", &calendar.Event{Id: "doomed", Summary: "Doomed Event"})
+
+	// A full sync returns everything plus a token for the next sync
+	full, err := svc.Events.List("primary").Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(full.Items) != 2 {
+		t.Errorf("expected 2 events in full sync, got %d", len(full.Items))
+	}
+	if full.NextSyncToken == "" {
+		t.Fatal("expected nextSyncToken on full sync")
+	}
+
+	// Nothing has changed yet
+	delta, err := svc.Events.List("primary").SyncToken(full.NextSyncToken).Do()
+	if err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+	if len(delta.Items) != 0 {
+		t.Errorf("expected empty delta, got %d items", len(delta.Items))
+	}
+
+	// One insert and one delete show up in the next delta
+	created, err := svc.Events.Insert("primary", &calendar.Event{Summary: "New Event"}).Do()
+	if err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+	if err := svc.Events.Delete("primary", "doomed").Do(); err != nil {
+		t.Fatalf("failed to delete event: %v", err)
+	}
+
+	delta, err = svc.Events.List("primary").SyncToken(full.NextSyncToken).Do()
+	if err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+	statuses := make(map[string]string)
+	for _, item := range delta.Items {
+		statuses[item.Id] = item.Status
+	}
+	if len(statuses) != 2 || statuses[created.Id] != "confirmed" || statuses["doomed"] != "cancelled" {
+		t.Errorf("expected inserted event and cancelled deletion in delta, got %v", statuses)
+	}
+	if delta.NextSyncToken == "" || delta.NextSyncToken == full.NextSyncToken {
+		t.Errorf("expected a new sync token after changes, got %q", delta.NextSyncToken)
+	}
+
+	// Invalid tokens, and tokens issued before a Reset, require a full sync
+	_, err = svc.Events.List("primary").SyncToken("bogus").Do()
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusGone {
+		t.Errorf("expected 410 Gone for invalid token, got %v", err)
+	}
+
+	server.Reset()
+	_, err = svc.Events.List("primary").SyncToken(delta.NextSyncToken).Do()
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusGone {
+		t.Errorf("expected 410 Gone for expired token, got %v", err)
+	}
+}
+
+func TestMockServer_SnapshotRestore(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	// Seed shared reference data
+	server.AddEvent("primary", &calendar.Event{Id: "reference", Summary: "Reference Event"})
+	restore := server.Snapshot()
+
+	tests := []struct {
+		name   string
+		mutate func(t *testing.T)
+	}{
+		{
+			name: "insert",
+			mutate: func(t *testing.T) {
+				if _, err := svc.Events.Insert("primary", &calendar.Event{Summary: "Scratch"}).Do(); err != nil {
+					t.Fatalf("failed to insert event: %v", err)
+				}
+			},
+		},
+		{
+			name: "update",
+			mutate: func(t *testing.T) {
+				if _, err := svc.Events.Update("primary", "reference", &calendar.Event{Summary: "Changed"}).Do(); err != nil {
+					t.Fatalf("failed to update event: %v", err)
+				}
+			},
+		},
+		{
+			name: "delete",
+			mutate: func(t *testing.T) {
+				if err := svc.Events.Delete("primary", "reference").Do(); err != nil {
+					t.Fatalf("failed to delete event: %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer restore()
+			tt.mutate(t)
+		})
+
+		events := server.GetEvents("primary")
+		if len(events) != 1 || events[0].Id != "reference" || events[0].Summary != "Reference Event" {
+			t.Errorf("%s: expected seeded event to be restored, got %d events", tt.name, len(events))
+		}
+	}
+
+	// The ID counter is restored too, so generated IDs are reproducible
+	created, err := svc.Events.Insert("primary", &calendar.Event{Summary: "After Restore"}).Do()
+	if err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+	if created.Id != "event1" {
+		t.Errorf("expected restored ID counter to yield 'event1', got %q", created.Id)
+	}
+}
+
+func TestMockServer_ListEventsUpdatedMin(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	// Both events were last touched well in the past
+	for _, id := range []string{"stale", "bumped"} {
+		server.AddEvent("primary", &calendar.Event{
+			Id:      id,
+			Summary: id,
+			Start:   &calendar.EventDateTime{DateTime: "2024-01-15T10:00:00Z"},
+			Updated: "2024-01-01T00:00:00Z",
+		})
+	}
+
+	// Bumping one via update moves its Updated time to now
+	if _, err := svc.Events.Update("primary", "bumped", &calendar.Event{
+		Summary: "bumped",
+		Start:   &calendar.EventDateTime{DateTime: "2024-01-15T10:00:00Z"},
+	}).Do(); err != nil {
+		t.Fatalf("failed to update event: %v", err)
+	}
+
+	cutoff := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	events, err := svc.Events.List("primary").UpdatedMin(cutoff).Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(events.Items) != 1 || events.Items[0].Id != "bumped" {
+		t.Errorf("expected only 'bumped' after cutoff, got %d items", len(events.Items))
+	}
+
+	// updatedMin combines with the time window
+	events, err = svc.Events.List("primary").
+		UpdatedMin(cutoff).
+		TimeMin("2024-02-01T00:00:00Z").
+		Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(events.Items) != 0 {
+		t.Errorf("expected no events after both filters, got %d", len(events.Items))
+	}
+}
+
+func TestMockServer_PaginatesExpandedInstances(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	server.AddEvent("primary", &calendar.Event{
+		Id:         "daily",
+		Summary:    "Daily Check-in",
+		Start:      &calendar.EventDateTime{DateTime: "2024-01-01T09:00:00Z"},
+		End:        &calendar.EventDateTime{DateTime: "2024-01-01T09:15:00Z"},
+		Recurrence: []string{"RRULE:FREQ=DAILY;COUNT=100"},
+	})
+
+	for _, orderBy := range []string{"", "startTime"} {
+		seen := make(map[string]bool)
+		pages := 0
+		pageToken := ""
+		for {
+			call := svc.Events.List("primary").SingleEvents(true).MaxResults(10)
+			if orderBy != "" {
+				call = call.OrderBy(orderBy)
+			}
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+
+			events, err := call.Do()
+			if err != nil {
+				t.Fatalf("failed to list events: %v", err)
+			}
+			pages++
+			if len(events.Items) != 10 {
+				t.Errorf("orderBy=%q page %d: expected 10 instances, got %d", orderBy, pages, len(events.Items))
+			}
+			for _, item := range events.Items {
+				if seen[item.Id] {
+					t.Errorf("orderBy=%q: instance %s returned on more than one page", orderBy, item.Id)
+				}
+				seen[item.Id] = true
+			}
+
+			if events.NextPageToken == "" {
+				break
+			}
+			pageToken = events.NextPageToken
+		}
+
+		if pages != 10 {
+			t.Errorf("orderBy=%q: expected 10 pages, got %d", orderBy, pages)
+		}
+		if len(seen) != 100 {
+			t.Errorf("orderBy=%q: expected 100 distinct instances, got %d", orderBy, len(seen))
+		}
+	}
+}
+
+func TestMockServer_ListInstancesWindowAndPaging(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	server.AddEvent("primary", &calendar.Event{
+		Id:         "daily",
+		Summary:    "Daily Check-in",
+		Start:      &calendar.EventDateTime{DateTime: "2024-01-01T09:00:00Z"},
+		End:        &calendar.EventDateTime{DateTime: "2024-01-01T09:15:00Z"},
+		Recurrence: []string{"RRULE:FREQ=DAILY"},
+	})
+
+	// timeMax is inclusive of an instance starting exactly at it, as with
+	// the events list
+	var starts []string
+	pages := 0
+	err = svc.Events.Instances("primary", "daily").
+		TimeMin("2024-01-10T00:00:00Z").
+		TimeMax("2024-01-20T09:00:00Z").
+		MaxResults(4).
+		Pages(ctx, func(page *calendar.Events) error {
+			pages++
+			for _, item := range page.Items {
+				starts = append(starts, item.Start.DateTime)
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("failed to list instances: %v", err)
+	}
+
+	if len(starts) != 11 || starts[0] != "2024-01-10T09:00:00Z" || starts[10] != "2024-01-20T09:00:00Z" {
+		t.Errorf("expected the 11 instances from Jan 10 to Jan 20, got %v", starts)
+	}
+	if pages != 3 {
+		t.Errorf("expected 3 pages of at most 4, got %d", pages)
+	}
+}
+
+func TestMockServer_ExpandsOpenSeriesLongAfterStart(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	// More than maxRecurrenceInstances days before the window
+	server.AddEvent("primary", &calendar.Event{
+		Id:         "standup",
+		Summary:    "Daily Standup",
+		Start:      &calendar.EventDateTime{DateTime: "2020-01-01T09:00:00Z"},
+		End:        &calendar.EventDateTime{DateTime: "2020-01-01T09:15:00Z"},
+		Recurrence: []string{"RRULE:FREQ=DAILY"},
+	})
+
+	events, err := svc.Events.List("primary").
+		SingleEvents(true).
+		TimeMin("2024-06-01T00:00:00Z").
+		TimeMax("2024-06-08T00:00:00Z").
+		Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(events.Items) != 7 {
+		t.Errorf("expected 7 instances in the window, got %d", len(events.Items))
+	}
+
+	instances, err := svc.Events.Instances("primary", "standup").
+		TimeMin("2024-06-01T00:00:00Z").
+		TimeMax("2024-06-08T00:00:00Z").
+		Do()
+	if err != nil {
+		t.Fatalf("failed to list instances: %v", err)
+	}
+	if len(instances.Items) != 7 {
+		t.Errorf("expected 7 instances from the instances endpoint, got %d", len(instances.Items))
+	}
+
+	// COUNT still runs from the series start
+	rule, err := parseRRule("RRULE:FREQ=DAILY;COUNT=10")
+	if err != nil {
+		t.Fatalf("parseRRule() failed: %v", err)
+	}
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	if got := rule.occurrences(start, start.AddDate(0, 0, 8), time.Time{}); len(got) != 2 {
+		t.Errorf("expected the last 2 of 10 occurrences, got %v", got)
+	}
+}
+
+func TestMockServer_ListEventsQuery(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	events := []*calendar.Event{
+		{Summary: "Team Standup"},
+		{Summary: "Sync", Description: "weekly STANDUP retro"},
+		{Summary: "Offsite", Location: "Standup Comedy Club"},
+		{Summary: "1:1", Attendees: []*calendar.EventAttendee{{Email: "standup-bot@example.com"}}},
+		{Summary: "Lunch", Attendees: []*calendar.EventAttendee{{Email: "chef@example.com", DisplayName: "Chef"}}},
+		{Summary: "Planning", Description: "quarterly goals"},
+	}
+	for _, event := range events {
+		if _, err := svc.Events.Insert("primary", event).Do(); err != nil {
+			t.Fatalf("failed to insert event: %v", err)
+		}
+	}
+
+	result, err := svc.Events.List("primary").Q("standup").Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(result.Items) != 4 {
+		t.Errorf("expected 4 events matching 'standup', got %d", len(result.Items))
+	}
+	for _, item := range result.Items {
+		if item.Summary == "Lunch" || item.Summary == "Planning" {
+			t.Errorf("unexpected match %q", item.Summary)
+		}
+	}
+
+	// Display names are searched too
+	result, err = svc.Events.List("primary").Q("chef").Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(result.Items) != 1 || result.Items[0].Summary != "Lunch" {
+		t.Errorf("expected only 'Lunch' matching 'chef', got %d items", len(result.Items))
+	}
+}
+
+func TestMockServer_FieldsProjection(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		server.AddEvent("primary", &calendar.Event{
+			Summary: "Projected Event",
+			Start:   &calendar.EventDateTime{DateTime: "2024-01-15T10:00:00Z"},
+		})
+	}
+
+	events, err := svc.Events.List("primary").Fields("items/id").Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(events.Items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(events.Items))
+	}
+	for _, item := range events.Items {
+		if item.Id == "" {
+			t.Error("expected id to be kept")
+		}
+		if item.Summary != "" || item.Start != nil {
+			t.Errorf("expected unselected fields to be omitted, got summary %q start %v", item.Summary, item.Start)
+		}
+	}
+	if events.Kind != "" {
+		t.Errorf("expected top-level kind to be omitted, got %q", events.Kind)
+	}
+
+	// Sub-selections and top-level fields combine
+	events, err = svc.Events.List("primary").MaxResults(2).Fields("nextPageToken,items(id,start/dateTime)").Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if events.NextPageToken == "" {
+		t.Error("expected nextPageToken to be kept")
+	}
+	for _, item := range events.Items {
+		if item.Start == nil || item.Start.DateTime == "" || item.Summary != "" {
+			t.Errorf("expected only id and start.dateTime, got %+v", item)
+		}
+	}
+
+	// Single-event responses are projected too
+	event, err := svc.Events.Get("primary", events.Items[0].Id).Fields("summary").Do()
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	if event.Summary != "Projected Event" || event.Id != "" {
+		t.Errorf("expected only summary, got id %q summary %q", event.Id, event.Summary)
+	}
+}
+
+func TestMockServer_RecordsRequests(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	if _, ok := server.LastRequest(); ok {
+		t.Error("expected no recorded requests on a fresh server")
+	}
+
+	if _, err := svc.Events.Insert("primary", &calendar.Event{
+		Summary:  "Recorded Event",
+		Location: "Room 1",
+	}).Do(); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	last, ok := server.LastRequest()
+	if !ok {
+		t.Fatal("expected a recorded request")
+	}
+	if last.Method != http.MethodPost {
+		t.Errorf("expected POST, got %s", last.Method)
+	}
+	if last.Path != "/calendars/primary/events" {
+		t.Errorf("expected path /calendars/primary/events, got %s", last.Path)
+	}
+	if last.Body["summary"] != "Recorded Event" || last.Body["location"] != "Room 1" {
+		t.Errorf("expected recorded body to match inserted event, got %v", last.Body)
+	}
+
+	if _, err := svc.Events.List("primary").SingleEvents(true).Do(); err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	requests := server.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 recorded requests, got %d", len(requests))
+	}
+	if requests[1].Query.Get("singleEvents") != "true" || requests[1].Body != nil {
+		t.Errorf("expected GET with singleEvents=true and no body, got %+v", requests[1])
+	}
+
+	server.Reset()
+	if len(server.Requests()) != 0 {
+		t.Error("expected Reset to clear recorded requests")
+	}
+}
+
+func TestMockServer_InjectResponseField(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	server.InjectResponseField("futureFeature", map[string]any{"enabled": true, "levels": []int{1, 2}})
+
+	created, err := svc.Events.Insert("primary", &calendar.Event{Summary: "Forward Compatible"}).Do()
+	if err != nil {
+		t.Fatalf("failed to insert event with injected field: %v", err)
+	}
+	if created.Summary != "Forward Compatible" {
+		t.Errorf("expected summary 'Forward Compatible', got %q", created.Summary)
+	}
+
+	events, err := svc.Events.List("primary").Do()
+	if err != nil {
+		t.Fatalf("failed to list events with injected field: %v", err)
+	}
+	if len(events.Items) != 1 || events.Items[0].Id != created.Id {
+		t.Errorf("expected the inserted event in the listing, got %d items", len(events.Items))
+	}
+
+	// The field really is on the wire
+	last, err := http.Get(server.URL + "/calendars/primary/events/" + created.Id)
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	defer last.Body.Close()
+	var raw map[string]any
+	if err := json.NewDecoder(last.Body).Decode(&raw); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if _, ok := raw["futureFeature"]; !ok {
+		t.Error("expected injected field in raw response")
+	}
+}
+
+func TestMockServer_StructuredErrors(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	server.AddEvent("primary", &calendar.Event{Id: "exists"})
+
+	_, err = svc.Events.Get("primary", "missing").Do()
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *googleapi.Error, got %T: %v", err, err)
+	}
+	if apiErr.Code != http.StatusNotFound {
+		t.Errorf("expected code 404, got %d", apiErr.Code)
+	}
+	if apiErr.Message != "event not found" {
+		t.Errorf("expected message 'event not found', got %q", apiErr.Message)
+	}
+	if len(apiErr.Errors) != 1 || apiErr.Errors[0].Reason != "notFound" {
+		t.Errorf("expected a single notFound error item, got %+v", apiErr.Errors)
+	}
+}
+
+func TestMockServer_AllocatedIDCount(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	// Events seeded with explicit IDs don't consume generated ones
+	server.AddEvent("primary", &calendar.Event{Id: "explicit"})
+	if got := server.AllocatedIDCount(); got != 0 {
+		t.Errorf("expected 0 allocated IDs after explicit AddEvent, got %d", got)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := svc.Events.Insert("primary", &calendar.Event{Summary: "Generated"}).Do(); err != nil {
+			t.Fatalf("failed to insert event: %v", err)
+		}
+	}
+	server.AddEvent("other", &calendar.Event{Summary: "Seeded without ID"})
+
+	if got := server.AllocatedIDCount(); got != 4 {
+		t.Errorf("expected 4 allocated IDs, got %d", got)
+	}
+
+	server.Reset()
+	if got := server.AllocatedIDCount(); got != 0 {
+		t.Errorf("expected 0 allocated IDs after Reset, got %d", got)
+	}
+}
+
+func TestMockServer_InsertKeepsClientID(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	created, err := svc.Events.Insert("primary", &calendar.Event{Id: "retro2024", Summary: "Retro"}).Do()
+	if err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+	if created.Id != "retro2024" {
+		t.Errorf("expected the client's ID kept, got %s", created.Id)
+	}
+	if got := server.AllocatedIDCount(); got != 0 {
+		t.Errorf("expected no generated IDs, got %d", got)
+	}
+
+	_, err = svc.Events.Insert("primary", &calendar.Event{Id: "retro2024", Summary: "Again"}).Do()
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusConflict {
+		t.Fatalf("expected a 409 inserting a taken ID, got %v", err)
+	}
+	if events := server.GetEvents("primary"); len(events) != 1 || events[0].Summary != "Retro" {
+		t.Errorf("expected the original event untouched, got %+v", events)
+	}
+}
+
+func TestMockServer_ResetRestoresSettings(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	frozen := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	server.StrictCalendars(true)
+	server.SoftDelete(true)
+	server.SetClock(func() time.Time { return frozen })
+	server.SetCurrentUser("ann@example.com")
+	server.Reset()
+
+	if _, err := svc.Events.List("team@example.com").Do(); err != nil {
+		t.Errorf("expected unknown calendars to be lenient after Reset, got %v", err)
+	}
+	created, err := svc.Events.Insert("primary", &calendar.Event{Summary: "Draft"}).Do()
+	if err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+	if created.Created == frozen.Format(time.RFC3339) {
+		t.Error("expected the real clock after Reset")
+	}
+	if err := svc.Events.Delete("primary", created.Id).Do(); err != nil {
+		t.Fatalf("failed to delete event: %v", err)
+	}
+	if events := server.GetEvents("primary"); len(events) != 0 {
+		t.Errorf("expected deletes to remove events after Reset, got %d left", len(events))
+	}
+	list, err := svc.CalendarList.List().Do()
+	if err != nil {
+		t.Fatalf("failed to list calendars: %v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Id != "user@example.com" {
+		t.Errorf("expected the default user's primary calendar after Reset, got %+v", list.Items)
+	}
+}
+
+func TestMockServer_PatchMergesFields(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	seed := func() {
+		server.AddEvent("primary", &calendar.Event{
+			Id:          "evt",
+			Summary:     "Original",
+			Location:    "Room 1",
+			Description: "Agenda",
+		})
+	}
+
+	// PATCH only touches the fields it sends
+	seed()
+	patched, err := svc.Events.Patch("primary", "evt", &calendar.Event{Summary: "Renamed"}).Do()
+	if err != nil {
+		t.Fatalf("failed to patch event: %v", err)
+	}
+	if patched.Summary != "Renamed" {
+		t.Errorf("expected summary 'Renamed', got %q", patched.Summary)
+	}
+	if patched.Location != "Room 1" || patched.Description != "Agenda" {
+		t.Errorf("expected location and description to be preserved, got %q / %q", patched.Location, patched.Description)
+	}
+
+	// Explicit nulls clear a field
+	patched, err = svc.Events.Patch("primary", "evt", &calendar.Event{NullFields: []string{"Location"}}).Do()
+	if err != nil {
+		t.Fatalf("failed to patch event: %v", err)
+	}
+	if patched.Location != "" || patched.Summary != "Renamed" {
+		t.Errorf("expected only location cleared, got summary %q location %q", patched.Summary, patched.Location)
+	}
+
+	// PUT still replaces the whole event
+	seed()
+	updated, err := svc.Events.Update("primary", "evt", &calendar.Event{Summary: "Replaced"}).Do()
+	if err != nil {
+		t.Fatalf("failed to update event: %v", err)
+	}
+	if updated.Summary != "Replaced" || updated.Location != "" {
+		t.Errorf("expected PUT to replace the event, got summary %q location %q", updated.Summary, updated.Location)
+	}
+}
+
+func TestMockServer_PatchMergesNestedObjects(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	server.AddEvent("primary", &calendar.Event{
+		Id: "evt",
+		ExtendedProperties: &calendar.EventExtendedProperties{
+			Private: map[string]string{"ticket": "OPS-12", "source": "jira"},
+			Shared:  map[string]string{"team": "sre"},
+		},
+	})
+
+	patched, err := svc.Events.Patch("primary", "evt", &calendar.Event{
+		ExtendedProperties: &calendar.EventExtendedProperties{
+			Private:    map[string]string{"status": "closed"},
+			NullFields: []string{"Private.source"},
+		},
+	}).Do()
+	if err != nil {
+		t.Fatalf("failed to patch event: %v", err)
+	}
+	private := patched.ExtendedProperties.Private
+	if len(private) != 2 || private["ticket"] != "OPS-12" || private["status"] != "closed" {
+		t.Errorf("expected private properties to merge, got %v", private)
+	}
+	if patched.ExtendedProperties.Shared["team"] != "sre" {
+		t.Errorf("expected shared properties to be preserved, got %v", patched.ExtendedProperties.Shared)
+	}
+}
+
+func TestMockServer_ExtendedPropertyFilters(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	server.AddEvent("primary", &calendar.Event{Id: "a", ExtendedProperties: &calendar.EventExtendedProperties{
+		Private: map[string]string{"ticket": "OPS-12", "source": "jira"},
+	}})
+	server.AddEvent("primary", &calendar.Event{Id: "b", ExtendedProperties: &calendar.EventExtendedProperties{
+		Private: map[string]string{"ticket": "OPS-13"},
+		Shared:  map[string]string{"ticket": "OPS-12"},
+	}})
+	server.AddEvent("primary", &calendar.Event{Id: "c"})
+
+	tests := []struct {
+		name    string
+		private []string
+		shared  []string
+		want    string
+	}{
+		{name: "private", private: []string{"ticket=OPS-12"}, want: "a"},
+		{name: "all private filters", private: []string{"ticket=OPS-12", "source=github"}, want: ""},
+		{name: "shared", shared: []string{"ticket=OPS-12"}, want: "b"},
+		{name: "private and shared", private: []string{"ticket=OPS-13"}, shared: []string{"ticket=OPS-12"}, want: "b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			call := svc.Events.List("primary")
+			if len(tt.private) > 0 {
+				call = call.PrivateExtendedProperty(tt.private...)
+			}
+			if len(tt.shared) > 0 {
+				call = call.SharedExtendedProperty(tt.shared...)
+			}
+			events, err := call.Do()
+			if err != nil {
+				t.Fatalf("failed to list events: %v", err)
+			}
+			var ids []string
+			for _, e := range events.Items {
+				ids = append(ids, e.Id)
+			}
+			if got := strings.Join(ids, ","); got != tt.want {
+				t.Errorf("got events %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMockServer_ExtendedPropertyFiltersTaggedEvents(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	// An app tags the events it creates; the rest of the calendar is untagged
+	tagged := &calendar.EventExtendedProperties{Private: map[string]string{"createdBy": "my-app"}}
+	server.AddEvent("primary", &calendar.Event{Id: "tagged1", Summary: "Synced 1", ExtendedProperties: tagged})
+	server.AddEvent("primary", &calendar.Event{Id: "tagged2", Summary: "Synced 2", ExtendedProperties: tagged})
+	server.AddEvent("primary", &calendar.Event{Id: "manual", Summary: "Added by hand"})
+	server.AddEvent("primary", &calendar.Event{Id: "other", Summary: "Other app", ExtendedProperties: &calendar.EventExtendedProperties{
+		Private: map[string]string{"createdBy": "other-app"},
+	}})
+
+	events, err := svc.Events.List("primary").PrivateExtendedProperty("createdBy=my-app").Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	var ids []string
+	for _, e := range events.Items {
+		ids = append(ids, e.Id)
+	}
+	sort.Strings(ids)
+	if got := strings.Join(ids, ","); got != "tagged1,tagged2" {
+		t.Errorf("expected only the tagged events, got %q", got)
+	}
+
+	// Without a filter every event is listed
+	all, err := svc.Events.List("primary").Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(all.Items) != 4 {
+		t.Errorf("expected 4 events without a filter, got %d", len(all.Items))
+	}
+
+	// Filters must be propertyName=value
+	_, err = svc.Events.List("primary").PrivateExtendedProperty("createdBy").Do()
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		t.Errorf("expected a 400 for a malformed filter, got %v", err)
+	}
+}
+
+func TestMockServer_ListEventsFiltersAllDayByDate(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	server.AddEvent("primary", &calendar.Event{
+		Id:      "holiday",
+		Summary: "Holiday",
+		Start:   &calendar.EventDateTime{Date: "2024-03-15"},
+		End:     &calendar.EventDateTime{Date: "2024-03-16"},
+	})
+
+	tests := []struct {
+		name    string
+		timeMin string
+		timeMax string
+		want    int
+	}{
+		{name: "window covering the date", timeMin: "2024-03-14T00:00:00Z", timeMax: "2024-03-17T00:00:00Z", want: 1},
+		{name: "window inside the day", timeMin: "2024-03-15T09:00:00Z", timeMax: "2024-03-15T17:00:00Z", want: 1},
+		{name: "window before the date", timeMin: "2024-03-10T00:00:00Z", timeMax: "2024-03-15T00:00:00Z", want: 0},
+		{name: "window after the date", timeMin: "2024-03-16T00:00:00Z", timeMax: "2024-03-20T00:00:00Z", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := svc.Events.List("primary").TimeMin(tt.timeMin).TimeMax(tt.timeMax).Do()
+			if err != nil {
+				t.Fatalf("failed to list events: %v", err)
+			}
+			if len(events.Items) != tt.want {
+				t.Errorf("expected %d events, got %d", tt.want, len(events.Items))
+			}
+		})
+	}
+}
+
+func TestMockServer_PageTokens(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		server.AddEvent("primary", &calendar.Event{Summary: "Paged"})
+	}
+
+	// A returned token round-trips to the next page
+	first, err := svc.Events.List("primary").MaxResults(3).Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if first.NextPageToken == "" || first.NextPageToken == "3" {
+		t.Fatalf("expected an opaque next page token, got %q", first.NextPageToken)
+	}
+	second, err := svc.Events.List("primary").MaxResults(3).PageToken(first.NextPageToken).Do()
+	if err != nil {
+		t.Fatalf("failed to list second page: %v", err)
+	}
+	if len(second.Items) != 2 || second.NextPageToken != "" {
+		t.Errorf("expected final page of 2 items, got %d (next %q)", len(second.Items), second.NextPageToken)
+	}
+
+	// Bare indices and tampered tokens are rejected
+	tampered := []byte(first.NextPageToken)
+	tampered[0] ^= 1
+	for _, token := range []string{"3", "bogus!", string(tampered)} {
+		_, err := svc.Events.List("primary").PageToken(token).Do()
+		var apiErr *googleapi.Error
+		if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for page token %q, got %v", token, err)
+		}
+	}
+}
+
+func TestMockServer_MaxResultsDefaultAndCap(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	for i := 0; i < 300; i++ {
+		server.AddEvent("primary", &calendar.Event{Summary: "Bulk"})
+	}
+
+	// Without maxResults the first page holds 250 events
+	first, err := svc.Events.List("primary").Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(first.Items) != 250 {
+		t.Errorf("expected default page of 250, got %d", len(first.Items))
+	}
+	if first.NextPageToken == "" {
+		t.Fatal("expected a continuation token after the default page")
+	}
+	second, err := svc.Events.List("primary").PageToken(first.NextPageToken).Do()
+	if err != nil {
+		t.Fatalf("failed to list second page: %v", err)
+	}
+	if len(second.Items) != 50 || second.NextPageToken != "" {
+		t.Errorf("expected final page of 50, got %d (next %q)", len(second.Items), second.NextPageToken)
+	}
+
+	// Out-of-range values are clamped into [1, 2500]
+	for _, tt := range []struct {
+		maxResults int64
+		want       int
+	}{
+		{maxResults: 5000, want: 300},
+		{maxResults: 0, want: 1},
+	} {
+		events, err := svc.Events.List("primary").MaxResults(tt.maxResults).Do()
+		if err != nil {
+			t.Fatalf("failed to list events: %v", err)
+		}
+		if len(events.Items) != tt.want {
+			t.Errorf("maxResults=%d: expected %d events, got %d", tt.maxResults, tt.want, len(events.Items))
+		}
+	}
+}
+
+func TestMockServer_PersistsOptionalAttendees(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	created, err := svc.Events.Insert("primary", &calendar.Event{
+		Summary: "Review",
+		Attendees: []*calendar.EventAttendee{
+			{Email: "required@example.com"},
+			{Email: "optional@example.com", Optional: true},
+		},
+	}).Do()
+	if err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	fetched, err := svc.Events.Get("primary", created.Id).Do()
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	if len(fetched.Attendees) != 2 {
+		t.Fatalf("expected 2 attendees, got %d", len(fetched.Attendees))
+	}
+	if fetched.Attendees[0].Optional || !fetched.Attendees[1].Optional {
+		t.Errorf("expected only the second attendee to be optional, got %v / %v",
+			fetched.Attendees[0].Optional, fetched.Attendees[1].Optional)
+	}
+}
+
+func TestMockServer_ValidatesTimeRange(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	valid := &calendar.Event{
+		Summary: "Valid",
+		Start:   &calendar.EventDateTime{DateTime: "2024-03-01T09:00:00Z"},
+		End:     &calendar.EventDateTime{DateTime: "2024-03-01T10:00:00Z"},
+	}
+	created, err := svc.Events.Insert("primary", valid).Do()
+	if err != nil {
+		t.Fatalf("expected valid event to be accepted, got %v", err)
+	}
+
+	reversed := []*calendar.Event{
+		{
+			Summary: "Reversed",
+			Start:   &calendar.EventDateTime{DateTime: "2024-03-01T10:00:00Z"},
+			End:     &calendar.EventDateTime{DateTime: "2024-03-01T09:00:00Z"},
+		},
+		{
+			Summary: "Reversed All-Day",
+			Start:   &calendar.EventDateTime{Date: "2024-03-02"},
+			End:     &calendar.EventDateTime{Date: "2024-03-01"},
+		},
+	}
+	for _, event := range reversed {
+		_, err := svc.Events.Insert("primary", event).Do()
+		var apiErr *googleapi.Error
+		if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+			t.Errorf("insert %q: expected 400, got %v", event.Summary, err)
+		} else if len(apiErr.Errors) != 1 || apiErr.Errors[0].Reason != "invalid" {
+			t.Errorf("insert %q: expected reason 'invalid', got %+v", event.Summary, apiErr.Errors)
+		}
+	}
+
+	// Updates are validated too
+	_, err = svc.Events.Update("primary", created.Id, reversed[0]).Do()
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		t.Errorf("update: expected 400, got %v", err)
+	}
+	if len(server.GetEvents("primary")) != 1 {
+		t.Errorf("expected only the valid event to be stored")
+	}
+}
+
+func TestMockServer_DropAfterItems(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	for i := 0; i < 4; i++ {
+		server.AddEvent("primary", &calendar.Event{Summary: fmt.Sprintf("Event %d", i)})
+	}
+	server.DropAfterItems(2)
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	first, err := svc.Events.List("primary").MaxResults(2).Do()
+	if err != nil {
+		t.Fatalf("expected the first page to succeed, got %v", err)
+	}
+	if len(first.Items) != 2 || first.NextPageToken == "" {
+		t.Fatalf("expected 2 items and a next page, got %d items", len(first.Items))
+	}
+
+	_, err = svc.Events.List("primary").MaxResults(2).PageToken(first.NextPageToken).Do()
+	if err == nil {
+		t.Fatal("expected the second page to fail")
+	}
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		t.Errorf("expected a connection error, got API error %v", apiErr)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected unexpected EOF, got %v", err)
+	}
+}
+
+func TestMockServer_DropAfterBytes(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.AddEvent("primary", &calendar.Event{Id: "evt", Summary: "Event"})
+	server.DropAfterBytes(10)
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	if _, err := svc.Events.Get("primary", "evt").Do(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected unexpected EOF, got %v", err)
+	}
+
+	// Reset clears the fault
+	server.Reset()
+	server.AddEvent("primary", &calendar.Event{Id: "evt", Summary: "Event"})
+	if _, err := svc.Events.Get("primary", "evt").Do(); err != nil {
+		t.Errorf("expected success after Reset, got %v", err)
+	}
+}
+
+func TestMockServer_FixtureRoundTrip(t *testing.T) {
+	const fixture = `{
+		"primary": {
+			"event7": {"summary": "Standup", "start": {"dateTime": "2024-03-01T09:00:00Z"}, "end": {"dateTime": "2024-03-01T09:15:00Z"}},
+			"offsite": {"summary": "Offsite", "start": {"date": "2024-03-04"}, "end": {"date": "2024-03-06"}}
+		},
+		"team@example.com": {
+			"event2": {"summary": "Planning"}
+		}
+	}`
+
+	server := NewServer()
+	defer server.Close()
+	if err := server.LoadFixture(strings.NewReader(fixture)); err != nil {
+		t.Fatalf("failed to load fixture: %v", err)
+	}
+
+	primary := server.GetEvents("primary")
+	if len(primary) != 2 {
+		t.Fatalf("expected 2 primary events, got %d", len(primary))
+	}
+	summaries := map[string]string{}
+	for _, evt := range primary {
+		summaries[evt.Id] = evt.Summary
+	}
+	if summaries["event7"] != "Standup" || summaries["offsite"] != "Offsite" {
+		t.Errorf("expected fixture IDs to be preserved, got %v", summaries)
+	}
+	if team := server.GetEvents("team@example.com"); len(team) != 1 || team[0].Id != "event2" {
+		t.Errorf("expected team calendar to hold event2, got %v", team)
+	}
+
+	// New events must not collide with loaded IDs, which aren't counted as
+	// generated
+	added := &calendar.Event{Summary: "New"}
+	server.AddEvent("primary", added)
+	if added.Id != "event8" {
+		t.Errorf("expected the ID counter to move past event7, got %s", added.Id)
+	}
+	if got := server.AllocatedIDCount(); got != 1 {
+		t.Errorf("expected 1 allocated ID, got %d", got)
+	}
+
+	// Saving and loading into a fresh server reproduces the same state
+	var buf bytes.Buffer
+	if err := server.SaveFixture(&buf); err != nil {
+		t.Fatalf("failed to save fixture: %v", err)
+	}
+	restored := NewServer()
+	defer restored.Close()
+	if err := restored.LoadFixture(&buf); err != nil {
+		t.Fatalf("failed to load saved fixture: %v", err)
+	}
+	for _, calendarID := range []string{"primary", "team@example.com"} {
+		want, _ := json.Marshal(eventsByID(server.GetEvents(calendarID)))
+		got, _ := json.Marshal(eventsByID(restored.GetEvents(calendarID)))
+		if !bytes.Equal(want, got) {
+			t.Errorf("calendar %s: round trip mismatch\nwant %s\ngot  %s", calendarID, want, got)
+		}
+	}
+}
+
+// eventsByID indexes events by ID, since GetEvents returns them unordered
+func eventsByID(events []*calendar.Event) map[string]*calendar.Event {
+	byID := make(map[string]*calendar.Event, len(events))
+	for _, evt := range events {
+		byID[evt.Id] = evt
+	}
+	return byID
+}
+
+func TestMockServer_LoadFixtureRejectsMismatchedIDs(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	err := server.LoadFixture(strings.NewReader(`{"primary": {"a": {"id": "b"}}}`))
+	if err == nil {
+		t.Fatal("expected an error for a mismatched id")
+	}
+	if len(server.GetEvents("primary")) != 0 {
+		t.Error("expected a rejected fixture to leave the store untouched")
+	}
+}
+
+func TestMockServer_RequireAuth(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.AddEvent("primary", &calendar.Event{Id: "evt", Summary: "Event"})
+	server.RequireAuth("secret-token")
+
+	ctx := context.Background()
+	newService := func(client *http.Client) *calendar.Service {
+		t.Helper()
+		svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+		if err != nil {
+			t.Fatalf("failed to create calendar service: %v", err)
+		}
+		return svc
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		client := oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "secret-token"}))
+		if _, err := newService(client).Events.Get("primary", "evt").Do(); err != nil {
+			t.Fatalf("expected an authorized request to succeed, got %v", err)
+		}
+		if got := server.LastAuthToken(); got != "secret-token" {
+			t.Errorf("expected captured token secret-token, got %q", got)
+		}
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		for name, client := range map[string]*http.Client{
+			"missing": {},
+			"wrong":   oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "other-token"})),
+		} {
+			_, err := newService(client).Events.Get("primary", "evt").Do()
+			var apiErr *googleapi.Error
+			if !errors.As(err, &apiErr) || apiErr.Code != http.StatusUnauthorized {
+				t.Errorf("%s token: expected 401, got %v", name, err)
+				continue
+			}
+			if len(apiErr.Errors) != 1 || apiErr.Errors[0].Reason != "authError" {
+				t.Errorf("%s token: expected reason 'authError', got %+v", name, apiErr.Errors)
+			}
+		}
+	})
+}
+
+func TestMockServer_WatchNotifiesOnChange(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	notifications := make(chan http.Header, 10)
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		notifications <- r.Header
+	}))
+	defer callback.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	channel, err := svc.Events.Watch("primary", &calendar.Channel{
+		Id:      "channel-1",
+		Type:    "web_hook",
+		Address: callback.URL,
+		Token:   "verify-me",
+	}).Do()
+	if err != nil {
+		t.Fatalf("failed to watch events: %v", err)
+	}
+	if channel.Id != "channel-1" || channel.ResourceId == "" || channel.Expiration == 0 {
+		t.Errorf("expected a channel with ID, resource ID and expiration, got %+v", channel)
+	}
+
+	next := func() http.Header {
+		t.Helper()
+		select {
+		case h := <-notifications:
+			return h
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a notification")
+			return nil
+		}
+	}
+
+	if got := next().Get("X-Goog-Resource-State"); got != "sync" {
+		t.Errorf("expected an initial sync notification, got state %q", got)
+	}
+
+	if _, err := svc.Events.Insert("primary", &calendar.Event{Summary: "New"}).Do(); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+	h := next()
+	if got := h.Get("X-Goog-Resource-State"); got != "exists" {
+		t.Errorf("expected state exists, got %q", got)
+	}
+	if h.Get("X-Goog-Channel-ID") != "channel-1" || h.Get("X-Goog-Channel-Token") != "verify-me" {
+		t.Errorf("expected channel ID and token headers, got %v", h)
+	}
+	if got := h.Get("X-Goog-Resource-ID"); got != channel.ResourceId {
+		t.Errorf("expected resource ID %q, got %q", channel.ResourceId, got)
+	}
+	if got := h.Get("X-Goog-Message-Number"); got != "2" {
+		t.Errorf("expected message number 2, got %q", got)
+	}
+
+	// Other calendars don't notify this channel, and stopped channels are silent
+	server.AddEvent("team@example.com", &calendar.Event{Summary: "Elsewhere"})
+	if err := svc.Channels.Stop(&calendar.Channel{Id: channel.Id, ResourceId: channel.ResourceId}).Do(); err != nil {
+		t.Fatalf("failed to stop channel: %v", err)
+	}
+	server.AddEvent("primary", &calendar.Event{Summary: "After stop"})
+	select {
+	case h := <-notifications:
+		t.Errorf("expected no further notifications, got %v", h)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	err = svc.Channels.Stop(&calendar.Channel{Id: channel.Id, ResourceId: channel.ResourceId}).Do()
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound {
+		t.Errorf("expected 404 stopping an unknown channel, got %v", err)
+	}
+}
+
+func TestMockServer_ConferenceDataVersion(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &http.Client{}
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(client), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	newEvent := func() *calendar.Event {
+		return &calendar.Event{
+			Summary: "Sync",
+			ConferenceData: &calendar.ConferenceData{
+				CreateRequest: &calendar.CreateConferenceRequest{
+					RequestId:             "req-1",
+					ConferenceSolutionKey: &calendar.ConferenceSolutionKey{Type: "hangoutsMeet"},
+				},
+			},
+		}
+	}
+
+	created, err := svc.Events.Insert("primary", newEvent()).ConferenceDataVersion(1).Do()
+	if err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+	conf := created.ConferenceData
+	if conf == nil || len(conf.EntryPoints) != 1 || conf.EntryPoints[0].EntryPointType != "video" {
+		t.Fatalf("expected a video entry point, got %+v", conf)
+	}
+	if conf.CreateRequest.Status.StatusCode != "success" || conf.CreateRequest.RequestId != "req-1" {
+		t.Errorf("expected a successful create request, got %+v", conf.CreateRequest)
+	}
+	if created.HangoutLink != conf.EntryPoints[0].Uri {
+		t.Errorf("expected hangoutLink %q, got %q", conf.EntryPoints[0].Uri, created.HangoutLink)
+	}
+
+	// Version 0 (the default) ignores conference data
+	ignored, err := svc.Events.Insert("primary", newEvent()).Do()
+	if err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+	if ignored.ConferenceData != nil {
+		t.Errorf("expected conference data to be ignored, got %+v", ignored.ConferenceData)
+	}
+}
+
+func TestMockServer_ConferenceDataOnUpdate(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	createMeet := &calendar.ConferenceData{
+		CreateRequest: &calendar.CreateConferenceRequest{
+			RequestId:             "req-2",
+			ConferenceSolutionKey: &calendar.ConferenceSolutionKey{Type: "hangoutsMeet"},
+		},
+	}
+
+	// Adding a conference to an existing event
+	server.AddEvent("primary", &calendar.Event{Id: "review", Summary: "Review"})
+	patched, err := svc.Events.Patch("primary", "review", &calendar.Event{ConferenceData: createMeet}).ConferenceDataVersion(1).Do()
+	if err != nil {
+		t.Fatalf("failed to patch event: %v", err)
+	}
+	if patched.ConferenceData == nil || len(patched.ConferenceData.EntryPoints) != 1 ||
+		!strings.HasPrefix(patched.ConferenceData.EntryPoints[0].Uri, "https://meet.google.com/") {
+		t.Fatalf("expected a Meet link on the patched event, got %+v", patched.ConferenceData)
+	}
+	link := patched.HangoutLink
+
+	// Version 0 updates leave the conference alone, even on a full replace
+	replaced, err := svc.Events.Update("primary", "review", &calendar.Event{Summary: "Review (v2)"}).Do()
+	if err != nil {
+		t.Fatalf("failed to update event: %v", err)
+	}
+	if replaced.ConferenceData == nil || replaced.HangoutLink != link {
+		t.Errorf("expected the conference kept, got %+v / %q", replaced.ConferenceData, replaced.HangoutLink)
+	}
+
+	// Version 1 updates without conference data remove it
+	removed, err := svc.Events.Update("primary", "review", &calendar.Event{Summary: "Review (v3)"}).ConferenceDataVersion(1).Do()
+	if err != nil {
+		t.Fatalf("failed to update event: %v", err)
+	}
+	if removed.ConferenceData != nil || removed.HangoutLink != "" {
+		t.Errorf("expected the conference removed, got %+v / %q", removed.ConferenceData, removed.HangoutLink)
+	}
+
+	// Only versions 0 and 1 exist
+	var apiErr *googleapi.Error
+	if _, err := svc.Events.Insert("primary", &calendar.Event{Summary: "Bad"}).ConferenceDataVersion(2).Do(); !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 inserting with conferenceDataVersion=2, got %v", err)
+	}
+	if _, err := svc.Events.Patch("primary", "review", &calendar.Event{Summary: "Bad"}).ConferenceDataVersion(2).Do(); !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 patching with conferenceDataVersion=2, got %v", err)
+	}
+}
+
+func TestMockServer_QuickAdd(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	created, err := svc.Events.QuickAdd("primary", "Dinner Friday 7pm").Do()
+	if err != nil {
+		t.Fatalf("failed to quick add event: %v", err)
+	}
+	if created.Id == "" || created.Summary != "Dinner Friday 7pm" || created.Status != "confirmed" {
+		t.Errorf("unexpected quick-added event: %+v", created)
+	}
+	if created.ICalUID != created.Id+"@google.com" {
+		t.Errorf("expected an iCalUID derived from the ID, got %q", created.ICalUID)
+	}
+
+	fetched, err := svc.Events.Get("primary", created.Id).Do()
+	if err != nil {
+		t.Fatalf("failed to get quick-added event: %v", err)
+	}
+	if fetched.Summary != created.Summary {
+		t.Errorf("expected stored summary %q, got %q", created.Summary, fetched.Summary)
+	}
+
+	_, err = svc.Events.QuickAdd("primary", "").Do()
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		t.Errorf("expected a 400 for missing text, got %v", err)
+	}
+}
+
+func TestMockServer_ImportEvent(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	imported, err := svc.Events.Import("primary", &calendar.Event{
+		ICalUID: "migrated-1@example.com",
+		Summary: "Migrated",
+		Start:   &calendar.EventDateTime{DateTime: "2024-07-01T09:00:00Z"},
+		End:     &calendar.EventDateTime{DateTime: "2024-07-01T10:00:00Z"},
+	}).Do()
+	if err != nil {
+		t.Fatalf("failed to import event: %v", err)
+	}
+	if imported.Id == "" || imported.ICalUID != "migrated-1@example.com" || imported.Status != "confirmed" {
+		t.Errorf("unexpected imported event: %+v", imported)
+	}
+
+	found, err := svc.Events.List("primary").ICalUID("migrated-1@example.com").Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(found.Items) != 1 || found.Items[0].Id != imported.Id {
+		t.Fatalf("expected the imported event by its iCalUID, got %+v", found.Items)
+	}
+
+	// Importing the same iCalUID again updates the event in place
+	reimported, err := svc.Events.Import("primary", &calendar.Event{
+		ICalUID: "migrated-1@example.com",
+		Summary: "Migrated (edited)",
+		Start:   &calendar.EventDateTime{DateTime: "2024-07-01T09:00:00Z"},
+		End:     &calendar.EventDateTime{DateTime: "2024-07-01T10:00:00Z"},
+	}).Do()
+	if err != nil {
+		t.Fatalf("failed to re-import event: %v", err)
+	}
+	if reimported.Id != imported.Id {
+		t.Errorf("expected the re-import to keep ID %s, got %s", imported.Id, reimported.Id)
+	}
+	if events := server.GetEvents("primary"); len(events) != 1 || events[0].Summary != "Migrated (edited)" {
+		t.Errorf("expected one updated event, got %+v", events)
+	}
+
+	_, err = svc.Events.Import("primary", &calendar.Event{Summary: "No UID"}).Do()
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		t.Errorf("expected a 400 for a missing iCalUID, got %v", err)
+	}
+}
+
+func TestMockServer_CalendarList(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.AddCalendar(&calendar.CalendarListEntry{Id: "b@group.calendar.google.com", AccessRole: "reader"})
+	server.AddCalendar(&calendar.CalendarListEntry{Id: "a@group.calendar.google.com", Summary: "A"})
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	list, err := svc.CalendarList.List().Do()
+	if err != nil {
+		t.Fatalf("failed to list calendars: %v", err)
+	}
+	var ids []string
+	for _, entry := range list.Items {
+		ids = append(ids, entry.Id)
+	}
+	want := []string{defaultCurrentUser, "a@group.calendar.google.com", "b@group.calendar.google.com"}
+	if strings.Join(ids, ",") != strings.Join(want, ",") {
+		t.Errorf("expected calendars %v, got %v", want, ids)
+	}
+
+	// Defaults fill in the summary and access role
+	b, err := svc.CalendarList.Get("b@group.calendar.google.com").Do()
+	if err != nil {
+		t.Fatalf("failed to get calendar: %v", err)
+	}
+	if b.Summary != b.Id || b.AccessRole != "reader" || b.Primary {
+		t.Errorf("unexpected calendar entry: %+v", b)
+	}
+	a, err := svc.CalendarList.Get("a@group.calendar.google.com").Do()
+	if err != nil {
+		t.Fatalf("failed to get calendar: %v", err)
+	}
+	if a.AccessRole != "owner" {
+		t.Errorf("expected access role to default to owner, got %q", a.AccessRole)
+	}
+
+	owned, err := svc.CalendarList.List().MinAccessRole("owner").Do()
+	if err != nil {
+		t.Fatalf("failed to list calendars: %v", err)
+	}
+	if len(owned.Items) != 2 {
+		t.Errorf("expected 2 owned calendars, got %d", len(owned.Items))
+	}
+
+	_, err = svc.CalendarList.Get("missing@group.calendar.google.com").Do()
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound {
+		t.Errorf("expected a 404 for an unknown calendar, got %v", err)
+	}
+
+	server.Reset()
+	list, err = svc.CalendarList.List().Do()
+	if err != nil {
+		t.Fatalf("failed to list calendars: %v", err)
+	}
+	if len(list.Items) != 1 || !list.Items[0].Primary {
+		t.Errorf("expected only the primary calendar after Reset, got %d", len(list.Items))
+	}
+}
+
+func TestMockServer_FreeBusy(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	// A daily series, with one instance cancelled
+	server.AddEvent("primary", &calendar.Event{
+		Id:         "daily",
+		Start:      &calendar.EventDateTime{DateTime: "2024-03-04T09:00:00Z"},
+		End:        &calendar.EventDateTime{DateTime: "2024-03-04T10:00:00Z"},
+		Recurrence: []string{"RRULE:FREQ=DAILY;COUNT=3"},
+	})
+	server.AddEvent("primary", &calendar.Event{
+		Id:                "daily_20240305",
+		RecurringEventId:  "daily",
+		OriginalStartTime: &calendar.EventDateTime{DateTime: "2024-03-05T09:00:00Z"},
+		Status:            "cancelled",
+	})
+	// All-day events block the whole day; transparent ones block nothing
+	server.AddEvent("primary", &calendar.Event{
+		Id:    "conference",
+		Start: &calendar.EventDateTime{Date: "2024-03-06"},
+		End:   &calendar.EventDateTime{Date: "2024-03-07"},
+	})
+	server.AddEvent("primary", &calendar.Event{
+		Id:           "ooo-note",
+		Transparency: "transparent",
+		Start:        &calendar.EventDateTime{DateTime: "2024-03-04T12:00:00Z"},
+		End:          &calendar.EventDateTime{DateTime: "2024-03-04T13:00:00Z"},
+	})
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	resp, err := svc.Freebusy.Query(&calendar.FreeBusyRequest{
+		TimeMin: "2024-03-04T00:00:00Z",
+		TimeMax: "2024-03-08T00:00:00Z",
+		Items:   []*calendar.FreeBusyRequestItem{{Id: "primary"}, {Id: "nobody@example.com"}},
+	}).Do()
+	if err != nil {
+		t.Fatalf("failed to query free/busy: %v", err)
+	}
+
+	var got []string
+	for _, busy := range resp.Calendars["primary"].Busy {
+		got = append(got, busy.Start+"/"+busy.End)
+	}
+	want := []string{
+		"2024-03-04T09:00:00Z/2024-03-04T10:00:00Z",
+		"2024-03-06T00:00:00Z/2024-03-07T00:00:00Z",
+	}
+	// The 2024-03-06 09:00 instance is inside the all-day block and merges into it
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected busy %v, got %v", want, got)
+	}
+	if errs := resp.Calendars["nobody@example.com"].Errors; len(errs) != 1 || errs[0].Reason != "notFound" {
+		t.Errorf("expected a notFound error for an unknown calendar, got %+v", errs)
+	}
+
+	_, err = svc.Freebusy.Query(&calendar.FreeBusyRequest{
+		TimeMin: "2024-03-08T00:00:00Z",
+		TimeMax: "2024-03-04T00:00:00Z",
+	}).Do()
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		t.Errorf("expected a 400 for an empty time range, got %v", err)
+	}
+}
+
+func TestMockServer_SetLatency(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.SetLatency(50 * time.Millisecond)
+
+	start := time.Now()
+	resp, err := http.Get(server.URL + "/calendars/primary/events")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected the response to be delayed by at least 50ms, took %v", elapsed)
+	}
+
+	// Clients that give up aren't held for the full delay
+	server.SetLatency(time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/calendars/primary/events", nil)
+	if _, err := http.DefaultClient.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the client to time out, got %v", err)
+	}
+
+	server.Reset()
+	start = time.Now()
+	resp, err = http.Get(server.URL + "/calendars/primary/events")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected Reset to clear the latency, took %v", elapsed)
+	}
+}
+
+func TestMockServer_OnRequest(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	// The hook sees each request before the latency, so it can cancel the
+	// client mid-delay; the handler then gives up instead of answering
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var seen []string
+	server.SetLatency(time.Minute)
+	server.OnRequest(func(r *http.Request) {
+		seen = append(seen, r.Method+" "+r.URL.Path)
+		cancel()
+	})
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/calendars/primary/events", strings.NewReader(`{"summary":"Never stored"}`))
+	start := time.Now()
+	if _, err := http.DefaultClient.Do(req); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the request to be cancelled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the cancellation to end the delay, took %v", elapsed)
+	}
+	if len(seen) != 1 || seen[0] != "POST /calendars/primary/events" {
+		t.Errorf("expected the hook to see the insert, got %v", seen)
+	}
+
+	// Reset removes the hook
+	server.Reset()
+	resp, err := http.Get(server.URL + "/calendars/primary/events")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if len(seen) != 1 {
+		t.Errorf("expected Reset to remove the hook, got %v", seen)
+	}
+}
+
+func TestMockServer_StartTimeTiesOrderedByID(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	at := func(t time.Time) *calendar.EventDateTime {
+		return &calendar.EventDateTime{DateTime: t.Format(time.RFC3339)}
+	}
+	for _, id := range []string{"delta", "alpha", "echo", "charlie", "bravo"} {
+		server.AddEvent("primary", &calendar.Event{Id: id, Start: at(start), End: at(start.Add(time.Hour))})
+	}
+	server.AddEvent("primary", &calendar.Event{Id: "aardvark", Start: at(start.Add(time.Hour)), End: at(start.Add(2 * time.Hour))})
+
+	want := "alpha,bravo,charlie,delta,echo,aardvark"
+	for i := 0; i < 10; i++ {
+		events, err := svc.Events.List("primary").SingleEvents(true).OrderBy("startTime").Do()
+		if err != nil {
+			t.Fatalf("failed to list events: %v", err)
+		}
+		var ids []string
+		for _, e := range events.Items {
+			ids = append(ids, e.Id)
+		}
+		if got := strings.Join(ids, ","); got != want {
+			t.Fatalf("list %d returned %s, want %s", i, got, want)
+		}
+	}
+
+	// Ties also split across pages deterministically
+	first, err := svc.Events.List("primary").SingleEvents(true).OrderBy("startTime").MaxResults(3).Do()
+	if err != nil {
+		t.Fatalf("failed to list first page: %v", err)
+	}
+	second, err := svc.Events.List("primary").SingleEvents(true).OrderBy("startTime").MaxResults(3).PageToken(first.NextPageToken).Do()
+	if err != nil {
+		t.Fatalf("failed to list second page: %v", err)
+	}
+	var ids []string
+	for _, e := range append(first.Items, second.Items...) {
+		ids = append(ids, e.Id)
+	}
+	if got := strings.Join(ids, ","); got != want {
+		t.Errorf("paged list returned %s, want %s", got, want)
+	}
+}
+
+func TestMockServer_SetClock(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	now := time.Date(2024, 2, 29, 12, 30, 0, 0, time.UTC)
+	server.SetClock(func() time.Time { return now })
+
+	created, err := svc.Events.Insert("primary", &calendar.Event{Summary: "Leap day"}).Do()
+	if err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+	if created.Created != "2024-02-29T12:30:00Z" || created.Updated != created.Created {
+		t.Errorf("expected Created and Updated at the frozen time, got %q / %q", created.Created, created.Updated)
+	}
+
+	now = now.Add(90 * time.Minute)
+	updated, err := svc.Events.Patch("primary", created.Id, &calendar.Event{Summary: "Leap day party"}).Do()
+	if err != nil {
+		t.Fatalf("failed to patch event: %v", err)
+	}
+	if updated.Created != "2024-02-29T12:30:00Z" || updated.Updated != "2024-02-29T14:00:00Z" {
+		t.Errorf("expected Created kept and Updated advanced, got %q / %q", updated.Created, updated.Updated)
+	}
+
+	// A nil clock goes back to the wall clock
+	server.SetClock(nil)
+	added, err := svc.Events.QuickAdd("primary", "Now").Do()
+	if err != nil {
+		t.Fatalf("failed to quick add event: %v", err)
+	}
+	if stamped, err := time.Parse(time.RFC3339, added.Created); err != nil || time.Since(stamped) > time.Minute {
+		t.Errorf("expected a current Created time, got %q", added.Created)
+	}
+}
+
+func TestMockServer_StrictCalendars(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	statusOf := func(err error) int {
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) {
+			return apiErr.Code
+		}
+		return 0
+	}
+
+	// Lenient by default: unknown calendars act as empty ones
+	events, err := svc.Events.List("nobody@example.com").Do()
+	if err != nil || len(events.Items) != 0 {
+		t.Fatalf("expected an empty list for an unknown calendar, got %v, %v", events, err)
+	}
+	if _, err := svc.Events.Insert("scratch@example.com", &calendar.Event{Summary: "Note"}).Do(); err != nil {
+		t.Fatalf("expected lenient inserts to create the calendar, got %v", err)
+	}
+
+	server.StrictCalendars(true)
+	server.AddCalendar(&calendar.CalendarListEntry{Id: "team@example.com"})
+	server.AddEvent("seeded@example.com", &calendar.Event{Id: "seeded"})
+
+	// Known calendars, even empty ones, still work
+	for _, id := range []string{"primary", "user@example.com", "team@example.com", "seeded@example.com", "scratch@example.com"} {
+		if _, err := svc.Events.List(id).Do(); err != nil {
+			t.Errorf("expected listing known calendar %s to succeed, got %v", id, err)
+		}
+	}
+	if _, err := svc.Events.Insert("team@example.com", &calendar.Event{Summary: "Sync"}).Do(); err != nil {
+		t.Errorf("expected inserting into a registered calendar to succeed, got %v", err)
+	}
+
+	// Unknown calendars fail with notFound, without creating storage
+	if _, err := svc.Events.List("nobody@example.com").Do(); statusOf(err) != http.StatusNotFound {
+		t.Errorf("expected 404 listing an unknown calendar, got %v", err)
+	}
+	if _, err := svc.Events.Insert("nobody@example.com", &calendar.Event{Summary: "Lost"}).Do(); statusOf(err) != http.StatusNotFound {
+		t.Errorf("expected 404 inserting into an unknown calendar, got %v", err)
+	}
+	if _, err := svc.Events.QuickAdd("nobody@example.com", "Lunch").Do(); statusOf(err) != http.StatusNotFound {
+		t.Errorf("expected 404 quick-adding to an unknown calendar, got %v", err)
+	}
+	if got := server.GetEvents("nobody@example.com"); len(got) != 0 {
+		t.Errorf("expected no events stored for an unknown calendar, got %d", len(got))
+	}
+
+	// Blank calendar IDs are malformed in either mode
+	server.StrictCalendars(false)
+	if _, err := svc.Events.List(" ").Do(); statusOf(err) != http.StatusBadRequest {
+		t.Errorf("expected 400 for a blank calendar ID, got %v", err)
+	}
+}
+
+func TestMockServer_TimeZoneParameter(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	server.AddEvent("primary", &calendar.Event{
+		Id:    "call",
+		Start: &calendar.EventDateTime{DateTime: "2024-06-03T09:00:00-04:00", TimeZone: "America/New_York"},
+		End:   &calendar.EventDateTime{DateTime: "2024-06-03T10:00:00-04:00", TimeZone: "America/New_York"},
+	})
+	server.AddEvent("primary", &calendar.Event{
+		Id:    "holiday",
+		Start: &calendar.EventDateTime{Date: "2024-06-04"},
+		End:   &calendar.EventDateTime{Date: "2024-06-05"},
+	})
+
+	event, err := svc.Events.Get("primary", "call").TimeZone("Asia/Tokyo").Do()
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	if event.Start.DateTime != "2024-06-03T22:00:00+09:00" || event.Start.TimeZone != "Asia/Tokyo" {
+		t.Errorf("expected the start in Tokyo time, got %+v", event.Start)
+	}
+	if event.End.DateTime != "2024-06-03T23:00:00+09:00" {
+		t.Errorf("expected the end in Tokyo time, got %+v", event.End)
+	}
+
+	events, err := svc.Events.List("primary").TimeZone("Asia/Tokyo").Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if events.TimeZone != "Asia/Tokyo" {
+		t.Errorf("expected the list labelled Asia/Tokyo, got %q", events.TimeZone)
+	}
+	for _, e := range events.Items {
+		switch e.Id {
+		case "call":
+			if e.Start.DateTime != "2024-06-03T22:00:00+09:00" {
+				t.Errorf("expected the listed start in Tokyo time, got %s", e.Start.DateTime)
+			}
+		case "holiday":
+			if e.Start.Date != "2024-06-04" || e.Start.DateTime != "" || e.Start.TimeZone != "" {
+				t.Errorf("expected the all-day date untouched, got %+v", e.Start)
+			}
+		}
+	}
+
+	// Stored events keep their original zone
+	stored, err := svc.Events.Get("primary", "call").Do()
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	if stored.Start.DateTime != "2024-06-03T09:00:00-04:00" || stored.Start.TimeZone != "America/New_York" {
+		t.Errorf("expected the stored start unchanged, got %+v", stored.Start)
+	}
+
+	var apiErr *googleapi.Error
+	if _, err := svc.Events.Get("primary", "call").TimeZone("Mars/Olympus_Mons").Do(); !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown time zone, got %v", err)
+	}
+}
+
+func TestMockServer_MaxAttendees(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	server.SetCurrentUser("me@example.com")
+	server.AddEvent("primary", &calendar.Event{Id: "big", Attendees: []*calendar.EventAttendee{
+		{Email: "ann@example.com"},
+		{Email: "bob@example.com"},
+		{Email: "cat@example.com"},
+		{Email: "me@example.com"},
+		{Email: "dan@example.com"},
+	}})
+	server.AddEvent("primary", &calendar.Event{Id: "small", Attendees: []*calendar.EventAttendee{
+		{Email: "ann@example.com"},
+	}})
+
+	emails := func(e *calendar.Event) string {
+		var out []string
+		for _, a := range e.Attendees {
+			out = append(out, a.Email)
+		}
+		return strings.Join(out, ",")
+	}
+
+	// The signed-in user is kept, in place, when the list is cut
+	event, err := svc.Events.Get("primary", "big").MaxAttendees(2).Do()
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	if got := emails(event); got != "ann@example.com,me@example.com" || !event.AttendeesOmitted {
+		t.Errorf("expected ann and the user with attendeesOmitted, got %q (omitted %v)", got, event.AttendeesOmitted)
+	}
+
+	events, err := svc.Events.List("primary").MaxAttendees(3).Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	for _, e := range events.Items {
+		switch e.Id {
+		case "big":
+			if got := emails(e); got != "ann@example.com,bob@example.com,me@example.com" {
+				t.Errorf("big event attendees = %q", got)
+			}
+		case "small":
+			if e.AttendeesOmitted || len(e.Attendees) != 1 {
+				t.Errorf("expected a small event to be untouched, got %+v", e)
+			}
+		}
+	}
+
+	// The stored event keeps every attendee
+	if got := len(server.GetEvents("primary")); got != 2 {
+		t.Fatalf("expected 2 events, got %d", got)
+	}
+	for _, e := range server.GetEvents("primary") {
+		if e.Id == "big" && (len(e.Attendees) != 5 || e.AttendeesOmitted) {
+			t.Errorf("expected the stored event to be unchanged, got %+v", e)
+		}
+	}
+
+	// Like Google, values below 1 are rejected
+	var apiErr *googleapi.Error
+	if _, err := svc.Events.List("primary").MaxAttendees(0).Do(); !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for maxAttendees=0, got %v", err)
+	}
+}
+
+func TestMockServer_IfMatch(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	created, err := svc.Events.Insert("primary", &calendar.Event{Summary: "Draft"}).Do()
+	if err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+	staleEtag := created.Etag
+
+	// A matching ETag lets the update through and yields a new ETag
+	update := svc.Events.Update("primary", created.Id, &calendar.Event{Summary: "Final"})
+	update.Header().Set("If-Match", staleEtag)
+	updated, err := update.Do()
+	if err != nil {
+		t.Fatalf("expected a matching If-Match to succeed: %v", err)
+	}
+	if updated.Etag == staleEtag {
+		t.Errorf("expected the ETag to change on update, still %s", updated.Etag)
+	}
+
+	// The old ETag no longer matches, for updates or deletes
+	var apiErr *googleapi.Error
+	update = svc.Events.Update("primary", created.Id, &calendar.Event{Summary: "Lost update"})
+	update.Header().Set("If-Match", staleEtag)
+	if _, err := update.Do(); !errors.As(err, &apiErr) || apiErr.Code != http.StatusPreconditionFailed {
+		t.Errorf("expected a 412 for a stale ETag, got %v", err)
+	}
+	del := svc.Events.Delete("primary", created.Id)
+	del.Header().Set("If-Match", staleEtag)
+	if err := del.Do(); !errors.As(err, &apiErr) || apiErr.Code != http.StatusPreconditionFailed {
+		t.Errorf("expected a 412 deleting with a stale ETag, got %v", err)
+	}
+
+	stored := server.GetEvents("primary")
+	if len(stored) != 1 || stored[0].Summary != "Final" {
+		t.Errorf("expected the event to be unchanged by rejected requests, got %+v", stored)
+	}
+
+	// Events added directly get an ETag too
+	server.AddEvent("primary", &calendar.Event{Id: "seeded"})
+	if seeded, err := svc.Events.Get("primary", "seeded").Do(); err != nil || seeded.Etag == "" {
+		t.Errorf("expected a seeded event to have an ETag, got %v (%v)", seeded, err)
+	}
+}
+
+func TestMockServer_AllDayFilteringInCalendarTimeZone(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	// March 10 in Tokyo (UTC+9) runs from 15:00 UTC on the 9th to 15:00 UTC
+	// on the 10th
+	server.AddCalendar(&calendar.CalendarListEntry{Id: "tokyo", TimeZone: "Asia/Tokyo"})
+	for _, id := range []string{"tokyo", "utc"} {
+		server.AddEvent(id, &calendar.Event{
+			Id:      "holiday",
+			Summary: "Holiday",
+			Start:   &calendar.EventDateTime{Date: "2026-03-10"},
+			End:     &calendar.EventDateTime{Date: "2026-03-11"},
+		})
+	}
+
+	evening9th := [2]string{"2026-03-09T16:00:00Z", "2026-03-09T18:00:00Z"}
+	evening10th := [2]string{"2026-03-10T16:00:00Z", "2026-03-10T18:00:00Z"}
+	tests := []struct {
+		name       string
+		calendarID string
+		window     [2]string
+		timeZone   string
+		want       bool
+	}{
+		{name: "calendar zone, inside the day", calendarID: "tokyo", window: evening9th, want: true},
+		{name: "calendar zone, after the day", calendarID: "tokyo", window: evening10th, want: false},
+		// Calendars without a zone keep UTC days
+		{name: "UTC, before the day", calendarID: "utc", window: evening9th, want: false},
+		{name: "UTC, inside the day", calendarID: "utc", window: evening10th, want: true},
+		// An explicit timeZone wins over the calendar's
+		{name: "requested zone", calendarID: "tokyo", window: evening10th, timeZone: "UTC", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			call := svc.Events.List(tt.calendarID).TimeMin(tt.window[0]).TimeMax(tt.window[1])
+			if tt.timeZone != "" {
+				call = call.TimeZone(tt.timeZone)
+			}
+			events, err := call.Do()
+			if err != nil {
+				t.Fatalf("failed to list events: %v", err)
+			}
+			if got := len(events.Items) == 1; got != tt.want {
+				t.Errorf("matched = %v, want %v (items: %d)", got, tt.want, len(events.Items))
+			}
+		})
+	}
+}
+
+func TestMockServer_ListMetadata(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, err := calendar.NewService(ctx, option.WithHTTPClient(&http.Client{}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+
+	server.SetCurrentUser("me@example.com")
+	server.AddCalendar(&calendar.CalendarListEntry{Id: "team", Summary: "Team", TimeZone: "Europe/Berlin", AccessRole: "reader"})
+	for _, id := range []string{"primary", "team", "scratch"} {
+		server.AddEvent(id, &calendar.Event{Id: "standup", Summary: "Standup"})
+	}
+
+	tests := []struct {
+		calendarID string
+		summary    string
+		timeZone   string
+		accessRole string
+	}{
+		{calendarID: "primary", summary: "me@example.com", timeZone: "UTC", accessRole: "owner"},
+		{calendarID: "team", summary: "Team", timeZone: "Europe/Berlin", accessRole: "reader"},
+		// Calendars never added are described by their ID
+		{calendarID: "scratch", summary: "scratch", timeZone: "UTC", accessRole: "owner"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.calendarID, func(t *testing.T) {
+			events, err := svc.Events.List(tt.calendarID).Do()
+			if err != nil {
+				t.Fatalf("failed to list events: %v", err)
+			}
+			if events.Summary != tt.summary || events.TimeZone != tt.timeZone || events.AccessRole != tt.accessRole {
+				t.Errorf("got summary %q, timeZone %q, accessRole %q; want %q, %q, %q",
+					events.Summary, events.TimeZone, events.AccessRole, tt.summary, tt.timeZone, tt.accessRole)
+			}
+			if events.NextSyncToken == "" || events.NextPageToken != "" {
+				t.Errorf("expected a full list to end with a sync token, got sync %q page %q", events.NextSyncToken, events.NextPageToken)
+			}
+		})
+	}
+
+	// Only the last page of a paged list carries the sync token
+	server.AddEvent("team", &calendar.Event{Id: "retro", Summary: "Retro"})
+	first, err := svc.Events.List("team").MaxResults(1).Do()
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if first.NextSyncToken != "" || first.NextPageToken == "" || first.TimeZone != "Europe/Berlin" {
+		t.Errorf("expected a page token and metadata on the first page, got %+v", first)
+	}
+}
@@ -0,0 +1,78 @@
+package googlecaltest
+
+import (
+	"encoding/json"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// Snapshot captures the server's current events and ID counter and returns a
+// function that restores them, discarding anything done in between. This lets
+// table-driven tests share a seeded baseline without re-seeding per case:
+//
+//	restore := server.Snapshot()
+//	defer restore()
+//
+// Restoring expires outstanding sync tokens, as Reset does.
+func (s *Server) Snapshot() (restore func()) {
+	s.mu.RLock()
+	events := copyEvents(s.events)
+	tombstones := copyEvents(s.tombstones)
+	changedAt := copyChanges(s.changedAt)
+	nextID, allocatedIDs := s.nextID, s.allocatedIDs
+	s.mu.RUnlock()
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		// Copy again so the snapshot can be restored more than once
+		s.events = copyEvents(events)
+		s.tombstones = copyEvents(tombstones)
+		s.changedAt = copyChanges(changedAt)
+		s.nextID = nextID
+		s.allocatedIDs = allocatedIDs
+
+		// Expire every token issued so far
+		s.changeSeq++
+		s.minSyncSeq = s.changeSeq
+	}
+}
+
+// copyEvents deep-copies a calendarID -> eventID -> event map.
+func copyEvents(events map[string]map[string]*calendar.Event) map[string]map[string]*calendar.Event {
+	out := make(map[string]map[string]*calendar.Event, len(events))
+	for calendarID, calEvents := range events {
+		out[calendarID] = make(map[string]*calendar.Event, len(calEvents))
+		for eventID, evt := range calEvents {
+			out[calendarID][eventID] = copyEvent(evt)
+		}
+	}
+	return out
+}
+
+// copyChanges copies a calendarID -> eventID -> changeSeq map.
+func copyChanges(changedAt map[string]map[string]int64) map[string]map[string]int64 {
+	out := make(map[string]map[string]int64, len(changedAt))
+	for calendarID, changes := range changedAt {
+		out[calendarID] = make(map[string]int64, len(changes))
+		for eventID, seq := range changes {
+			out[calendarID][eventID] = seq
+		}
+	}
+	return out
+}
+
+// copyEvent deep-copies an event by round-tripping it through JSON, which
+// covers every nested pointer and slice the API type carries.
+func copyEvent(evt *calendar.Event) *calendar.Event {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		panic("googlecaltest: failed to copy event: " + err.Error())
+	}
+	var out calendar.Event
+	if err := json.Unmarshal(data, &out); err != nil {
+		panic("googlecaltest: failed to copy event: " + err.Error())
+	}
+	return &out
+}
@@ -0,0 +1,69 @@
+package googlecaltest
+
+import (
+	"fmt"
+	"strconv"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// recordChange bumps the change counter and stamps eventID with it so the
+// event is included in syncs from earlier tokens, and notifies any channels
+// watching the calendar. Callers must hold s.mu.
+func (s *Server) recordChange(calendarID, eventID string) {
+	s.changeSeq++
+	if s.changedAt[calendarID] == nil {
+		s.changedAt[calendarID] = make(map[string]int64)
+	}
+	s.changedAt[calendarID][eventID] = s.changeSeq
+	s.notifyWatchers(calendarID)
+}
+
+// recordRemoval keeps a cancelled copy of a hard-deleted event so incremental
+// syncs can still report the deletion. Callers must hold s.mu.
+func (s *Server) recordRemoval(calendarID string, event *calendar.Event) {
+	tombstone := *event
+	tombstone.Status = "cancelled"
+	if s.tombstones[calendarID] == nil {
+		s.tombstones[calendarID] = make(map[string]*calendar.Event)
+	}
+	s.tombstones[calendarID][event.Id] = &tombstone
+	s.recordChange(calendarID, event.Id)
+}
+
+// etag returns an entity tag for the latest change. Callers must hold s.mu.
+func (s *Server) etag() string {
+	return fmt.Sprintf(`"%d"`, s.changeSeq)
+}
+
+// syncToken returns the token describing the server's current state.
+func (s *Server) syncToken() string {
+	return strconv.FormatInt(s.changeSeq, 10)
+}
+
+// parseSyncToken returns the change counter encoded in token, reporting false
+// for tokens that are malformed, from the future, or issued before a Reset.
+func (s *Server) parseSyncToken(token string) (int64, bool) {
+	seq, err := strconv.ParseInt(token, 10, 64)
+	if err != nil || seq < s.minSyncSeq || seq > s.changeSeq {
+		return 0, false
+	}
+	return seq, true
+}
+
+// changedSince returns the events (including cancelled tombstones) of a
+// calendar whose latest change is newer than seq.
+func (s *Server) changedSince(calendarID string, seq int64) []*calendar.Event {
+	var events []*calendar.Event
+	for eventID, changed := range s.changedAt[calendarID] {
+		if changed <= seq {
+			continue
+		}
+		if evt := s.events[calendarID][eventID]; evt != nil {
+			events = append(events, evt)
+		} else if tombstone := s.tombstones[calendarID][eventID]; tombstone != nil {
+			events = append(events, tombstone)
+		}
+	}
+	return events
+}
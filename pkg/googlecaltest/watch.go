@@ -0,0 +1,148 @@
+package googlecaltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// defaultChannelTTL is how long a watch channel lives when the request does
+// not ask for an expiration, matching Google's one-week default.
+const defaultChannelTTL = 7 * 24 * time.Hour
+
+// watchChannel is a registered push-notification channel.
+type watchChannel struct {
+	channel    calendar.Channel
+	calendarID string
+	messages   int64         // notifications sent so far, for X-Goog-Message-Number
+	delivered  chan struct{} // closed once the latest notification is delivered
+}
+
+// watchEvents handles POST /calendars/{calendarId}/events/watch
+func (s *Server) watchEvents(w http.ResponseWriter, r *http.Request, calendarID string) {
+	var req calendar.Channel
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "parseError", fmt.Sprintf("invalid JSON: %v", err))
+		return
+	}
+	if req.Id == "" || req.Address == "" {
+		writeAPIError(w, http.StatusBadRequest, "required", "channel id and address are required")
+		return
+	}
+	if req.Type != "web_hook" && req.Type != "webhook" {
+		writeAPIError(w, http.StatusBadRequest, "invalid", fmt.Sprintf("unsupported channel type %q", req.Type))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.channels[req.Id]; exists {
+		writeAPIError(w, http.StatusBadRequest, "channelIdNotUnique", fmt.Sprintf("channel id %s not unique", req.Id))
+		return
+	}
+
+	expiration := req.Expiration
+	if expiration == 0 {
+		expiration = s.clock().Add(defaultChannelTTL).UnixMilli()
+	}
+	s.nextChannel++
+	ch := &watchChannel{
+		channel: calendar.Channel{
+			Kind:        "api#channel",
+			Id:          req.Id,
+			ResourceId:  fmt.Sprintf("resource%d", s.nextChannel),
+			ResourceUri: fmt.Sprintf("%s/calendars/%s/events", s.URL, calendarID),
+			Token:       req.Token,
+			Expiration:  expiration,
+			Address:     req.Address,
+			Type:        req.Type,
+		},
+		calendarID: calendarID,
+	}
+	s.channels[req.Id] = ch
+
+	// Google confirms every new channel with a "sync" message
+	s.notify(ch, "sync")
+
+	resp := ch.channel
+	resp.Address = ""
+	resp.Type = ""
+	s.writeJSON(w, r, &resp)
+}
+
+// stopChannel handles POST /channels/stop
+func (s *Server) stopChannel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, http.StatusMethodNotAllowed, "httpMethodNotAllowed", "method not allowed")
+		return
+	}
+	var req calendar.Channel
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "parseError", fmt.Sprintf("invalid JSON: %v", err))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch, ok := s.channels[req.Id]
+	if !ok || ch.channel.ResourceId != req.ResourceId {
+		writeAPIError(w, http.StatusNotFound, "notFound", fmt.Sprintf("channel %q not found for resource %q", req.Id, req.ResourceId))
+		return
+	}
+	delete(s.channels, req.Id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// notifyWatchers tells every live channel on calendarID that its events
+// changed. Callers must hold s.mu.
+func (s *Server) notifyWatchers(calendarID string) {
+	now := s.clock().UnixMilli()
+	for id, ch := range s.channels {
+		if ch.channel.Expiration <= now {
+			delete(s.channels, id)
+			continue
+		}
+		if ch.calendarID == calendarID {
+			s.notify(ch, "exists")
+		}
+	}
+}
+
+// notify POSTs a push notification for ch in the background, so handlers
+// holding s.mu never wait on (or deadlock with) the callback. Each channel's
+// notifications are delivered in order. Callers must hold s.mu.
+func (s *Server) notify(ch *watchChannel, state string) {
+	ch.messages++
+	req, err := http.NewRequest(http.MethodPost, ch.channel.Address, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("X-Goog-Channel-ID", ch.channel.Id)
+	if ch.channel.Token != "" {
+		req.Header.Set("X-Goog-Channel-Token", ch.channel.Token)
+	}
+	req.Header.Set("X-Goog-Channel-Expiration", time.UnixMilli(ch.channel.Expiration).UTC().Format(http.TimeFormat))
+	req.Header.Set("X-Goog-Resource-ID", ch.channel.ResourceId)
+	req.Header.Set("X-Goog-Resource-URI", ch.channel.ResourceUri)
+	req.Header.Set("X-Goog-Resource-State", state)
+	req.Header.Set("X-Goog-Message-Number", strconv.FormatInt(ch.messages, 10))
+
+	prev, done := ch.delivered, make(chan struct{})
+	ch.delivered = done
+	go func() {
+		defer close(done)
+		if prev != nil {
+			<-prev
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+}
@@ -7,6 +7,7 @@
 package proto
 
 import (
+	_ "github.com/drewfead/proto-cli/proto/cli/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -22,6 +23,64 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// EditScope says how much of a recurring series an update to one of its
+// instances changes
+type EditScope int32
+
+const (
+	// not a recurring update; an instance is updated on its own
+	EditScope_EDIT_SCOPE_UNSPECIFIED EditScope = 0
+	// just the instance event_id names, which becomes an exception
+	EditScope_EDIT_SCOPE_SINGLE EditScope = 1
+	// the instance and every later one, splitting the series in two
+	EditScope_EDIT_SCOPE_FOLLOWING EditScope = 2
+	// the whole series
+	EditScope_EDIT_SCOPE_ALL EditScope = 3
+)
+
+// Enum value maps for EditScope.
+var (
+	EditScope_name = map[int32]string{
+		0: "EDIT_SCOPE_UNSPECIFIED",
+		1: "EDIT_SCOPE_SINGLE",
+		2: "EDIT_SCOPE_FOLLOWING",
+		3: "EDIT_SCOPE_ALL",
+	}
+	EditScope_value = map[string]int32{
+		"EDIT_SCOPE_UNSPECIFIED": 0,
+		"EDIT_SCOPE_SINGLE":      1,
+		"EDIT_SCOPE_FOLLOWING":   2,
+		"EDIT_SCOPE_ALL":         3,
+	}
+)
+
+func (x EditScope) Enum() *EditScope {
+	p := new(EditScope)
+	*p = x
+	return p
+}
+
+func (x EditScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EditScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_calendar_proto_enumTypes[0].Descriptor()
+}
+
+func (EditScope) Type() protoreflect.EnumType {
+	return &file_calendar_proto_enumTypes[0]
+}
+
+func (x EditScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EditScope.Descriptor instead.
+func (EditScope) EnumDescriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{0}
+}
+
 type AddEventRequest struct {
 	state                   protoimpl.MessageState `protogen:"open.v1"`
 	Summary                 string                 `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
@@ -36,7 +95,20 @@ type AddEventRequest struct {
 	IdempotencyKey          *string                `protobuf:"bytes,10,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`                                  // used to set event ID for deduplication
 	SourceTitle             *string                `protobuf:"bytes,11,opt,name=source_title,json=sourceTitle,proto3,oneof" json:"source_title,omitempty"`                                           // title of the source of the event
 	SourceUrl               *string                `protobuf:"bytes,12,opt,name=source_url,json=sourceUrl,proto3,oneof" json:"source_url,omitempty"`                                                 // URL for the source of the event
-	BlocksTime              *bool                  `protobuf:"varint,13,opt,name=blocks_time,json=blocksTime,proto3,oneof" json:"blocks_time,omitempty"`                                             // default false (transparent), true means opaque
+	BlocksTime              *bool                  `protobuf:"varint,13,opt,name=blocks_time,json=blocksTime,proto3,oneof" json:"blocks_time,omitempty"`                                             // unset defaults by event_type (see MapProtoToEvent), true means opaque
+	EventType               *string                `protobuf:"bytes,14,opt,name=event_type,json=eventType,proto3,oneof" json:"event_type,omitempty"`                                                 // default, outOfOffice, focusTime, workingLocation
+	Attendees               *AttendeeList          `protobuf:"bytes,15,opt,name=attendees,proto3,oneof" json:"attendees,omitempty"`                                                                  // guests to invite
+	Recurrence              *Recurrence            `protobuf:"bytes,16,opt,name=recurrence,proto3,oneof" json:"recurrence,omitempty"`                                                                // makes the event a recurring series
+	Reminders               *ReminderList          `protobuf:"bytes,17,opt,name=reminders,proto3,oneof" json:"reminders,omitempty"`                                                                  // overrides the calendar's default reminders
+	TimeZone                *string                `protobuf:"bytes,18,opt,name=time_zone,json=timeZone,proto3,oneof" json:"time_zone,omitempty"`                                                    // IANA name (e.g. America/New_York) for the start and end times, defaults to UTC
+	AllDay                  *bool                  `protobuf:"varint,19,opt,name=all_day,json=allDay,proto3,oneof" json:"all_day,omitempty"`                                                         // use the start (and end) dates only; the end date is exclusive and defaults to the day after the start
+	Visibility              *string                `protobuf:"bytes,20,opt,name=visibility,proto3,oneof" json:"visibility,omitempty"`                                                                // default, public, private, confidential
+	AddConference           *bool                  `protobuf:"varint,21,opt,name=add_conference,json=addConference,proto3,oneof" json:"add_conference,omitempty"`                                    // create a Google Meet link for the event
+	Attachments             *AttachmentList        `protobuf:"bytes,22,opt,name=attachments,proto3,oneof" json:"attachments,omitempty"`                                                              // files (e.g. Google Drive links) to attach
+	SendUpdates             *string                `protobuf:"bytes,23,opt,name=send_updates,json=sendUpdates,proto3,oneof" json:"send_updates,omitempty"`                                           // who Google emails about the new event: none (default), externalOnly, all
+	ColorId                 *string                `protobuf:"bytes,24,opt,name=color_id,json=colorId,proto3,oneof" json:"color_id,omitempty"`                                                       // Google event color, "1" to "11" (defaults to the calendar's color)
+	PrivateProperties       *PropertyMap           `protobuf:"bytes,25,opt,name=private_properties,json=privateProperties,proto3,oneof" json:"private_properties,omitempty"`                         // extended properties visible only on this calendar's copy
+	SharedProperties        *PropertyMap           `protobuf:"bytes,26,opt,name=shared_properties,json=sharedProperties,proto3,oneof" json:"shared_properties,omitempty"`                            // extended properties visible to every attendee
 	unknownFields           protoimpl.UnknownFields
 	sizeCache               protoimpl.SizeCache
 }
@@ -162,6 +234,97 @@ func (x *AddEventRequest) GetBlocksTime() bool {
 	return false
 }
 
+func (x *AddEventRequest) GetEventType() string {
+	if x != nil && x.EventType != nil {
+		return *x.EventType
+	}
+	return ""
+}
+
+func (x *AddEventRequest) GetAttendees() *AttendeeList {
+	if x != nil {
+		return x.Attendees
+	}
+	return nil
+}
+
+func (x *AddEventRequest) GetRecurrence() *Recurrence {
+	if x != nil {
+		return x.Recurrence
+	}
+	return nil
+}
+
+func (x *AddEventRequest) GetReminders() *ReminderList {
+	if x != nil {
+		return x.Reminders
+	}
+	return nil
+}
+
+func (x *AddEventRequest) GetTimeZone() string {
+	if x != nil && x.TimeZone != nil {
+		return *x.TimeZone
+	}
+	return ""
+}
+
+func (x *AddEventRequest) GetAllDay() bool {
+	if x != nil && x.AllDay != nil {
+		return *x.AllDay
+	}
+	return false
+}
+
+func (x *AddEventRequest) GetVisibility() string {
+	if x != nil && x.Visibility != nil {
+		return *x.Visibility
+	}
+	return ""
+}
+
+func (x *AddEventRequest) GetAddConference() bool {
+	if x != nil && x.AddConference != nil {
+		return *x.AddConference
+	}
+	return false
+}
+
+func (x *AddEventRequest) GetAttachments() *AttachmentList {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *AddEventRequest) GetSendUpdates() string {
+	if x != nil && x.SendUpdates != nil {
+		return *x.SendUpdates
+	}
+	return ""
+}
+
+func (x *AddEventRequest) GetColorId() string {
+	if x != nil && x.ColorId != nil {
+		return *x.ColorId
+	}
+	return ""
+}
+
+func (x *AddEventRequest) GetPrivateProperties() *PropertyMap {
+	if x != nil {
+		return x.PrivateProperties
+	}
+	return nil
+}
+
+func (x *AddEventRequest) GetSharedProperties() *PropertyMap {
+	if x != nil {
+		return x.SharedProperties
+	}
+	return nil
+}
+
 type AddEventResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
@@ -169,6 +332,7 @@ type AddEventResponse struct {
 	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 	HtmlLink      string                 `protobuf:"bytes,4,opt,name=html_link,json=htmlLink,proto3" json:"html_link,omitempty"`       // Link to view in Google Calendar
 	CalendarId    string                 `protobuf:"bytes,5,opt,name=calendar_id,json=calendarId,proto3" json:"calendar_id,omitempty"` // Which calendar was used
+	SelfLink      string                 `protobuf:"bytes,6,opt,name=self_link,json=selfLink,proto3" json:"self_link,omitempty"`       // API URL for re-fetching the event
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -238,21 +402,40 @@ func (x *AddEventResponse) GetCalendarId() string {
 	return ""
 }
 
+func (x *AddEventResponse) GetSelfLink() string {
+	if x != nil {
+		return x.SelfLink
+	}
+	return ""
+}
+
 type UpdateEventRequest struct {
 	state                   protoimpl.MessageState `protogen:"open.v1"`
 	EventId                 string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
 	CalendarId              *string                `protobuf:"bytes,2,opt,name=calendar_id,json=calendarId,proto3,oneof" json:"calendar_id,omitempty"` // defaults to "primary"
-	Summary                 *string                `protobuf:"bytes,3,opt,name=summary,proto3,oneof" json:"summary,omitempty"`
-	Description             *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"` // supports HTML
+	Summary                 *string                `protobuf:"bytes,3,opt,name=summary,proto3,oneof" json:"summary,omitempty"`                         // unset leaves it unchanged; "" clears it
+	Description             *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`                 // supports HTML; unset leaves it unchanged, "" clears it
 	StartTime               *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3,oneof" json:"start_time,omitempty"`
 	EndTime                 *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3,oneof" json:"end_time,omitempty"`
-	Location                *string                `protobuf:"bytes,7,opt,name=location,proto3,oneof" json:"location,omitempty"`
+	Location                *string                `protobuf:"bytes,7,opt,name=location,proto3,oneof" json:"location,omitempty"` // unset leaves it unchanged; "" clears it
 	GuestsCanSeeOtherGuests *bool                  `protobuf:"varint,8,opt,name=guests_can_see_other_guests,json=guestsCanSeeOtherGuests,proto3,oneof" json:"guests_can_see_other_guests,omitempty"`
 	GuestsCanModify         *bool                  `protobuf:"varint,9,opt,name=guests_can_modify,json=guestsCanModify,proto3,oneof" json:"guests_can_modify,omitempty"`
 	GuestsCanInviteOthers   *bool                  `protobuf:"varint,10,opt,name=guests_can_invite_others,json=guestsCanInviteOthers,proto3,oneof" json:"guests_can_invite_others,omitempty"`
 	SourceTitle             *string                `protobuf:"bytes,11,opt,name=source_title,json=sourceTitle,proto3,oneof" json:"source_title,omitempty"`
 	SourceUrl               *string                `protobuf:"bytes,12,opt,name=source_url,json=sourceUrl,proto3,oneof" json:"source_url,omitempty"`
 	BlocksTime              *bool                  `protobuf:"varint,13,opt,name=blocks_time,json=blocksTime,proto3,oneof" json:"blocks_time,omitempty"`
+	Attendees               *AttendeeList          `protobuf:"bytes,14,opt,name=attendees,proto3,oneof" json:"attendees,omitempty"`                                          // replaces the guest list when set (empty removes all guests)
+	Recurrence              *Recurrence            `protobuf:"bytes,15,opt,name=recurrence,proto3,oneof" json:"recurrence,omitempty"`                                        // replaces the recurrence rules when set (empty ends the series' recurrence)
+	Reminders               *ReminderList          `protobuf:"bytes,16,opt,name=reminders,proto3,oneof" json:"reminders,omitempty"`                                          // replaces the reminder overrides when set (empty restores the calendar's defaults)
+	TimeZone                *string                `protobuf:"bytes,17,opt,name=time_zone,json=timeZone,proto3,oneof" json:"time_zone,omitempty"`                            // IANA name (e.g. America/New_York) to express the start and end times in
+	Visibility              *string                `protobuf:"bytes,18,opt,name=visibility,proto3,oneof" json:"visibility,omitempty"`                                        // default, public, private, confidential
+	Attachments             *AttachmentList        `protobuf:"bytes,19,opt,name=attachments,proto3,oneof" json:"attachments,omitempty"`                                      // replaces the attachments when set (empty removes them all)
+	SendUpdates             *string                `protobuf:"bytes,20,opt,name=send_updates,json=sendUpdates,proto3,oneof" json:"send_updates,omitempty"`                   // who Google emails about the change: none (default), externalOnly, all
+	ColorId                 *string                `protobuf:"bytes,21,opt,name=color_id,json=colorId,proto3,oneof" json:"color_id,omitempty"`                               // Google event color, "1" to "11"; "" restores the calendar's color
+	PrivateProperties       *PropertyMap           `protobuf:"bytes,22,opt,name=private_properties,json=privateProperties,proto3,oneof" json:"private_properties,omitempty"` // merged into the private extended properties; an empty value deletes the key
+	SharedProperties        *PropertyMap           `protobuf:"bytes,23,opt,name=shared_properties,json=sharedProperties,proto3,oneof" json:"shared_properties,omitempty"`    // merged into the shared extended properties; an empty value deletes the key
+	EditScope               EditScope              `protobuf:"varint,24,opt,name=edit_scope,json=editScope,proto3,enum=calendar.EditScope" json:"edit_scope,omitempty"`      // for recurring events: how much of the series to change
+	AllDay                  *bool                  `protobuf:"varint,25,opt,name=all_day,json=allDay,proto3,oneof" json:"all_day,omitempty"`                                 // switch the event to dates only (true) or date-times (false); unset keeps its current kind
 	unknownFields           protoimpl.UnknownFields
 	sizeCache               protoimpl.SizeCache
 }
@@ -378,6 +561,90 @@ func (x *UpdateEventRequest) GetBlocksTime() bool {
 	return false
 }
 
+func (x *UpdateEventRequest) GetAttendees() *AttendeeList {
+	if x != nil {
+		return x.Attendees
+	}
+	return nil
+}
+
+func (x *UpdateEventRequest) GetRecurrence() *Recurrence {
+	if x != nil {
+		return x.Recurrence
+	}
+	return nil
+}
+
+func (x *UpdateEventRequest) GetReminders() *ReminderList {
+	if x != nil {
+		return x.Reminders
+	}
+	return nil
+}
+
+func (x *UpdateEventRequest) GetTimeZone() string {
+	if x != nil && x.TimeZone != nil {
+		return *x.TimeZone
+	}
+	return ""
+}
+
+func (x *UpdateEventRequest) GetVisibility() string {
+	if x != nil && x.Visibility != nil {
+		return *x.Visibility
+	}
+	return ""
+}
+
+func (x *UpdateEventRequest) GetAttachments() *AttachmentList {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *UpdateEventRequest) GetSendUpdates() string {
+	if x != nil && x.SendUpdates != nil {
+		return *x.SendUpdates
+	}
+	return ""
+}
+
+func (x *UpdateEventRequest) GetColorId() string {
+	if x != nil && x.ColorId != nil {
+		return *x.ColorId
+	}
+	return ""
+}
+
+func (x *UpdateEventRequest) GetPrivateProperties() *PropertyMap {
+	if x != nil {
+		return x.PrivateProperties
+	}
+	return nil
+}
+
+func (x *UpdateEventRequest) GetSharedProperties() *PropertyMap {
+	if x != nil {
+		return x.SharedProperties
+	}
+	return nil
+}
+
+func (x *UpdateEventRequest) GetEditScope() EditScope {
+	if x != nil {
+		return x.EditScope
+	}
+	return EditScope_EDIT_SCOPE_UNSPECIFIED
+}
+
+func (x *UpdateEventRequest) GetAllDay() bool {
+	if x != nil && x.AllDay != nil {
+		return *x.AllDay
+	}
+	return false
+}
+
 type UpdateEventResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
@@ -457,7 +724,8 @@ func (x *UpdateEventResponse) GetCalendarId() string {
 type DeleteEventRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
-	CalendarId    *string                `protobuf:"bytes,2,opt,name=calendar_id,json=calendarId,proto3,oneof" json:"calendar_id,omitempty"` // defaults to "primary"
+	CalendarId    *string                `protobuf:"bytes,2,opt,name=calendar_id,json=calendarId,proto3,oneof" json:"calendar_id,omitempty"`    // defaults to "primary"
+	SendUpdates   *string                `protobuf:"bytes,3,opt,name=send_updates,json=sendUpdates,proto3,oneof" json:"send_updates,omitempty"` // who Google emails about the cancellation: none (default), externalOnly, all
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -506,6 +774,13 @@ func (x *DeleteEventRequest) GetCalendarId() string {
 	return ""
 }
 
+func (x *DeleteEventRequest) GetSendUpdates() string {
+	if x != nil && x.SendUpdates != nil {
+		return *x.SendUpdates
+	}
+	return ""
+}
+
 type DeleteEventResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -567,11 +842,12 @@ func (x *DeleteEventResponse) GetCalendarId() string {
 }
 
 type GetEventRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
-	CalendarId    *string                `protobuf:"bytes,2,opt,name=calendar_id,json=calendarId,proto3,oneof" json:"calendar_id,omitempty"` // defaults to "primary"
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EventId        string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	CalendarId     *string                `protobuf:"bytes,2,opt,name=calendar_id,json=calendarId,proto3,oneof" json:"calendar_id,omitempty"`              // defaults to "primary"
+	IncludeDeleted *bool                  `protobuf:"varint,3,opt,name=include_deleted,json=includeDeleted,proto3,oneof" json:"include_deleted,omitempty"` // return a deleted event as its tombstone (status cancelled) instead of not found
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GetEventRequest) Reset() {
@@ -618,6 +894,13 @@ func (x *GetEventRequest) GetCalendarId() string {
 	return ""
 }
 
+func (x *GetEventRequest) GetIncludeDeleted() bool {
+	if x != nil && x.IncludeDeleted != nil {
+		return *x.IncludeDeleted
+	}
+	return false
+}
+
 type GetEventResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Event         *Event                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
@@ -662,35 +945,28 @@ func (x *GetEventResponse) GetEvent() *Event {
 	return nil
 }
 
-type ListEventsRequest struct {
-	state      protoimpl.MessageState `protogen:"open.v1"`
-	CalendarId *string                `protobuf:"bytes,1,opt,name=calendar_id,json=calendarId,proto3,oneof" json:"calendar_id,omitempty"` // defaults to "primary"
-	// Time filtering options (mutually exclusive with after/before)
-	After  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=after,proto3,oneof" json:"after,omitempty"`   // only events after this time
-	Before *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=before,proto3,oneof" json:"before,omitempty"` // only events before this time
-	// Predefined time scopes (mutually exclusive with each other and with after/before)
-	Future        *bool   `protobuf:"varint,4,opt,name=future,proto3,oneof" json:"future,omitempty"` // events after now
-	Past          *bool   `protobuf:"varint,5,opt,name=past,proto3,oneof" json:"past,omitempty"`     // events before now
-	Limit         *int32  `protobuf:"varint,6,opt,name=limit,proto3,oneof" json:"limit,omitempty"`   // page size (number of events per page)
-	Anchor        *string `protobuf:"bytes,7,opt,name=anchor,proto3,oneof" json:"anchor,omitempty"`  // token for retrieving the next page of results
+type QuickAddRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`                                     // e.g. "Lunch with Sam tomorrow at noon"
+	CalendarId    *string                `protobuf:"bytes,2,opt,name=calendar_id,json=calendarId,proto3,oneof" json:"calendar_id,omitempty"` // defaults to "primary"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListEventsRequest) Reset() {
-	*x = ListEventsRequest{}
+func (x *QuickAddRequest) Reset() {
+	*x = QuickAddRequest{}
 	mi := &file_calendar_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListEventsRequest) String() string {
+func (x *QuickAddRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListEventsRequest) ProtoMessage() {}
+func (*QuickAddRequest) ProtoMessage() {}
 
-func (x *ListEventsRequest) ProtoReflect() protoreflect.Message {
+func (x *QuickAddRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_calendar_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -702,82 +978,46 @@ func (x *ListEventsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListEventsRequest.ProtoReflect.Descriptor instead.
-func (*ListEventsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use QuickAddRequest.ProtoReflect.Descriptor instead.
+func (*QuickAddRequest) Descriptor() ([]byte, []int) {
 	return file_calendar_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ListEventsRequest) GetCalendarId() string {
-	if x != nil && x.CalendarId != nil {
-		return *x.CalendarId
-	}
-	return ""
-}
-
-func (x *ListEventsRequest) GetAfter() *timestamppb.Timestamp {
-	if x != nil {
-		return x.After
-	}
-	return nil
-}
-
-func (x *ListEventsRequest) GetBefore() *timestamppb.Timestamp {
+func (x *QuickAddRequest) GetText() string {
 	if x != nil {
-		return x.Before
-	}
-	return nil
-}
-
-func (x *ListEventsRequest) GetFuture() bool {
-	if x != nil && x.Future != nil {
-		return *x.Future
-	}
-	return false
-}
-
-func (x *ListEventsRequest) GetPast() bool {
-	if x != nil && x.Past != nil {
-		return *x.Past
-	}
-	return false
-}
-
-func (x *ListEventsRequest) GetLimit() int32 {
-	if x != nil && x.Limit != nil {
-		return *x.Limit
+		return x.Text
 	}
-	return 0
+	return ""
 }
 
-func (x *ListEventsRequest) GetAnchor() string {
-	if x != nil && x.Anchor != nil {
-		return *x.Anchor
+func (x *QuickAddRequest) GetCalendarId() string {
+	if x != nil && x.CalendarId != nil {
+		return *x.CalendarId
 	}
 	return ""
 }
 
-type ListEventsResponse struct {
+type QuickAddResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Event         *Event                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`                                   // the event (present for all messages except potentially the last)
-	NextAnchor    *string                `protobuf:"bytes,2,opt,name=next_anchor,json=nextAnchor,proto3,oneof" json:"next_anchor,omitempty"` // token for the next page (only set on the last message if more results exist)
+	Event         *Event                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"` // the event as Google interpreted the text
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListEventsResponse) Reset() {
-	*x = ListEventsResponse{}
+func (x *QuickAddResponse) Reset() {
+	*x = QuickAddResponse{}
 	mi := &file_calendar_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListEventsResponse) String() string {
+func (x *QuickAddResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListEventsResponse) ProtoMessage() {}
+func (*QuickAddResponse) ProtoMessage() {}
 
-func (x *ListEventsResponse) ProtoReflect() protoreflect.Message {
+func (x *QuickAddResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_calendar_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -789,62 +1029,49 @@ func (x *ListEventsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListEventsResponse.ProtoReflect.Descriptor instead.
-func (*ListEventsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use QuickAddResponse.ProtoReflect.Descriptor instead.
+func (*QuickAddResponse) Descriptor() ([]byte, []int) {
 	return file_calendar_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ListEventsResponse) GetEvent() *Event {
+func (x *QuickAddResponse) GetEvent() *Event {
 	if x != nil {
 		return x.Event
 	}
 	return nil
 }
 
-func (x *ListEventsResponse) GetNextAnchor() string {
-	if x != nil && x.NextAnchor != nil {
-		return *x.NextAnchor
-	}
-	return ""
-}
-
-type Event struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Summary        string                 `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
-	Description    *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	StartTime      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3,oneof" json:"start_time,omitempty"`
-	EndTime        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3,oneof" json:"end_time,omitempty"`
-	Location       *string                `protobuf:"bytes,6,opt,name=location,proto3,oneof" json:"location,omitempty"`
-	HtmlLink       string                 `protobuf:"bytes,7,opt,name=html_link,json=htmlLink,proto3" json:"html_link,omitempty"`
-	CalendarId     string                 `protobuf:"bytes,8,opt,name=calendar_id,json=calendarId,proto3" json:"calendar_id,omitempty"`
-	Status         *string                `protobuf:"bytes,9,opt,name=status,proto3,oneof" json:"status,omitempty"` // confirmed, tentative, cancelled
-	Attendees      []string               `protobuf:"bytes,10,rep,name=attendees,proto3" json:"attendees,omitempty"`
-	Transparency   *string                `protobuf:"bytes,11,opt,name=transparency,proto3,oneof" json:"transparency,omitempty"` // "opaque" (blocks time) or "transparent" (doesn't block time)
-	OrganizerEmail *string                `protobuf:"bytes,12,opt,name=organizer_email,json=organizerEmail,proto3,oneof" json:"organizer_email,omitempty"`
-	OrganizerName  *string                `protobuf:"bytes,13,opt,name=organizer_name,json=organizerName,proto3,oneof" json:"organizer_name,omitempty"`
-	ConferenceUri  *string                `protobuf:"bytes,14,opt,name=conference_uri,json=conferenceUri,proto3,oneof" json:"conference_uri,omitempty"` // Primary video conference link (Google Meet, Zoom, etc.)
-	ConferenceId   *string                `protobuf:"bytes,15,opt,name=conference_id,json=conferenceId,proto3,oneof" json:"conference_id,omitempty"`    // Conference ID (e.g., "abc-defg-hij" for Meet)
-	SourceTitle    *string                `protobuf:"bytes,16,opt,name=source_title,json=sourceTitle,proto3,oneof" json:"source_title,omitempty"`       // Title of the source of the event
-	SourceUrl      *string                `protobuf:"bytes,17,opt,name=source_url,json=sourceUrl,proto3,oneof" json:"source_url,omitempty"`             // URL for the source of the event
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type ImportEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IcalUid       string                 `protobuf:"bytes,1,opt,name=ical_uid,json=icalUid,proto3" json:"ical_uid,omitempty"` // the event's UID in the calendar it comes from
+	Summary       string                 `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"` // supports HTML
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3,oneof" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3,oneof" json:"end_time,omitempty"`
+	Location      *string                `protobuf:"bytes,6,opt,name=location,proto3,oneof" json:"location,omitempty"`
+	CalendarId    *string                `protobuf:"bytes,7,opt,name=calendar_id,json=calendarId,proto3,oneof" json:"calendar_id,omitempty"` // defaults to "primary"
+	TimeZone      *string                `protobuf:"bytes,8,opt,name=time_zone,json=timeZone,proto3,oneof" json:"time_zone,omitempty"`       // IANA name (e.g. America/New_York) for the start and end times, defaults to UTC
+	AllDay        *bool                  `protobuf:"varint,9,opt,name=all_day,json=allDay,proto3,oneof" json:"all_day,omitempty"`            // use the start (and end) dates only; the end date is exclusive and defaults to the day after the start
+	Attendees     *AttendeeList          `protobuf:"bytes,10,opt,name=attendees,proto3,oneof" json:"attendees,omitempty"`                    // the event's guests (Google doesn't email them about an import)
+	Recurrence    *Recurrence            `protobuf:"bytes,11,opt,name=recurrence,proto3,oneof" json:"recurrence,omitempty"`                  // makes the event a recurring series
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Event) Reset() {
-	*x = Event{}
+func (x *ImportEventRequest) Reset() {
+	*x = ImportEventRequest{}
 	mi := &file_calendar_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Event) String() string {
+func (x *ImportEventRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Event) ProtoMessage() {}
+func (*ImportEventRequest) ProtoMessage() {}
 
-func (x *Event) ProtoReflect() protoreflect.Message {
+func (x *ImportEventRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_calendar_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -856,135 +1083,1729 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Event.ProtoReflect.Descriptor instead.
-func (*Event) Descriptor() ([]byte, []int) {
+// Deprecated: Use ImportEventRequest.ProtoReflect.Descriptor instead.
+func (*ImportEventRequest) Descriptor() ([]byte, []int) {
 	return file_calendar_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *Event) GetId() string {
+func (x *ImportEventRequest) GetIcalUid() string {
 	if x != nil {
-		return x.Id
+		return x.IcalUid
 	}
 	return ""
 }
 
-func (x *Event) GetSummary() string {
+func (x *ImportEventRequest) GetSummary() string {
 	if x != nil {
 		return x.Summary
 	}
 	return ""
 }
 
-func (x *Event) GetDescription() string {
+func (x *ImportEventRequest) GetDescription() string {
 	if x != nil && x.Description != nil {
 		return *x.Description
 	}
 	return ""
 }
 
-func (x *Event) GetStartTime() *timestamppb.Timestamp {
+func (x *ImportEventRequest) GetStartTime() *timestamppb.Timestamp {
 	if x != nil {
 		return x.StartTime
 	}
 	return nil
 }
 
-func (x *Event) GetEndTime() *timestamppb.Timestamp {
+func (x *ImportEventRequest) GetEndTime() *timestamppb.Timestamp {
 	if x != nil {
 		return x.EndTime
 	}
 	return nil
 }
 
-func (x *Event) GetLocation() string {
+func (x *ImportEventRequest) GetLocation() string {
 	if x != nil && x.Location != nil {
 		return *x.Location
 	}
 	return ""
 }
 
-func (x *Event) GetHtmlLink() string {
+func (x *ImportEventRequest) GetCalendarId() string {
+	if x != nil && x.CalendarId != nil {
+		return *x.CalendarId
+	}
+	return ""
+}
+
+func (x *ImportEventRequest) GetTimeZone() string {
+	if x != nil && x.TimeZone != nil {
+		return *x.TimeZone
+	}
+	return ""
+}
+
+func (x *ImportEventRequest) GetAllDay() bool {
+	if x != nil && x.AllDay != nil {
+		return *x.AllDay
+	}
+	return false
+}
+
+func (x *ImportEventRequest) GetAttendees() *AttendeeList {
+	if x != nil {
+		return x.Attendees
+	}
+	return nil
+}
+
+func (x *ImportEventRequest) GetRecurrence() *Recurrence {
+	if x != nil {
+		return x.Recurrence
+	}
+	return nil
+}
+
+type ListEventsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CalendarId *string                `protobuf:"bytes,1,opt,name=calendar_id,json=calendarId,proto3,oneof" json:"calendar_id,omitempty"` // defaults to "primary"
+	// Time filtering options (mutually exclusive with after/before)
+	After  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=after,proto3,oneof" json:"after,omitempty"`   // only events after this time
+	Before *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=before,proto3,oneof" json:"before,omitempty"` // only events before this time
+	// Predefined time scopes (mutually exclusive with each other and with after/before)
+	Future          *bool        `protobuf:"varint,4,opt,name=future,proto3,oneof" json:"future,omitempty"`                                          // events after now
+	Past            *bool        `protobuf:"varint,5,opt,name=past,proto3,oneof" json:"past,omitempty"`                                              // events before now
+	Limit           *int32       `protobuf:"varint,6,opt,name=limit,proto3,oneof" json:"limit,omitempty"`                                            // page size (number of events per page)
+	Anchor          *string      `protobuf:"bytes,7,opt,name=anchor,proto3,oneof" json:"anchor,omitempty"`                                           // token for retrieving the next page of results
+	SingleEvents    *bool        `protobuf:"varint,8,opt,name=single_events,json=singleEvents,proto3,oneof" json:"single_events,omitempty"`          // expand recurring events into instances (default true); false lists the recurring masters
+	FollowPages     *bool        `protobuf:"varint,9,opt,name=follow_pages,json=followPages,proto3,oneof" json:"follow_pages,omitempty"`             // stream every page instead of stopping after one with a next_anchor
+	PrivateProperty *PropertyMap `protobuf:"bytes,10,opt,name=private_property,json=privateProperty,proto3,oneof" json:"private_property,omitempty"` // only events whose private extended properties include every pair
+	SharedProperty  *PropertyMap `protobuf:"bytes,11,opt,name=shared_property,json=sharedProperty,proto3,oneof" json:"shared_property,omitempty"`    // only events whose shared extended properties include every pair
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListEventsRequest) Reset() {
+	*x = ListEventsRequest{}
+	mi := &file_calendar_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsRequest) ProtoMessage() {}
+
+func (x *ListEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListEventsRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListEventsRequest) GetCalendarId() string {
+	if x != nil && x.CalendarId != nil {
+		return *x.CalendarId
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetFuture() bool {
+	if x != nil && x.Future != nil {
+		return *x.Future
+	}
+	return false
+}
+
+func (x *ListEventsRequest) GetPast() bool {
+	if x != nil && x.Past != nil {
+		return *x.Past
+	}
+	return false
+}
+
+func (x *ListEventsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetAnchor() string {
+	if x != nil && x.Anchor != nil {
+		return *x.Anchor
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetSingleEvents() bool {
+	if x != nil && x.SingleEvents != nil {
+		return *x.SingleEvents
+	}
+	return false
+}
+
+func (x *ListEventsRequest) GetFollowPages() bool {
+	if x != nil && x.FollowPages != nil {
+		return *x.FollowPages
+	}
+	return false
+}
+
+func (x *ListEventsRequest) GetPrivateProperty() *PropertyMap {
+	if x != nil {
+		return x.PrivateProperty
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetSharedProperty() *PropertyMap {
+	if x != nil {
+		return x.SharedProperty
+	}
+	return nil
+}
+
+type ListEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *Event                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`                                   // the event (present for all messages except potentially the last)
+	NextAnchor    *string                `protobuf:"bytes,2,opt,name=next_anchor,json=nextAnchor,proto3,oneof" json:"next_anchor,omitempty"` // token for the next page; without follow_pages, always set on the last message when more results exist
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsResponse) Reset() {
+	*x = ListEventsResponse{}
+	mi := &file_calendar_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsResponse) ProtoMessage() {}
+
+func (x *ListEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListEventsResponse) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListEventsResponse) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ListEventsResponse) GetNextAnchor() string {
+	if x != nil && x.NextAnchor != nil {
+		return *x.NextAnchor
+	}
+	return ""
+}
+
+type SearchEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                                   // free text, matched as Google's search box does
+	CalendarId    *string                `protobuf:"bytes,2,opt,name=calendar_id,json=calendarId,proto3,oneof" json:"calendar_id,omitempty"` // defaults to "primary"
+	After         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=after,proto3,oneof" json:"after,omitempty"`                             // only events after this time
+	Before        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=before,proto3,oneof" json:"before,omitempty"`                           // only events before this time
+	Limit         *int32                 `protobuf:"varint,5,opt,name=limit,proto3,oneof" json:"limit,omitempty"`                            // maximum number of events to return (default all)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchEventsRequest) Reset() {
+	*x = SearchEventsRequest{}
+	mi := &file_calendar_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchEventsRequest) ProtoMessage() {}
+
+func (x *SearchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchEventsRequest.ProtoReflect.Descriptor instead.
+func (*SearchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SearchEventsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchEventsRequest) GetCalendarId() string {
+	if x != nil && x.CalendarId != nil {
+		return *x.CalendarId
+	}
+	return ""
+}
+
+func (x *SearchEventsRequest) GetAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *SearchEventsRequest) GetBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *SearchEventsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type ListCalendarsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinAccessRole *string                `protobuf:"bytes,1,opt,name=min_access_role,json=minAccessRole,proto3,oneof" json:"min_access_role,omitempty"` // freeBusyReader, reader, writer, or owner
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCalendarsRequest) Reset() {
+	*x = ListCalendarsRequest{}
+	mi := &file_calendar_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCalendarsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCalendarsRequest) ProtoMessage() {}
+
+func (x *ListCalendarsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCalendarsRequest.ProtoReflect.Descriptor instead.
+func (*ListCalendarsRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListCalendarsRequest) GetMinAccessRole() string {
+	if x != nil && x.MinAccessRole != nil {
+		return *x.MinAccessRole
+	}
+	return ""
+}
+
+type Calendar struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // pass as calendar_id to target this calendar
+	Summary       string                 `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	AccessRole    string                 `protobuf:"bytes,3,opt,name=access_role,json=accessRole,proto3" json:"access_role,omitempty"` // freeBusyReader, reader, writer, owner
+	Primary       bool                   `protobuf:"varint,4,opt,name=primary,proto3" json:"primary,omitempty"`                        // the authenticated user's own calendar
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Calendar) Reset() {
+	*x = Calendar{}
+	mi := &file_calendar_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Calendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Calendar) ProtoMessage() {}
+
+func (x *Calendar) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Calendar.ProtoReflect.Descriptor instead.
+func (*Calendar) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Calendar) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Calendar) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *Calendar) GetAccessRole() string {
+	if x != nil {
+		return x.AccessRole
+	}
+	return ""
+}
+
+func (x *Calendar) GetPrimary() bool {
+	if x != nil {
+		return x.Primary
+	}
+	return false
+}
+
+type FreeBusyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	After         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=after,proto3,oneof" json:"after,omitempty"`       // start of the range (required)
+	Before        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=before,proto3,oneof" json:"before,omitempty"`     // end of the range (required)
+	Calendar      *CalendarIdList        `protobuf:"bytes,3,opt,name=calendar,proto3,oneof" json:"calendar,omitempty"` // calendars to query, defaults to "primary"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FreeBusyRequest) Reset() {
+	*x = FreeBusyRequest{}
+	mi := &file_calendar_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FreeBusyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreeBusyRequest) ProtoMessage() {}
+
+func (x *FreeBusyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreeBusyRequest.ProtoReflect.Descriptor instead.
+func (*FreeBusyRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FreeBusyRequest) GetAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *FreeBusyRequest) GetBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *FreeBusyRequest) GetCalendar() *CalendarIdList {
+	if x != nil {
+		return x.Calendar
+	}
+	return nil
+}
+
+// CalendarIdList wraps calendar IDs so they can be passed as a single flag: a
+// comma-separated list, e.g. "primary,team@group.calendar.google.com"
+type CalendarIdList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalendarIdList) Reset() {
+	*x = CalendarIdList{}
+	mi := &file_calendar_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalendarIdList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalendarIdList) ProtoMessage() {}
+
+func (x *CalendarIdList) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalendarIdList.ProtoReflect.Descriptor instead.
+func (*CalendarIdList) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CalendarIdList) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BusyPeriod struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BusyPeriod) Reset() {
+	*x = BusyPeriod{}
+	mi := &file_calendar_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BusyPeriod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BusyPeriod) ProtoMessage() {}
+
+func (x *BusyPeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BusyPeriod.ProtoReflect.Descriptor instead.
+func (*BusyPeriod) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BusyPeriod) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *BusyPeriod) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+type FreeBusyCalendar struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CalendarId    string                 `protobuf:"bytes,1,opt,name=calendar_id,json=calendarId,proto3" json:"calendar_id,omitempty"`
+	Busy          []*BusyPeriod          `protobuf:"bytes,2,rep,name=busy,proto3" json:"busy,omitempty"`     // merged, non-overlapping, in start order
+	Errors        []string               `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"` // reasons the calendar couldn't be queried (e.g. notFound)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FreeBusyCalendar) Reset() {
+	*x = FreeBusyCalendar{}
+	mi := &file_calendar_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FreeBusyCalendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreeBusyCalendar) ProtoMessage() {}
+
+func (x *FreeBusyCalendar) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreeBusyCalendar.ProtoReflect.Descriptor instead.
+func (*FreeBusyCalendar) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FreeBusyCalendar) GetCalendarId() string {
+	if x != nil {
+		return x.CalendarId
+	}
+	return ""
+}
+
+func (x *FreeBusyCalendar) GetBusy() []*BusyPeriod {
+	if x != nil {
+		return x.Busy
+	}
+	return nil
+}
+
+func (x *FreeBusyCalendar) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ImportICSRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          string                 `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`                                     // path to the .ics file, or - for stdin
+	CalendarId    *string                `protobuf:"bytes,2,opt,name=calendar_id,json=calendarId,proto3,oneof" json:"calendar_id,omitempty"` // defaults to "primary"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportICSRequest) Reset() {
+	*x = ImportICSRequest{}
+	mi := &file_calendar_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportICSRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportICSRequest) ProtoMessage() {}
+
+func (x *ImportICSRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportICSRequest.ProtoReflect.Descriptor instead.
+func (*ImportICSRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ImportICSRequest) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *ImportICSRequest) GetCalendarId() string {
+	if x != nil && x.CalendarId != nil {
+		return *x.CalendarId
+	}
+	return ""
+}
+
+type RevokeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeRequest) Reset() {
+	*x = RevokeRequest{}
+	mi := &file_calendar_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeRequest) ProtoMessage() {}
+
+func (x *RevokeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeRequest.ProtoReflect.Descriptor instead.
+func (*RevokeRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{21}
+}
+
+type RevokeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	TokenPath     string                 `protobuf:"bytes,3,opt,name=token_path,json=tokenPath,proto3" json:"token_path,omitempty"` // the token file that was deleted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeResponse) Reset() {
+	*x = RevokeResponse{}
+	mi := &file_calendar_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeResponse) ProtoMessage() {}
+
+func (x *RevokeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeResponse.ProtoReflect.Descriptor instead.
+func (*RevokeResponse) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RevokeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RevokeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RevokeResponse) GetTokenPath() string {
+	if x != nil {
+		return x.TokenPath
+	}
+	return ""
+}
+
+type PingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingRequest) Reset() {
+	*x = PingRequest{}
+	mi := &file_calendar_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingRequest) ProtoMessage() {}
+
+func (x *PingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
+func (*PingRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{23}
+}
+
+type PingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	AuthMode      string                 `protobuf:"bytes,3,opt,name=auth_mode,json=authMode,proto3" json:"auth_mode,omitempty"` // adc, service_account, or oauth
+	Endpoint      string                 `protobuf:"bytes,4,opt,name=endpoint,proto3" json:"endpoint,omitempty"`                 // the Calendar API endpoint that was called
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingResponse) Reset() {
+	*x = PingResponse{}
+	mi := &file_calendar_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingResponse) ProtoMessage() {}
+
+func (x *PingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
+func (*PingResponse) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PingResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PingResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PingResponse) GetAuthMode() string {
+	if x != nil {
+		return x.AuthMode
+	}
+	return ""
+}
+
+func (x *PingResponse) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+type FindDuplicatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CalendarId    *string                `protobuf:"bytes,1,opt,name=calendar_id,json=calendarId,proto3,oneof" json:"calendar_id,omitempty"` // defaults to "primary"
+	After         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=after,proto3,oneof" json:"after,omitempty"`                             // only events after this time
+	Before        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=before,proto3,oneof" json:"before,omitempty"`                           // only events before this time
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindDuplicatesRequest) Reset() {
+	*x = FindDuplicatesRequest{}
+	mi := &file_calendar_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindDuplicatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDuplicatesRequest) ProtoMessage() {}
+
+func (x *FindDuplicatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDuplicatesRequest.ProtoReflect.Descriptor instead.
+func (*FindDuplicatesRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *FindDuplicatesRequest) GetCalendarId() string {
+	if x != nil && x.CalendarId != nil {
+		return *x.CalendarId
+	}
+	return ""
+}
+
+func (x *FindDuplicatesRequest) GetAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *FindDuplicatesRequest) GetBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+type DuplicateCluster struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // two or more events that look like copies of each other
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicateCluster) Reset() {
+	*x = DuplicateCluster{}
+	mi := &file_calendar_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateCluster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateCluster) ProtoMessage() {}
+
+func (x *DuplicateCluster) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateCluster.ProtoReflect.Descriptor instead.
+func (*DuplicateCluster) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DuplicateCluster) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type Event struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Id                      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Summary                 string                 `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	Description             *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	StartTime               *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3,oneof" json:"start_time,omitempty"`
+	EndTime                 *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3,oneof" json:"end_time,omitempty"`
+	Location                *string                `protobuf:"bytes,6,opt,name=location,proto3,oneof" json:"location,omitempty"`
+	HtmlLink                string                 `protobuf:"bytes,7,opt,name=html_link,json=htmlLink,proto3" json:"html_link,omitempty"`
+	CalendarId              string                 `protobuf:"bytes,8,opt,name=calendar_id,json=calendarId,proto3" json:"calendar_id,omitempty"`
+	Status                  *string                `protobuf:"bytes,9,opt,name=status,proto3,oneof" json:"status,omitempty"` // confirmed, tentative, cancelled
+	Attendees               []string               `protobuf:"bytes,10,rep,name=attendees,proto3" json:"attendees,omitempty"`
+	Transparency            *string                `protobuf:"bytes,11,opt,name=transparency,proto3,oneof" json:"transparency,omitempty"` // "opaque" (blocks time) or "transparent" (doesn't block time)
+	OrganizerEmail          *string                `protobuf:"bytes,12,opt,name=organizer_email,json=organizerEmail,proto3,oneof" json:"organizer_email,omitempty"`
+	OrganizerName           *string                `protobuf:"bytes,13,opt,name=organizer_name,json=organizerName,proto3,oneof" json:"organizer_name,omitempty"`
+	ConferenceUri           *string                `protobuf:"bytes,14,opt,name=conference_uri,json=conferenceUri,proto3,oneof" json:"conference_uri,omitempty"`                                                                                 // Primary video conference link (Google Meet, Zoom, etc.)
+	ConferenceId            *string                `protobuf:"bytes,15,opt,name=conference_id,json=conferenceId,proto3,oneof" json:"conference_id,omitempty"`                                                                                    // Conference ID (e.g., "abc-defg-hij" for Meet)
+	SourceTitle             *string                `protobuf:"bytes,16,opt,name=source_title,json=sourceTitle,proto3,oneof" json:"source_title,omitempty"`                                                                                       // Title of the source of the event
+	SourceUrl               *string                `protobuf:"bytes,17,opt,name=source_url,json=sourceUrl,proto3,oneof" json:"source_url,omitempty"`                                                                                             // URL for the source of the event
+	AttendeeDetails         []*Attendee            `protobuf:"bytes,18,rep,name=attendee_details,json=attendeeDetails,proto3" json:"attendee_details,omitempty"`                                                                                 // Attendees with their display names and flags
+	Recurrence              []string               `protobuf:"bytes,19,rep,name=recurrence,proto3" json:"recurrence,omitempty"`                                                                                                                  // RRULE/RDATE/EXDATE lines (recurring masters only)
+	Reminders               []*Reminder            `protobuf:"bytes,20,rep,name=reminders,proto3" json:"reminders,omitempty"`                                                                                                                    // reminder overrides (empty when the calendar's defaults apply)
+	Visibility              *string                `protobuf:"bytes,21,opt,name=visibility,proto3,oneof" json:"visibility,omitempty"`                                                                                                            // default, public, private, confidential
+	Attachments             []*Attachment          `protobuf:"bytes,22,rep,name=attachments,proto3" json:"attachments,omitempty"`                                                                                                                // attached files
+	TimeZone                *string                `protobuf:"bytes,23,opt,name=time_zone,json=timeZone,proto3,oneof" json:"time_zone,omitempty"`                                                                                                // IANA name the start and end were given in (timed events only)
+	ColorId                 *string                `protobuf:"bytes,24,opt,name=color_id,json=colorId,proto3,oneof" json:"color_id,omitempty"`                                                                                                   // Google event color, "1" to "11" (unset uses the calendar's color)
+	PrivateProperties       map[string]string      `protobuf:"bytes,25,rep,name=private_properties,json=privateProperties,proto3" json:"private_properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // extended properties private to this calendar's copy
+	SharedProperties        map[string]string      `protobuf:"bytes,26,rep,name=shared_properties,json=sharedProperties,proto3" json:"shared_properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`    // extended properties shared with every attendee
+	AllDay                  *bool                  `protobuf:"varint,27,opt,name=all_day,json=allDay,proto3,oneof" json:"all_day,omitempty"`                                                                                                     // the start and end are dates only (the end date is exclusive)
+	GuestsCanModify         *bool                  `protobuf:"varint,28,opt,name=guests_can_modify,json=guestsCanModify,proto3,oneof" json:"guests_can_modify,omitempty"`
+	GuestsCanInviteOthers   *bool                  `protobuf:"varint,29,opt,name=guests_can_invite_others,json=guestsCanInviteOthers,proto3,oneof" json:"guests_can_invite_others,omitempty"`
+	GuestsCanSeeOtherGuests *bool                  `protobuf:"varint,30,opt,name=guests_can_see_other_guests,json=guestsCanSeeOtherGuests,proto3,oneof" json:"guests_can_see_other_guests,omitempty"`
+	EventType               *string                `protobuf:"bytes,31,opt,name=event_type,json=eventType,proto3,oneof" json:"event_type,omitempty"` // default, outOfOffice, focusTime, workingLocation
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_calendar_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Event) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Event) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *Event) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Event) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *Event) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *Event) GetLocation() string {
+	if x != nil && x.Location != nil {
+		return *x.Location
+	}
+	return ""
+}
+
+func (x *Event) GetHtmlLink() string {
+	if x != nil {
+		return x.HtmlLink
+	}
+	return ""
+}
+
+func (x *Event) GetCalendarId() string {
+	if x != nil {
+		return x.CalendarId
+	}
+	return ""
+}
+
+func (x *Event) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *Event) GetAttendees() []string {
+	if x != nil {
+		return x.Attendees
+	}
+	return nil
+}
+
+func (x *Event) GetTransparency() string {
+	if x != nil && x.Transparency != nil {
+		return *x.Transparency
+	}
+	return ""
+}
+
+func (x *Event) GetOrganizerEmail() string {
+	if x != nil && x.OrganizerEmail != nil {
+		return *x.OrganizerEmail
+	}
+	return ""
+}
+
+func (x *Event) GetOrganizerName() string {
+	if x != nil && x.OrganizerName != nil {
+		return *x.OrganizerName
+	}
+	return ""
+}
+
+func (x *Event) GetConferenceUri() string {
+	if x != nil && x.ConferenceUri != nil {
+		return *x.ConferenceUri
+	}
+	return ""
+}
+
+func (x *Event) GetConferenceId() string {
+	if x != nil && x.ConferenceId != nil {
+		return *x.ConferenceId
+	}
+	return ""
+}
+
+func (x *Event) GetSourceTitle() string {
+	if x != nil && x.SourceTitle != nil {
+		return *x.SourceTitle
+	}
+	return ""
+}
+
+func (x *Event) GetSourceUrl() string {
+	if x != nil && x.SourceUrl != nil {
+		return *x.SourceUrl
+	}
+	return ""
+}
+
+func (x *Event) GetAttendeeDetails() []*Attendee {
+	if x != nil {
+		return x.AttendeeDetails
+	}
+	return nil
+}
+
+func (x *Event) GetRecurrence() []string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return nil
+}
+
+func (x *Event) GetReminders() []*Reminder {
+	if x != nil {
+		return x.Reminders
+	}
+	return nil
+}
+
+func (x *Event) GetVisibility() string {
+	if x != nil && x.Visibility != nil {
+		return *x.Visibility
+	}
+	return ""
+}
+
+func (x *Event) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *Event) GetTimeZone() string {
+	if x != nil && x.TimeZone != nil {
+		return *x.TimeZone
+	}
+	return ""
+}
+
+func (x *Event) GetColorId() string {
+	if x != nil && x.ColorId != nil {
+		return *x.ColorId
+	}
+	return ""
+}
+
+func (x *Event) GetPrivateProperties() map[string]string {
+	if x != nil {
+		return x.PrivateProperties
+	}
+	return nil
+}
+
+func (x *Event) GetSharedProperties() map[string]string {
+	if x != nil {
+		return x.SharedProperties
+	}
+	return nil
+}
+
+func (x *Event) GetAllDay() bool {
+	if x != nil && x.AllDay != nil {
+		return *x.AllDay
+	}
+	return false
+}
+
+func (x *Event) GetGuestsCanModify() bool {
+	if x != nil && x.GuestsCanModify != nil {
+		return *x.GuestsCanModify
+	}
+	return false
+}
+
+func (x *Event) GetGuestsCanInviteOthers() bool {
+	if x != nil && x.GuestsCanInviteOthers != nil {
+		return *x.GuestsCanInviteOthers
+	}
+	return false
+}
+
+func (x *Event) GetGuestsCanSeeOtherGuests() bool {
+	if x != nil && x.GuestsCanSeeOtherGuests != nil {
+		return *x.GuestsCanSeeOtherGuests
+	}
+	return false
+}
+
+func (x *Event) GetEventType() string {
+	if x != nil && x.EventType != nil {
+		return *x.EventType
+	}
+	return ""
+}
+
+type Attendee struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Email          string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	DisplayName    *string                `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3,oneof" json:"display_name,omitempty"`
+	Optional       bool                   `protobuf:"varint,3,opt,name=optional,proto3" json:"optional,omitempty"`                                        // optional attendees are not required to attend
+	ResponseStatus *string                `protobuf:"bytes,4,opt,name=response_status,json=responseStatus,proto3,oneof" json:"response_status,omitempty"` // needsAction, declined, tentative, accepted
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Attendee) Reset() {
+	*x = Attendee{}
+	mi := &file_calendar_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attendee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attendee) ProtoMessage() {}
+
+func (x *Attendee) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attendee.ProtoReflect.Descriptor instead.
+func (*Attendee) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Attendee) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Attendee) GetDisplayName() string {
+	if x != nil && x.DisplayName != nil {
+		return *x.DisplayName
+	}
+	return ""
+}
+
+func (x *Attendee) GetOptional() bool {
+	if x != nil {
+		return x.Optional
+	}
+	return false
+}
+
+func (x *Attendee) GetResponseStatus() string {
+	if x != nil && x.ResponseStatus != nil {
+		return *x.ResponseStatus
+	}
+	return ""
+}
+
+// PropertyMap wraps extended properties so they can be passed as a single
+// flag: comma-separated key=value pairs, or a JSON object
+type PropertyMap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Properties    map[string]string      `protobuf:"bytes,1,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PropertyMap) Reset() {
+	*x = PropertyMap{}
+	mi := &file_calendar_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PropertyMap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PropertyMap) ProtoMessage() {}
+
+func (x *PropertyMap) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PropertyMap.ProtoReflect.Descriptor instead.
+func (*PropertyMap) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PropertyMap) GetProperties() map[string]string {
 	if x != nil {
-		return x.HtmlLink
+		return x.Properties
 	}
-	return ""
+	return nil
 }
 
-func (x *Event) GetCalendarId() string {
+// AttendeeList wraps attendees so they can be passed as a single flag: a
+// comma-separated list of emails, or a JSON array of Attendee objects
+type AttendeeList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attendees     []*Attendee            `protobuf:"bytes,1,rep,name=attendees,proto3" json:"attendees,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttendeeList) Reset() {
+	*x = AttendeeList{}
+	mi := &file_calendar_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttendeeList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttendeeList) ProtoMessage() {}
+
+func (x *AttendeeList) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[30]
 	if x != nil {
-		return x.CalendarId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Event) GetStatus() string {
-	if x != nil && x.Status != nil {
-		return *x.Status
-	}
-	return ""
+// Deprecated: Use AttendeeList.ProtoReflect.Descriptor instead.
+func (*AttendeeList) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *Event) GetAttendees() []string {
+func (x *AttendeeList) GetAttendees() []*Attendee {
 	if x != nil {
 		return x.Attendees
 	}
 	return nil
 }
 
-func (x *Event) GetTransparency() string {
-	if x != nil && x.Transparency != nil {
-		return *x.Transparency
+// Recurrence holds an event's RRULE, RDATE, and EXDATE lines (RFC 5545). As
+// a flag, lines are separated by whitespace, e.g.
+// "RRULE:FREQ=WEEKLY;BYDAY=MO EXDATE:20240311T090000Z"
+type Recurrence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []string               `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Recurrence) Reset() {
+	*x = Recurrence{}
+	mi := &file_calendar_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Recurrence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recurrence) ProtoMessage() {}
+
+func (x *Recurrence) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Event) GetOrganizerEmail() string {
-	if x != nil && x.OrganizerEmail != nil {
-		return *x.OrganizerEmail
+// Deprecated: Use Recurrence.ProtoReflect.Descriptor instead.
+func (*Recurrence) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Recurrence) GetRules() []string {
+	if x != nil {
+		return x.Rules
 	}
-	return ""
+	return nil
 }
 
-func (x *Event) GetOrganizerName() string {
-	if x != nil && x.OrganizerName != nil {
-		return *x.OrganizerName
+type Reminder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`    // popup or email
+	Minutes       int32                  `protobuf:"varint,2,opt,name=minutes,proto3" json:"minutes,omitempty"` // minutes before the event starts
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reminder) Reset() {
+	*x = Reminder{}
+	mi := &file_calendar_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reminder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reminder) ProtoMessage() {}
+
+func (x *Reminder) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Event) GetConferenceUri() string {
-	if x != nil && x.ConferenceUri != nil {
-		return *x.ConferenceUri
+// Deprecated: Use Reminder.ProtoReflect.Descriptor instead.
+func (*Reminder) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Reminder) GetMethod() string {
+	if x != nil {
+		return x.Method
 	}
 	return ""
 }
 
-func (x *Event) GetConferenceId() string {
-	if x != nil && x.ConferenceId != nil {
-		return *x.ConferenceId
+func (x *Reminder) GetMinutes() int32 {
+	if x != nil {
+		return x.Minutes
+	}
+	return 0
+}
+
+// ReminderList wraps reminders so they can be passed as a single flag:
+// comma-separated method:minutes pairs (e.g. "popup:10,email:60"), or a JSON
+// array of Reminder objects
+type ReminderList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reminders     []*Reminder            `protobuf:"bytes,1,rep,name=reminders,proto3" json:"reminders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReminderList) Reset() {
+	*x = ReminderList{}
+	mi := &file_calendar_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReminderList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReminderList) ProtoMessage() {}
+
+func (x *ReminderList) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReminderList.ProtoReflect.Descriptor instead.
+func (*ReminderList) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ReminderList) GetReminders() []*Reminder {
+	if x != nil {
+		return x.Reminders
+	}
+	return nil
+}
+
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileUrl       string                 `protobuf:"bytes,1,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"` // URL of the file, e.g. a Google Drive link
+	Title         *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	MimeType      *string                `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3,oneof" json:"mime_type,omitempty"` // e.g. application/vnd.google-apps.document
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_calendar_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Attachment) GetFileUrl() string {
+	if x != nil {
+		return x.FileUrl
 	}
 	return ""
 }
 
-func (x *Event) GetSourceTitle() string {
-	if x != nil && x.SourceTitle != nil {
-		return *x.SourceTitle
+func (x *Attachment) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
 	}
 	return ""
 }
 
-func (x *Event) GetSourceUrl() string {
-	if x != nil && x.SourceUrl != nil {
-		return *x.SourceUrl
+func (x *Attachment) GetMimeType() string {
+	if x != nil && x.MimeType != nil {
+		return *x.MimeType
 	}
 	return ""
 }
 
+// AttachmentList wraps attachments so they can be passed as a single flag: a
+// comma-separated list of file URLs, or a JSON array of Attachment objects
+type AttachmentList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachments   []*Attachment          `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentList) Reset() {
+	*x = AttachmentList{}
+	mi := &file_calendar_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentList) ProtoMessage() {}
+
+func (x *AttachmentList) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentList.ProtoReflect.Descriptor instead.
+func (*AttachmentList) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AttachmentList) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
 var File_calendar_proto protoreflect.FileDescriptor
 
 const file_calendar_proto_rawDesc = "" +
 	"\n" +
-	"\x0ecalendar.proto\x12\bcalendar\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc7\x06\n" +
+	"\x0ecalendar.proto\x12\bcalendar\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16proto/cli/v1/cli.proto\"\x9b\r\n" +
 	"\x0fAddEventRequest\x12\x18\n" +
 	"\asummary\x18\x01 \x01(\tR\asummary\x12%\n" +
 	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01\x12>\n" +
@@ -1004,7 +2825,25 @@ const file_calendar_proto_rawDesc = "" +
 	"source_url\x18\f \x01(\tH\n" +
 	"R\tsourceUrl\x88\x01\x01\x12$\n" +
 	"\vblocks_time\x18\r \x01(\bH\vR\n" +
-	"blocksTime\x88\x01\x01B\x0e\n" +
+	"blocksTime\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"event_type\x18\x0e \x01(\tH\fR\teventType\x88\x01\x01\x129\n" +
+	"\tattendees\x18\x0f \x01(\v2\x16.calendar.AttendeeListH\rR\tattendees\x88\x01\x01\x129\n" +
+	"\n" +
+	"recurrence\x18\x10 \x01(\v2\x14.calendar.RecurrenceH\x0eR\n" +
+	"recurrence\x88\x01\x01\x129\n" +
+	"\treminders\x18\x11 \x01(\v2\x16.calendar.ReminderListH\x0fR\treminders\x88\x01\x01\x12 \n" +
+	"\ttime_zone\x18\x12 \x01(\tH\x10R\btimeZone\x88\x01\x01\x12\x1c\n" +
+	"\aall_day\x18\x13 \x01(\bH\x11R\x06allDay\x88\x01\x01\x12#\n" +
+	"\n" +
+	"visibility\x18\x14 \x01(\tH\x12R\n" +
+	"visibility\x88\x01\x01\x12*\n" +
+	"\x0eadd_conference\x18\x15 \x01(\bH\x13R\raddConference\x88\x01\x01\x12?\n" +
+	"\vattachments\x18\x16 \x01(\v2\x18.calendar.AttachmentListH\x14R\vattachments\x88\x01\x01\x12&\n" +
+	"\fsend_updates\x18\x17 \x01(\tH\x15R\vsendUpdates\x88\x01\x01\x12\x1e\n" +
+	"\bcolor_id\x18\x18 \x01(\tH\x16R\acolorId\x88\x01\x01\x12I\n" +
+	"\x12private_properties\x18\x19 \x01(\v2\x15.calendar.PropertyMapH\x17R\x11privateProperties\x88\x01\x01\x12G\n" +
+	"\x11shared_properties\x18\x1a \x01(\v2\x15.calendar.PropertyMapH\x18R\x10sharedProperties\x88\x01\x01B\x0e\n" +
 	"\f_descriptionB\r\n" +
 	"\v_start_timeB\v\n" +
 	"\t_end_timeB\v\n" +
@@ -1016,14 +2855,32 @@ const file_calendar_proto_rawDesc = "" +
 	"\x10_idempotency_keyB\x0f\n" +
 	"\r_source_titleB\r\n" +
 	"\v_source_urlB\x0e\n" +
-	"\f_blocks_time\"\x9f\x01\n" +
+	"\f_blocks_timeB\r\n" +
+	"\v_event_typeB\f\n" +
+	"\n" +
+	"_attendeesB\r\n" +
+	"\v_recurrenceB\f\n" +
+	"\n" +
+	"_remindersB\f\n" +
+	"\n" +
+	"_time_zoneB\n" +
+	"\n" +
+	"\b_all_dayB\r\n" +
+	"\v_visibilityB\x11\n" +
+	"\x0f_add_conferenceB\x0e\n" +
+	"\f_attachmentsB\x0f\n" +
+	"\r_send_updatesB\v\n" +
+	"\t_color_idB\x15\n" +
+	"\x13_private_propertiesB\x14\n" +
+	"\x12_shared_properties\"\xbc\x01\n" +
 	"\x10AddEventResponse\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1b\n" +
 	"\thtml_link\x18\x04 \x01(\tR\bhtmlLink\x12\x1f\n" +
 	"\vcalendar_id\x18\x05 \x01(\tR\n" +
-	"calendarId\"\xb4\x06\n" +
+	"calendarId\x12\x1b\n" +
+	"\tself_link\x18\x06 \x01(\tR\bselfLink\"\xca\f\n" +
 	"\x12UpdateEventRequest\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12$\n" +
 	"\vcalendar_id\x18\x02 \x01(\tH\x00R\n" +
@@ -1043,7 +2900,24 @@ const file_calendar_proto_rawDesc = "" +
 	"source_url\x18\f \x01(\tH\n" +
 	"R\tsourceUrl\x88\x01\x01\x12$\n" +
 	"\vblocks_time\x18\r \x01(\bH\vR\n" +
-	"blocksTime\x88\x01\x01B\x0e\n" +
+	"blocksTime\x88\x01\x01\x129\n" +
+	"\tattendees\x18\x0e \x01(\v2\x16.calendar.AttendeeListH\fR\tattendees\x88\x01\x01\x129\n" +
+	"\n" +
+	"recurrence\x18\x0f \x01(\v2\x14.calendar.RecurrenceH\rR\n" +
+	"recurrence\x88\x01\x01\x129\n" +
+	"\treminders\x18\x10 \x01(\v2\x16.calendar.ReminderListH\x0eR\treminders\x88\x01\x01\x12 \n" +
+	"\ttime_zone\x18\x11 \x01(\tH\x0fR\btimeZone\x88\x01\x01\x12#\n" +
+	"\n" +
+	"visibility\x18\x12 \x01(\tH\x10R\n" +
+	"visibility\x88\x01\x01\x12?\n" +
+	"\vattachments\x18\x13 \x01(\v2\x18.calendar.AttachmentListH\x11R\vattachments\x88\x01\x01\x12&\n" +
+	"\fsend_updates\x18\x14 \x01(\tH\x12R\vsendUpdates\x88\x01\x01\x12\x1e\n" +
+	"\bcolor_id\x18\x15 \x01(\tH\x13R\acolorId\x88\x01\x01\x12I\n" +
+	"\x12private_properties\x18\x16 \x01(\v2\x15.calendar.PropertyMapH\x14R\x11privateProperties\x88\x01\x01\x12G\n" +
+	"\x11shared_properties\x18\x17 \x01(\v2\x15.calendar.PropertyMapH\x15R\x10sharedProperties\x88\x01\x01\x122\n" +
+	"\n" +
+	"edit_scope\x18\x18 \x01(\x0e2\x13.calendar.EditScopeR\teditScope\x12\x1c\n" +
+	"\aall_day\x18\x19 \x01(\bH\x16R\x06allDay\x88\x01\x01B\x0e\n" +
 	"\f_calendar_idB\n" +
 	"\n" +
 	"\b_summaryB\x0e\n" +
@@ -1056,31 +2930,86 @@ const file_calendar_proto_rawDesc = "" +
 	"\x19_guests_can_invite_othersB\x0f\n" +
 	"\r_source_titleB\r\n" +
 	"\v_source_urlB\x0e\n" +
-	"\f_blocks_time\"\xa2\x01\n" +
+	"\f_blocks_timeB\f\n" +
+	"\n" +
+	"_attendeesB\r\n" +
+	"\v_recurrenceB\f\n" +
+	"\n" +
+	"_remindersB\f\n" +
+	"\n" +
+	"_time_zoneB\r\n" +
+	"\v_visibilityB\x0e\n" +
+	"\f_attachmentsB\x0f\n" +
+	"\r_send_updatesB\v\n" +
+	"\t_color_idB\x15\n" +
+	"\x13_private_propertiesB\x14\n" +
+	"\x12_shared_propertiesB\n" +
+	"\n" +
+	"\b_all_day\"\xa2\x01\n" +
 	"\x13UpdateEventResponse\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1b\n" +
 	"\thtml_link\x18\x04 \x01(\tR\bhtmlLink\x12\x1f\n" +
 	"\vcalendar_id\x18\x05 \x01(\tR\n" +
-	"calendarId\"e\n" +
+	"calendarId\"\x9e\x01\n" +
 	"\x12DeleteEventRequest\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12$\n" +
 	"\vcalendar_id\x18\x02 \x01(\tH\x00R\n" +
-	"calendarId\x88\x01\x01B\x0e\n" +
-	"\f_calendar_id\"j\n" +
+	"calendarId\x88\x01\x01\x12&\n" +
+	"\fsend_updates\x18\x03 \x01(\tH\x01R\vsendUpdates\x88\x01\x01B\x0e\n" +
+	"\f_calendar_idB\x0f\n" +
+	"\r_send_updates\"j\n" +
 	"\x13DeleteEventResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
 	"\vcalendar_id\x18\x03 \x01(\tR\n" +
-	"calendarId\"b\n" +
+	"calendarId\"\xa4\x01\n" +
 	"\x0fGetEventRequest\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12$\n" +
 	"\vcalendar_id\x18\x02 \x01(\tH\x00R\n" +
+	"calendarId\x88\x01\x01\x12,\n" +
+	"\x0finclude_deleted\x18\x03 \x01(\bH\x01R\x0eincludeDeleted\x88\x01\x01B\x0e\n" +
+	"\f_calendar_idB\x12\n" +
+	"\x10_include_deleted\"9\n" +
+	"\x10GetEventResponse\x12%\n" +
+	"\x05event\x18\x01 \x01(\v2\x0f.calendar.EventR\x05event\"[\n" +
+	"\x0fQuickAddRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12$\n" +
+	"\vcalendar_id\x18\x02 \x01(\tH\x00R\n" +
 	"calendarId\x88\x01\x01B\x0e\n" +
 	"\f_calendar_id\"9\n" +
-	"\x10GetEventResponse\x12%\n" +
-	"\x05event\x18\x01 \x01(\v2\x0f.calendar.EventR\x05event\"\xe5\x02\n" +
+	"\x10QuickAddResponse\x12%\n" +
+	"\x05event\x18\x01 \x01(\v2\x0f.calendar.EventR\x05event\"\xe9\x04\n" +
+	"\x12ImportEventRequest\x12\x19\n" +
+	"\bical_uid\x18\x01 \x01(\tR\aicalUid\x12\x18\n" +
+	"\asummary\x18\x02 \x01(\tR\asummary\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12>\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\tstartTime\x88\x01\x01\x12:\n" +
+	"\bend_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x02R\aendTime\x88\x01\x01\x12\x1f\n" +
+	"\blocation\x18\x06 \x01(\tH\x03R\blocation\x88\x01\x01\x12$\n" +
+	"\vcalendar_id\x18\a \x01(\tH\x04R\n" +
+	"calendarId\x88\x01\x01\x12 \n" +
+	"\ttime_zone\x18\b \x01(\tH\x05R\btimeZone\x88\x01\x01\x12\x1c\n" +
+	"\aall_day\x18\t \x01(\bH\x06R\x06allDay\x88\x01\x01\x129\n" +
+	"\tattendees\x18\n" +
+	" \x01(\v2\x16.calendar.AttendeeListH\aR\tattendees\x88\x01\x01\x129\n" +
+	"\n" +
+	"recurrence\x18\v \x01(\v2\x14.calendar.RecurrenceH\bR\n" +
+	"recurrence\x88\x01\x01B\x0e\n" +
+	"\f_descriptionB\r\n" +
+	"\v_start_timeB\v\n" +
+	"\t_end_timeB\v\n" +
+	"\t_locationB\x0e\n" +
+	"\f_calendar_idB\f\n" +
+	"\n" +
+	"_time_zoneB\n" +
+	"\n" +
+	"\b_all_dayB\f\n" +
+	"\n" +
+	"_attendeesB\r\n" +
+	"\v_recurrence\"\x8f\x05\n" +
 	"\x11ListEventsRequest\x12$\n" +
 	"\vcalendar_id\x18\x01 \x01(\tH\x00R\n" +
 	"calendarId\x88\x01\x01\x125\n" +
@@ -1089,19 +3018,94 @@ const file_calendar_proto_rawDesc = "" +
 	"\x06future\x18\x04 \x01(\bH\x03R\x06future\x88\x01\x01\x12\x17\n" +
 	"\x04past\x18\x05 \x01(\bH\x04R\x04past\x88\x01\x01\x12\x19\n" +
 	"\x05limit\x18\x06 \x01(\x05H\x05R\x05limit\x88\x01\x01\x12\x1b\n" +
-	"\x06anchor\x18\a \x01(\tH\x06R\x06anchor\x88\x01\x01B\x0e\n" +
+	"\x06anchor\x18\a \x01(\tH\x06R\x06anchor\x88\x01\x01\x12(\n" +
+	"\rsingle_events\x18\b \x01(\bH\aR\fsingleEvents\x88\x01\x01\x12&\n" +
+	"\ffollow_pages\x18\t \x01(\bH\bR\vfollowPages\x88\x01\x01\x12E\n" +
+	"\x10private_property\x18\n" +
+	" \x01(\v2\x15.calendar.PropertyMapH\tR\x0fprivateProperty\x88\x01\x01\x12C\n" +
+	"\x0fshared_property\x18\v \x01(\v2\x15.calendar.PropertyMapH\n" +
+	"R\x0esharedProperty\x88\x01\x01B\x0e\n" +
 	"\f_calendar_idB\b\n" +
 	"\x06_afterB\t\n" +
 	"\a_beforeB\t\n" +
 	"\a_futureB\a\n" +
 	"\x05_pastB\b\n" +
 	"\x06_limitB\t\n" +
-	"\a_anchor\"q\n" +
+	"\a_anchorB\x10\n" +
+	"\x0e_single_eventsB\x0f\n" +
+	"\r_follow_pagesB\x13\n" +
+	"\x11_private_propertyB\x12\n" +
+	"\x10_shared_property\"q\n" +
 	"\x12ListEventsResponse\x12%\n" +
 	"\x05event\x18\x01 \x01(\v2\x0f.calendar.EventR\x05event\x12$\n" +
 	"\vnext_anchor\x18\x02 \x01(\tH\x00R\n" +
 	"nextAnchor\x88\x01\x01B\x0e\n" +
-	"\f_next_anchor\"\xd4\x06\n" +
+	"\f_next_anchor\"\x8b\x02\n" +
+	"\x13SearchEventsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12$\n" +
+	"\vcalendar_id\x18\x02 \x01(\tH\x00R\n" +
+	"calendarId\x88\x01\x01\x125\n" +
+	"\x05after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x05after\x88\x01\x01\x127\n" +
+	"\x06before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x02R\x06before\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x05 \x01(\x05H\x03R\x05limit\x88\x01\x01B\x0e\n" +
+	"\f_calendar_idB\b\n" +
+	"\x06_afterB\t\n" +
+	"\a_beforeB\b\n" +
+	"\x06_limit\"W\n" +
+	"\x14ListCalendarsRequest\x12+\n" +
+	"\x0fmin_access_role\x18\x01 \x01(\tH\x00R\rminAccessRole\x88\x01\x01B\x12\n" +
+	"\x10_min_access_role\"o\n" +
+	"\bCalendar\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\asummary\x18\x02 \x01(\tR\asummary\x12\x1f\n" +
+	"\vaccess_role\x18\x03 \x01(\tR\n" +
+	"accessRole\x12\x18\n" +
+	"\aprimary\x18\x04 \x01(\bR\aprimary\"\xde\x01\n" +
+	"\x0fFreeBusyRequest\x125\n" +
+	"\x05after\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x05after\x88\x01\x01\x127\n" +
+	"\x06before\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x06before\x88\x01\x01\x129\n" +
+	"\bcalendar\x18\x03 \x01(\v2\x18.calendar.CalendarIdListH\x02R\bcalendar\x88\x01\x01B\b\n" +
+	"\x06_afterB\t\n" +
+	"\a_beforeB\v\n" +
+	"\t_calendar\"\"\n" +
+	"\x0eCalendarIdList\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"l\n" +
+	"\n" +
+	"BusyPeriod\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"u\n" +
+	"\x10FreeBusyCalendar\x12\x1f\n" +
+	"\vcalendar_id\x18\x01 \x01(\tR\n" +
+	"calendarId\x12(\n" +
+	"\x04busy\x18\x02 \x03(\v2\x14.calendar.BusyPeriodR\x04busy\x12\x16\n" +
+	"\x06errors\x18\x03 \x03(\tR\x06errors\"\\\n" +
+	"\x10ImportICSRequest\x12\x12\n" +
+	"\x04file\x18\x01 \x01(\tR\x04file\x12$\n" +
+	"\vcalendar_id\x18\x02 \x01(\tH\x00R\n" +
+	"calendarId\x88\x01\x01B\x0e\n" +
+	"\f_calendar_id\"\x0f\n" +
+	"\rRevokeRequest\"c\n" +
+	"\x0eRevokeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"token_path\x18\x03 \x01(\tR\ttokenPath\"\r\n" +
+	"\vPingRequest\"{\n" +
+	"\fPingResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
+	"\tauth_mode\x18\x03 \x01(\tR\bauthMode\x12\x1a\n" +
+	"\bendpoint\x18\x04 \x01(\tR\bendpoint\"\xd2\x01\n" +
+	"\x15FindDuplicatesRequest\x12$\n" +
+	"\vcalendar_id\x18\x01 \x01(\tH\x00R\n" +
+	"calendarId\x88\x01\x01\x125\n" +
+	"\x05after\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x05after\x88\x01\x01\x127\n" +
+	"\x06before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x02R\x06before\x88\x01\x01B\x0e\n" +
+	"\f_calendar_idB\b\n" +
+	"\x06_afterB\t\n" +
+	"\a_before\";\n" +
+	"\x10DuplicateCluster\x12'\n" +
+	"\x06events\x18\x01 \x03(\v2\x0f.calendar.EventR\x06events\"\xc6\x0e\n" +
 	"\x05Event\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\asummary\x18\x02 \x01(\tR\asummary\x12%\n" +
@@ -1124,7 +3128,32 @@ const file_calendar_proto_rawDesc = "" +
 	"\fsource_title\x18\x10 \x01(\tH\n" +
 	"R\vsourceTitle\x88\x01\x01\x12\"\n" +
 	"\n" +
-	"source_url\x18\x11 \x01(\tH\vR\tsourceUrl\x88\x01\x01B\x0e\n" +
+	"source_url\x18\x11 \x01(\tH\vR\tsourceUrl\x88\x01\x01\x12=\n" +
+	"\x10attendee_details\x18\x12 \x03(\v2\x12.calendar.AttendeeR\x0fattendeeDetails\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\x13 \x03(\tR\n" +
+	"recurrence\x120\n" +
+	"\treminders\x18\x14 \x03(\v2\x12.calendar.ReminderR\treminders\x12#\n" +
+	"\n" +
+	"visibility\x18\x15 \x01(\tH\fR\n" +
+	"visibility\x88\x01\x01\x126\n" +
+	"\vattachments\x18\x16 \x03(\v2\x14.calendar.AttachmentR\vattachments\x12 \n" +
+	"\ttime_zone\x18\x17 \x01(\tH\rR\btimeZone\x88\x01\x01\x12\x1e\n" +
+	"\bcolor_id\x18\x18 \x01(\tH\x0eR\acolorId\x88\x01\x01\x12U\n" +
+	"\x12private_properties\x18\x19 \x03(\v2&.calendar.Event.PrivatePropertiesEntryR\x11privateProperties\x12R\n" +
+	"\x11shared_properties\x18\x1a \x03(\v2%.calendar.Event.SharedPropertiesEntryR\x10sharedProperties\x12\x1c\n" +
+	"\aall_day\x18\x1b \x01(\bH\x0fR\x06allDay\x88\x01\x01\x12/\n" +
+	"\x11guests_can_modify\x18\x1c \x01(\bH\x10R\x0fguestsCanModify\x88\x01\x01\x12<\n" +
+	"\x18guests_can_invite_others\x18\x1d \x01(\bH\x11R\x15guestsCanInviteOthers\x88\x01\x01\x12A\n" +
+	"\x1bguests_can_see_other_guests\x18\x1e \x01(\bH\x12R\x17guestsCanSeeOtherGuests\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"event_type\x18\x1f \x01(\tH\x13R\teventType\x88\x01\x01\x1aD\n" +
+	"\x16PrivatePropertiesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aC\n" +
+	"\x15SharedPropertiesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
 	"\f_descriptionB\r\n" +
 	"\v_start_timeB\v\n" +
 	"\t_end_timeB\v\n" +
@@ -1136,14 +3165,78 @@ const file_calendar_proto_rawDesc = "" +
 	"\x0f_conference_uriB\x10\n" +
 	"\x0e_conference_idB\x0f\n" +
 	"\r_source_titleB\r\n" +
-	"\v_source_url2\xfa\x02\n" +
+	"\v_source_urlB\r\n" +
+	"\v_visibilityB\f\n" +
+	"\n" +
+	"_time_zoneB\v\n" +
+	"\t_color_idB\n" +
+	"\n" +
+	"\b_all_dayB\x14\n" +
+	"\x12_guests_can_modifyB\x1b\n" +
+	"\x19_guests_can_invite_othersB\x1e\n" +
+	"\x1c_guests_can_see_other_guestsB\r\n" +
+	"\v_event_type\"\xb7\x01\n" +
+	"\bAttendee\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12&\n" +
+	"\fdisplay_name\x18\x02 \x01(\tH\x00R\vdisplayName\x88\x01\x01\x12\x1a\n" +
+	"\boptional\x18\x03 \x01(\bR\boptional\x12,\n" +
+	"\x0fresponse_status\x18\x04 \x01(\tH\x01R\x0eresponseStatus\x88\x01\x01B\x0f\n" +
+	"\r_display_nameB\x12\n" +
+	"\x10_response_status\"\x93\x01\n" +
+	"\vPropertyMap\x12E\n" +
+	"\n" +
+	"properties\x18\x01 \x03(\v2%.calendar.PropertyMap.PropertiesEntryR\n" +
+	"properties\x1a=\n" +
+	"\x0fPropertiesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"@\n" +
+	"\fAttendeeList\x120\n" +
+	"\tattendees\x18\x01 \x03(\v2\x12.calendar.AttendeeR\tattendees\"\"\n" +
+	"\n" +
+	"Recurrence\x12\x14\n" +
+	"\x05rules\x18\x01 \x03(\tR\x05rules\"<\n" +
+	"\bReminder\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x18\n" +
+	"\aminutes\x18\x02 \x01(\x05R\aminutes\"@\n" +
+	"\fReminderList\x120\n" +
+	"\treminders\x18\x01 \x03(\v2\x12.calendar.ReminderR\treminders\"|\n" +
+	"\n" +
+	"Attachment\x12\x19\n" +
+	"\bfile_url\x18\x01 \x01(\tR\afileUrl\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12 \n" +
+	"\tmime_type\x18\x03 \x01(\tH\x01R\bmimeType\x88\x01\x01B\b\n" +
+	"\x06_titleB\f\n" +
+	"\n" +
+	"_mime_type\"H\n" +
+	"\x0eAttachmentList\x126\n" +
+	"\vattachments\x18\x01 \x03(\v2\x14.calendar.AttachmentR\vattachments*l\n" +
+	"\tEditScope\x12\x1a\n" +
+	"\x16EDIT_SCOPE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11EDIT_SCOPE_SINGLE\x10\x01\x12\x18\n" +
+	"\x14EDIT_SCOPE_FOLLOWING\x10\x02\x12\x12\n" +
+	"\x0eEDIT_SCOPE_ALL\x10\x032\xb7\n" +
+	"\n" +
 	"\x0fCalendarService\x12A\n" +
 	"\bAddEvent\x12\x19.calendar.AddEventRequest\x1a\x1a.calendar.AddEventResponse\x12J\n" +
 	"\vUpdateEvent\x12\x1c.calendar.UpdateEventRequest\x1a\x1d.calendar.UpdateEventResponse\x12J\n" +
 	"\vDeleteEvent\x12\x1c.calendar.DeleteEventRequest\x1a\x1d.calendar.DeleteEventResponse\x12A\n" +
-	"\bGetEvent\x12\x19.calendar.GetEventRequest\x1a\x1a.calendar.GetEventResponse\x12I\n" +
+	"\bGetEvent\x12\x19.calendar.GetEventRequest\x1a\x1a.calendar.GetEventResponse\x12A\n" +
+	"\bQuickAdd\x12\x19.calendar.QuickAddRequest\x1a\x1a.calendar.QuickAddResponse\x12G\n" +
+	"\vImportEvent\x12\x1c.calendar.ImportEventRequest\x1a\x1a.calendar.AddEventResponse\x12I\n" +
+	"\n" +
+	"ListEvents\x12\x1b.calendar.ListEventsRequest\x1a\x1c.calendar.ListEventsResponse0\x01\x12\xa2\x01\n" +
+	"\fSearchEvents\x12\x1d.calendar.SearchEventsRequest\x1a\x0f.calendar.Event\"`\x8a\xb5\x18\\\n" +
+	"\x06search\x12Rfind events whose text (summary, description, location, attendees) matches --query0\x01\x12E\n" +
+	"\rListCalendars\x12\x1e.calendar.ListCalendarsRequest\x1a\x12.calendar.Calendar0\x01\x12\x8e\x01\n" +
+	"\bFreeBusy\x12\x19.calendar.FreeBusyRequest\x1a\x1a.calendar.FreeBusyCalendar\"I\x8a\xb5\x18E\n" +
+	"\bfreebusy\x129show when calendars are busy between --after and --before0\x01\x12\x87\x01\n" +
+	"\tImportICS\x12\x1a.calendar.ImportICSRequest\x1a\x1a.calendar.AddEventResponse\"@\x8a\xb5\x18<\n" +
 	"\n" +
-	"ListEvents\x12\x1b.calendar.ListEventsRequest\x1a\x1c.calendar.ListEventsResponse0\x01B Z\x1egithub.com/drewfead/cali/protob\x06proto3"
+	"import-ics\x12.create events from the VEVENTs in an .ics file0\x01\x12;\n" +
+	"\x06Revoke\x12\x17.calendar.RevokeRequest\x1a\x18.calendar.RevokeResponse\x125\n" +
+	"\x04Ping\x12\x15.calendar.PingRequest\x1a\x16.calendar.PingResponse\x12\xb3\x01\n" +
+	"\x0eFindDuplicates\x12\x1f.calendar.FindDuplicatesRequest\x1a\x1a.calendar.DuplicateCluster\"b\x8a\xb5\x18^\n" +
+	"\x12duplicate-detector\x12Hreport likely duplicate events (same summary and times, or same iCalUID)0\x01B Z\x1egithub.com/drewfead/cali/protob\x06proto3"
 
 var (
 	file_calendar_proto_rawDescOnce sync.Once
@@ -1157,47 +3250,135 @@ func file_calendar_proto_rawDescGZIP() []byte {
 	return file_calendar_proto_rawDescData
 }
 
-var file_calendar_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_calendar_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_calendar_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
 var file_calendar_proto_goTypes = []any{
-	(*AddEventRequest)(nil),       // 0: calendar.AddEventRequest
-	(*AddEventResponse)(nil),      // 1: calendar.AddEventResponse
-	(*UpdateEventRequest)(nil),    // 2: calendar.UpdateEventRequest
-	(*UpdateEventResponse)(nil),   // 3: calendar.UpdateEventResponse
-	(*DeleteEventRequest)(nil),    // 4: calendar.DeleteEventRequest
-	(*DeleteEventResponse)(nil),   // 5: calendar.DeleteEventResponse
-	(*GetEventRequest)(nil),       // 6: calendar.GetEventRequest
-	(*GetEventResponse)(nil),      // 7: calendar.GetEventResponse
-	(*ListEventsRequest)(nil),     // 8: calendar.ListEventsRequest
-	(*ListEventsResponse)(nil),    // 9: calendar.ListEventsResponse
-	(*Event)(nil),                 // 10: calendar.Event
-	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(EditScope)(0),                // 0: calendar.EditScope
+	(*AddEventRequest)(nil),       // 1: calendar.AddEventRequest
+	(*AddEventResponse)(nil),      // 2: calendar.AddEventResponse
+	(*UpdateEventRequest)(nil),    // 3: calendar.UpdateEventRequest
+	(*UpdateEventResponse)(nil),   // 4: calendar.UpdateEventResponse
+	(*DeleteEventRequest)(nil),    // 5: calendar.DeleteEventRequest
+	(*DeleteEventResponse)(nil),   // 6: calendar.DeleteEventResponse
+	(*GetEventRequest)(nil),       // 7: calendar.GetEventRequest
+	(*GetEventResponse)(nil),      // 8: calendar.GetEventResponse
+	(*QuickAddRequest)(nil),       // 9: calendar.QuickAddRequest
+	(*QuickAddResponse)(nil),      // 10: calendar.QuickAddResponse
+	(*ImportEventRequest)(nil),    // 11: calendar.ImportEventRequest
+	(*ListEventsRequest)(nil),     // 12: calendar.ListEventsRequest
+	(*ListEventsResponse)(nil),    // 13: calendar.ListEventsResponse
+	(*SearchEventsRequest)(nil),   // 14: calendar.SearchEventsRequest
+	(*ListCalendarsRequest)(nil),  // 15: calendar.ListCalendarsRequest
+	(*Calendar)(nil),              // 16: calendar.Calendar
+	(*FreeBusyRequest)(nil),       // 17: calendar.FreeBusyRequest
+	(*CalendarIdList)(nil),        // 18: calendar.CalendarIdList
+	(*BusyPeriod)(nil),            // 19: calendar.BusyPeriod
+	(*FreeBusyCalendar)(nil),      // 20: calendar.FreeBusyCalendar
+	(*ImportICSRequest)(nil),      // 21: calendar.ImportICSRequest
+	(*RevokeRequest)(nil),         // 22: calendar.RevokeRequest
+	(*RevokeResponse)(nil),        // 23: calendar.RevokeResponse
+	(*PingRequest)(nil),           // 24: calendar.PingRequest
+	(*PingResponse)(nil),          // 25: calendar.PingResponse
+	(*FindDuplicatesRequest)(nil), // 26: calendar.FindDuplicatesRequest
+	(*DuplicateCluster)(nil),      // 27: calendar.DuplicateCluster
+	(*Event)(nil),                 // 28: calendar.Event
+	(*Attendee)(nil),              // 29: calendar.Attendee
+	(*PropertyMap)(nil),           // 30: calendar.PropertyMap
+	(*AttendeeList)(nil),          // 31: calendar.AttendeeList
+	(*Recurrence)(nil),            // 32: calendar.Recurrence
+	(*Reminder)(nil),              // 33: calendar.Reminder
+	(*ReminderList)(nil),          // 34: calendar.ReminderList
+	(*Attachment)(nil),            // 35: calendar.Attachment
+	(*AttachmentList)(nil),        // 36: calendar.AttachmentList
+	nil,                           // 37: calendar.Event.PrivatePropertiesEntry
+	nil,                           // 38: calendar.Event.SharedPropertiesEntry
+	nil,                           // 39: calendar.PropertyMap.PropertiesEntry
+	(*timestamppb.Timestamp)(nil), // 40: google.protobuf.Timestamp
 }
 var file_calendar_proto_depIdxs = []int32{
-	11, // 0: calendar.AddEventRequest.start_time:type_name -> google.protobuf.Timestamp
-	11, // 1: calendar.AddEventRequest.end_time:type_name -> google.protobuf.Timestamp
-	11, // 2: calendar.UpdateEventRequest.start_time:type_name -> google.protobuf.Timestamp
-	11, // 3: calendar.UpdateEventRequest.end_time:type_name -> google.protobuf.Timestamp
-	10, // 4: calendar.GetEventResponse.event:type_name -> calendar.Event
-	11, // 5: calendar.ListEventsRequest.after:type_name -> google.protobuf.Timestamp
-	11, // 6: calendar.ListEventsRequest.before:type_name -> google.protobuf.Timestamp
-	10, // 7: calendar.ListEventsResponse.event:type_name -> calendar.Event
-	11, // 8: calendar.Event.start_time:type_name -> google.protobuf.Timestamp
-	11, // 9: calendar.Event.end_time:type_name -> google.protobuf.Timestamp
-	0,  // 10: calendar.CalendarService.AddEvent:input_type -> calendar.AddEventRequest
-	2,  // 11: calendar.CalendarService.UpdateEvent:input_type -> calendar.UpdateEventRequest
-	4,  // 12: calendar.CalendarService.DeleteEvent:input_type -> calendar.DeleteEventRequest
-	6,  // 13: calendar.CalendarService.GetEvent:input_type -> calendar.GetEventRequest
-	8,  // 14: calendar.CalendarService.ListEvents:input_type -> calendar.ListEventsRequest
-	1,  // 15: calendar.CalendarService.AddEvent:output_type -> calendar.AddEventResponse
-	3,  // 16: calendar.CalendarService.UpdateEvent:output_type -> calendar.UpdateEventResponse
-	5,  // 17: calendar.CalendarService.DeleteEvent:output_type -> calendar.DeleteEventResponse
-	7,  // 18: calendar.CalendarService.GetEvent:output_type -> calendar.GetEventResponse
-	9,  // 19: calendar.CalendarService.ListEvents:output_type -> calendar.ListEventsResponse
-	15, // [15:20] is the sub-list for method output_type
-	10, // [10:15] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	40, // 0: calendar.AddEventRequest.start_time:type_name -> google.protobuf.Timestamp
+	40, // 1: calendar.AddEventRequest.end_time:type_name -> google.protobuf.Timestamp
+	31, // 2: calendar.AddEventRequest.attendees:type_name -> calendar.AttendeeList
+	32, // 3: calendar.AddEventRequest.recurrence:type_name -> calendar.Recurrence
+	34, // 4: calendar.AddEventRequest.reminders:type_name -> calendar.ReminderList
+	36, // 5: calendar.AddEventRequest.attachments:type_name -> calendar.AttachmentList
+	30, // 6: calendar.AddEventRequest.private_properties:type_name -> calendar.PropertyMap
+	30, // 7: calendar.AddEventRequest.shared_properties:type_name -> calendar.PropertyMap
+	40, // 8: calendar.UpdateEventRequest.start_time:type_name -> google.protobuf.Timestamp
+	40, // 9: calendar.UpdateEventRequest.end_time:type_name -> google.protobuf.Timestamp
+	31, // 10: calendar.UpdateEventRequest.attendees:type_name -> calendar.AttendeeList
+	32, // 11: calendar.UpdateEventRequest.recurrence:type_name -> calendar.Recurrence
+	34, // 12: calendar.UpdateEventRequest.reminders:type_name -> calendar.ReminderList
+	36, // 13: calendar.UpdateEventRequest.attachments:type_name -> calendar.AttachmentList
+	30, // 14: calendar.UpdateEventRequest.private_properties:type_name -> calendar.PropertyMap
+	30, // 15: calendar.UpdateEventRequest.shared_properties:type_name -> calendar.PropertyMap
+	0,  // 16: calendar.UpdateEventRequest.edit_scope:type_name -> calendar.EditScope
+	28, // 17: calendar.GetEventResponse.event:type_name -> calendar.Event
+	28, // 18: calendar.QuickAddResponse.event:type_name -> calendar.Event
+	40, // 19: calendar.ImportEventRequest.start_time:type_name -> google.protobuf.Timestamp
+	40, // 20: calendar.ImportEventRequest.end_time:type_name -> google.protobuf.Timestamp
+	31, // 21: calendar.ImportEventRequest.attendees:type_name -> calendar.AttendeeList
+	32, // 22: calendar.ImportEventRequest.recurrence:type_name -> calendar.Recurrence
+	40, // 23: calendar.ListEventsRequest.after:type_name -> google.protobuf.Timestamp
+	40, // 24: calendar.ListEventsRequest.before:type_name -> google.protobuf.Timestamp
+	30, // 25: calendar.ListEventsRequest.private_property:type_name -> calendar.PropertyMap
+	30, // 26: calendar.ListEventsRequest.shared_property:type_name -> calendar.PropertyMap
+	28, // 27: calendar.ListEventsResponse.event:type_name -> calendar.Event
+	40, // 28: calendar.SearchEventsRequest.after:type_name -> google.protobuf.Timestamp
+	40, // 29: calendar.SearchEventsRequest.before:type_name -> google.protobuf.Timestamp
+	40, // 30: calendar.FreeBusyRequest.after:type_name -> google.protobuf.Timestamp
+	40, // 31: calendar.FreeBusyRequest.before:type_name -> google.protobuf.Timestamp
+	18, // 32: calendar.FreeBusyRequest.calendar:type_name -> calendar.CalendarIdList
+	40, // 33: calendar.BusyPeriod.start:type_name -> google.protobuf.Timestamp
+	40, // 34: calendar.BusyPeriod.end:type_name -> google.protobuf.Timestamp
+	19, // 35: calendar.FreeBusyCalendar.busy:type_name -> calendar.BusyPeriod
+	40, // 36: calendar.FindDuplicatesRequest.after:type_name -> google.protobuf.Timestamp
+	40, // 37: calendar.FindDuplicatesRequest.before:type_name -> google.protobuf.Timestamp
+	28, // 38: calendar.DuplicateCluster.events:type_name -> calendar.Event
+	40, // 39: calendar.Event.start_time:type_name -> google.protobuf.Timestamp
+	40, // 40: calendar.Event.end_time:type_name -> google.protobuf.Timestamp
+	29, // 41: calendar.Event.attendee_details:type_name -> calendar.Attendee
+	33, // 42: calendar.Event.reminders:type_name -> calendar.Reminder
+	35, // 43: calendar.Event.attachments:type_name -> calendar.Attachment
+	37, // 44: calendar.Event.private_properties:type_name -> calendar.Event.PrivatePropertiesEntry
+	38, // 45: calendar.Event.shared_properties:type_name -> calendar.Event.SharedPropertiesEntry
+	39, // 46: calendar.PropertyMap.properties:type_name -> calendar.PropertyMap.PropertiesEntry
+	29, // 47: calendar.AttendeeList.attendees:type_name -> calendar.Attendee
+	33, // 48: calendar.ReminderList.reminders:type_name -> calendar.Reminder
+	35, // 49: calendar.AttachmentList.attachments:type_name -> calendar.Attachment
+	1,  // 50: calendar.CalendarService.AddEvent:input_type -> calendar.AddEventRequest
+	3,  // 51: calendar.CalendarService.UpdateEvent:input_type -> calendar.UpdateEventRequest
+	5,  // 52: calendar.CalendarService.DeleteEvent:input_type -> calendar.DeleteEventRequest
+	7,  // 53: calendar.CalendarService.GetEvent:input_type -> calendar.GetEventRequest
+	9,  // 54: calendar.CalendarService.QuickAdd:input_type -> calendar.QuickAddRequest
+	11, // 55: calendar.CalendarService.ImportEvent:input_type -> calendar.ImportEventRequest
+	12, // 56: calendar.CalendarService.ListEvents:input_type -> calendar.ListEventsRequest
+	14, // 57: calendar.CalendarService.SearchEvents:input_type -> calendar.SearchEventsRequest
+	15, // 58: calendar.CalendarService.ListCalendars:input_type -> calendar.ListCalendarsRequest
+	17, // 59: calendar.CalendarService.FreeBusy:input_type -> calendar.FreeBusyRequest
+	21, // 60: calendar.CalendarService.ImportICS:input_type -> calendar.ImportICSRequest
+	22, // 61: calendar.CalendarService.Revoke:input_type -> calendar.RevokeRequest
+	24, // 62: calendar.CalendarService.Ping:input_type -> calendar.PingRequest
+	26, // 63: calendar.CalendarService.FindDuplicates:input_type -> calendar.FindDuplicatesRequest
+	2,  // 64: calendar.CalendarService.AddEvent:output_type -> calendar.AddEventResponse
+	4,  // 65: calendar.CalendarService.UpdateEvent:output_type -> calendar.UpdateEventResponse
+	6,  // 66: calendar.CalendarService.DeleteEvent:output_type -> calendar.DeleteEventResponse
+	8,  // 67: calendar.CalendarService.GetEvent:output_type -> calendar.GetEventResponse
+	10, // 68: calendar.CalendarService.QuickAdd:output_type -> calendar.QuickAddResponse
+	2,  // 69: calendar.CalendarService.ImportEvent:output_type -> calendar.AddEventResponse
+	13, // 70: calendar.CalendarService.ListEvents:output_type -> calendar.ListEventsResponse
+	28, // 71: calendar.CalendarService.SearchEvents:output_type -> calendar.Event
+	16, // 72: calendar.CalendarService.ListCalendars:output_type -> calendar.Calendar
+	20, // 73: calendar.CalendarService.FreeBusy:output_type -> calendar.FreeBusyCalendar
+	2,  // 74: calendar.CalendarService.ImportICS:output_type -> calendar.AddEventResponse
+	23, // 75: calendar.CalendarService.Revoke:output_type -> calendar.RevokeResponse
+	25, // 76: calendar.CalendarService.Ping:output_type -> calendar.PingResponse
+	27, // 77: calendar.CalendarService.FindDuplicates:output_type -> calendar.DuplicateCluster
+	64, // [64:78] is the sub-list for method output_type
+	50, // [50:64] is the sub-list for method input_type
+	50, // [50:50] is the sub-list for extension type_name
+	50, // [50:50] is the sub-list for extension extendee
+	0,  // [0:50] is the sub-list for field type_name
 }
 
 func init() { file_calendar_proto_init() }
@@ -1210,20 +3391,30 @@ func file_calendar_proto_init() {
 	file_calendar_proto_msgTypes[4].OneofWrappers = []any{}
 	file_calendar_proto_msgTypes[6].OneofWrappers = []any{}
 	file_calendar_proto_msgTypes[8].OneofWrappers = []any{}
-	file_calendar_proto_msgTypes[9].OneofWrappers = []any{}
 	file_calendar_proto_msgTypes[10].OneofWrappers = []any{}
+	file_calendar_proto_msgTypes[11].OneofWrappers = []any{}
+	file_calendar_proto_msgTypes[12].OneofWrappers = []any{}
+	file_calendar_proto_msgTypes[13].OneofWrappers = []any{}
+	file_calendar_proto_msgTypes[14].OneofWrappers = []any{}
+	file_calendar_proto_msgTypes[16].OneofWrappers = []any{}
+	file_calendar_proto_msgTypes[20].OneofWrappers = []any{}
+	file_calendar_proto_msgTypes[25].OneofWrappers = []any{}
+	file_calendar_proto_msgTypes[27].OneofWrappers = []any{}
+	file_calendar_proto_msgTypes[28].OneofWrappers = []any{}
+	file_calendar_proto_msgTypes[34].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_calendar_proto_rawDesc), len(file_calendar_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      1,
+			NumMessages:   39,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_calendar_proto_goTypes,
 		DependencyIndexes: file_calendar_proto_depIdxs,
+		EnumInfos:         file_calendar_proto_enumTypes,
 		MessageInfos:      file_calendar_proto_msgTypes,
 	}.Build()
 	File_calendar_proto = out.File
@@ -74,6 +74,216 @@ func (s *localServerStream_ListEvents) RecvMsg(m any) error {
 	return fmt.Errorf("RecvMsg not supported on server streaming")
 }
 
+// localServerStream_SearchEvents is a helper type for local server streaming calls to SearchEvents
+type localServerStream_SearchEvents struct {
+	ctx       context.Context
+	responses chan *Event
+	errors    chan error
+}
+
+func (s *localServerStream_SearchEvents) Send(resp *Event) error {
+	select {
+	case s.responses <- resp:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func (s *localServerStream_SearchEvents) Context() context.Context {
+	return s.ctx
+}
+
+func (s *localServerStream_SearchEvents) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *localServerStream_SearchEvents) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *localServerStream_SearchEvents) SetTrailer(metadata.MD) {}
+
+func (s *localServerStream_SearchEvents) SendMsg(m any) error {
+	msg, ok := m.(*Event)
+	if !ok {
+		return fmt.Errorf("invalid message type: expected *%s, got %T", "Event", m)
+	}
+	return s.Send(msg)
+}
+
+func (s *localServerStream_SearchEvents) RecvMsg(m any) error {
+	return fmt.Errorf("RecvMsg not supported on server streaming")
+}
+
+// localServerStream_ListCalendars is a helper type for local server streaming calls to ListCalendars
+type localServerStream_ListCalendars struct {
+	ctx       context.Context
+	responses chan *Calendar
+	errors    chan error
+}
+
+func (s *localServerStream_ListCalendars) Send(resp *Calendar) error {
+	select {
+	case s.responses <- resp:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func (s *localServerStream_ListCalendars) Context() context.Context {
+	return s.ctx
+}
+
+func (s *localServerStream_ListCalendars) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *localServerStream_ListCalendars) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *localServerStream_ListCalendars) SetTrailer(metadata.MD) {}
+
+func (s *localServerStream_ListCalendars) SendMsg(m any) error {
+	msg, ok := m.(*Calendar)
+	if !ok {
+		return fmt.Errorf("invalid message type: expected *%s, got %T", "Calendar", m)
+	}
+	return s.Send(msg)
+}
+
+func (s *localServerStream_ListCalendars) RecvMsg(m any) error {
+	return fmt.Errorf("RecvMsg not supported on server streaming")
+}
+
+// localServerStream_FreeBusy is a helper type for local server streaming calls to FreeBusy
+type localServerStream_FreeBusy struct {
+	ctx       context.Context
+	responses chan *FreeBusyCalendar
+	errors    chan error
+}
+
+func (s *localServerStream_FreeBusy) Send(resp *FreeBusyCalendar) error {
+	select {
+	case s.responses <- resp:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func (s *localServerStream_FreeBusy) Context() context.Context {
+	return s.ctx
+}
+
+func (s *localServerStream_FreeBusy) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *localServerStream_FreeBusy) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *localServerStream_FreeBusy) SetTrailer(metadata.MD) {}
+
+func (s *localServerStream_FreeBusy) SendMsg(m any) error {
+	msg, ok := m.(*FreeBusyCalendar)
+	if !ok {
+		return fmt.Errorf("invalid message type: expected *%s, got %T", "FreeBusyCalendar", m)
+	}
+	return s.Send(msg)
+}
+
+func (s *localServerStream_FreeBusy) RecvMsg(m any) error {
+	return fmt.Errorf("RecvMsg not supported on server streaming")
+}
+
+// localServerStream_ImportICS is a helper type for local server streaming calls to ImportICS
+type localServerStream_ImportICS struct {
+	ctx       context.Context
+	responses chan *AddEventResponse
+	errors    chan error
+}
+
+func (s *localServerStream_ImportICS) Send(resp *AddEventResponse) error {
+	select {
+	case s.responses <- resp:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func (s *localServerStream_ImportICS) Context() context.Context {
+	return s.ctx
+}
+
+func (s *localServerStream_ImportICS) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *localServerStream_ImportICS) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *localServerStream_ImportICS) SetTrailer(metadata.MD) {}
+
+func (s *localServerStream_ImportICS) SendMsg(m any) error {
+	msg, ok := m.(*AddEventResponse)
+	if !ok {
+		return fmt.Errorf("invalid message type: expected *%s, got %T", "AddEventResponse", m)
+	}
+	return s.Send(msg)
+}
+
+func (s *localServerStream_ImportICS) RecvMsg(m any) error {
+	return fmt.Errorf("RecvMsg not supported on server streaming")
+}
+
+// localServerStream_FindDuplicates is a helper type for local server streaming calls to FindDuplicates
+type localServerStream_FindDuplicates struct {
+	ctx       context.Context
+	responses chan *DuplicateCluster
+	errors    chan error
+}
+
+func (s *localServerStream_FindDuplicates) Send(resp *DuplicateCluster) error {
+	select {
+	case s.responses <- resp:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func (s *localServerStream_FindDuplicates) Context() context.Context {
+	return s.ctx
+}
+
+func (s *localServerStream_FindDuplicates) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *localServerStream_FindDuplicates) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *localServerStream_FindDuplicates) SetTrailer(metadata.MD) {}
+
+func (s *localServerStream_FindDuplicates) SendMsg(m any) error {
+	msg, ok := m.(*DuplicateCluster)
+	if !ok {
+		return fmt.Errorf("invalid message type: expected *%s, got %T", "DuplicateCluster", m)
+	}
+	return s.Send(msg)
+}
+
+func (s *localServerStream_FindDuplicates) RecvMsg(m any) error {
+	return fmt.Errorf("RecvMsg not supported on server streaming")
+}
+
 // CalendarServiceCommand creates a CLI for CalendarService with options
 // The implOrFactory parameter can be either a direct service implementation or a factory function
 func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...protocli.ServiceOption) *protocli.ServiceCLI {
@@ -153,6 +363,58 @@ func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Name:  "blocks-time",
 		Usage: "BlocksTime",
 	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "event-type",
+		Usage: "EventType",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "attendees",
+		Usage: "Attendees (calendar.AttendeeList)",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "recurrence",
+		Usage: "Recurrence (calendar.Recurrence)",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "reminders",
+		Usage: "Reminders (calendar.ReminderList)",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "time-zone",
+		Usage: "TimeZone",
+	})
+	flags_add_event = append(flags_add_event, &v3.BoolFlag{
+		Name:  "all-day",
+		Usage: "AllDay",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "visibility",
+		Usage: "Visibility",
+	})
+	flags_add_event = append(flags_add_event, &v3.BoolFlag{
+		Name:  "add-conference",
+		Usage: "AddConference",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "attachments",
+		Usage: "Attachments (calendar.AttachmentList)",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "send-updates",
+		Usage: "SendUpdates",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "color-id",
+		Usage: "ColorId",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "private-properties",
+		Usage: "PrivateProperties (calendar.PropertyMap)",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "shared-properties",
+		Usage: "SharedProperties (calendar.PropertyMap)",
+	})
 
 	// Add format-specific flags from registered formats
 	for _, outputFmt := range options.OutputFormats() {
@@ -293,67 +555,239 @@ func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 					val := cmd.Bool("blocks-time")
 					req.BlocksTime = &val
 				}
-			}
-
-			// Check if using remote gRPC call or direct implementation call
-			remoteAddr := cmd.String("remote")
-			var resp *AddEventResponse
-			var err error
-
-			if remoteAddr != "" {
-				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-				if connErr != nil {
-					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
-				}
-				defer conn.Close()
-
-				client := NewCalendarServiceClient(conn)
-				resp, err = client.AddEvent(cmdCtx, req)
-				if err != nil {
-					return fmt.Errorf("remote call failed: %w", err)
+				if cmd.IsSet("event-type") {
+					val := cmd.String("event-type")
+					req.EventType = &val
 				}
-			} else {
-				// Direct implementation call (no config)
-				svcImpl := implOrFactory.(CalendarServiceServer)
-				resp, err = svcImpl.AddEvent(cmdCtx, req)
-				if err != nil {
-					return fmt.Errorf("method failed: %w", err)
+				// Field Attendees: check for custom deserializer for calendar.AttendeeList
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.AttendeeList"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: attendees
+					fieldFlags := protocli.NewFlagContainer(cmd, "attendees")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Attendees: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*AttendeeList)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.AttendeeList returned wrong type: expected *AttendeeList, got %T", fieldMsg)
+						}
+						req.Attendees = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("attendees") {
+						return fmt.Errorf("flag --attendees requires a custom deserializer for calendar.AttendeeList (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
 				}
-			}
-
-			// Open output writer
-			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
-			if err != nil {
-				return fmt.Errorf("failed to open output: %w", err)
-			}
-			if closer, ok := outputWriter.(io.Closer); ok {
-				defer closer.Close()
-			}
-
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
+				// Field Recurrence: check for custom deserializer for calendar.Recurrence
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.Recurrence"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: recurrence
+					fieldFlags := protocli.NewFlagContainer(cmd, "recurrence")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Recurrence: %w", fieldErr)
 					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*Recurrence)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.Recurrence returned wrong type: expected *Recurrence, got %T", fieldMsg)
+						}
+						req.Recurrence = typedField
 					}
-					return nil
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("recurrence") {
+						return fmt.Errorf("flag --recurrence requires a custom deserializer for calendar.Recurrence (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
 				}
-			}
-
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
+				// Field Reminders: check for custom deserializer for calendar.ReminderList
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.ReminderList"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: reminders
+					fieldFlags := protocli.NewFlagContainer(cmd, "reminders")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Reminders: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*ReminderList)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.ReminderList returned wrong type: expected *ReminderList, got %T", fieldMsg)
+						}
+						req.Reminders = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("reminders") {
+						return fmt.Errorf("flag --reminders requires a custom deserializer for calendar.ReminderList (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("time-zone") {
+					val := cmd.String("time-zone")
+					req.TimeZone = &val
+				}
+				if cmd.IsSet("all-day") {
+					val := cmd.Bool("all-day")
+					req.AllDay = &val
+				}
+				if cmd.IsSet("visibility") {
+					val := cmd.String("visibility")
+					req.Visibility = &val
+				}
+				if cmd.IsSet("add-conference") {
+					val := cmd.Bool("add-conference")
+					req.AddConference = &val
+				}
+				// Field Attachments: check for custom deserializer for calendar.AttachmentList
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.AttachmentList"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: attachments
+					fieldFlags := protocli.NewFlagContainer(cmd, "attachments")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Attachments: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*AttachmentList)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.AttachmentList returned wrong type: expected *AttachmentList, got %T", fieldMsg)
+						}
+						req.Attachments = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("attachments") {
+						return fmt.Errorf("flag --attachments requires a custom deserializer for calendar.AttachmentList (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("send-updates") {
+					val := cmd.String("send-updates")
+					req.SendUpdates = &val
+				}
+				if cmd.IsSet("color-id") {
+					val := cmd.String("color-id")
+					req.ColorId = &val
+				}
+				// Field PrivateProperties: check for custom deserializer for calendar.PropertyMap
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.PropertyMap"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: private-properties
+					fieldFlags := protocli.NewFlagContainer(cmd, "private-properties")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field PrivateProperties: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*PropertyMap)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.PropertyMap returned wrong type: expected *PropertyMap, got %T", fieldMsg)
+						}
+						req.PrivateProperties = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("private-properties") {
+						return fmt.Errorf("flag --private-properties requires a custom deserializer for calendar.PropertyMap (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field SharedProperties: check for custom deserializer for calendar.PropertyMap
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.PropertyMap"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: shared-properties
+					fieldFlags := protocli.NewFlagContainer(cmd, "shared-properties")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field SharedProperties: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*PropertyMap)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.PropertyMap returned wrong type: expected *PropertyMap, got %T", fieldMsg)
+						}
+						req.SharedProperties = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("shared-properties") {
+						return fmt.Errorf("flag --shared-properties requires a custom deserializer for calendar.PropertyMap (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *AddEventResponse
+			var err error
+
+			if remoteAddr != "" {
+				// Remote gRPC call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				resp, err = client.AddEvent(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+				resp, err = svcImpl.AddEvent(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find and use the appropriate output format
+			formatName := cmd.String("format")
+
+			// Try registered formats
+			for _, outputFmt := range options.OutputFormats() {
+				if outputFmt.Name() == formatName {
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+					// Write final newline to keep terminal clean
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+					return nil
+				}
+			}
+
+			// Format not found - build list of available formats
+			var availableFormats []string
+			for _, f := range options.OutputFormats() {
+				availableFormats = append(availableFormats, f.Name())
+			}
+			if len(availableFormats) == 0 {
 				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
 			}
 			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
@@ -429,6 +863,54 @@ func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Name:  "blocks-time",
 		Usage: "BlocksTime",
 	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "attendees",
+		Usage: "Attendees (calendar.AttendeeList)",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "recurrence",
+		Usage: "Recurrence (calendar.Recurrence)",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "reminders",
+		Usage: "Reminders (calendar.ReminderList)",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "time-zone",
+		Usage: "TimeZone",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "visibility",
+		Usage: "Visibility",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "attachments",
+		Usage: "Attachments (calendar.AttachmentList)",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "send-updates",
+		Usage: "SendUpdates",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "color-id",
+		Usage: "ColorId",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "private-properties",
+		Usage: "PrivateProperties (calendar.PropertyMap)",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "shared-properties",
+		Usage: "SharedProperties (calendar.PropertyMap)",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "edit-scope",
+		Usage: "EditScope (single, following, all)",
+	})
+	flags_update_event = append(flags_update_event, &v3.BoolFlag{
+		Name:  "all-day",
+		Usage: "AllDay",
+	})
 
 	// Add format-specific flags from registered formats
 	for _, outputFmt := range options.OutputFormats() {
@@ -569,6 +1051,181 @@ func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 					val := cmd.Bool("blocks-time")
 					req.BlocksTime = &val
 				}
+				// Field Attendees: check for custom deserializer for calendar.AttendeeList
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.AttendeeList"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: attendees
+					fieldFlags := protocli.NewFlagContainer(cmd, "attendees")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Attendees: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*AttendeeList)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.AttendeeList returned wrong type: expected *AttendeeList, got %T", fieldMsg)
+						}
+						req.Attendees = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("attendees") {
+						return fmt.Errorf("flag --attendees requires a custom deserializer for calendar.AttendeeList (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field Recurrence: check for custom deserializer for calendar.Recurrence
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.Recurrence"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: recurrence
+					fieldFlags := protocli.NewFlagContainer(cmd, "recurrence")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Recurrence: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*Recurrence)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.Recurrence returned wrong type: expected *Recurrence, got %T", fieldMsg)
+						}
+						req.Recurrence = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("recurrence") {
+						return fmt.Errorf("flag --recurrence requires a custom deserializer for calendar.Recurrence (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field Reminders: check for custom deserializer for calendar.ReminderList
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.ReminderList"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: reminders
+					fieldFlags := protocli.NewFlagContainer(cmd, "reminders")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Reminders: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*ReminderList)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.ReminderList returned wrong type: expected *ReminderList, got %T", fieldMsg)
+						}
+						req.Reminders = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("reminders") {
+						return fmt.Errorf("flag --reminders requires a custom deserializer for calendar.ReminderList (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("time-zone") {
+					val := cmd.String("time-zone")
+					req.TimeZone = &val
+				}
+				if cmd.IsSet("visibility") {
+					val := cmd.String("visibility")
+					req.Visibility = &val
+				}
+				// Field Attachments: check for custom deserializer for calendar.AttachmentList
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.AttachmentList"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: attachments
+					fieldFlags := protocli.NewFlagContainer(cmd, "attachments")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Attachments: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*AttachmentList)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.AttachmentList returned wrong type: expected *AttachmentList, got %T", fieldMsg)
+						}
+						req.Attachments = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("attachments") {
+						return fmt.Errorf("flag --attachments requires a custom deserializer for calendar.AttachmentList (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("send-updates") {
+					val := cmd.String("send-updates")
+					req.SendUpdates = &val
+				}
+				if cmd.IsSet("color-id") {
+					val := cmd.String("color-id")
+					req.ColorId = &val
+				}
+				// Field PrivateProperties: check for custom deserializer for calendar.PropertyMap
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.PropertyMap"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: private-properties
+					fieldFlags := protocli.NewFlagContainer(cmd, "private-properties")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field PrivateProperties: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*PropertyMap)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.PropertyMap returned wrong type: expected *PropertyMap, got %T", fieldMsg)
+						}
+						req.PrivateProperties = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("private-properties") {
+						return fmt.Errorf("flag --private-properties requires a custom deserializer for calendar.PropertyMap (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field SharedProperties: check for custom deserializer for calendar.PropertyMap
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.PropertyMap"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: shared-properties
+					fieldFlags := protocli.NewFlagContainer(cmd, "shared-properties")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field SharedProperties: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*PropertyMap)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.PropertyMap returned wrong type: expected *PropertyMap, got %T", fieldMsg)
+						}
+						req.SharedProperties = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("shared-properties") {
+						return fmt.Errorf("flag --shared-properties requires a custom deserializer for calendar.PropertyMap (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("edit-scope") {
+					val := cmd.String("edit-scope")
+					enumVal, ok := EditScope_value[val]
+					if !ok {
+						enumVal, ok = EditScope_value["EDIT_SCOPE_"+strings.ToUpper(val)]
+					}
+					if !ok {
+						return fmt.Errorf("invalid value %q for --edit-scope: expected single, following, or all", val)
+					}
+					req.EditScope = EditScope(enumVal)
+				}
+				if cmd.IsSet("all-day") {
+					val := cmd.Bool("all-day")
+					req.AllDay = &val
+				}
 			}
 
 			// Check if using remote gRPC call or direct implementation call
@@ -661,6 +1318,10 @@ func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Name:  "calendar-id",
 		Usage: "CalendarId",
 	})
+	flags_delete_event = append(flags_delete_event, &v3.StringFlag{
+		Name:  "send-updates",
+		Usage: "SendUpdates",
+	})
 
 	// Add format-specific flags from registered formats
 	for _, outputFmt := range options.OutputFormats() {
@@ -717,6 +1378,10 @@ func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 					val := cmd.String("calendar-id")
 					req.CalendarId = &val
 				}
+				if cmd.IsSet("send-updates") {
+					val := cmd.String("send-updates")
+					req.SendUpdates = &val
+				}
 			}
 
 			// Check if using remote gRPC call or direct implementation call
@@ -809,6 +1474,10 @@ func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Name:  "calendar-id",
 		Usage: "CalendarId",
 	})
+	flags_get_event = append(flags_get_event, &v3.BoolFlag{
+		Name:  "include-deleted",
+		Usage: "IncludeDeleted",
+	})
 
 	// Add format-specific flags from registered formats
 	for _, outputFmt := range options.OutputFormats() {
@@ -865,6 +1534,10 @@ func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 					val := cmd.String("calendar-id")
 					req.CalendarId = &val
 				}
+				if cmd.IsSet("include-deleted") {
+					val := cmd.Bool("include-deleted")
+					req.IncludeDeleted = &val
+				}
 			}
 
 			// Check if using remote gRPC call or direct implementation call
@@ -935,8 +1608,8 @@ func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Usage: "GetEvent",
 	})
 
-	// Build flags for list-events
-	flags_list_events := []v3.Flag{&v3.StringFlag{
+	// Build flags for quick-add
+	flags_quick_add := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
 		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
 	}, &v3.StringFlag{
@@ -947,57 +1620,211 @@ func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
-	}, &v3.StringFlag{
-		Name:  "delimiter",
-		Usage: "Delimiter between streamed messages",
-		Value: "\n",
 	}}
 
-	flags_list_events = append(flags_list_events, &v3.StringFlag{
+	flags_quick_add = append(flags_quick_add, &v3.StringFlag{
+		Name:  "text",
+		Usage: "Text",
+	})
+	flags_quick_add = append(flags_quick_add, &v3.StringFlag{
 		Name:  "calendar-id",
 		Usage: "CalendarId",
 	})
-	flags_list_events = append(flags_list_events, &v3.StringFlag{
-		Name:  "after",
-		Usage: "After (google.protobuf.Timestamp)",
-	})
-	flags_list_events = append(flags_list_events, &v3.StringFlag{
-		Name:  "before",
-		Usage: "Before (google.protobuf.Timestamp)",
-	})
-	flags_list_events = append(flags_list_events, &v3.BoolFlag{
-		Name:  "future",
-		Usage: "Future",
-	})
-	flags_list_events = append(flags_list_events, &v3.BoolFlag{
-		Name:  "past",
-		Usage: "Past",
-	})
-	flags_list_events = append(flags_list_events, &v3.Int32Flag{
-		Name:  "limit",
-		Usage: "Limit",
-	})
-	flags_list_events = append(flags_list_events, &v3.StringFlag{
-		Name:  "anchor",
-		Usage: "Anchor",
-	})
 
 	// Add format-specific flags from registered formats
 	for _, outputFmt := range options.OutputFormats() {
 		// Check if format implements FlagConfiguredOutputFormat
 		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_list_events = append(flags_list_events, flagConfigured.Flags()...)
+			flags_quick_add = append(flags_quick_add, flagConfigured.Flags()...)
 		}
 	}
 
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
 			for _, hook := range options.BeforeCommandHooks() {
 				if err := hook(cmdCtx, cmd); err != nil {
 					return fmt.Errorf("before hook failed: %w", err)
 				}
 			}
 
+			// Build request message
+			var req *QuickAddRequest
+
+			// Check for custom flag deserializer for calendar.QuickAddRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.QuickAddRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				// Create FlagContainer (deserializer can access multiple flags via Command())
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				// Handle nil return from deserializer
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*QuickAddRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "QuickAddRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &QuickAddRequest{}
+				req.Text = cmd.String("text")
+				if cmd.IsSet("calendar-id") {
+					val := cmd.String("calendar-id")
+					req.CalendarId = &val
+				}
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *QuickAddResponse
+			var err error
+
+			if remoteAddr != "" {
+				// Remote gRPC call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				resp, err = client.QuickAdd(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+				resp, err = svcImpl.QuickAdd(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find and use the appropriate output format
+			formatName := cmd.String("format")
+
+			// Try registered formats
+			for _, outputFmt := range options.OutputFormats() {
+				if outputFmt.Name() == formatName {
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+					// Write final newline to keep terminal clean
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+					return nil
+				}
+			}
+
+			// Format not found - build list of available formats
+			var availableFormats []string
+			for _, f := range options.OutputFormats() {
+				availableFormats = append(availableFormats, f.Name())
+			}
+			if len(availableFormats) == 0 {
+				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
+			}
+			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+		},
+		Flags: flags_quick_add,
+		Name:  "quick-add",
+		Usage: "QuickAdd",
+	})
+
+	// Build flags for import-event
+	flags_import_event := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}}
+
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "ical-uid",
+		Usage: "IcalUid",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "summary",
+		Usage: "Summary",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "description",
+		Usage: "Description",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "start-time",
+		Usage: "StartTime (google.protobuf.Timestamp)",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "end-time",
+		Usage: "EndTime (google.protobuf.Timestamp)",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "location",
+		Usage: "Location",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "calendar-id",
+		Usage: "CalendarId",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "time-zone",
+		Usage: "TimeZone",
+	})
+	flags_import_event = append(flags_import_event, &v3.BoolFlag{
+		Name:  "all-day",
+		Usage: "AllDay",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "attendees",
+		Usage: "Attendees (calendar.AttendeeList)",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "recurrence",
+		Usage: "Recurrence (calendar.Recurrence)",
+	})
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_import_event = append(flags_import_event, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -1007,41 +1834,51 @@ func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 			}()
 
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
 			// Build request message
-			var req *ListEventsRequest
+			var req *ImportEventRequest
 
-			// Check for custom flag deserializer for calendar.ListEventsRequest
-			deserializer, hasDeserializer := options.FlagDeserializer("calendar.ListEventsRequest")
+			// Check for custom flag deserializer for calendar.ImportEventRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.ImportEventRequest")
 			if hasDeserializer {
 				// Use custom deserializer for top-level request
+				// Create FlagContainer (deserializer can access multiple flags via Command())
 				requestFlags := protocli.NewFlagContainer(cmd, "")
 				msg, err := deserializer(cmdCtx, requestFlags)
 				if err != nil {
 					return fmt.Errorf("custom deserializer failed: %w", err)
 				}
+				// Handle nil return from deserializer
 				if msg == nil {
 					return fmt.Errorf("custom deserializer returned nil message")
 				}
 				var ok bool
-				req, ok = msg.(*ListEventsRequest)
+				req, ok = msg.(*ImportEventRequest)
 				if !ok {
-					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "ListEventsRequest", msg)
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "ImportEventRequest", msg)
 				}
 			} else {
 				// Use auto-generated flag parsing
-				req = &ListEventsRequest{}
-				if cmd.IsSet("calendar-id") {
-					val := cmd.String("calendar-id")
-					req.CalendarId = &val
+				req = &ImportEventRequest{}
+				req.IcalUid = cmd.String("ical-uid")
+				req.Summary = cmd.String("summary")
+				if cmd.IsSet("description") {
+					val := cmd.String("description")
+					req.Description = &val
 				}
-				// Field After: check for custom deserializer for google.protobuf.Timestamp
+				// Field StartTime: check for custom deserializer for google.protobuf.Timestamp
 				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
 					// Use custom deserializer for nested message
-					// Create FlagContainer for field flag: after
-					fieldFlags := protocli.NewFlagContainer(cmd, "after")
+					// Create FlagContainer for field flag: start-time
+					fieldFlags := protocli.NewFlagContainer(cmd, "start-time")
 					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
 					if fieldErr != nil {
-						return fmt.Errorf("failed to deserialize field After: %w", fieldErr)
+						return fmt.Errorf("failed to deserialize field StartTime: %w", fieldErr)
 					}
 					// Handle nil return from deserializer (means skip/use default)
 					if fieldMsg != nil {
@@ -1049,23 +1886,23 @@ func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 						if !fieldOk {
 							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
 						}
-						req.After = typedField
+						req.StartTime = typedField
 					}
 				} else {
 					// No custom deserializer - check if user provided a value
-					if cmd.IsSet("after") {
-						return fmt.Errorf("flag --after requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					if cmd.IsSet("start-time") {
+						return fmt.Errorf("flag --start-time requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
 					}
 					// No value provided - leave field as nil
 				}
-				// Field Before: check for custom deserializer for google.protobuf.Timestamp
+				// Field EndTime: check for custom deserializer for google.protobuf.Timestamp
 				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
 					// Use custom deserializer for nested message
-					// Create FlagContainer for field flag: before
-					fieldFlags := protocli.NewFlagContainer(cmd, "before")
+					// Create FlagContainer for field flag: end-time
+					fieldFlags := protocli.NewFlagContainer(cmd, "end-time")
 					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
 					if fieldErr != nil {
-						return fmt.Errorf("failed to deserialize field Before: %w", fieldErr)
+						return fmt.Errorf("failed to deserialize field EndTime: %w", fieldErr)
 					}
 					// Handle nil return from deserializer (means skip/use default)
 					if fieldMsg != nil {
@@ -1073,58 +1910,4628 @@ func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 						if !fieldOk {
 							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
 						}
-						req.Before = typedField
+						req.EndTime = typedField
 					}
 				} else {
 					// No custom deserializer - check if user provided a value
-					if cmd.IsSet("before") {
-						return fmt.Errorf("flag --before requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					if cmd.IsSet("end-time") {
+						return fmt.Errorf("flag --end-time requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
 					}
 					// No value provided - leave field as nil
 				}
-				if cmd.IsSet("future") {
-					val := cmd.Bool("future")
-					req.Future = &val
-				}
-				if cmd.IsSet("past") {
-					val := cmd.Bool("past")
-					req.Past = &val
-				}
-				if cmd.IsSet("limit") {
-					val := cmd.Int32("limit")
-					req.Limit = &val
+				if cmd.IsSet("location") {
+					val := cmd.String("location")
+					req.Location = &val
 				}
-				if cmd.IsSet("anchor") {
-					val := cmd.String("anchor")
-					req.Anchor = &val
+				if cmd.IsSet("calendar-id") {
+					val := cmd.String("calendar-id")
+					req.CalendarId = &val
 				}
-			}
-
-			// Open output writer
-			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
-			if err != nil {
-				return fmt.Errorf("failed to open output: %w", err)
-			}
-			if closer, ok := outputWriter.(io.Closer); ok {
-				defer closer.Close()
-			}
-
-			// Find the appropriate output format
-			formatName := cmd.String("format")
-			var outputFmt protocli.OutputFormat
-			for _, f := range options.OutputFormats() {
-				if f.Name() == formatName {
-					outputFmt = f
-					break
+				if cmd.IsSet("time-zone") {
+					val := cmd.String("time-zone")
+					req.TimeZone = &val
 				}
-			}
-			if outputFmt == nil {
-				var availableFormats []string
-				for _, f := range options.OutputFormats() {
-					availableFormats = append(availableFormats, f.Name())
+				if cmd.IsSet("all-day") {
+					val := cmd.Bool("all-day")
+					req.AllDay = &val
 				}
-				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
-			}
+				// Field Attendees: check for custom deserializer for calendar.AttendeeList
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.AttendeeList"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: attendees
+					fieldFlags := protocli.NewFlagContainer(cmd, "attendees")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Attendees: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*AttendeeList)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.AttendeeList returned wrong type: expected *AttendeeList, got %T", fieldMsg)
+						}
+						req.Attendees = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("attendees") {
+						return fmt.Errorf("flag --attendees requires a custom deserializer for calendar.AttendeeList (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field Recurrence: check for custom deserializer for calendar.Recurrence
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.Recurrence"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: recurrence
+					fieldFlags := protocli.NewFlagContainer(cmd, "recurrence")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Recurrence: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*Recurrence)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.Recurrence returned wrong type: expected *Recurrence, got %T", fieldMsg)
+						}
+						req.Recurrence = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("recurrence") {
+						return fmt.Errorf("flag --recurrence requires a custom deserializer for calendar.Recurrence (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *AddEventResponse
+			var err error
+
+			if remoteAddr != "" {
+				// Remote gRPC call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				resp, err = client.ImportEvent(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+				resp, err = svcImpl.ImportEvent(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find and use the appropriate output format
+			formatName := cmd.String("format")
+
+			// Try registered formats
+			for _, outputFmt := range options.OutputFormats() {
+				if outputFmt.Name() == formatName {
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+					// Write final newline to keep terminal clean
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+					return nil
+				}
+			}
+
+			// Format not found - build list of available formats
+			var availableFormats []string
+			for _, f := range options.OutputFormats() {
+				availableFormats = append(availableFormats, f.Name())
+			}
+			if len(availableFormats) == 0 {
+				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
+			}
+			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+		},
+		Flags: flags_import_event,
+		Name:  "import-event",
+		Usage: "ImportEvent",
+	})
+
+	// Build flags for list-events
+	flags_list_events := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "delimiter",
+		Usage: "Delimiter between streamed messages",
+		Value: "\n",
+	}}
+
+	flags_list_events = append(flags_list_events, &v3.StringFlag{
+		Name:  "calendar-id",
+		Usage: "CalendarId",
+	})
+	flags_list_events = append(flags_list_events, &v3.StringFlag{
+		Name:  "after",
+		Usage: "After (google.protobuf.Timestamp)",
+	})
+	flags_list_events = append(flags_list_events, &v3.StringFlag{
+		Name:  "before",
+		Usage: "Before (google.protobuf.Timestamp)",
+	})
+	flags_list_events = append(flags_list_events, &v3.BoolFlag{
+		Name:  "future",
+		Usage: "Future",
+	})
+	flags_list_events = append(flags_list_events, &v3.BoolFlag{
+		Name:  "past",
+		Usage: "Past",
+	})
+	flags_list_events = append(flags_list_events, &v3.Int32Flag{
+		Name:  "limit",
+		Usage: "Limit",
+	})
+	flags_list_events = append(flags_list_events, &v3.StringFlag{
+		Name:  "anchor",
+		Usage: "Anchor",
+	})
+	flags_list_events = append(flags_list_events, &v3.BoolFlag{
+		Name:  "single-events",
+		Usage: "SingleEvents",
+	})
+	flags_list_events = append(flags_list_events, &v3.BoolFlag{
+		Name:  "follow-pages",
+		Usage: "FollowPages",
+	})
+	flags_list_events = append(flags_list_events, &v3.StringFlag{
+		Name:  "private-property",
+		Usage: "PrivateProperty (calendar.PropertyMap)",
+	})
+	flags_list_events = append(flags_list_events, &v3.StringFlag{
+		Name:  "shared-property",
+		Usage: "SharedProperty (calendar.PropertyMap)",
+	})
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_list_events = append(flags_list_events, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			// Build request message
+			var req *ListEventsRequest
+
+			// Check for custom flag deserializer for calendar.ListEventsRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.ListEventsRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*ListEventsRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "ListEventsRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &ListEventsRequest{}
+				if cmd.IsSet("calendar-id") {
+					val := cmd.String("calendar-id")
+					req.CalendarId = &val
+				}
+				// Field After: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: after
+					fieldFlags := protocli.NewFlagContainer(cmd, "after")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field After: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.After = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("after") {
+						return fmt.Errorf("flag --after requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field Before: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: before
+					fieldFlags := protocli.NewFlagContainer(cmd, "before")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Before: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.Before = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("before") {
+						return fmt.Errorf("flag --before requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("future") {
+					val := cmd.Bool("future")
+					req.Future = &val
+				}
+				if cmd.IsSet("past") {
+					val := cmd.Bool("past")
+					req.Past = &val
+				}
+				if cmd.IsSet("limit") {
+					val := cmd.Int32("limit")
+					req.Limit = &val
+				}
+				if cmd.IsSet("anchor") {
+					val := cmd.String("anchor")
+					req.Anchor = &val
+				}
+				if cmd.IsSet("single-events") {
+					val := cmd.Bool("single-events")
+					req.SingleEvents = &val
+				}
+				if cmd.IsSet("follow-pages") {
+					val := cmd.Bool("follow-pages")
+					req.FollowPages = &val
+				}
+				// Field PrivateProperty: check for custom deserializer for calendar.PropertyMap
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.PropertyMap"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: private-property
+					fieldFlags := protocli.NewFlagContainer(cmd, "private-property")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field PrivateProperty: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*PropertyMap)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.PropertyMap returned wrong type: expected *PropertyMap, got %T", fieldMsg)
+						}
+						req.PrivateProperty = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("private-property") {
+						return fmt.Errorf("flag --private-property requires a custom deserializer for calendar.PropertyMap (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field SharedProperty: check for custom deserializer for calendar.PropertyMap
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.PropertyMap"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: shared-property
+					fieldFlags := protocli.NewFlagContainer(cmd, "shared-property")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field SharedProperty: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*PropertyMap)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.PropertyMap returned wrong type: expected *PropertyMap, got %T", fieldMsg)
+						}
+						req.SharedProperty = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("shared-property") {
+						return fmt.Errorf("flag --shared-property requires a custom deserializer for calendar.PropertyMap (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find the appropriate output format
+			formatName := cmd.String("format")
+			var outputFmt protocli.OutputFormat
+			for _, f := range options.OutputFormats() {
+				if f.Name() == formatName {
+					outputFmt = f
+					break
+				}
+			}
+			if outputFmt == nil {
+				var availableFormats []string
+				for _, f := range options.OutputFormats() {
+					availableFormats = append(availableFormats, f.Name())
+				}
+				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			}
+
+			// Get delimiter for separating streamed messages
+			delimiter := cmd.String("delimiter")
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+
+			if remoteAddr != "" {
+				// Remote gRPC streaming call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				stream, err := client.ListEvents(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("failed to start stream: %w", err)
+				}
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					msg, recvErr := stream.Recv()
+					if recvErr == io.EOF {
+						break
+					}
+					if recvErr != nil {
+						return fmt.Errorf("stream receive error: %w", recvErr)
+					}
+
+					// Format and write the message
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+
+				// Create local stream wrapper for direct call
+				localStream := &localServerStream_ListEvents{
+					ctx:       cmdCtx,
+					errors:    make(chan error),
+					responses: make(chan *ListEventsResponse),
+				}
+
+				// Call streaming method in goroutine
+				go func() {
+					var methodErr error
+					methodErr = svcImpl.ListEvents(req, localStream)
+					close(localStream.responses)
+					if methodErr != nil {
+						localStream.errors <- methodErr
+					}
+					close(localStream.errors)
+				}()
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					select {
+					case msg, ok := <-localStream.responses:
+						if !ok {
+							// Stream closed, check for errors
+							if streamErr := <-localStream.errors; streamErr != nil {
+								return fmt.Errorf("stream error: %w", streamErr)
+							}
+							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+								if _, err := outputWriter.Write([]byte("\n")); err != nil {
+									return fmt.Errorf("failed to write final newline: %w", err)
+								}
+							}
+							return nil
+						}
+
+						// Format and write the message
+						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+							return fmt.Errorf("format failed: %w", err)
+						}
+
+						// Write delimiter
+						if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+							return fmt.Errorf("failed to write delimiter: %w", err)
+						}
+						messageCount++
+					case <-cmdCtx.Done():
+						return cmdCtx.Err()
+					}
+				}
+			}
+
+			return nil
+		},
+		Flags: flags_list_events,
+		Name:  "list-events",
+		Usage: "ListEvents (streaming)",
+	})
+
+	// Build flags for search
+	flags_search := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "delimiter",
+		Usage: "Delimiter between streamed messages",
+		Value: "\n",
+	}}
+
+	flags_search = append(flags_search, &v3.StringFlag{
+		Name:  "query",
+		Usage: "Query",
+	})
+	flags_search = append(flags_search, &v3.StringFlag{
+		Name:  "calendar-id",
+		Usage: "CalendarId",
+	})
+	flags_search = append(flags_search, &v3.StringFlag{
+		Name:  "after",
+		Usage: "After (google.protobuf.Timestamp)",
+	})
+	flags_search = append(flags_search, &v3.StringFlag{
+		Name:  "before",
+		Usage: "Before (google.protobuf.Timestamp)",
+	})
+	flags_search = append(flags_search, &v3.Int32Flag{
+		Name:  "limit",
+		Usage: "Limit",
+	})
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_search = append(flags_search, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			// Build request message
+			var req *SearchEventsRequest
+
+			// Check for custom flag deserializer for calendar.SearchEventsRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.SearchEventsRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*SearchEventsRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "SearchEventsRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &SearchEventsRequest{}
+				req.Query = cmd.String("query")
+				if cmd.IsSet("calendar-id") {
+					val := cmd.String("calendar-id")
+					req.CalendarId = &val
+				}
+				// Field After: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: after
+					fieldFlags := protocli.NewFlagContainer(cmd, "after")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field After: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.After = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("after") {
+						return fmt.Errorf("flag --after requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field Before: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: before
+					fieldFlags := protocli.NewFlagContainer(cmd, "before")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Before: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.Before = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("before") {
+						return fmt.Errorf("flag --before requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("limit") {
+					val := cmd.Int32("limit")
+					req.Limit = &val
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find the appropriate output format
+			formatName := cmd.String("format")
+			var outputFmt protocli.OutputFormat
+			for _, f := range options.OutputFormats() {
+				if f.Name() == formatName {
+					outputFmt = f
+					break
+				}
+			}
+			if outputFmt == nil {
+				var availableFormats []string
+				for _, f := range options.OutputFormats() {
+					availableFormats = append(availableFormats, f.Name())
+				}
+				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			}
+
+			// Get delimiter for separating streamed messages
+			delimiter := cmd.String("delimiter")
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+
+			if remoteAddr != "" {
+				// Remote gRPC streaming call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				stream, err := client.SearchEvents(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("failed to start stream: %w", err)
+				}
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					msg, recvErr := stream.Recv()
+					if recvErr == io.EOF {
+						break
+					}
+					if recvErr != nil {
+						return fmt.Errorf("stream receive error: %w", recvErr)
+					}
+
+					// Format and write the message
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+
+				// Create local stream wrapper for direct call
+				localStream := &localServerStream_SearchEvents{
+					ctx:       cmdCtx,
+					errors:    make(chan error),
+					responses: make(chan *Event),
+				}
+
+				// Call streaming method in goroutine
+				go func() {
+					var methodErr error
+					methodErr = svcImpl.SearchEvents(req, localStream)
+					close(localStream.responses)
+					if methodErr != nil {
+						localStream.errors <- methodErr
+					}
+					close(localStream.errors)
+				}()
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					select {
+					case msg, ok := <-localStream.responses:
+						if !ok {
+							// Stream closed, check for errors
+							if streamErr := <-localStream.errors; streamErr != nil {
+								return fmt.Errorf("stream error: %w", streamErr)
+							}
+							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+								if _, err := outputWriter.Write([]byte("\n")); err != nil {
+									return fmt.Errorf("failed to write final newline: %w", err)
+								}
+							}
+							return nil
+						}
+
+						// Format and write the message
+						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+							return fmt.Errorf("format failed: %w", err)
+						}
+
+						// Write delimiter
+						if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+							return fmt.Errorf("failed to write delimiter: %w", err)
+						}
+						messageCount++
+					case <-cmdCtx.Done():
+						return cmdCtx.Err()
+					}
+				}
+			}
+
+			return nil
+		},
+		Flags: flags_search,
+		Name:  "search",
+		Usage: "find events whose text (summary, description, location, attendees) matches --query",
+	})
+
+	// Build flags for list-calendars
+	flags_list_calendars := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "delimiter",
+		Usage: "Delimiter between streamed messages",
+		Value: "\n",
+	}}
+
+	flags_list_calendars = append(flags_list_calendars, &v3.StringFlag{
+		Name:  "min-access-role",
+		Usage: "MinAccessRole",
+	})
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_list_calendars = append(flags_list_calendars, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			// Build request message
+			var req *ListCalendarsRequest
+
+			// Check for custom flag deserializer for calendar.ListCalendarsRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.ListCalendarsRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*ListCalendarsRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "ListCalendarsRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &ListCalendarsRequest{}
+				if cmd.IsSet("min-access-role") {
+					val := cmd.String("min-access-role")
+					req.MinAccessRole = &val
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find the appropriate output format
+			formatName := cmd.String("format")
+			var outputFmt protocli.OutputFormat
+			for _, f := range options.OutputFormats() {
+				if f.Name() == formatName {
+					outputFmt = f
+					break
+				}
+			}
+			if outputFmt == nil {
+				var availableFormats []string
+				for _, f := range options.OutputFormats() {
+					availableFormats = append(availableFormats, f.Name())
+				}
+				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			}
+
+			// Get delimiter for separating streamed messages
+			delimiter := cmd.String("delimiter")
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+
+			if remoteAddr != "" {
+				// Remote gRPC streaming call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				stream, err := client.ListCalendars(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("failed to start stream: %w", err)
+				}
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					msg, recvErr := stream.Recv()
+					if recvErr == io.EOF {
+						break
+					}
+					if recvErr != nil {
+						return fmt.Errorf("stream receive error: %w", recvErr)
+					}
+
+					// Format and write the message
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+
+				// Create local stream wrapper for direct call
+				localStream := &localServerStream_ListCalendars{
+					ctx:       cmdCtx,
+					errors:    make(chan error),
+					responses: make(chan *Calendar),
+				}
+
+				// Call streaming method in goroutine
+				go func() {
+					var methodErr error
+					methodErr = svcImpl.ListCalendars(req, localStream)
+					close(localStream.responses)
+					if methodErr != nil {
+						localStream.errors <- methodErr
+					}
+					close(localStream.errors)
+				}()
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					select {
+					case msg, ok := <-localStream.responses:
+						if !ok {
+							// Stream closed, check for errors
+							if streamErr := <-localStream.errors; streamErr != nil {
+								return fmt.Errorf("stream error: %w", streamErr)
+							}
+							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+								if _, err := outputWriter.Write([]byte("\n")); err != nil {
+									return fmt.Errorf("failed to write final newline: %w", err)
+								}
+							}
+							return nil
+						}
+
+						// Format and write the message
+						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+							return fmt.Errorf("format failed: %w", err)
+						}
+
+						// Write delimiter
+						if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+							return fmt.Errorf("failed to write delimiter: %w", err)
+						}
+						messageCount++
+					case <-cmdCtx.Done():
+						return cmdCtx.Err()
+					}
+				}
+			}
+
+			return nil
+		},
+		Flags: flags_list_calendars,
+		Name:  "list-calendars",
+		Usage: "ListCalendars (streaming)",
+	})
+
+	// Build flags for freebusy
+	flags_freebusy := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "delimiter",
+		Usage: "Delimiter between streamed messages",
+		Value: "\n",
+	}}
+
+	flags_freebusy = append(flags_freebusy, &v3.StringFlag{
+		Name:  "after",
+		Usage: "After (google.protobuf.Timestamp)",
+	})
+	flags_freebusy = append(flags_freebusy, &v3.StringFlag{
+		Name:  "before",
+		Usage: "Before (google.protobuf.Timestamp)",
+	})
+	flags_freebusy = append(flags_freebusy, &v3.StringFlag{
+		Name:  "calendar",
+		Usage: "Calendar (calendar.CalendarIdList)",
+	})
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_freebusy = append(flags_freebusy, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			// Build request message
+			var req *FreeBusyRequest
+
+			// Check for custom flag deserializer for calendar.FreeBusyRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.FreeBusyRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*FreeBusyRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "FreeBusyRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &FreeBusyRequest{}
+				// Field After: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: after
+					fieldFlags := protocli.NewFlagContainer(cmd, "after")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field After: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.After = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("after") {
+						return fmt.Errorf("flag --after requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field Before: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: before
+					fieldFlags := protocli.NewFlagContainer(cmd, "before")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Before: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.Before = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("before") {
+						return fmt.Errorf("flag --before requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field Calendar: check for custom deserializer for calendar.CalendarIdList
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.CalendarIdList"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: calendar
+					fieldFlags := protocli.NewFlagContainer(cmd, "calendar")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Calendar: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*CalendarIdList)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.CalendarIdList returned wrong type: expected *CalendarIdList, got %T", fieldMsg)
+						}
+						req.Calendar = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("calendar") {
+						return fmt.Errorf("flag --calendar requires a custom deserializer for calendar.CalendarIdList (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find the appropriate output format
+			formatName := cmd.String("format")
+			var outputFmt protocli.OutputFormat
+			for _, f := range options.OutputFormats() {
+				if f.Name() == formatName {
+					outputFmt = f
+					break
+				}
+			}
+			if outputFmt == nil {
+				var availableFormats []string
+				for _, f := range options.OutputFormats() {
+					availableFormats = append(availableFormats, f.Name())
+				}
+				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			}
+
+			// Get delimiter for separating streamed messages
+			delimiter := cmd.String("delimiter")
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+
+			if remoteAddr != "" {
+				// Remote gRPC streaming call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				stream, err := client.FreeBusy(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("failed to start stream: %w", err)
+				}
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					msg, recvErr := stream.Recv()
+					if recvErr == io.EOF {
+						break
+					}
+					if recvErr != nil {
+						return fmt.Errorf("stream receive error: %w", recvErr)
+					}
+
+					// Format and write the message
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+
+				// Create local stream wrapper for direct call
+				localStream := &localServerStream_FreeBusy{
+					ctx:       cmdCtx,
+					errors:    make(chan error),
+					responses: make(chan *FreeBusyCalendar),
+				}
+
+				// Call streaming method in goroutine
+				go func() {
+					var methodErr error
+					methodErr = svcImpl.FreeBusy(req, localStream)
+					close(localStream.responses)
+					if methodErr != nil {
+						localStream.errors <- methodErr
+					}
+					close(localStream.errors)
+				}()
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					select {
+					case msg, ok := <-localStream.responses:
+						if !ok {
+							// Stream closed, check for errors
+							if streamErr := <-localStream.errors; streamErr != nil {
+								return fmt.Errorf("stream error: %w", streamErr)
+							}
+							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+								if _, err := outputWriter.Write([]byte("\n")); err != nil {
+									return fmt.Errorf("failed to write final newline: %w", err)
+								}
+							}
+							return nil
+						}
+
+						// Format and write the message
+						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+							return fmt.Errorf("format failed: %w", err)
+						}
+
+						// Write delimiter
+						if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+							return fmt.Errorf("failed to write delimiter: %w", err)
+						}
+						messageCount++
+					case <-cmdCtx.Done():
+						return cmdCtx.Err()
+					}
+				}
+			}
+
+			return nil
+		},
+		Flags: flags_freebusy,
+		Name:  "freebusy",
+		Usage: "show when calendars are busy between --after and --before",
+	})
+
+	// Build flags for import-ics
+	flags_import_ics := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "delimiter",
+		Usage: "Delimiter between streamed messages",
+		Value: "\n",
+	}}
+
+	flags_import_ics = append(flags_import_ics, &v3.StringFlag{
+		Name:  "file",
+		Usage: "File",
+	})
+	flags_import_ics = append(flags_import_ics, &v3.StringFlag{
+		Name:  "calendar-id",
+		Usage: "CalendarId",
+	})
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_import_ics = append(flags_import_ics, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			// Build request message
+			var req *ImportICSRequest
+
+			// Check for custom flag deserializer for calendar.ImportICSRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.ImportICSRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*ImportICSRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "ImportICSRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &ImportICSRequest{}
+				req.File = cmd.String("file")
+				if cmd.IsSet("calendar-id") {
+					val := cmd.String("calendar-id")
+					req.CalendarId = &val
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find the appropriate output format
+			formatName := cmd.String("format")
+			var outputFmt protocli.OutputFormat
+			for _, f := range options.OutputFormats() {
+				if f.Name() == formatName {
+					outputFmt = f
+					break
+				}
+			}
+			if outputFmt == nil {
+				var availableFormats []string
+				for _, f := range options.OutputFormats() {
+					availableFormats = append(availableFormats, f.Name())
+				}
+				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			}
+
+			// Get delimiter for separating streamed messages
+			delimiter := cmd.String("delimiter")
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+
+			if remoteAddr != "" {
+				// Remote gRPC streaming call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				stream, err := client.ImportICS(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("failed to start stream: %w", err)
+				}
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					msg, recvErr := stream.Recv()
+					if recvErr == io.EOF {
+						break
+					}
+					if recvErr != nil {
+						return fmt.Errorf("stream receive error: %w", recvErr)
+					}
+
+					// Format and write the message
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+
+				// Create local stream wrapper for direct call
+				localStream := &localServerStream_ImportICS{
+					ctx:       cmdCtx,
+					errors:    make(chan error),
+					responses: make(chan *AddEventResponse),
+				}
+
+				// Call streaming method in goroutine
+				go func() {
+					var methodErr error
+					methodErr = svcImpl.ImportICS(req, localStream)
+					close(localStream.responses)
+					if methodErr != nil {
+						localStream.errors <- methodErr
+					}
+					close(localStream.errors)
+				}()
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					select {
+					case msg, ok := <-localStream.responses:
+						if !ok {
+							// Stream closed, check for errors
+							if streamErr := <-localStream.errors; streamErr != nil {
+								return fmt.Errorf("stream error: %w", streamErr)
+							}
+							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+								if _, err := outputWriter.Write([]byte("\n")); err != nil {
+									return fmt.Errorf("failed to write final newline: %w", err)
+								}
+							}
+							return nil
+						}
+
+						// Format and write the message
+						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+							return fmt.Errorf("format failed: %w", err)
+						}
+
+						// Write delimiter
+						if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+							return fmt.Errorf("failed to write delimiter: %w", err)
+						}
+						messageCount++
+					case <-cmdCtx.Done():
+						return cmdCtx.Err()
+					}
+				}
+			}
+
+			return nil
+		},
+		Flags: flags_import_ics,
+		Name:  "import-ics",
+		Usage: "create events from the VEVENTs in an .ics file",
+	})
+
+	// Build flags for revoke
+	flags_revoke := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}}
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_revoke = append(flags_revoke, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			// Build request message
+			var req *RevokeRequest
+
+			// Check for custom flag deserializer for calendar.RevokeRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.RevokeRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				// Create FlagContainer (deserializer can access multiple flags via Command())
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				// Handle nil return from deserializer
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*RevokeRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "RevokeRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &RevokeRequest{}
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *RevokeResponse
+			var err error
+
+			if remoteAddr != "" {
+				// Remote gRPC call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				resp, err = client.Revoke(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+				resp, err = svcImpl.Revoke(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find and use the appropriate output format
+			formatName := cmd.String("format")
+
+			// Try registered formats
+			for _, outputFmt := range options.OutputFormats() {
+				if outputFmt.Name() == formatName {
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+					// Write final newline to keep terminal clean
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+					return nil
+				}
+			}
+
+			// Format not found - build list of available formats
+			var availableFormats []string
+			for _, f := range options.OutputFormats() {
+				availableFormats = append(availableFormats, f.Name())
+			}
+			if len(availableFormats) == 0 {
+				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
+			}
+			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+		},
+		Flags: flags_revoke,
+		Name:  "revoke",
+		Usage: "Revoke",
+	})
+
+	// Build flags for ping
+	flags_ping := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}}
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_ping = append(flags_ping, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			// Build request message
+			var req *PingRequest
+
+			// Check for custom flag deserializer for calendar.PingRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.PingRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				// Create FlagContainer (deserializer can access multiple flags via Command())
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				// Handle nil return from deserializer
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*PingRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "PingRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &PingRequest{}
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *PingResponse
+			var err error
+
+			if remoteAddr != "" {
+				// Remote gRPC call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				resp, err = client.Ping(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+				resp, err = svcImpl.Ping(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find and use the appropriate output format
+			formatName := cmd.String("format")
+
+			// Try registered formats
+			for _, outputFmt := range options.OutputFormats() {
+				if outputFmt.Name() == formatName {
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+					// Write final newline to keep terminal clean
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+					return nil
+				}
+			}
+
+			// Format not found - build list of available formats
+			var availableFormats []string
+			for _, f := range options.OutputFormats() {
+				availableFormats = append(availableFormats, f.Name())
+			}
+			if len(availableFormats) == 0 {
+				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
+			}
+			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+		},
+		Flags: flags_ping,
+		Name:  "ping",
+		Usage: "Ping",
+	})
+
+	// Build flags for duplicate-detector
+	flags_duplicate_detector := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "delimiter",
+		Usage: "Delimiter between streamed messages",
+		Value: "\n",
+	}}
+
+	flags_duplicate_detector = append(flags_duplicate_detector, &v3.StringFlag{
+		Name:  "calendar-id",
+		Usage: "CalendarId",
+	})
+	flags_duplicate_detector = append(flags_duplicate_detector, &v3.StringFlag{
+		Name:  "after",
+		Usage: "After (google.protobuf.Timestamp)",
+	})
+	flags_duplicate_detector = append(flags_duplicate_detector, &v3.StringFlag{
+		Name:  "before",
+		Usage: "Before (google.protobuf.Timestamp)",
+	})
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_duplicate_detector = append(flags_duplicate_detector, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			// Build request message
+			var req *FindDuplicatesRequest
+
+			// Check for custom flag deserializer for calendar.FindDuplicatesRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.FindDuplicatesRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*FindDuplicatesRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "FindDuplicatesRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &FindDuplicatesRequest{}
+				if cmd.IsSet("calendar-id") {
+					val := cmd.String("calendar-id")
+					req.CalendarId = &val
+				}
+				// Field After: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: after
+					fieldFlags := protocli.NewFlagContainer(cmd, "after")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field After: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.After = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("after") {
+						return fmt.Errorf("flag --after requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field Before: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: before
+					fieldFlags := protocli.NewFlagContainer(cmd, "before")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Before: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.Before = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("before") {
+						return fmt.Errorf("flag --before requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find the appropriate output format
+			formatName := cmd.String("format")
+			var outputFmt protocli.OutputFormat
+			for _, f := range options.OutputFormats() {
+				if f.Name() == formatName {
+					outputFmt = f
+					break
+				}
+			}
+			if outputFmt == nil {
+				var availableFormats []string
+				for _, f := range options.OutputFormats() {
+					availableFormats = append(availableFormats, f.Name())
+				}
+				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			}
+
+			// Get delimiter for separating streamed messages
+			delimiter := cmd.String("delimiter")
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+
+			if remoteAddr != "" {
+				// Remote gRPC streaming call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				stream, err := client.FindDuplicates(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("failed to start stream: %w", err)
+				}
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					msg, recvErr := stream.Recv()
+					if recvErr == io.EOF {
+						break
+					}
+					if recvErr != nil {
+						return fmt.Errorf("stream receive error: %w", recvErr)
+					}
+
+					// Format and write the message
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+
+				// Create local stream wrapper for direct call
+				localStream := &localServerStream_FindDuplicates{
+					ctx:       cmdCtx,
+					errors:    make(chan error),
+					responses: make(chan *DuplicateCluster),
+				}
+
+				// Call streaming method in goroutine
+				go func() {
+					var methodErr error
+					methodErr = svcImpl.FindDuplicates(req, localStream)
+					close(localStream.responses)
+					if methodErr != nil {
+						localStream.errors <- methodErr
+					}
+					close(localStream.errors)
+				}()
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					select {
+					case msg, ok := <-localStream.responses:
+						if !ok {
+							// Stream closed, check for errors
+							if streamErr := <-localStream.errors; streamErr != nil {
+								return fmt.Errorf("stream error: %w", streamErr)
+							}
+							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+								if _, err := outputWriter.Write([]byte("\n")); err != nil {
+									return fmt.Errorf("failed to write final newline: %w", err)
+								}
+							}
+							return nil
+						}
+
+						// Format and write the message
+						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+							return fmt.Errorf("format failed: %w", err)
+						}
+
+						// Write delimiter
+						if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+							return fmt.Errorf("failed to write delimiter: %w", err)
+						}
+						messageCount++
+					case <-cmdCtx.Done():
+						return cmdCtx.Err()
+					}
+				}
+			}
+
+			return nil
+		},
+		Flags: flags_duplicate_detector,
+		Name:  "duplicate-detector",
+		Usage: "report likely duplicate events (same summary and times, or same iCalUID)",
+	})
+
+	return &protocli.ServiceCLI{
+		Command: &v3.Command{
+			Commands: commands,
+			Name:     "calendar-service",
+			Usage:    "Calendar commands",
+		},
+		ConfigMessageType: "",
+		FactoryOrImpl:     implOrFactory,
+		RegisterFunc: func(s *grpc.Server, impl interface{}) {
+			RegisterCalendarServiceServer(s, impl.(CalendarServiceServer))
+		},
+		ServiceName: "calendar-service",
+	}
+}
+
+// CalendarServiceCommandsFlat creates a flat command structure for CalendarService (for single-service CLIs)
+// This returns RPC commands directly at the root level instead of nested under a service command.
+// The implOrFactory parameter can be either a direct service implementation or a factory function
+// The returned slice includes all RPC commands plus a daemonize command for starting a gRPC server.
+func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opts ...protocli.ServiceOption) []*v3.Command {
+	options := protocli.ApplyServiceOptions(opts...)
+
+	// Determine default format (first registered format, or empty if none)
+	var defaultFormat string
+	if len(options.OutputFormats()) > 0 {
+		defaultFormat = options.OutputFormats()[0].Name()
+	}
+
+	var commands []*v3.Command
+
+	// Build flags for add-event
+	flags_add_event := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}}
+
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "summary",
+		Usage: "Summary",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "description",
+		Usage: "Description",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "start-time",
+		Usage: "StartTime (google.protobuf.Timestamp)",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "end-time",
+		Usage: "EndTime (google.protobuf.Timestamp)",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "location",
+		Usage: "Location",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "calendar-id",
+		Usage: "CalendarId",
+	})
+	flags_add_event = append(flags_add_event, &v3.BoolFlag{
+		Name:  "guests-can-see-other-guests",
+		Usage: "GuestsCanSeeOtherGuests",
+	})
+	flags_add_event = append(flags_add_event, &v3.BoolFlag{
+		Name:  "guests-can-modify",
+		Usage: "GuestsCanModify",
+	})
+	flags_add_event = append(flags_add_event, &v3.BoolFlag{
+		Name:  "guests-can-invite-others",
+		Usage: "GuestsCanInviteOthers",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "idempotency-key",
+		Usage: "IdempotencyKey",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "source-title",
+		Usage: "SourceTitle",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "source-url",
+		Usage: "SourceUrl",
+	})
+	flags_add_event = append(flags_add_event, &v3.BoolFlag{
+		Name:  "blocks-time",
+		Usage: "BlocksTime",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "event-type",
+		Usage: "EventType",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "attendees",
+		Usage: "Attendees (calendar.AttendeeList)",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "recurrence",
+		Usage: "Recurrence (calendar.Recurrence)",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "reminders",
+		Usage: "Reminders (calendar.ReminderList)",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "time-zone",
+		Usage: "TimeZone",
+	})
+	flags_add_event = append(flags_add_event, &v3.BoolFlag{
+		Name:  "all-day",
+		Usage: "AllDay",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "visibility",
+		Usage: "Visibility",
+	})
+	flags_add_event = append(flags_add_event, &v3.BoolFlag{
+		Name:  "add-conference",
+		Usage: "AddConference",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "attachments",
+		Usage: "Attachments (calendar.AttachmentList)",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "send-updates",
+		Usage: "SendUpdates",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "color-id",
+		Usage: "ColorId",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "private-properties",
+		Usage: "PrivateProperties (calendar.PropertyMap)",
+	})
+	flags_add_event = append(flags_add_event, &v3.StringFlag{
+		Name:  "shared-properties",
+		Usage: "SharedProperties (calendar.PropertyMap)",
+	})
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_add_event = append(flags_add_event, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			// Build request message
+			var req *AddEventRequest
+
+			// Check for custom flag deserializer for calendar.AddEventRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.AddEventRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				// Create FlagContainer (deserializer can access multiple flags via Command())
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				// Handle nil return from deserializer
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*AddEventRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "AddEventRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &AddEventRequest{}
+				req.Summary = cmd.String("summary")
+				if cmd.IsSet("description") {
+					val := cmd.String("description")
+					req.Description = &val
+				}
+				// Field StartTime: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: start-time
+					fieldFlags := protocli.NewFlagContainer(cmd, "start-time")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field StartTime: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.StartTime = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("start-time") {
+						return fmt.Errorf("flag --start-time requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field EndTime: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: end-time
+					fieldFlags := protocli.NewFlagContainer(cmd, "end-time")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field EndTime: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.EndTime = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("end-time") {
+						return fmt.Errorf("flag --end-time requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("location") {
+					val := cmd.String("location")
+					req.Location = &val
+				}
+				if cmd.IsSet("calendar-id") {
+					val := cmd.String("calendar-id")
+					req.CalendarId = &val
+				}
+				if cmd.IsSet("guests-can-see-other-guests") {
+					val := cmd.Bool("guests-can-see-other-guests")
+					req.GuestsCanSeeOtherGuests = &val
+				}
+				if cmd.IsSet("guests-can-modify") {
+					val := cmd.Bool("guests-can-modify")
+					req.GuestsCanModify = &val
+				}
+				if cmd.IsSet("guests-can-invite-others") {
+					val := cmd.Bool("guests-can-invite-others")
+					req.GuestsCanInviteOthers = &val
+				}
+				if cmd.IsSet("idempotency-key") {
+					val := cmd.String("idempotency-key")
+					req.IdempotencyKey = &val
+				}
+				if cmd.IsSet("source-title") {
+					val := cmd.String("source-title")
+					req.SourceTitle = &val
+				}
+				if cmd.IsSet("source-url") {
+					val := cmd.String("source-url")
+					req.SourceUrl = &val
+				}
+				if cmd.IsSet("blocks-time") {
+					val := cmd.Bool("blocks-time")
+					req.BlocksTime = &val
+				}
+				if cmd.IsSet("event-type") {
+					val := cmd.String("event-type")
+					req.EventType = &val
+				}
+				// Field Attendees: check for custom deserializer for calendar.AttendeeList
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.AttendeeList"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: attendees
+					fieldFlags := protocli.NewFlagContainer(cmd, "attendees")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Attendees: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*AttendeeList)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.AttendeeList returned wrong type: expected *AttendeeList, got %T", fieldMsg)
+						}
+						req.Attendees = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("attendees") {
+						return fmt.Errorf("flag --attendees requires a custom deserializer for calendar.AttendeeList (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field Recurrence: check for custom deserializer for calendar.Recurrence
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.Recurrence"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: recurrence
+					fieldFlags := protocli.NewFlagContainer(cmd, "recurrence")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Recurrence: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*Recurrence)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.Recurrence returned wrong type: expected *Recurrence, got %T", fieldMsg)
+						}
+						req.Recurrence = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("recurrence") {
+						return fmt.Errorf("flag --recurrence requires a custom deserializer for calendar.Recurrence (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field Reminders: check for custom deserializer for calendar.ReminderList
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.ReminderList"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: reminders
+					fieldFlags := protocli.NewFlagContainer(cmd, "reminders")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Reminders: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*ReminderList)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.ReminderList returned wrong type: expected *ReminderList, got %T", fieldMsg)
+						}
+						req.Reminders = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("reminders") {
+						return fmt.Errorf("flag --reminders requires a custom deserializer for calendar.ReminderList (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("time-zone") {
+					val := cmd.String("time-zone")
+					req.TimeZone = &val
+				}
+				if cmd.IsSet("all-day") {
+					val := cmd.Bool("all-day")
+					req.AllDay = &val
+				}
+				if cmd.IsSet("visibility") {
+					val := cmd.String("visibility")
+					req.Visibility = &val
+				}
+				if cmd.IsSet("add-conference") {
+					val := cmd.Bool("add-conference")
+					req.AddConference = &val
+				}
+				// Field Attachments: check for custom deserializer for calendar.AttachmentList
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.AttachmentList"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: attachments
+					fieldFlags := protocli.NewFlagContainer(cmd, "attachments")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Attachments: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*AttachmentList)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.AttachmentList returned wrong type: expected *AttachmentList, got %T", fieldMsg)
+						}
+						req.Attachments = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("attachments") {
+						return fmt.Errorf("flag --attachments requires a custom deserializer for calendar.AttachmentList (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("send-updates") {
+					val := cmd.String("send-updates")
+					req.SendUpdates = &val
+				}
+				if cmd.IsSet("color-id") {
+					val := cmd.String("color-id")
+					req.ColorId = &val
+				}
+				// Field PrivateProperties: check for custom deserializer for calendar.PropertyMap
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.PropertyMap"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: private-properties
+					fieldFlags := protocli.NewFlagContainer(cmd, "private-properties")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field PrivateProperties: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*PropertyMap)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.PropertyMap returned wrong type: expected *PropertyMap, got %T", fieldMsg)
+						}
+						req.PrivateProperties = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("private-properties") {
+						return fmt.Errorf("flag --private-properties requires a custom deserializer for calendar.PropertyMap (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field SharedProperties: check for custom deserializer for calendar.PropertyMap
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.PropertyMap"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: shared-properties
+					fieldFlags := protocli.NewFlagContainer(cmd, "shared-properties")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field SharedProperties: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*PropertyMap)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.PropertyMap returned wrong type: expected *PropertyMap, got %T", fieldMsg)
+						}
+						req.SharedProperties = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("shared-properties") {
+						return fmt.Errorf("flag --shared-properties requires a custom deserializer for calendar.PropertyMap (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *AddEventResponse
+			var err error
+
+			if remoteAddr != "" {
+				// Remote gRPC call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				resp, err = client.AddEvent(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+				resp, err = svcImpl.AddEvent(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find and use the appropriate output format
+			formatName := cmd.String("format")
+
+			// Try registered formats
+			for _, outputFmt := range options.OutputFormats() {
+				if outputFmt.Name() == formatName {
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+					// Write final newline to keep terminal clean
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+					return nil
+				}
+			}
+
+			// Format not found - build list of available formats
+			var availableFormats []string
+			for _, f := range options.OutputFormats() {
+				availableFormats = append(availableFormats, f.Name())
+			}
+			if len(availableFormats) == 0 {
+				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
+			}
+			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+		},
+		Flags: flags_add_event,
+		Name:  "add-event",
+		Usage: "AddEvent",
+	})
+
+	// Build flags for update-event
+	flags_update_event := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}}
+
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "event-id",
+		Usage: "EventId",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "calendar-id",
+		Usage: "CalendarId",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "summary",
+		Usage: "Summary",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "description",
+		Usage: "Description",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "start-time",
+		Usage: "StartTime (google.protobuf.Timestamp)",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "end-time",
+		Usage: "EndTime (google.protobuf.Timestamp)",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "location",
+		Usage: "Location",
+	})
+	flags_update_event = append(flags_update_event, &v3.BoolFlag{
+		Name:  "guests-can-see-other-guests",
+		Usage: "GuestsCanSeeOtherGuests",
+	})
+	flags_update_event = append(flags_update_event, &v3.BoolFlag{
+		Name:  "guests-can-modify",
+		Usage: "GuestsCanModify",
+	})
+	flags_update_event = append(flags_update_event, &v3.BoolFlag{
+		Name:  "guests-can-invite-others",
+		Usage: "GuestsCanInviteOthers",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "source-title",
+		Usage: "SourceTitle",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "source-url",
+		Usage: "SourceUrl",
+	})
+	flags_update_event = append(flags_update_event, &v3.BoolFlag{
+		Name:  "blocks-time",
+		Usage: "BlocksTime",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "attendees",
+		Usage: "Attendees (calendar.AttendeeList)",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "recurrence",
+		Usage: "Recurrence (calendar.Recurrence)",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "reminders",
+		Usage: "Reminders (calendar.ReminderList)",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "time-zone",
+		Usage: "TimeZone",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "visibility",
+		Usage: "Visibility",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "attachments",
+		Usage: "Attachments (calendar.AttachmentList)",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "send-updates",
+		Usage: "SendUpdates",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "color-id",
+		Usage: "ColorId",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "private-properties",
+		Usage: "PrivateProperties (calendar.PropertyMap)",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "shared-properties",
+		Usage: "SharedProperties (calendar.PropertyMap)",
+	})
+	flags_update_event = append(flags_update_event, &v3.StringFlag{
+		Name:  "edit-scope",
+		Usage: "EditScope (single, following, all)",
+	})
+	flags_update_event = append(flags_update_event, &v3.BoolFlag{
+		Name:  "all-day",
+		Usage: "AllDay",
+	})
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_update_event = append(flags_update_event, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			// Build request message
+			var req *UpdateEventRequest
+
+			// Check for custom flag deserializer for calendar.UpdateEventRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.UpdateEventRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				// Create FlagContainer (deserializer can access multiple flags via Command())
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				// Handle nil return from deserializer
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*UpdateEventRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "UpdateEventRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &UpdateEventRequest{}
+				req.EventId = cmd.String("event-id")
+				if cmd.IsSet("calendar-id") {
+					val := cmd.String("calendar-id")
+					req.CalendarId = &val
+				}
+				if cmd.IsSet("summary") {
+					val := cmd.String("summary")
+					req.Summary = &val
+				}
+				if cmd.IsSet("description") {
+					val := cmd.String("description")
+					req.Description = &val
+				}
+				// Field StartTime: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: start-time
+					fieldFlags := protocli.NewFlagContainer(cmd, "start-time")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field StartTime: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.StartTime = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("start-time") {
+						return fmt.Errorf("flag --start-time requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field EndTime: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: end-time
+					fieldFlags := protocli.NewFlagContainer(cmd, "end-time")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field EndTime: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.EndTime = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("end-time") {
+						return fmt.Errorf("flag --end-time requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("location") {
+					val := cmd.String("location")
+					req.Location = &val
+				}
+				if cmd.IsSet("guests-can-see-other-guests") {
+					val := cmd.Bool("guests-can-see-other-guests")
+					req.GuestsCanSeeOtherGuests = &val
+				}
+				if cmd.IsSet("guests-can-modify") {
+					val := cmd.Bool("guests-can-modify")
+					req.GuestsCanModify = &val
+				}
+				if cmd.IsSet("guests-can-invite-others") {
+					val := cmd.Bool("guests-can-invite-others")
+					req.GuestsCanInviteOthers = &val
+				}
+				if cmd.IsSet("source-title") {
+					val := cmd.String("source-title")
+					req.SourceTitle = &val
+				}
+				if cmd.IsSet("source-url") {
+					val := cmd.String("source-url")
+					req.SourceUrl = &val
+				}
+				if cmd.IsSet("blocks-time") {
+					val := cmd.Bool("blocks-time")
+					req.BlocksTime = &val
+				}
+				// Field Attendees: check for custom deserializer for calendar.AttendeeList
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.AttendeeList"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: attendees
+					fieldFlags := protocli.NewFlagContainer(cmd, "attendees")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Attendees: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*AttendeeList)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.AttendeeList returned wrong type: expected *AttendeeList, got %T", fieldMsg)
+						}
+						req.Attendees = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("attendees") {
+						return fmt.Errorf("flag --attendees requires a custom deserializer for calendar.AttendeeList (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field Recurrence: check for custom deserializer for calendar.Recurrence
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.Recurrence"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: recurrence
+					fieldFlags := protocli.NewFlagContainer(cmd, "recurrence")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Recurrence: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*Recurrence)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.Recurrence returned wrong type: expected *Recurrence, got %T", fieldMsg)
+						}
+						req.Recurrence = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("recurrence") {
+						return fmt.Errorf("flag --recurrence requires a custom deserializer for calendar.Recurrence (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field Reminders: check for custom deserializer for calendar.ReminderList
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.ReminderList"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: reminders
+					fieldFlags := protocli.NewFlagContainer(cmd, "reminders")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Reminders: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*ReminderList)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.ReminderList returned wrong type: expected *ReminderList, got %T", fieldMsg)
+						}
+						req.Reminders = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("reminders") {
+						return fmt.Errorf("flag --reminders requires a custom deserializer for calendar.ReminderList (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("time-zone") {
+					val := cmd.String("time-zone")
+					req.TimeZone = &val
+				}
+				if cmd.IsSet("visibility") {
+					val := cmd.String("visibility")
+					req.Visibility = &val
+				}
+				// Field Attachments: check for custom deserializer for calendar.AttachmentList
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.AttachmentList"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: attachments
+					fieldFlags := protocli.NewFlagContainer(cmd, "attachments")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Attachments: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*AttachmentList)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.AttachmentList returned wrong type: expected *AttachmentList, got %T", fieldMsg)
+						}
+						req.Attachments = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("attachments") {
+						return fmt.Errorf("flag --attachments requires a custom deserializer for calendar.AttachmentList (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("send-updates") {
+					val := cmd.String("send-updates")
+					req.SendUpdates = &val
+				}
+				if cmd.IsSet("color-id") {
+					val := cmd.String("color-id")
+					req.ColorId = &val
+				}
+				// Field PrivateProperties: check for custom deserializer for calendar.PropertyMap
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.PropertyMap"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: private-properties
+					fieldFlags := protocli.NewFlagContainer(cmd, "private-properties")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field PrivateProperties: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*PropertyMap)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.PropertyMap returned wrong type: expected *PropertyMap, got %T", fieldMsg)
+						}
+						req.PrivateProperties = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("private-properties") {
+						return fmt.Errorf("flag --private-properties requires a custom deserializer for calendar.PropertyMap (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field SharedProperties: check for custom deserializer for calendar.PropertyMap
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.PropertyMap"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: shared-properties
+					fieldFlags := protocli.NewFlagContainer(cmd, "shared-properties")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field SharedProperties: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*PropertyMap)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.PropertyMap returned wrong type: expected *PropertyMap, got %T", fieldMsg)
+						}
+						req.SharedProperties = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("shared-properties") {
+						return fmt.Errorf("flag --shared-properties requires a custom deserializer for calendar.PropertyMap (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("edit-scope") {
+					val := cmd.String("edit-scope")
+					enumVal, ok := EditScope_value[val]
+					if !ok {
+						enumVal, ok = EditScope_value["EDIT_SCOPE_"+strings.ToUpper(val)]
+					}
+					if !ok {
+						return fmt.Errorf("invalid value %q for --edit-scope: expected single, following, or all", val)
+					}
+					req.EditScope = EditScope(enumVal)
+				}
+				if cmd.IsSet("all-day") {
+					val := cmd.Bool("all-day")
+					req.AllDay = &val
+				}
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *UpdateEventResponse
+			var err error
+
+			if remoteAddr != "" {
+				// Remote gRPC call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				resp, err = client.UpdateEvent(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+				resp, err = svcImpl.UpdateEvent(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find and use the appropriate output format
+			formatName := cmd.String("format")
+
+			// Try registered formats
+			for _, outputFmt := range options.OutputFormats() {
+				if outputFmt.Name() == formatName {
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+					// Write final newline to keep terminal clean
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+					return nil
+				}
+			}
+
+			// Format not found - build list of available formats
+			var availableFormats []string
+			for _, f := range options.OutputFormats() {
+				availableFormats = append(availableFormats, f.Name())
+			}
+			if len(availableFormats) == 0 {
+				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
+			}
+			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+		},
+		Flags: flags_update_event,
+		Name:  "update-event",
+		Usage: "UpdateEvent",
+	})
+
+	// Build flags for delete-event
+	flags_delete_event := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}}
+
+	flags_delete_event = append(flags_delete_event, &v3.StringFlag{
+		Name:  "event-id",
+		Usage: "EventId",
+	})
+	flags_delete_event = append(flags_delete_event, &v3.StringFlag{
+		Name:  "calendar-id",
+		Usage: "CalendarId",
+	})
+	flags_delete_event = append(flags_delete_event, &v3.StringFlag{
+		Name:  "send-updates",
+		Usage: "SendUpdates",
+	})
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_delete_event = append(flags_delete_event, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			// Build request message
+			var req *DeleteEventRequest
+
+			// Check for custom flag deserializer for calendar.DeleteEventRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.DeleteEventRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				// Create FlagContainer (deserializer can access multiple flags via Command())
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				// Handle nil return from deserializer
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*DeleteEventRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "DeleteEventRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &DeleteEventRequest{}
+				req.EventId = cmd.String("event-id")
+				if cmd.IsSet("calendar-id") {
+					val := cmd.String("calendar-id")
+					req.CalendarId = &val
+				}
+				if cmd.IsSet("send-updates") {
+					val := cmd.String("send-updates")
+					req.SendUpdates = &val
+				}
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *DeleteEventResponse
+			var err error
+
+			if remoteAddr != "" {
+				// Remote gRPC call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				resp, err = client.DeleteEvent(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+				resp, err = svcImpl.DeleteEvent(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find and use the appropriate output format
+			formatName := cmd.String("format")
+
+			// Try registered formats
+			for _, outputFmt := range options.OutputFormats() {
+				if outputFmt.Name() == formatName {
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+					// Write final newline to keep terminal clean
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+					return nil
+				}
+			}
+
+			// Format not found - build list of available formats
+			var availableFormats []string
+			for _, f := range options.OutputFormats() {
+				availableFormats = append(availableFormats, f.Name())
+			}
+			if len(availableFormats) == 0 {
+				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
+			}
+			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+		},
+		Flags: flags_delete_event,
+		Name:  "delete-event",
+		Usage: "DeleteEvent",
+	})
+
+	// Build flags for get-event
+	flags_get_event := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}}
+
+	flags_get_event = append(flags_get_event, &v3.StringFlag{
+		Name:  "event-id",
+		Usage: "EventId",
+	})
+	flags_get_event = append(flags_get_event, &v3.StringFlag{
+		Name:  "calendar-id",
+		Usage: "CalendarId",
+	})
+	flags_get_event = append(flags_get_event, &v3.BoolFlag{
+		Name:  "include-deleted",
+		Usage: "IncludeDeleted",
+	})
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_get_event = append(flags_get_event, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			// Build request message
+			var req *GetEventRequest
+
+			// Check for custom flag deserializer for calendar.GetEventRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.GetEventRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				// Create FlagContainer (deserializer can access multiple flags via Command())
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				// Handle nil return from deserializer
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*GetEventRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "GetEventRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &GetEventRequest{}
+				req.EventId = cmd.String("event-id")
+				if cmd.IsSet("calendar-id") {
+					val := cmd.String("calendar-id")
+					req.CalendarId = &val
+				}
+				if cmd.IsSet("include-deleted") {
+					val := cmd.Bool("include-deleted")
+					req.IncludeDeleted = &val
+				}
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *GetEventResponse
+			var err error
+
+			if remoteAddr != "" {
+				// Remote gRPC call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				resp, err = client.GetEvent(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+				resp, err = svcImpl.GetEvent(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find and use the appropriate output format
+			formatName := cmd.String("format")
+
+			// Try registered formats
+			for _, outputFmt := range options.OutputFormats() {
+				if outputFmt.Name() == formatName {
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+					// Write final newline to keep terminal clean
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+					return nil
+				}
+			}
+
+			// Format not found - build list of available formats
+			var availableFormats []string
+			for _, f := range options.OutputFormats() {
+				availableFormats = append(availableFormats, f.Name())
+			}
+			if len(availableFormats) == 0 {
+				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
+			}
+			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+		},
+		Flags: flags_get_event,
+		Name:  "get-event",
+		Usage: "GetEvent",
+	})
+
+	// Build flags for quick-add
+	flags_quick_add := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}}
+
+	flags_quick_add = append(flags_quick_add, &v3.StringFlag{
+		Name:  "text",
+		Usage: "Text",
+	})
+	flags_quick_add = append(flags_quick_add, &v3.StringFlag{
+		Name:  "calendar-id",
+		Usage: "CalendarId",
+	})
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_quick_add = append(flags_quick_add, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			// Build request message
+			var req *QuickAddRequest
+
+			// Check for custom flag deserializer for calendar.QuickAddRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.QuickAddRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				// Create FlagContainer (deserializer can access multiple flags via Command())
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				// Handle nil return from deserializer
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*QuickAddRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "QuickAddRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &QuickAddRequest{}
+				req.Text = cmd.String("text")
+				if cmd.IsSet("calendar-id") {
+					val := cmd.String("calendar-id")
+					req.CalendarId = &val
+				}
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *QuickAddResponse
+			var err error
+
+			if remoteAddr != "" {
+				// Remote gRPC call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				resp, err = client.QuickAdd(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+				resp, err = svcImpl.QuickAdd(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find and use the appropriate output format
+			formatName := cmd.String("format")
+
+			// Try registered formats
+			for _, outputFmt := range options.OutputFormats() {
+				if outputFmt.Name() == formatName {
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+					// Write final newline to keep terminal clean
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+					return nil
+				}
+			}
+
+			// Format not found - build list of available formats
+			var availableFormats []string
+			for _, f := range options.OutputFormats() {
+				availableFormats = append(availableFormats, f.Name())
+			}
+			if len(availableFormats) == 0 {
+				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
+			}
+			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+		},
+		Flags: flags_quick_add,
+		Name:  "quick-add",
+		Usage: "QuickAdd",
+	})
+
+	// Build flags for import-event
+	flags_import_event := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}}
+
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "ical-uid",
+		Usage: "IcalUid",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "summary",
+		Usage: "Summary",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "description",
+		Usage: "Description",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "start-time",
+		Usage: "StartTime (google.protobuf.Timestamp)",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "end-time",
+		Usage: "EndTime (google.protobuf.Timestamp)",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "location",
+		Usage: "Location",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "calendar-id",
+		Usage: "CalendarId",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "time-zone",
+		Usage: "TimeZone",
+	})
+	flags_import_event = append(flags_import_event, &v3.BoolFlag{
+		Name:  "all-day",
+		Usage: "AllDay",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "attendees",
+		Usage: "Attendees (calendar.AttendeeList)",
+	})
+	flags_import_event = append(flags_import_event, &v3.StringFlag{
+		Name:  "recurrence",
+		Usage: "Recurrence (calendar.Recurrence)",
+	})
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_import_event = append(flags_import_event, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			// Build request message
+			var req *ImportEventRequest
+
+			// Check for custom flag deserializer for calendar.ImportEventRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.ImportEventRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				// Create FlagContainer (deserializer can access multiple flags via Command())
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				// Handle nil return from deserializer
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*ImportEventRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "ImportEventRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &ImportEventRequest{}
+				req.IcalUid = cmd.String("ical-uid")
+				req.Summary = cmd.String("summary")
+				if cmd.IsSet("description") {
+					val := cmd.String("description")
+					req.Description = &val
+				}
+				// Field StartTime: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: start-time
+					fieldFlags := protocli.NewFlagContainer(cmd, "start-time")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field StartTime: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.StartTime = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("start-time") {
+						return fmt.Errorf("flag --start-time requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field EndTime: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: end-time
+					fieldFlags := protocli.NewFlagContainer(cmd, "end-time")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field EndTime: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.EndTime = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("end-time") {
+						return fmt.Errorf("flag --end-time requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("location") {
+					val := cmd.String("location")
+					req.Location = &val
+				}
+				if cmd.IsSet("calendar-id") {
+					val := cmd.String("calendar-id")
+					req.CalendarId = &val
+				}
+				if cmd.IsSet("time-zone") {
+					val := cmd.String("time-zone")
+					req.TimeZone = &val
+				}
+				if cmd.IsSet("all-day") {
+					val := cmd.Bool("all-day")
+					req.AllDay = &val
+				}
+				// Field Attendees: check for custom deserializer for calendar.AttendeeList
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.AttendeeList"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: attendees
+					fieldFlags := protocli.NewFlagContainer(cmd, "attendees")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Attendees: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*AttendeeList)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.AttendeeList returned wrong type: expected *AttendeeList, got %T", fieldMsg)
+						}
+						req.Attendees = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("attendees") {
+						return fmt.Errorf("flag --attendees requires a custom deserializer for calendar.AttendeeList (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field Recurrence: check for custom deserializer for calendar.Recurrence
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.Recurrence"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: recurrence
+					fieldFlags := protocli.NewFlagContainer(cmd, "recurrence")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Recurrence: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*Recurrence)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.Recurrence returned wrong type: expected *Recurrence, got %T", fieldMsg)
+						}
+						req.Recurrence = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("recurrence") {
+						return fmt.Errorf("flag --recurrence requires a custom deserializer for calendar.Recurrence (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *AddEventResponse
+			var err error
+
+			if remoteAddr != "" {
+				// Remote gRPC call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				resp, err = client.ImportEvent(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+				resp, err = svcImpl.ImportEvent(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find and use the appropriate output format
+			formatName := cmd.String("format")
+
+			// Try registered formats
+			for _, outputFmt := range options.OutputFormats() {
+				if outputFmt.Name() == formatName {
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+					// Write final newline to keep terminal clean
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+					return nil
+				}
+			}
+
+			// Format not found - build list of available formats
+			var availableFormats []string
+			for _, f := range options.OutputFormats() {
+				availableFormats = append(availableFormats, f.Name())
+			}
+			if len(availableFormats) == 0 {
+				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
+			}
+			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+		},
+		Flags: flags_import_event,
+		Name:  "import-event",
+		Usage: "ImportEvent",
+	})
+
+	// Build flags for list-events
+	flags_list_events := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "delimiter",
+		Usage: "Delimiter between streamed messages",
+		Value: "\n",
+	}}
+
+	flags_list_events = append(flags_list_events, &v3.StringFlag{
+		Name:  "calendar-id",
+		Usage: "CalendarId",
+	})
+	flags_list_events = append(flags_list_events, &v3.StringFlag{
+		Name:  "after",
+		Usage: "After (google.protobuf.Timestamp)",
+	})
+	flags_list_events = append(flags_list_events, &v3.StringFlag{
+		Name:  "before",
+		Usage: "Before (google.protobuf.Timestamp)",
+	})
+	flags_list_events = append(flags_list_events, &v3.BoolFlag{
+		Name:  "future",
+		Usage: "Future",
+	})
+	flags_list_events = append(flags_list_events, &v3.BoolFlag{
+		Name:  "past",
+		Usage: "Past",
+	})
+	flags_list_events = append(flags_list_events, &v3.Int32Flag{
+		Name:  "limit",
+		Usage: "Limit",
+	})
+	flags_list_events = append(flags_list_events, &v3.StringFlag{
+		Name:  "anchor",
+		Usage: "Anchor",
+	})
+	flags_list_events = append(flags_list_events, &v3.BoolFlag{
+		Name:  "single-events",
+		Usage: "SingleEvents",
+	})
+	flags_list_events = append(flags_list_events, &v3.BoolFlag{
+		Name:  "follow-pages",
+		Usage: "FollowPages",
+	})
+	flags_list_events = append(flags_list_events, &v3.StringFlag{
+		Name:  "private-property",
+		Usage: "PrivateProperty (calendar.PropertyMap)",
+	})
+	flags_list_events = append(flags_list_events, &v3.StringFlag{
+		Name:  "shared-property",
+		Usage: "SharedProperty (calendar.PropertyMap)",
+	})
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_list_events = append(flags_list_events, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			// Build request message
+			var req *ListEventsRequest
+
+			// Check for custom flag deserializer for calendar.ListEventsRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.ListEventsRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*ListEventsRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "ListEventsRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &ListEventsRequest{}
+				if cmd.IsSet("calendar-id") {
+					val := cmd.String("calendar-id")
+					req.CalendarId = &val
+				}
+				// Field After: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: after
+					fieldFlags := protocli.NewFlagContainer(cmd, "after")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field After: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.After = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("after") {
+						return fmt.Errorf("flag --after requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field Before: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: before
+					fieldFlags := protocli.NewFlagContainer(cmd, "before")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Before: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.Before = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("before") {
+						return fmt.Errorf("flag --before requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("future") {
+					val := cmd.Bool("future")
+					req.Future = &val
+				}
+				if cmd.IsSet("past") {
+					val := cmd.Bool("past")
+					req.Past = &val
+				}
+				if cmd.IsSet("limit") {
+					val := cmd.Int32("limit")
+					req.Limit = &val
+				}
+				if cmd.IsSet("anchor") {
+					val := cmd.String("anchor")
+					req.Anchor = &val
+				}
+				if cmd.IsSet("single-events") {
+					val := cmd.Bool("single-events")
+					req.SingleEvents = &val
+				}
+				if cmd.IsSet("follow-pages") {
+					val := cmd.Bool("follow-pages")
+					req.FollowPages = &val
+				}
+				// Field PrivateProperty: check for custom deserializer for calendar.PropertyMap
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.PropertyMap"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: private-property
+					fieldFlags := protocli.NewFlagContainer(cmd, "private-property")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field PrivateProperty: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*PropertyMap)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.PropertyMap returned wrong type: expected *PropertyMap, got %T", fieldMsg)
+						}
+						req.PrivateProperty = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("private-property") {
+						return fmt.Errorf("flag --private-property requires a custom deserializer for calendar.PropertyMap (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field SharedProperty: check for custom deserializer for calendar.PropertyMap
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.PropertyMap"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: shared-property
+					fieldFlags := protocli.NewFlagContainer(cmd, "shared-property")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field SharedProperty: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*PropertyMap)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.PropertyMap returned wrong type: expected *PropertyMap, got %T", fieldMsg)
+						}
+						req.SharedProperty = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("shared-property") {
+						return fmt.Errorf("flag --shared-property requires a custom deserializer for calendar.PropertyMap (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find the appropriate output format
+			formatName := cmd.String("format")
+			var outputFmt protocli.OutputFormat
+			for _, f := range options.OutputFormats() {
+				if f.Name() == formatName {
+					outputFmt = f
+					break
+				}
+			}
+			if outputFmt == nil {
+				var availableFormats []string
+				for _, f := range options.OutputFormats() {
+					availableFormats = append(availableFormats, f.Name())
+				}
+				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			}
+
+			// Get delimiter for separating streamed messages
+			delimiter := cmd.String("delimiter")
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+
+			if remoteAddr != "" {
+				// Remote gRPC streaming call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				stream, err := client.ListEvents(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("failed to start stream: %w", err)
+				}
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					msg, recvErr := stream.Recv()
+					if recvErr == io.EOF {
+						break
+					}
+					if recvErr != nil {
+						return fmt.Errorf("stream receive error: %w", recvErr)
+					}
+
+					// Format and write the message
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+
+				// Create local stream wrapper for direct call
+				localStream := &localServerStream_ListEvents{
+					ctx:       cmdCtx,
+					errors:    make(chan error),
+					responses: make(chan *ListEventsResponse),
+				}
+
+				// Call streaming method in goroutine
+				go func() {
+					var methodErr error
+					methodErr = svcImpl.ListEvents(req, localStream)
+					close(localStream.responses)
+					if methodErr != nil {
+						localStream.errors <- methodErr
+					}
+					close(localStream.errors)
+				}()
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					select {
+					case msg, ok := <-localStream.responses:
+						if !ok {
+							// Stream closed, check for errors
+							if streamErr := <-localStream.errors; streamErr != nil {
+								return fmt.Errorf("stream error: %w", streamErr)
+							}
+							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+								if _, err := outputWriter.Write([]byte("\n")); err != nil {
+									return fmt.Errorf("failed to write final newline: %w", err)
+								}
+							}
+							return nil
+						}
+
+						// Format and write the message
+						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+							return fmt.Errorf("format failed: %w", err)
+						}
+
+						// Write delimiter
+						if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+							return fmt.Errorf("failed to write delimiter: %w", err)
+						}
+						messageCount++
+					case <-cmdCtx.Done():
+						return cmdCtx.Err()
+					}
+				}
+			}
+
+			return nil
+		},
+		Flags: flags_list_events,
+		Name:  "list-events",
+		Usage: "ListEvents (streaming)",
+	})
+
+	// Build flags for search
+	flags_search := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "delimiter",
+		Usage: "Delimiter between streamed messages",
+		Value: "\n",
+	}}
+
+	flags_search = append(flags_search, &v3.StringFlag{
+		Name:  "query",
+		Usage: "Query",
+	})
+	flags_search = append(flags_search, &v3.StringFlag{
+		Name:  "calendar-id",
+		Usage: "CalendarId",
+	})
+	flags_search = append(flags_search, &v3.StringFlag{
+		Name:  "after",
+		Usage: "After (google.protobuf.Timestamp)",
+	})
+	flags_search = append(flags_search, &v3.StringFlag{
+		Name:  "before",
+		Usage: "Before (google.protobuf.Timestamp)",
+	})
+	flags_search = append(flags_search, &v3.Int32Flag{
+		Name:  "limit",
+		Usage: "Limit",
+	})
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_search = append(flags_search, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			// Build request message
+			var req *SearchEventsRequest
+
+			// Check for custom flag deserializer for calendar.SearchEventsRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.SearchEventsRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*SearchEventsRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "SearchEventsRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &SearchEventsRequest{}
+				req.Query = cmd.String("query")
+				if cmd.IsSet("calendar-id") {
+					val := cmd.String("calendar-id")
+					req.CalendarId = &val
+				}
+				// Field After: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: after
+					fieldFlags := protocli.NewFlagContainer(cmd, "after")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field After: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.After = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("after") {
+						return fmt.Errorf("flag --after requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				// Field Before: check for custom deserializer for google.protobuf.Timestamp
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: before
+					fieldFlags := protocli.NewFlagContainer(cmd, "before")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Before: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
+						}
+						req.Before = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("before") {
+						return fmt.Errorf("flag --before requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
+				}
+				if cmd.IsSet("limit") {
+					val := cmd.Int32("limit")
+					req.Limit = &val
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find the appropriate output format
+			formatName := cmd.String("format")
+			var outputFmt protocli.OutputFormat
+			for _, f := range options.OutputFormats() {
+				if f.Name() == formatName {
+					outputFmt = f
+					break
+				}
+			}
+			if outputFmt == nil {
+				var availableFormats []string
+				for _, f := range options.OutputFormats() {
+					availableFormats = append(availableFormats, f.Name())
+				}
+				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			}
+
+			// Get delimiter for separating streamed messages
+			delimiter := cmd.String("delimiter")
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+
+			if remoteAddr != "" {
+				// Remote gRPC streaming call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				stream, err := client.SearchEvents(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("failed to start stream: %w", err)
+				}
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					msg, recvErr := stream.Recv()
+					if recvErr == io.EOF {
+						break
+					}
+					if recvErr != nil {
+						return fmt.Errorf("stream receive error: %w", recvErr)
+					}
+
+					// Format and write the message
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+				}
+			} else {
+				// Direct implementation call (no config)
+				svcImpl := implOrFactory.(CalendarServiceServer)
+
+				// Create local stream wrapper for direct call
+				localStream := &localServerStream_SearchEvents{
+					ctx:       cmdCtx,
+					errors:    make(chan error),
+					responses: make(chan *Event),
+				}
+
+				// Call streaming method in goroutine
+				go func() {
+					var methodErr error
+					methodErr = svcImpl.SearchEvents(req, localStream)
+					close(localStream.responses)
+					if methodErr != nil {
+						localStream.errors <- methodErr
+					}
+					close(localStream.errors)
+				}()
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					select {
+					case msg, ok := <-localStream.responses:
+						if !ok {
+							// Stream closed, check for errors
+							if streamErr := <-localStream.errors; streamErr != nil {
+								return fmt.Errorf("stream error: %w", streamErr)
+							}
+							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+								if _, err := outputWriter.Write([]byte("\n")); err != nil {
+									return fmt.Errorf("failed to write final newline: %w", err)
+								}
+							}
+							return nil
+						}
+
+						// Format and write the message
+						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+							return fmt.Errorf("format failed: %w", err)
+						}
+
+						// Write delimiter
+						if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+							return fmt.Errorf("failed to write delimiter: %w", err)
+						}
+						messageCount++
+					case <-cmdCtx.Done():
+						return cmdCtx.Err()
+					}
+				}
+			}
+
+			return nil
+		},
+		Flags: flags_search,
+		Name:  "search",
+		Usage: "find events whose text (summary, description, location, attendees) matches --query",
+	})
+
+	// Build flags for list-calendars
+	flags_list_calendars := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "delimiter",
+		Usage: "Delimiter between streamed messages",
+		Value: "\n",
+	}}
+
+	flags_list_calendars = append(flags_list_calendars, &v3.StringFlag{
+		Name:  "min-access-role",
+		Usage: "MinAccessRole",
+	})
+
+	// Add format-specific flags from registered formats
+	for _, outputFmt := range options.OutputFormats() {
+		// Check if format implements FlagConfiguredOutputFormat
+		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
+			flags_list_calendars = append(flags_list_calendars, flagConfigured.Flags()...)
+		}
+	}
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			// Build request message
+			var req *ListCalendarsRequest
+
+			// Check for custom flag deserializer for calendar.ListCalendarsRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.ListCalendarsRequest")
+			if hasDeserializer {
+				// Use custom deserializer for top-level request
+				requestFlags := protocli.NewFlagContainer(cmd, "")
+				msg, err := deserializer(cmdCtx, requestFlags)
+				if err != nil {
+					return fmt.Errorf("custom deserializer failed: %w", err)
+				}
+				if msg == nil {
+					return fmt.Errorf("custom deserializer returned nil message")
+				}
+				var ok bool
+				req, ok = msg.(*ListCalendarsRequest)
+				if !ok {
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "ListCalendarsRequest", msg)
+				}
+			} else {
+				// Use auto-generated flag parsing
+				req = &ListCalendarsRequest{}
+				if cmd.IsSet("min-access-role") {
+					val := cmd.String("min-access-role")
+					req.MinAccessRole = &val
+				}
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find the appropriate output format
+			formatName := cmd.String("format")
+			var outputFmt protocli.OutputFormat
+			for _, f := range options.OutputFormats() {
+				if f.Name() == formatName {
+					outputFmt = f
+					break
+				}
+			}
+			if outputFmt == nil {
+				var availableFormats []string
+				for _, f := range options.OutputFormats() {
+					availableFormats = append(availableFormats, f.Name())
+				}
+				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			}
 
 			// Get delimiter for separating streamed messages
 			delimiter := cmd.String("delimiter")
@@ -1141,7 +6548,7 @@ func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				defer conn.Close()
 
 				client := NewCalendarServiceClient(conn)
-				stream, err := client.ListEvents(cmdCtx, req)
+				stream, err := client.ListCalendars(cmdCtx, req)
 				if err != nil {
 					return fmt.Errorf("failed to start stream: %w", err)
 				}
@@ -1180,16 +6587,16 @@ func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				svcImpl := implOrFactory.(CalendarServiceServer)
 
 				// Create local stream wrapper for direct call
-				localStream := &localServerStream_ListEvents{
+				localStream := &localServerStream_ListCalendars{
 					ctx:       cmdCtx,
 					errors:    make(chan error),
-					responses: make(chan *ListEventsResponse),
+					responses: make(chan *Calendar),
 				}
 
 				// Call streaming method in goroutine
 				go func() {
 					var methodErr error
-					methodErr = svcImpl.ListEvents(req, localStream)
+					methodErr = svcImpl.ListCalendars(req, localStream)
 					close(localStream.responses)
 					if methodErr != nil {
 						localStream.errors <- methodErr
@@ -1234,43 +6641,13 @@ func CalendarServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 
 			return nil
 		},
-		Flags: flags_list_events,
-		Name:  "list-events",
-		Usage: "ListEvents (streaming)",
+		Flags: flags_list_calendars,
+		Name:  "list-calendars",
+		Usage: "ListCalendars (streaming)",
 	})
 
-	return &protocli.ServiceCLI{
-		Command: &v3.Command{
-			Commands: commands,
-			Name:     "calendar-service",
-			Usage:    "Calendar commands",
-		},
-		ConfigMessageType: "",
-		FactoryOrImpl:     implOrFactory,
-		RegisterFunc: func(s *grpc.Server, impl interface{}) {
-			RegisterCalendarServiceServer(s, impl.(CalendarServiceServer))
-		},
-		ServiceName: "calendar-service",
-	}
-}
-
-// CalendarServiceCommandsFlat creates a flat command structure for CalendarService (for single-service CLIs)
-// This returns RPC commands directly at the root level instead of nested under a service command.
-// The implOrFactory parameter can be either a direct service implementation or a factory function
-// The returned slice includes all RPC commands plus a daemonize command for starting a gRPC server.
-func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opts ...protocli.ServiceOption) []*v3.Command {
-	options := protocli.ApplyServiceOptions(opts...)
-
-	// Determine default format (first registered format, or empty if none)
-	var defaultFormat string
-	if len(options.OutputFormats()) > 0 {
-		defaultFormat = options.OutputFormats()[0].Name()
-	}
-
-	var commands []*v3.Command
-
-	// Build flags for add-event
-	flags_add_event := []v3.Flag{&v3.StringFlag{
+	// Build flags for freebusy
+	flags_freebusy := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
 		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
 	}, &v3.StringFlag{
@@ -1281,71 +6658,41 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "delimiter",
+		Usage: "Delimiter between streamed messages",
+		Value: "\n",
 	}}
 
-	flags_add_event = append(flags_add_event, &v3.StringFlag{
-		Name:  "summary",
-		Usage: "Summary",
-	})
-	flags_add_event = append(flags_add_event, &v3.StringFlag{
-		Name:  "description",
-		Usage: "Description",
-	})
-	flags_add_event = append(flags_add_event, &v3.StringFlag{
-		Name:  "start-time",
-		Usage: "StartTime (google.protobuf.Timestamp)",
-	})
-	flags_add_event = append(flags_add_event, &v3.StringFlag{
-		Name:  "end-time",
-		Usage: "EndTime (google.protobuf.Timestamp)",
-	})
-	flags_add_event = append(flags_add_event, &v3.StringFlag{
-		Name:  "location",
-		Usage: "Location",
-	})
-	flags_add_event = append(flags_add_event, &v3.StringFlag{
-		Name:  "calendar-id",
-		Usage: "CalendarId",
-	})
-	flags_add_event = append(flags_add_event, &v3.BoolFlag{
-		Name:  "guests-can-see-other-guests",
-		Usage: "GuestsCanSeeOtherGuests",
-	})
-	flags_add_event = append(flags_add_event, &v3.BoolFlag{
-		Name:  "guests-can-modify",
-		Usage: "GuestsCanModify",
-	})
-	flags_add_event = append(flags_add_event, &v3.BoolFlag{
-		Name:  "guests-can-invite-others",
-		Usage: "GuestsCanInviteOthers",
-	})
-	flags_add_event = append(flags_add_event, &v3.StringFlag{
-		Name:  "idempotency-key",
-		Usage: "IdempotencyKey",
-	})
-	flags_add_event = append(flags_add_event, &v3.StringFlag{
-		Name:  "source-title",
-		Usage: "SourceTitle",
+	flags_freebusy = append(flags_freebusy, &v3.StringFlag{
+		Name:  "after",
+		Usage: "After (google.protobuf.Timestamp)",
 	})
-	flags_add_event = append(flags_add_event, &v3.StringFlag{
-		Name:  "source-url",
-		Usage: "SourceUrl",
+	flags_freebusy = append(flags_freebusy, &v3.StringFlag{
+		Name:  "before",
+		Usage: "Before (google.protobuf.Timestamp)",
 	})
-	flags_add_event = append(flags_add_event, &v3.BoolFlag{
-		Name:  "blocks-time",
-		Usage: "BlocksTime",
+	flags_freebusy = append(flags_freebusy, &v3.StringFlag{
+		Name:  "calendar",
+		Usage: "Calendar (calendar.CalendarIdList)",
 	})
 
 	// Add format-specific flags from registered formats
 	for _, outputFmt := range options.OutputFormats() {
 		// Check if format implements FlagConfiguredOutputFormat
 		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_add_event = append(flags_add_event, flagConfigured.Flags()...)
+			flags_freebusy = append(flags_freebusy, flagConfigured.Flags()...)
 		}
 	}
 
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -1355,50 +6702,37 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 			}()
 
-			for _, hook := range options.BeforeCommandHooks() {
-				if err := hook(cmdCtx, cmd); err != nil {
-					return fmt.Errorf("before hook failed: %w", err)
-				}
-			}
-
 			// Build request message
-			var req *AddEventRequest
+			var req *FreeBusyRequest
 
-			// Check for custom flag deserializer for calendar.AddEventRequest
-			deserializer, hasDeserializer := options.FlagDeserializer("calendar.AddEventRequest")
+			// Check for custom flag deserializer for calendar.FreeBusyRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.FreeBusyRequest")
 			if hasDeserializer {
 				// Use custom deserializer for top-level request
-				// Create FlagContainer (deserializer can access multiple flags via Command())
 				requestFlags := protocli.NewFlagContainer(cmd, "")
 				msg, err := deserializer(cmdCtx, requestFlags)
 				if err != nil {
 					return fmt.Errorf("custom deserializer failed: %w", err)
 				}
-				// Handle nil return from deserializer
 				if msg == nil {
 					return fmt.Errorf("custom deserializer returned nil message")
 				}
 				var ok bool
-				req, ok = msg.(*AddEventRequest)
+				req, ok = msg.(*FreeBusyRequest)
 				if !ok {
-					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "AddEventRequest", msg)
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "FreeBusyRequest", msg)
 				}
 			} else {
 				// Use auto-generated flag parsing
-				req = &AddEventRequest{}
-				req.Summary = cmd.String("summary")
-				if cmd.IsSet("description") {
-					val := cmd.String("description")
-					req.Description = &val
-				}
-				// Field StartTime: check for custom deserializer for google.protobuf.Timestamp
+				req = &FreeBusyRequest{}
+				// Field After: check for custom deserializer for google.protobuf.Timestamp
 				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
 					// Use custom deserializer for nested message
-					// Create FlagContainer for field flag: start-time
-					fieldFlags := protocli.NewFlagContainer(cmd, "start-time")
+					// Create FlagContainer for field flag: after
+					fieldFlags := protocli.NewFlagContainer(cmd, "after")
 					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
 					if fieldErr != nil {
-						return fmt.Errorf("failed to deserialize field StartTime: %w", fieldErr)
+						return fmt.Errorf("failed to deserialize field After: %w", fieldErr)
 					}
 					// Handle nil return from deserializer (means skip/use default)
 					if fieldMsg != nil {
@@ -1406,23 +6740,23 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 						if !fieldOk {
 							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
 						}
-						req.StartTime = typedField
+						req.After = typedField
 					}
 				} else {
 					// No custom deserializer - check if user provided a value
-					if cmd.IsSet("start-time") {
-						return fmt.Errorf("flag --start-time requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					if cmd.IsSet("after") {
+						return fmt.Errorf("flag --after requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
 					}
 					// No value provided - leave field as nil
 				}
-				// Field EndTime: check for custom deserializer for google.protobuf.Timestamp
+				// Field Before: check for custom deserializer for google.protobuf.Timestamp
 				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
 					// Use custom deserializer for nested message
-					// Create FlagContainer for field flag: end-time
-					fieldFlags := protocli.NewFlagContainer(cmd, "end-time")
+					// Create FlagContainer for field flag: before
+					fieldFlags := protocli.NewFlagContainer(cmd, "before")
 					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
 					if fieldErr != nil {
-						return fmt.Errorf("failed to deserialize field EndTime: %w", fieldErr)
+						return fmt.Errorf("failed to deserialize field Before: %w", fieldErr)
 					}
 					// Handle nil return from deserializer (means skip/use default)
 					if fieldMsg != nil {
@@ -1430,123 +6764,182 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 						if !fieldOk {
 							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
 						}
-						req.EndTime = typedField
+						req.Before = typedField
 					}
 				} else {
 					// No custom deserializer - check if user provided a value
-					if cmd.IsSet("end-time") {
-						return fmt.Errorf("flag --end-time requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
+					if cmd.IsSet("before") {
+						return fmt.Errorf("flag --before requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
 					}
 					// No value provided - leave field as nil
 				}
-				if cmd.IsSet("location") {
-					val := cmd.String("location")
-					req.Location = &val
-				}
-				if cmd.IsSet("calendar-id") {
-					val := cmd.String("calendar-id")
-					req.CalendarId = &val
-				}
-				if cmd.IsSet("guests-can-see-other-guests") {
-					val := cmd.Bool("guests-can-see-other-guests")
-					req.GuestsCanSeeOtherGuests = &val
-				}
-				if cmd.IsSet("guests-can-modify") {
-					val := cmd.Bool("guests-can-modify")
-					req.GuestsCanModify = &val
-				}
-				if cmd.IsSet("guests-can-invite-others") {
-					val := cmd.Bool("guests-can-invite-others")
-					req.GuestsCanInviteOthers = &val
-				}
-				if cmd.IsSet("idempotency-key") {
-					val := cmd.String("idempotency-key")
-					req.IdempotencyKey = &val
-				}
-				if cmd.IsSet("source-title") {
-					val := cmd.String("source-title")
-					req.SourceTitle = &val
+				// Field Calendar: check for custom deserializer for calendar.CalendarIdList
+				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("calendar.CalendarIdList"); hasFieldDeserializer {
+					// Use custom deserializer for nested message
+					// Create FlagContainer for field flag: calendar
+					fieldFlags := protocli.NewFlagContainer(cmd, "calendar")
+					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+					if fieldErr != nil {
+						return fmt.Errorf("failed to deserialize field Calendar: %w", fieldErr)
+					}
+					// Handle nil return from deserializer (means skip/use default)
+					if fieldMsg != nil {
+						typedField, fieldOk := fieldMsg.(*CalendarIdList)
+						if !fieldOk {
+							return fmt.Errorf("custom deserializer for calendar.CalendarIdList returned wrong type: expected *CalendarIdList, got %T", fieldMsg)
+						}
+						req.Calendar = typedField
+					}
+				} else {
+					// No custom deserializer - check if user provided a value
+					if cmd.IsSet("calendar") {
+						return fmt.Errorf("flag --calendar requires a custom deserializer for calendar.CalendarIdList (register with protocli.WithFlagDeserializer)")
+					}
+					// No value provided - leave field as nil
 				}
-				if cmd.IsSet("source-url") {
-					val := cmd.String("source-url")
-					req.SourceUrl = &val
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find the appropriate output format
+			formatName := cmd.String("format")
+			var outputFmt protocli.OutputFormat
+			for _, f := range options.OutputFormats() {
+				if f.Name() == formatName {
+					outputFmt = f
+					break
 				}
-				if cmd.IsSet("blocks-time") {
-					val := cmd.Bool("blocks-time")
-					req.BlocksTime = &val
+			}
+			if outputFmt == nil {
+				var availableFormats []string
+				for _, f := range options.OutputFormats() {
+					availableFormats = append(availableFormats, f.Name())
 				}
+				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
 			}
 
+			// Get delimiter for separating streamed messages
+			delimiter := cmd.String("delimiter")
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
-			var resp *AddEventResponse
-			var err error
 
-			if remoteAddr != "" {
-				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-				if connErr != nil {
-					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+			if remoteAddr != "" {
+				// Remote gRPC streaming call
+				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewCalendarServiceClient(conn)
+				stream, err := client.FreeBusy(cmdCtx, req)
+				if err != nil {
+					return fmt.Errorf("failed to start stream: %w", err)
+				}
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					msg, recvErr := stream.Recv()
+					if recvErr == io.EOF {
+						break
+					}
+					if recvErr != nil {
+						return fmt.Errorf("stream receive error: %w", recvErr)
+					}
+
+					// Format and write the message
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
 				}
-				defer conn.Close()
 
-				client := NewCalendarServiceClient(conn)
-				resp, err = client.AddEvent(cmdCtx, req)
-				if err != nil {
-					return fmt.Errorf("remote call failed: %w", err)
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
 				}
 			} else {
 				// Direct implementation call (no config)
 				svcImpl := implOrFactory.(CalendarServiceServer)
-				resp, err = svcImpl.AddEvent(cmdCtx, req)
-				if err != nil {
-					return fmt.Errorf("method failed: %w", err)
+
+				// Create local stream wrapper for direct call
+				localStream := &localServerStream_FreeBusy{
+					ctx:       cmdCtx,
+					errors:    make(chan error),
+					responses: make(chan *FreeBusyCalendar),
 				}
-			}
 
-			// Open output writer
-			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
-			if err != nil {
-				return fmt.Errorf("failed to open output: %w", err)
-			}
-			if closer, ok := outputWriter.(io.Closer); ok {
-				defer closer.Close()
-			}
+				// Call streaming method in goroutine
+				go func() {
+					var methodErr error
+					methodErr = svcImpl.FreeBusy(req, localStream)
+					close(localStream.responses)
+					if methodErr != nil {
+						localStream.errors <- methodErr
+					}
+					close(localStream.errors)
+				}()
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					select {
+					case msg, ok := <-localStream.responses:
+						if !ok {
+							// Stream closed, check for errors
+							if streamErr := <-localStream.errors; streamErr != nil {
+								return fmt.Errorf("stream error: %w", streamErr)
+							}
+							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+								if _, err := outputWriter.Write([]byte("\n")); err != nil {
+									return fmt.Errorf("failed to write final newline: %w", err)
+								}
+							}
+							return nil
+						}
 
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
+						// Format and write the message
+						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+							return fmt.Errorf("format failed: %w", err)
+						}
+
+						// Write delimiter
+						if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+							return fmt.Errorf("failed to write delimiter: %w", err)
+						}
+						messageCount++
+					case <-cmdCtx.Done():
+						return cmdCtx.Err()
 					}
-					return nil
 				}
 			}
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			return nil
 		},
-		Flags: flags_add_event,
-		Name:  "add-event",
-		Usage: "AddEvent",
+		Flags: flags_freebusy,
+		Name:  "freebusy",
+		Usage: "show when calendars are busy between --after and --before",
 	})
 
-	// Build flags for update-event
-	flags_update_event := []v3.Flag{&v3.StringFlag{
+	// Build flags for import-ics
+	flags_import_ics := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
 		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
 	}, &v3.StringFlag{
@@ -1557,71 +6950,37 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "delimiter",
+		Usage: "Delimiter between streamed messages",
+		Value: "\n",
 	}}
 
-	flags_update_event = append(flags_update_event, &v3.StringFlag{
-		Name:  "event-id",
-		Usage: "EventId",
+	flags_import_ics = append(flags_import_ics, &v3.StringFlag{
+		Name:  "file",
+		Usage: "File",
 	})
-	flags_update_event = append(flags_update_event, &v3.StringFlag{
+	flags_import_ics = append(flags_import_ics, &v3.StringFlag{
 		Name:  "calendar-id",
 		Usage: "CalendarId",
 	})
-	flags_update_event = append(flags_update_event, &v3.StringFlag{
-		Name:  "summary",
-		Usage: "Summary",
-	})
-	flags_update_event = append(flags_update_event, &v3.StringFlag{
-		Name:  "description",
-		Usage: "Description",
-	})
-	flags_update_event = append(flags_update_event, &v3.StringFlag{
-		Name:  "start-time",
-		Usage: "StartTime (google.protobuf.Timestamp)",
-	})
-	flags_update_event = append(flags_update_event, &v3.StringFlag{
-		Name:  "end-time",
-		Usage: "EndTime (google.protobuf.Timestamp)",
-	})
-	flags_update_event = append(flags_update_event, &v3.StringFlag{
-		Name:  "location",
-		Usage: "Location",
-	})
-	flags_update_event = append(flags_update_event, &v3.BoolFlag{
-		Name:  "guests-can-see-other-guests",
-		Usage: "GuestsCanSeeOtherGuests",
-	})
-	flags_update_event = append(flags_update_event, &v3.BoolFlag{
-		Name:  "guests-can-modify",
-		Usage: "GuestsCanModify",
-	})
-	flags_update_event = append(flags_update_event, &v3.BoolFlag{
-		Name:  "guests-can-invite-others",
-		Usage: "GuestsCanInviteOthers",
-	})
-	flags_update_event = append(flags_update_event, &v3.StringFlag{
-		Name:  "source-title",
-		Usage: "SourceTitle",
-	})
-	flags_update_event = append(flags_update_event, &v3.StringFlag{
-		Name:  "source-url",
-		Usage: "SourceUrl",
-	})
-	flags_update_event = append(flags_update_event, &v3.BoolFlag{
-		Name:  "blocks-time",
-		Usage: "BlocksTime",
-	})
 
 	// Add format-specific flags from registered formats
 	for _, outputFmt := range options.OutputFormats() {
 		// Check if format implements FlagConfiguredOutputFormat
 		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_update_event = append(flags_update_event, flagConfigured.Flags()...)
+			flags_import_ics = append(flags_import_ics, flagConfigured.Flags()...)
 		}
 	}
 
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -1631,135 +6990,70 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 			}()
 
-			for _, hook := range options.BeforeCommandHooks() {
-				if err := hook(cmdCtx, cmd); err != nil {
-					return fmt.Errorf("before hook failed: %w", err)
-				}
-			}
-
 			// Build request message
-			var req *UpdateEventRequest
+			var req *ImportICSRequest
 
-			// Check for custom flag deserializer for calendar.UpdateEventRequest
-			deserializer, hasDeserializer := options.FlagDeserializer("calendar.UpdateEventRequest")
+			// Check for custom flag deserializer for calendar.ImportICSRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.ImportICSRequest")
 			if hasDeserializer {
 				// Use custom deserializer for top-level request
-				// Create FlagContainer (deserializer can access multiple flags via Command())
 				requestFlags := protocli.NewFlagContainer(cmd, "")
 				msg, err := deserializer(cmdCtx, requestFlags)
 				if err != nil {
 					return fmt.Errorf("custom deserializer failed: %w", err)
 				}
-				// Handle nil return from deserializer
 				if msg == nil {
 					return fmt.Errorf("custom deserializer returned nil message")
 				}
 				var ok bool
-				req, ok = msg.(*UpdateEventRequest)
+				req, ok = msg.(*ImportICSRequest)
 				if !ok {
-					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "UpdateEventRequest", msg)
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "ImportICSRequest", msg)
 				}
 			} else {
 				// Use auto-generated flag parsing
-				req = &UpdateEventRequest{}
-				req.EventId = cmd.String("event-id")
+				req = &ImportICSRequest{}
+				req.File = cmd.String("file")
 				if cmd.IsSet("calendar-id") {
 					val := cmd.String("calendar-id")
 					req.CalendarId = &val
 				}
-				if cmd.IsSet("summary") {
-					val := cmd.String("summary")
-					req.Summary = &val
-				}
-				if cmd.IsSet("description") {
-					val := cmd.String("description")
-					req.Description = &val
-				}
-				// Field StartTime: check for custom deserializer for google.protobuf.Timestamp
-				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
-					// Use custom deserializer for nested message
-					// Create FlagContainer for field flag: start-time
-					fieldFlags := protocli.NewFlagContainer(cmd, "start-time")
-					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
-					if fieldErr != nil {
-						return fmt.Errorf("failed to deserialize field StartTime: %w", fieldErr)
-					}
-					// Handle nil return from deserializer (means skip/use default)
-					if fieldMsg != nil {
-						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
-						if !fieldOk {
-							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
-						}
-						req.StartTime = typedField
-					}
-				} else {
-					// No custom deserializer - check if user provided a value
-					if cmd.IsSet("start-time") {
-						return fmt.Errorf("flag --start-time requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
-					}
-					// No value provided - leave field as nil
-				}
-				// Field EndTime: check for custom deserializer for google.protobuf.Timestamp
-				if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
-					// Use custom deserializer for nested message
-					// Create FlagContainer for field flag: end-time
-					fieldFlags := protocli.NewFlagContainer(cmd, "end-time")
-					fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
-					if fieldErr != nil {
-						return fmt.Errorf("failed to deserialize field EndTime: %w", fieldErr)
-					}
-					// Handle nil return from deserializer (means skip/use default)
-					if fieldMsg != nil {
-						typedField, fieldOk := fieldMsg.(*timestamppb.Timestamp)
-						if !fieldOk {
-							return fmt.Errorf("custom deserializer for google.protobuf.Timestamp returned wrong type: expected *Timestamp, got %T", fieldMsg)
-						}
-						req.EndTime = typedField
-					}
-				} else {
-					// No custom deserializer - check if user provided a value
-					if cmd.IsSet("end-time") {
-						return fmt.Errorf("flag --end-time requires a custom deserializer for google.protobuf.Timestamp (register with protocli.WithFlagDeserializer)")
-					}
-					// No value provided - leave field as nil
-				}
-				if cmd.IsSet("location") {
-					val := cmd.String("location")
-					req.Location = &val
-				}
-				if cmd.IsSet("guests-can-see-other-guests") {
-					val := cmd.Bool("guests-can-see-other-guests")
-					req.GuestsCanSeeOtherGuests = &val
-				}
-				if cmd.IsSet("guests-can-modify") {
-					val := cmd.Bool("guests-can-modify")
-					req.GuestsCanModify = &val
-				}
-				if cmd.IsSet("guests-can-invite-others") {
-					val := cmd.Bool("guests-can-invite-others")
-					req.GuestsCanInviteOthers = &val
-				}
-				if cmd.IsSet("source-title") {
-					val := cmd.String("source-title")
-					req.SourceTitle = &val
-				}
-				if cmd.IsSet("source-url") {
-					val := cmd.String("source-url")
-					req.SourceUrl = &val
+			}
+
+			// Open output writer
+			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Find the appropriate output format
+			formatName := cmd.String("format")
+			var outputFmt protocli.OutputFormat
+			for _, f := range options.OutputFormats() {
+				if f.Name() == formatName {
+					outputFmt = f
+					break
 				}
-				if cmd.IsSet("blocks-time") {
-					val := cmd.Bool("blocks-time")
-					req.BlocksTime = &val
+			}
+			if outputFmt == nil {
+				var availableFormats []string
+				for _, f := range options.OutputFormats() {
+					availableFormats = append(availableFormats, f.Name())
 				}
+				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
 			}
 
+			// Get delimiter for separating streamed messages
+			delimiter := cmd.String("delimiter")
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
-			var resp *UpdateEventResponse
-			var err error
 
 			if remoteAddr != "" {
-				// Remote gRPC call
+				// Remote gRPC streaming call
 				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
@@ -1767,62 +7061,106 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				defer conn.Close()
 
 				client := NewCalendarServiceClient(conn)
-				resp, err = client.UpdateEvent(cmdCtx, req)
+				stream, err := client.ImportICS(cmdCtx, req)
 				if err != nil {
-					return fmt.Errorf("remote call failed: %w", err)
+					return fmt.Errorf("failed to start stream: %w", err)
+				}
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					msg, recvErr := stream.Recv()
+					if recvErr == io.EOF {
+						break
+					}
+					if recvErr != nil {
+						return fmt.Errorf("stream receive error: %w", recvErr)
+					}
+
+					// Format and write the message
+					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
 				}
 			} else {
 				// Direct implementation call (no config)
 				svcImpl := implOrFactory.(CalendarServiceServer)
-				resp, err = svcImpl.UpdateEvent(cmdCtx, req)
-				if err != nil {
-					return fmt.Errorf("method failed: %w", err)
+
+				// Create local stream wrapper for direct call
+				localStream := &localServerStream_ImportICS{
+					ctx:       cmdCtx,
+					errors:    make(chan error),
+					responses: make(chan *AddEventResponse),
 				}
-			}
 
-			// Open output writer
-			outputWriter, err := getOutputWriter(cmd, cmd.String("output"))
-			if err != nil {
-				return fmt.Errorf("failed to open output: %w", err)
-			}
-			if closer, ok := outputWriter.(io.Closer); ok {
-				defer closer.Close()
-			}
+				// Call streaming method in goroutine
+				go func() {
+					var methodErr error
+					methodErr = svcImpl.ImportICS(req, localStream)
+					close(localStream.responses)
+					if methodErr != nil {
+						localStream.errors <- methodErr
+					}
+					close(localStream.errors)
+				}()
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					select {
+					case msg, ok := <-localStream.responses:
+						if !ok {
+							// Stream closed, check for errors
+							if streamErr := <-localStream.errors; streamErr != nil {
+								return fmt.Errorf("stream error: %w", streamErr)
+							}
+							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+								if _, err := outputWriter.Write([]byte("\n")); err != nil {
+									return fmt.Errorf("failed to write final newline: %w", err)
+								}
+							}
+							return nil
+						}
 
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
+						// Format and write the message
+						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+							return fmt.Errorf("format failed: %w", err)
+						}
+
+						// Write delimiter
+						if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+							return fmt.Errorf("failed to write delimiter: %w", err)
+						}
+						messageCount++
+					case <-cmdCtx.Done():
+						return cmdCtx.Err()
 					}
-					return nil
 				}
 			}
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			return nil
 		},
-		Flags: flags_update_event,
-		Name:  "update-event",
-		Usage: "UpdateEvent",
+		Flags: flags_import_ics,
+		Name:  "import-ics",
+		Usage: "create events from the VEVENTs in an .ics file",
 	})
 
-	// Build flags for delete-event
-	flags_delete_event := []v3.Flag{&v3.StringFlag{
+	// Build flags for revoke
+	flags_revoke := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
 		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
 	}, &v3.StringFlag{
@@ -1835,20 +7173,11 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Value: "-",
 	}}
 
-	flags_delete_event = append(flags_delete_event, &v3.StringFlag{
-		Name:  "event-id",
-		Usage: "EventId",
-	})
-	flags_delete_event = append(flags_delete_event, &v3.StringFlag{
-		Name:  "calendar-id",
-		Usage: "CalendarId",
-	})
-
 	// Add format-specific flags from registered formats
 	for _, outputFmt := range options.OutputFormats() {
 		// Check if format implements FlagConfiguredOutputFormat
 		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_delete_event = append(flags_delete_event, flagConfigured.Flags()...)
+			flags_revoke = append(flags_revoke, flagConfigured.Flags()...)
 		}
 	}
 
@@ -1870,10 +7199,10 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 			}
 
 			// Build request message
-			var req *DeleteEventRequest
+			var req *RevokeRequest
 
-			// Check for custom flag deserializer for calendar.DeleteEventRequest
-			deserializer, hasDeserializer := options.FlagDeserializer("calendar.DeleteEventRequest")
+			// Check for custom flag deserializer for calendar.RevokeRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.RevokeRequest")
 			if hasDeserializer {
 				// Use custom deserializer for top-level request
 				// Create FlagContainer (deserializer can access multiple flags via Command())
@@ -1887,23 +7216,18 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 					return fmt.Errorf("custom deserializer returned nil message")
 				}
 				var ok bool
-				req, ok = msg.(*DeleteEventRequest)
+				req, ok = msg.(*RevokeRequest)
 				if !ok {
-					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "DeleteEventRequest", msg)
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "RevokeRequest", msg)
 				}
 			} else {
 				// Use auto-generated flag parsing
-				req = &DeleteEventRequest{}
-				req.EventId = cmd.String("event-id")
-				if cmd.IsSet("calendar-id") {
-					val := cmd.String("calendar-id")
-					req.CalendarId = &val
-				}
+				req = &RevokeRequest{}
 			}
 
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
-			var resp *DeleteEventResponse
+			var resp *RevokeResponse
 			var err error
 
 			if remoteAddr != "" {
@@ -1915,14 +7239,14 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				defer conn.Close()
 
 				client := NewCalendarServiceClient(conn)
-				resp, err = client.DeleteEvent(cmdCtx, req)
+				resp, err = client.Revoke(cmdCtx, req)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
 				svcImpl := implOrFactory.(CalendarServiceServer)
-				resp, err = svcImpl.DeleteEvent(cmdCtx, req)
+				resp, err = svcImpl.Revoke(cmdCtx, req)
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
@@ -1964,13 +7288,13 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 			}
 			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
 		},
-		Flags: flags_delete_event,
-		Name:  "delete-event",
-		Usage: "DeleteEvent",
+		Flags: flags_revoke,
+		Name:  "revoke",
+		Usage: "Revoke",
 	})
 
-	// Build flags for get-event
-	flags_get_event := []v3.Flag{&v3.StringFlag{
+	// Build flags for ping
+	flags_ping := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
 		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
 	}, &v3.StringFlag{
@@ -1983,20 +7307,11 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Value: "-",
 	}}
 
-	flags_get_event = append(flags_get_event, &v3.StringFlag{
-		Name:  "event-id",
-		Usage: "EventId",
-	})
-	flags_get_event = append(flags_get_event, &v3.StringFlag{
-		Name:  "calendar-id",
-		Usage: "CalendarId",
-	})
-
 	// Add format-specific flags from registered formats
 	for _, outputFmt := range options.OutputFormats() {
 		// Check if format implements FlagConfiguredOutputFormat
 		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_get_event = append(flags_get_event, flagConfigured.Flags()...)
+			flags_ping = append(flags_ping, flagConfigured.Flags()...)
 		}
 	}
 
@@ -2018,10 +7333,10 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 			}
 
 			// Build request message
-			var req *GetEventRequest
+			var req *PingRequest
 
-			// Check for custom flag deserializer for calendar.GetEventRequest
-			deserializer, hasDeserializer := options.FlagDeserializer("calendar.GetEventRequest")
+			// Check for custom flag deserializer for calendar.PingRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.PingRequest")
 			if hasDeserializer {
 				// Use custom deserializer for top-level request
 				// Create FlagContainer (deserializer can access multiple flags via Command())
@@ -2035,23 +7350,18 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 					return fmt.Errorf("custom deserializer returned nil message")
 				}
 				var ok bool
-				req, ok = msg.(*GetEventRequest)
+				req, ok = msg.(*PingRequest)
 				if !ok {
-					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "GetEventRequest", msg)
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "PingRequest", msg)
 				}
 			} else {
 				// Use auto-generated flag parsing
-				req = &GetEventRequest{}
-				req.EventId = cmd.String("event-id")
-				if cmd.IsSet("calendar-id") {
-					val := cmd.String("calendar-id")
-					req.CalendarId = &val
-				}
+				req = &PingRequest{}
 			}
 
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
-			var resp *GetEventResponse
+			var resp *PingResponse
 			var err error
 
 			if remoteAddr != "" {
@@ -2063,14 +7373,14 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				defer conn.Close()
 
 				client := NewCalendarServiceClient(conn)
-				resp, err = client.GetEvent(cmdCtx, req)
+				resp, err = client.Ping(cmdCtx, req)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
 				svcImpl := implOrFactory.(CalendarServiceServer)
-				resp, err = svcImpl.GetEvent(cmdCtx, req)
+				resp, err = svcImpl.Ping(cmdCtx, req)
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
@@ -2112,13 +7422,13 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 			}
 			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
 		},
-		Flags: flags_get_event,
-		Name:  "get-event",
-		Usage: "GetEvent",
+		Flags: flags_ping,
+		Name:  "ping",
+		Usage: "Ping",
 	})
 
-	// Build flags for list-events
-	flags_list_events := []v3.Flag{&v3.StringFlag{
+	// Build flags for duplicate-detector
+	flags_duplicate_detector := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
 		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
 	}, &v3.StringFlag{
@@ -2135,40 +7445,24 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Value: "\n",
 	}}
 
-	flags_list_events = append(flags_list_events, &v3.StringFlag{
+	flags_duplicate_detector = append(flags_duplicate_detector, &v3.StringFlag{
 		Name:  "calendar-id",
 		Usage: "CalendarId",
 	})
-	flags_list_events = append(flags_list_events, &v3.StringFlag{
+	flags_duplicate_detector = append(flags_duplicate_detector, &v3.StringFlag{
 		Name:  "after",
 		Usage: "After (google.protobuf.Timestamp)",
 	})
-	flags_list_events = append(flags_list_events, &v3.StringFlag{
+	flags_duplicate_detector = append(flags_duplicate_detector, &v3.StringFlag{
 		Name:  "before",
 		Usage: "Before (google.protobuf.Timestamp)",
 	})
-	flags_list_events = append(flags_list_events, &v3.BoolFlag{
-		Name:  "future",
-		Usage: "Future",
-	})
-	flags_list_events = append(flags_list_events, &v3.BoolFlag{
-		Name:  "past",
-		Usage: "Past",
-	})
-	flags_list_events = append(flags_list_events, &v3.Int32Flag{
-		Name:  "limit",
-		Usage: "Limit",
-	})
-	flags_list_events = append(flags_list_events, &v3.StringFlag{
-		Name:  "anchor",
-		Usage: "Anchor",
-	})
 
 	// Add format-specific flags from registered formats
 	for _, outputFmt := range options.OutputFormats() {
 		// Check if format implements FlagConfiguredOutputFormat
 		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_list_events = append(flags_list_events, flagConfigured.Flags()...)
+			flags_duplicate_detector = append(flags_duplicate_detector, flagConfigured.Flags()...)
 		}
 	}
 
@@ -2190,10 +7484,10 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 			}()
 
 			// Build request message
-			var req *ListEventsRequest
+			var req *FindDuplicatesRequest
 
-			// Check for custom flag deserializer for calendar.ListEventsRequest
-			deserializer, hasDeserializer := options.FlagDeserializer("calendar.ListEventsRequest")
+			// Check for custom flag deserializer for calendar.FindDuplicatesRequest
+			deserializer, hasDeserializer := options.FlagDeserializer("calendar.FindDuplicatesRequest")
 			if hasDeserializer {
 				// Use custom deserializer for top-level request
 				requestFlags := protocli.NewFlagContainer(cmd, "")
@@ -2205,13 +7499,13 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 					return fmt.Errorf("custom deserializer returned nil message")
 				}
 				var ok bool
-				req, ok = msg.(*ListEventsRequest)
+				req, ok = msg.(*FindDuplicatesRequest)
 				if !ok {
-					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "ListEventsRequest", msg)
+					return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "FindDuplicatesRequest", msg)
 				}
 			} else {
 				// Use auto-generated flag parsing
-				req = &ListEventsRequest{}
+				req = &FindDuplicatesRequest{}
 				if cmd.IsSet("calendar-id") {
 					val := cmd.String("calendar-id")
 					req.CalendarId = &val
@@ -2264,22 +7558,6 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 					}
 					// No value provided - leave field as nil
 				}
-				if cmd.IsSet("future") {
-					val := cmd.Bool("future")
-					req.Future = &val
-				}
-				if cmd.IsSet("past") {
-					val := cmd.Bool("past")
-					req.Past = &val
-				}
-				if cmd.IsSet("limit") {
-					val := cmd.Int32("limit")
-					req.Limit = &val
-				}
-				if cmd.IsSet("anchor") {
-					val := cmd.String("anchor")
-					req.Anchor = &val
-				}
 			}
 
 			// Open output writer
@@ -2323,7 +7601,7 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				defer conn.Close()
 
 				client := NewCalendarServiceClient(conn)
-				stream, err := client.ListEvents(cmdCtx, req)
+				stream, err := client.FindDuplicates(cmdCtx, req)
 				if err != nil {
 					return fmt.Errorf("failed to start stream: %w", err)
 				}
@@ -2362,16 +7640,16 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				svcImpl := implOrFactory.(CalendarServiceServer)
 
 				// Create local stream wrapper for direct call
-				localStream := &localServerStream_ListEvents{
+				localStream := &localServerStream_FindDuplicates{
 					ctx:       cmdCtx,
 					errors:    make(chan error),
-					responses: make(chan *ListEventsResponse),
+					responses: make(chan *DuplicateCluster),
 				}
 
 				// Call streaming method in goroutine
 				go func() {
 					var methodErr error
-					methodErr = svcImpl.ListEvents(req, localStream)
+					methodErr = svcImpl.FindDuplicates(req, localStream)
 					close(localStream.responses)
 					if methodErr != nil {
 						localStream.errors <- methodErr
@@ -2416,9 +7694,9 @@ func CalendarServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 
 			return nil
 		},
-		Flags: flags_list_events,
-		Name:  "list-events",
-		Usage: "ListEvents (streaming)",
+		Flags: flags_duplicate_detector,
+		Name:  "duplicate-detector",
+		Usage: "report likely duplicate events (same summary and times, or same iCalUID)",
 	})
 
 	// Create ServiceCLI for daemonize command
@@ -19,11 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CalendarService_AddEvent_FullMethodName    = "/calendar.CalendarService/AddEvent"
-	CalendarService_UpdateEvent_FullMethodName = "/calendar.CalendarService/UpdateEvent"
-	CalendarService_DeleteEvent_FullMethodName = "/calendar.CalendarService/DeleteEvent"
-	CalendarService_GetEvent_FullMethodName    = "/calendar.CalendarService/GetEvent"
-	CalendarService_ListEvents_FullMethodName  = "/calendar.CalendarService/ListEvents"
+	CalendarService_AddEvent_FullMethodName       = "/calendar.CalendarService/AddEvent"
+	CalendarService_UpdateEvent_FullMethodName    = "/calendar.CalendarService/UpdateEvent"
+	CalendarService_DeleteEvent_FullMethodName    = "/calendar.CalendarService/DeleteEvent"
+	CalendarService_GetEvent_FullMethodName       = "/calendar.CalendarService/GetEvent"
+	CalendarService_QuickAdd_FullMethodName       = "/calendar.CalendarService/QuickAdd"
+	CalendarService_ImportEvent_FullMethodName    = "/calendar.CalendarService/ImportEvent"
+	CalendarService_ListEvents_FullMethodName     = "/calendar.CalendarService/ListEvents"
+	CalendarService_SearchEvents_FullMethodName   = "/calendar.CalendarService/SearchEvents"
+	CalendarService_ListCalendars_FullMethodName  = "/calendar.CalendarService/ListCalendars"
+	CalendarService_FreeBusy_FullMethodName       = "/calendar.CalendarService/FreeBusy"
+	CalendarService_ImportICS_FullMethodName      = "/calendar.CalendarService/ImportICS"
+	CalendarService_Revoke_FullMethodName         = "/calendar.CalendarService/Revoke"
+	CalendarService_Ping_FullMethodName           = "/calendar.CalendarService/Ping"
+	CalendarService_FindDuplicates_FullMethodName = "/calendar.CalendarService/FindDuplicates"
 )
 
 // CalendarServiceClient is the client API for CalendarService service.
@@ -38,8 +47,30 @@ type CalendarServiceClient interface {
 	DeleteEvent(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*DeleteEventResponse, error)
 	// GetEvent retrieves a single calendar event by ID
 	GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*GetEventResponse, error)
+	// QuickAdd creates an event from a natural-language description
+	QuickAdd(ctx context.Context, in *QuickAddRequest, opts ...grpc.CallOption) (*QuickAddResponse, error)
+	// ImportEvent adds a copy of an event from another calendar, keeping its
+	// iCalUID; importing the same iCalUID again updates the earlier copy
+	ImportEvent(ctx context.Context, in *ImportEventRequest, opts ...grpc.CallOption) (*AddEventResponse, error)
 	// ListEvents streams all events from a calendar
 	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListEventsResponse], error)
+	// SearchEvents streams the events matching a free-text query
+	SearchEvents(ctx context.Context, in *SearchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	// ListCalendars streams the calendars on the user's calendar list
+	ListCalendars(ctx context.Context, in *ListCalendarsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Calendar], error)
+	// FreeBusy streams the busy blocks of one or more calendars in a time range
+	FreeBusy(ctx context.Context, in *FreeBusyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FreeBusyCalendar], error)
+	// ImportICS creates an event for each VEVENT in an iCalendar (.ics) file,
+	// streaming one result per event
+	ImportICS(ctx context.Context, in *ImportICSRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AddEventResponse], error)
+	// Revoke disconnects cali from the Google account by revoking and deleting
+	// the stored OAuth token
+	Revoke(ctx context.Context, in *RevokeRequest, opts ...grpc.CallOption) (*RevokeResponse, error)
+	// Ping checks that cali can authenticate and reach the Calendar API
+	// without changing anything
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	// FindDuplicates streams clusters of likely duplicate events in a time range
+	FindDuplicates(ctx context.Context, in *FindDuplicatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DuplicateCluster], error)
 }
 
 type calendarServiceClient struct {
@@ -90,6 +121,26 @@ func (c *calendarServiceClient) GetEvent(ctx context.Context, in *GetEventReques
 	return out, nil
 }
 
+func (c *calendarServiceClient) QuickAdd(ctx context.Context, in *QuickAddRequest, opts ...grpc.CallOption) (*QuickAddResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuickAddResponse)
+	err := c.cc.Invoke(ctx, CalendarService_QuickAdd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ImportEvent(ctx context.Context, in *ImportEventRequest, opts ...grpc.CallOption) (*AddEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddEventResponse)
+	err := c.cc.Invoke(ctx, CalendarService_ImportEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *calendarServiceClient) ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListEventsResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &CalendarService_ServiceDesc.Streams[0], CalendarService_ListEvents_FullMethodName, cOpts...)
@@ -109,6 +160,121 @@ func (c *calendarServiceClient) ListEvents(ctx context.Context, in *ListEventsRe
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type CalendarService_ListEventsClient = grpc.ServerStreamingClient[ListEventsResponse]
 
+func (c *calendarServiceClient) SearchEvents(ctx context.Context, in *SearchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CalendarService_ServiceDesc.Streams[1], CalendarService_SearchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SearchEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_SearchEventsClient = grpc.ServerStreamingClient[Event]
+
+func (c *calendarServiceClient) ListCalendars(ctx context.Context, in *ListCalendarsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Calendar], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CalendarService_ServiceDesc.Streams[2], CalendarService_ListCalendars_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListCalendarsRequest, Calendar]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_ListCalendarsClient = grpc.ServerStreamingClient[Calendar]
+
+func (c *calendarServiceClient) FreeBusy(ctx context.Context, in *FreeBusyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FreeBusyCalendar], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CalendarService_ServiceDesc.Streams[3], CalendarService_FreeBusy_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FreeBusyRequest, FreeBusyCalendar]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_FreeBusyClient = grpc.ServerStreamingClient[FreeBusyCalendar]
+
+func (c *calendarServiceClient) ImportICS(ctx context.Context, in *ImportICSRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AddEventResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CalendarService_ServiceDesc.Streams[4], CalendarService_ImportICS_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportICSRequest, AddEventResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_ImportICSClient = grpc.ServerStreamingClient[AddEventResponse]
+
+func (c *calendarServiceClient) Revoke(ctx context.Context, in *RevokeRequest, opts ...grpc.CallOption) (*RevokeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeResponse)
+	err := c.cc.Invoke(ctx, CalendarService_Revoke_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, CalendarService_Ping_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) FindDuplicates(ctx context.Context, in *FindDuplicatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DuplicateCluster], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CalendarService_ServiceDesc.Streams[5], CalendarService_FindDuplicates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindDuplicatesRequest, DuplicateCluster]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_FindDuplicatesClient = grpc.ServerStreamingClient[DuplicateCluster]
+
 // CalendarServiceServer is the server API for CalendarService service.
 // All implementations must embed UnimplementedCalendarServiceServer
 // for forward compatibility.
@@ -121,8 +287,30 @@ type CalendarServiceServer interface {
 	DeleteEvent(context.Context, *DeleteEventRequest) (*DeleteEventResponse, error)
 	// GetEvent retrieves a single calendar event by ID
 	GetEvent(context.Context, *GetEventRequest) (*GetEventResponse, error)
+	// QuickAdd creates an event from a natural-language description
+	QuickAdd(context.Context, *QuickAddRequest) (*QuickAddResponse, error)
+	// ImportEvent adds a copy of an event from another calendar, keeping its
+	// iCalUID; importing the same iCalUID again updates the earlier copy
+	ImportEvent(context.Context, *ImportEventRequest) (*AddEventResponse, error)
 	// ListEvents streams all events from a calendar
 	ListEvents(*ListEventsRequest, grpc.ServerStreamingServer[ListEventsResponse]) error
+	// SearchEvents streams the events matching a free-text query
+	SearchEvents(*SearchEventsRequest, grpc.ServerStreamingServer[Event]) error
+	// ListCalendars streams the calendars on the user's calendar list
+	ListCalendars(*ListCalendarsRequest, grpc.ServerStreamingServer[Calendar]) error
+	// FreeBusy streams the busy blocks of one or more calendars in a time range
+	FreeBusy(*FreeBusyRequest, grpc.ServerStreamingServer[FreeBusyCalendar]) error
+	// ImportICS creates an event for each VEVENT in an iCalendar (.ics) file,
+	// streaming one result per event
+	ImportICS(*ImportICSRequest, grpc.ServerStreamingServer[AddEventResponse]) error
+	// Revoke disconnects cali from the Google account by revoking and deleting
+	// the stored OAuth token
+	Revoke(context.Context, *RevokeRequest) (*RevokeResponse, error)
+	// Ping checks that cali can authenticate and reach the Calendar API
+	// without changing anything
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	// FindDuplicates streams clusters of likely duplicate events in a time range
+	FindDuplicates(*FindDuplicatesRequest, grpc.ServerStreamingServer[DuplicateCluster]) error
 	mustEmbedUnimplementedCalendarServiceServer()
 }
 
@@ -145,9 +333,36 @@ func (UnimplementedCalendarServiceServer) DeleteEvent(context.Context, *DeleteEv
 func (UnimplementedCalendarServiceServer) GetEvent(context.Context, *GetEventRequest) (*GetEventResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetEvent not implemented")
 }
+func (UnimplementedCalendarServiceServer) QuickAdd(context.Context, *QuickAddRequest) (*QuickAddResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method QuickAdd not implemented")
+}
+func (UnimplementedCalendarServiceServer) ImportEvent(context.Context, *ImportEventRequest) (*AddEventResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportEvent not implemented")
+}
 func (UnimplementedCalendarServiceServer) ListEvents(*ListEventsRequest, grpc.ServerStreamingServer[ListEventsResponse]) error {
 	return status.Error(codes.Unimplemented, "method ListEvents not implemented")
 }
+func (UnimplementedCalendarServiceServer) SearchEvents(*SearchEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Error(codes.Unimplemented, "method SearchEvents not implemented")
+}
+func (UnimplementedCalendarServiceServer) ListCalendars(*ListCalendarsRequest, grpc.ServerStreamingServer[Calendar]) error {
+	return status.Error(codes.Unimplemented, "method ListCalendars not implemented")
+}
+func (UnimplementedCalendarServiceServer) FreeBusy(*FreeBusyRequest, grpc.ServerStreamingServer[FreeBusyCalendar]) error {
+	return status.Error(codes.Unimplemented, "method FreeBusy not implemented")
+}
+func (UnimplementedCalendarServiceServer) ImportICS(*ImportICSRequest, grpc.ServerStreamingServer[AddEventResponse]) error {
+	return status.Error(codes.Unimplemented, "method ImportICS not implemented")
+}
+func (UnimplementedCalendarServiceServer) Revoke(context.Context, *RevokeRequest) (*RevokeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Revoke not implemented")
+}
+func (UnimplementedCalendarServiceServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Ping not implemented")
+}
+func (UnimplementedCalendarServiceServer) FindDuplicates(*FindDuplicatesRequest, grpc.ServerStreamingServer[DuplicateCluster]) error {
+	return status.Error(codes.Unimplemented, "method FindDuplicates not implemented")
+}
 func (UnimplementedCalendarServiceServer) mustEmbedUnimplementedCalendarServiceServer() {}
 func (UnimplementedCalendarServiceServer) testEmbeddedByValue()                         {}
 
@@ -241,6 +456,42 @@ func _CalendarService_GetEvent_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CalendarService_QuickAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuickAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).QuickAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_QuickAdd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).QuickAdd(ctx, req.(*QuickAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ImportEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ImportEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_ImportEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ImportEvent(ctx, req.(*ImportEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CalendarService_ListEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ListEventsRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -252,6 +503,97 @@ func _CalendarService_ListEvents_Handler(srv interface{}, stream grpc.ServerStre
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type CalendarService_ListEventsServer = grpc.ServerStreamingServer[ListEventsResponse]
 
+func _CalendarService_SearchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalendarServiceServer).SearchEvents(m, &grpc.GenericServerStream[SearchEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_SearchEventsServer = grpc.ServerStreamingServer[Event]
+
+func _CalendarService_ListCalendars_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListCalendarsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalendarServiceServer).ListCalendars(m, &grpc.GenericServerStream[ListCalendarsRequest, Calendar]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_ListCalendarsServer = grpc.ServerStreamingServer[Calendar]
+
+func _CalendarService_FreeBusy_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FreeBusyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalendarServiceServer).FreeBusy(m, &grpc.GenericServerStream[FreeBusyRequest, FreeBusyCalendar]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_FreeBusyServer = grpc.ServerStreamingServer[FreeBusyCalendar]
+
+func _CalendarService_ImportICS_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ImportICSRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalendarServiceServer).ImportICS(m, &grpc.GenericServerStream[ImportICSRequest, AddEventResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_ImportICSServer = grpc.ServerStreamingServer[AddEventResponse]
+
+func _CalendarService_Revoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).Revoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_Revoke_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).Revoke(ctx, req.(*RevokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_Ping_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_FindDuplicates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindDuplicatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalendarServiceServer).FindDuplicates(m, &grpc.GenericServerStream[FindDuplicatesRequest, DuplicateCluster]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalendarService_FindDuplicatesServer = grpc.ServerStreamingServer[DuplicateCluster]
+
 // CalendarService_ServiceDesc is the grpc.ServiceDesc for CalendarService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -275,6 +617,22 @@ var CalendarService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetEvent",
 			Handler:    _CalendarService_GetEvent_Handler,
 		},
+		{
+			MethodName: "QuickAdd",
+			Handler:    _CalendarService_QuickAdd_Handler,
+		},
+		{
+			MethodName: "ImportEvent",
+			Handler:    _CalendarService_ImportEvent_Handler,
+		},
+		{
+			MethodName: "Revoke",
+			Handler:    _CalendarService_Revoke_Handler,
+		},
+		{
+			MethodName: "Ping",
+			Handler:    _CalendarService_Ping_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -282,6 +640,31 @@ var CalendarService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _CalendarService_ListEvents_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SearchEvents",
+			Handler:       _CalendarService_SearchEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListCalendars",
+			Handler:       _CalendarService_ListCalendars_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FreeBusy",
+			Handler:       _CalendarService_FreeBusy_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportICS",
+			Handler:       _CalendarService_ImportICS_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindDuplicates",
+			Handler:       _CalendarService_FindDuplicates_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "calendar.proto",
 }
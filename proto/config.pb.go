@@ -29,9 +29,20 @@ type CaliConfig struct {
 	// Default calendar ID to use when not specified
 	DefaultCalendarId string `protobuf:"bytes,2,opt,name=default_calendar_id,json=defaultCalendarId,proto3" json:"default_calendar_id,omitempty"`
 	// API endpoint override (for testing with mock servers)
-	ApiEndpoint   string `protobuf:"bytes,3,opt,name=api_endpoint,json=apiEndpoint,proto3" json:"api_endpoint,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	ApiEndpoint string `protobuf:"bytes,3,opt,name=api_endpoint,json=apiEndpoint,proto3" json:"api_endpoint,omitempty"`
+	// Length of events created with a start but no end, as a Go duration
+	// (e.g. "30m", "45m"); defaults to 1h
+	DefaultEventDuration string `protobuf:"bytes,4,opt,name=default_event_duration,json=defaultEventDuration,proto3" json:"default_event_duration,omitempty"`
+	// Named auth settings selected with --profile (e.g. "work", "personal"),
+	// used in place of auth. A profile caches its OAuth token in
+	// ~/.config/cali/token-{name}.json unless it sets oauth_token_path; a
+	// profile not listed here reuses auth with that separate token file.
+	Profiles map[string]*AuthConfig `protobuf:"bytes,5,rep,name=profiles,proto3" json:"profiles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Most attendees a created or updated event may invite; requests over the
+	// limit are rejected before reaching Google. 0 (the default) means no limit
+	MaxAttendeesAllowed int32 `protobuf:"varint,6,opt,name=max_attendees_allowed,json=maxAttendeesAllowed,proto3" json:"max_attendees_allowed,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *CaliConfig) Reset() {
@@ -85,6 +96,27 @@ func (x *CaliConfig) GetApiEndpoint() string {
 	return ""
 }
 
+func (x *CaliConfig) GetDefaultEventDuration() string {
+	if x != nil {
+		return x.DefaultEventDuration
+	}
+	return ""
+}
+
+func (x *CaliConfig) GetProfiles() map[string]*AuthConfig {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+func (x *CaliConfig) GetMaxAttendeesAllowed() int32 {
+	if x != nil {
+		return x.MaxAttendeesAllowed
+	}
+	return 0
+}
+
 // AuthConfig holds authentication settings
 type AuthConfig struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -94,8 +126,29 @@ type AuthConfig struct {
 	OauthClient *OAuthClientCredentials `protobuf:"bytes,2,opt,name=oauth_client,json=oauthClient,proto3" json:"oauth_client,omitempty"`
 	// Path to OAuth token file for caching (optional, defaults to ~/.config/cali/token.json)
 	OauthTokenPath string `protobuf:"bytes,3,opt,name=oauth_token_path,json=oauthTokenPath,proto3" json:"oauth_token_path,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Local port the browser is redirected to at the end of the OAuth flow
+	// (optional, defaults to 8080); 0 picks any free port
+	OauthCallbackPort *int32 `protobuf:"varint,4,opt,name=oauth_callback_port,json=oauthCallbackPort,proto3,oneof" json:"oauth_callback_port,omitempty"`
+	// Google API scopes to request (optional, defaults to full calendar access,
+	// https://www.googleapis.com/auth/calendar); use
+	// https://www.googleapis.com/auth/calendar.readonly for read-only access
+	Scopes []string `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// Use Application Default Credentials (GOOGLE_APPLICATION_CREDENTIALS, gcloud,
+	// or the GKE/Cloud Run metadata server) even if other credentials are set.
+	// ADC is also the fallback when neither service_account nor oauth_client is
+	// configured.
+	UseAdc bool `protobuf:"varint,6,opt,name=use_adc,json=useAdc,proto3" json:"use_adc,omitempty"`
+	// How to authorize an OAuth client when no token is cached: "browser"
+	// (default, opens a browser and listens on oauth_callback_port) or "device"
+	// (prints a URL and code to enter on another device, for headless machines)
+	OauthFlow string `protobuf:"bytes,7,opt,name=oauth_flow,json=oauthFlow,proto3" json:"oauth_flow,omitempty"`
+	// Encrypts the cached OAuth token at rest (AES-GCM, key derived from this
+	// passphrase). Prefer setting it with CALI_AUTH_TOKEN_PASSPHRASE over
+	// writing it to a config file. Existing plaintext tokens still load and are
+	// encrypted when next saved.
+	TokenPassphrase string `protobuf:"bytes,8,opt,name=token_passphrase,json=tokenPassphrase,proto3" json:"token_passphrase,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *AuthConfig) Reset() {
@@ -149,6 +202,41 @@ func (x *AuthConfig) GetOauthTokenPath() string {
 	return ""
 }
 
+func (x *AuthConfig) GetOauthCallbackPort() int32 {
+	if x != nil && x.OauthCallbackPort != nil {
+		return *x.OauthCallbackPort
+	}
+	return 0
+}
+
+func (x *AuthConfig) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *AuthConfig) GetUseAdc() bool {
+	if x != nil {
+		return x.UseAdc
+	}
+	return false
+}
+
+func (x *AuthConfig) GetOauthFlow() string {
+	if x != nil {
+		return x.OauthFlow
+	}
+	return ""
+}
+
+func (x *AuthConfig) GetTokenPassphrase() string {
+	if x != nil {
+		return x.TokenPassphrase
+	}
+	return ""
+}
+
 // ServiceAccountCredentials contains Google Cloud service account credentials
 // This mirrors the structure of a service account JSON key file
 type ServiceAccountCredentials struct {
@@ -163,8 +251,11 @@ type ServiceAccountCredentials struct {
 	TokenUri                string                 `protobuf:"bytes,8,opt,name=token_uri,json=tokenUri,proto3" json:"token_uri,omitempty"`
 	AuthProviderX509CertUrl string                 `protobuf:"bytes,9,opt,name=auth_provider_x509_cert_url,json=authProviderX509CertUrl,proto3" json:"auth_provider_x509_cert_url,omitempty"`
 	ClientX509CertUrl       string                 `protobuf:"bytes,10,opt,name=client_x509_cert_url,json=clientX509CertUrl,proto3" json:"client_x509_cert_url,omitempty"`
-	unknownFields           protoimpl.UnknownFields
-	sizeCache               protoimpl.SizeCache
+	// Workspace user to act as via domain-wide delegation (not part of the key
+	// file; the service account must be granted delegation by a Workspace admin)
+	ImpersonateEmail string `protobuf:"bytes,11,opt,name=impersonate_email,json=impersonateEmail,proto3" json:"impersonate_email,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *ServiceAccountCredentials) Reset() {
@@ -267,6 +358,13 @@ func (x *ServiceAccountCredentials) GetClientX509CertUrl() string {
 	return ""
 }
 
+func (x *ServiceAccountCredentials) GetImpersonateEmail() string {
+	if x != nil {
+		return x.ImpersonateEmail
+	}
+	return ""
+}
+
 // OAuthClientCredentials contains OAuth 2.0 client credentials
 // This mirrors the structure of OAuth Desktop app credentials
 type OAuthClientCredentials struct {
@@ -278,8 +376,12 @@ type OAuthClientCredentials struct {
 	TokenUri                string                 `protobuf:"bytes,5,opt,name=token_uri,json=tokenUri,proto3" json:"token_uri,omitempty"`
 	AuthProviderX509CertUrl string                 `protobuf:"bytes,6,opt,name=auth_provider_x509_cert_url,json=authProviderX509CertUrl,proto3" json:"auth_provider_x509_cert_url,omitempty"`
 	RedirectUris            []string               `protobuf:"bytes,7,rep,name=redirect_uris,json=redirectUris,proto3" json:"redirect_uris,omitempty"`
-	unknownFields           protoimpl.UnknownFields
-	sizeCache               protoimpl.SizeCache
+	// Kind of OAuth client downloaded from Google Cloud: "installed" (desktop
+	// app, the default) or "web" (web application, whose authorized redirect
+	// URIs must include the local callback, e.g. http://localhost:8080/oauth2callback)
+	ClientType    string `protobuf:"bytes,8,opt,name=client_type,json=clientType,proto3" json:"client_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OAuthClientCredentials) Reset() {
@@ -361,21 +463,41 @@ func (x *OAuthClientCredentials) GetRedirectUris() []string {
 	return nil
 }
 
+func (x *OAuthClientCredentials) GetClientType() string {
+	if x != nil {
+		return x.ClientType
+	}
+	return ""
+}
+
 var File_config_proto protoreflect.FileDescriptor
 
 const file_config_proto_rawDesc = "" +
 	"\n" +
-	"\fconfig.proto\x12\bcalendar\"\x89\x01\n" +
+	"\fconfig.proto\x12\bcalendar\"\x86\x03\n" +
 	"\n" +
 	"CaliConfig\x12(\n" +
 	"\x04auth\x18\x01 \x01(\v2\x14.calendar.AuthConfigR\x04auth\x12.\n" +
 	"\x13default_calendar_id\x18\x02 \x01(\tR\x11defaultCalendarId\x12!\n" +
-	"\fapi_endpoint\x18\x03 \x01(\tR\vapiEndpoint\"\xc9\x01\n" +
+	"\fapi_endpoint\x18\x03 \x01(\tR\vapiEndpoint\x124\n" +
+	"\x16default_event_duration\x18\x04 \x01(\tR\x14defaultEventDuration\x12>\n" +
+	"\bprofiles\x18\x05 \x03(\v2\".calendar.CaliConfig.ProfilesEntryR\bprofiles\x122\n" +
+	"\x15max_attendees_allowed\x18\x06 \x01(\x05R\x13maxAttendeesAllowed\x1aQ\n" +
+	"\rProfilesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.calendar.AuthConfigR\x05value:\x028\x01\"\x91\x03\n" +
 	"\n" +
 	"AuthConfig\x12L\n" +
 	"\x0fservice_account\x18\x01 \x01(\v2#.calendar.ServiceAccountCredentialsR\x0eserviceAccount\x12C\n" +
 	"\foauth_client\x18\x02 \x01(\v2 .calendar.OAuthClientCredentialsR\voauthClient\x12(\n" +
-	"\x10oauth_token_path\x18\x03 \x01(\tR\x0eoauthTokenPath\"\xfc\x02\n" +
+	"\x10oauth_token_path\x18\x03 \x01(\tR\x0eoauthTokenPath\x123\n" +
+	"\x13oauth_callback_port\x18\x04 \x01(\x05H\x00R\x11oauthCallbackPort\x88\x01\x01\x12\x16\n" +
+	"\x06scopes\x18\x05 \x03(\tR\x06scopes\x12\x17\n" +
+	"\ause_adc\x18\x06 \x01(\bR\x06useAdc\x12\x1d\n" +
+	"\n" +
+	"oauth_flow\x18\a \x01(\tR\toauthFlow\x12)\n" +
+	"\x10token_passphrase\x18\b \x01(\tR\x0ftokenPassphraseB\x16\n" +
+	"\x14_oauth_callback_port\"\xa9\x03\n" +
 	"\x19ServiceAccountCredentials\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1d\n" +
 	"\n" +
@@ -389,7 +511,8 @@ const file_config_proto_rawDesc = "" +
 	"\ttoken_uri\x18\b \x01(\tR\btokenUri\x12<\n" +
 	"\x1bauth_provider_x509_cert_url\x18\t \x01(\tR\x17authProviderX509CertUrl\x12/\n" +
 	"\x14client_x509_cert_url\x18\n" +
-	" \x01(\tR\x11clientX509CertUrl\"\x94\x02\n" +
+	" \x01(\tR\x11clientX509CertUrl\x12+\n" +
+	"\x11impersonate_email\x18\v \x01(\tR\x10impersonateEmail\"\xb5\x02\n" +
 	"\x16OAuthClientCredentials\x12\x1b\n" +
 	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12#\n" +
 	"\rclient_secret\x18\x02 \x01(\tR\fclientSecret\x12\x1d\n" +
@@ -398,7 +521,9 @@ const file_config_proto_rawDesc = "" +
 	"\bauth_uri\x18\x04 \x01(\tR\aauthUri\x12\x1b\n" +
 	"\ttoken_uri\x18\x05 \x01(\tR\btokenUri\x12<\n" +
 	"\x1bauth_provider_x509_cert_url\x18\x06 \x01(\tR\x17authProviderX509CertUrl\x12#\n" +
-	"\rredirect_uris\x18\a \x03(\tR\fredirectUrisB Z\x1egithub.com/drewfead/cali/protob\x06proto3"
+	"\rredirect_uris\x18\a \x03(\tR\fredirectUris\x12\x1f\n" +
+	"\vclient_type\x18\b \x01(\tR\n" +
+	"clientTypeB Z\x1egithub.com/drewfead/cali/protob\x06proto3"
 
 var (
 	file_config_proto_rawDescOnce sync.Once
@@ -412,22 +537,25 @@ func file_config_proto_rawDescGZIP() []byte {
 	return file_config_proto_rawDescData
 }
 
-var file_config_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_config_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_config_proto_goTypes = []any{
 	(*CaliConfig)(nil),                // 0: calendar.CaliConfig
 	(*AuthConfig)(nil),                // 1: calendar.AuthConfig
 	(*ServiceAccountCredentials)(nil), // 2: calendar.ServiceAccountCredentials
 	(*OAuthClientCredentials)(nil),    // 3: calendar.OAuthClientCredentials
+	nil,                               // 4: calendar.CaliConfig.ProfilesEntry
 }
 var file_config_proto_depIdxs = []int32{
 	1, // 0: calendar.CaliConfig.auth:type_name -> calendar.AuthConfig
-	2, // 1: calendar.AuthConfig.service_account:type_name -> calendar.ServiceAccountCredentials
-	3, // 2: calendar.AuthConfig.oauth_client:type_name -> calendar.OAuthClientCredentials
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	4, // 1: calendar.CaliConfig.profiles:type_name -> calendar.CaliConfig.ProfilesEntry
+	2, // 2: calendar.AuthConfig.service_account:type_name -> calendar.ServiceAccountCredentials
+	3, // 3: calendar.AuthConfig.oauth_client:type_name -> calendar.OAuthClientCredentials
+	1, // 4: calendar.CaliConfig.ProfilesEntry.value:type_name -> calendar.AuthConfig
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_config_proto_init() }
@@ -435,13 +563,14 @@ func file_config_proto_init() {
 	if File_config_proto != nil {
 		return
 	}
+	file_config_proto_msgTypes[1].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_config_proto_rawDesc), len(file_config_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
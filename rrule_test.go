@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/drewfead/cali/internal/calendar"
+	gcalendar "google.golang.org/api/calendar/v3"
+)
+
+func TestValidateRRULE(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		wantErr string // substring naming the bad token; empty means valid
+	}{
+		{name: "weekly with prefix", rule: "RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR"},
+		{name: "daily count", rule: "FREQ=DAILY;COUNT=10;INTERVAL=2"},
+		{name: "monthly ordinal weekday", rule: "RRULE:FREQ=MONTHLY;BYDAY=-1FR"},
+		{name: "until date-time", rule: "RRULE:FREQ=DAILY;UNTIL=20240131T235959Z"},
+		{name: "until date", rule: "RRULE:FREQ=DAILY;UNTIL=20240131"},
+		{name: "misspelled freq", rule: "RRULE:FREQ=WEEKY", wantErr: `FREQ "WEEKY"`},
+		{name: "missing freq", rule: "RRULE:COUNT=3", wantErr: "missing FREQ"},
+		{name: "zero count", rule: "RRULE:FREQ=DAILY;COUNT=0", wantErr: `COUNT must be a positive integer, got "0"`},
+		{name: "non-numeric interval", rule: "RRULE:FREQ=DAILY;INTERVAL=two", wantErr: `INTERVAL must be a positive integer, got "two"`},
+		{name: "bad until", rule: "RRULE:FREQ=DAILY;UNTIL=2024-01-31", wantErr: `UNTIL "2024-01-31"`},
+		{name: "floating until", rule: "RRULE:FREQ=DAILY;UNTIL=20240131T235959", wantErr: `UNTIL "20240131T235959"`},
+		{name: "bad weekday", rule: "RRULE:FREQ=WEEKLY;BYDAY=MO,TX", wantErr: `BYDAY weekday "TX"`},
+		{name: "malformed part", rule: "RRULE:FREQ=DAILY;COUNT", wantErr: `malformed part "COUNT"`},
+		{name: "empty", rule: "RRULE:", wantErr: "empty rule"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := calendar.ValidateRRULE(tt.rule)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected %q to be valid, got %v", tt.rule, err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error for %q, got nil", tt.rule)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateRecurrence(t *testing.T) {
+	tests := []struct {
+		name       string
+		recurrence []string
+		wantErr    string
+	}{
+		{name: "weekly rule", recurrence: []string{"RRULE:FREQ=WEEKLY;BYDAY=MO,WE"}},
+		{name: "rule with exceptions", recurrence: []string{"RRULE:FREQ=DAILY;COUNT=5", "EXDATE:20240305T090000Z", "RDATE;TZID=America/New_York:20240310T090000"}},
+		{name: "unknown line type", recurrence: []string{"FREQ=WEEKLY"}, wantErr: "must start with RRULE:, RDATE:, or EXDATE:"},
+		{name: "deprecated EXRULE", recurrence: []string{"EXRULE:FREQ=WEEKLY"}, wantErr: "must start with"},
+		{name: "malformed rule", recurrence: []string{"RRULE:FREQ=FORTNIGHTLY"}, wantErr: "FREQ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := calendar.ValidateRecurrence(&gcalendar.Event{Recurrence: tt.recurrence})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected %v to be valid, got %v", tt.recurrence, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
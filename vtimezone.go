@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// icsLocalTime is the RFC 5545 form of a local (floating or TZID) date-time
+const icsLocalTime = "20060102T150405"
+
+// icsLocation resolves an event's IANA time zone for ICS output. UTC, unknown,
+// and empty zones return nil so callers fall back to UTC "Z" timestamps.
+func icsLocation(zone string) *time.Location {
+	if zone == "" || zone == "UTC" || zone == "Etc/UTC" || zone == "Local" {
+		return nil
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil
+	}
+	return loc
+}
+
+// icsDateTime formats ts as the value of a DTSTART or DTEND property,
+// including the separator: ";TZID=America/New_York:20240701T090000" in a
+// known zone, or ":20240701T130000Z" otherwise
+func icsDateTime(ts *timestamppb.Timestamp, zone string) string {
+	if ts == nil || !ts.IsValid() {
+		return ""
+	}
+	loc := icsLocation(zone)
+	if loc == nil {
+		return ":" + icsTimestamp(ts)
+	}
+	return fmt.Sprintf(";TZID=%s:%s", zone, ts.AsTime().In(loc).Format(icsLocalTime))
+}
+
+// icsDate formats ts, the midnight UTC an all-day event's date is held as,
+// as the value of a DTSTART or DTEND property: ";VALUE=DATE:20240701"
+func icsDate(ts *timestamppb.Timestamp) string {
+	if ts == nil || !ts.IsValid() {
+		return ""
+	}
+	return ";VALUE=DATE:" + ts.AsTime().UTC().Format("20060102")
+}
+
+// tzObservance is a switch between standard and daylight time
+type tzObservance struct {
+	at       time.Time // instant of the switch
+	from, to int       // UTC offsets in seconds before and after
+	name     string    // abbreviation in effect after, e.g. EDT
+	dst      bool
+}
+
+// local returns the wall-clock time of the switch, in the offset before it
+func (o tzObservance) local() time.Time {
+	return o.at.In(time.FixedZone("", o.from))
+}
+
+// zoneObservances lists the offset changes in loc during year
+func zoneObservances(loc *time.Location, year int) []tzObservance {
+	var observances []tzObservance
+	t := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	yearEnd := time.Date(year+1, 1, 1, 0, 0, 0, 0, loc)
+	for {
+		_, end := t.ZoneBounds()
+		if end.IsZero() || !end.Before(yearEnd) {
+			return observances
+		}
+		_, from := t.Zone()
+		name, to := end.Zone()
+		if from != to {
+			observances = append(observances, tzObservance{at: end, from: from, to: to, name: name, dst: end.IsDST()})
+		}
+		t = end
+	}
+}
+
+var icsWeekdays = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// observanceRule infers a yearly RRULE (e.g. "second Sunday of March") for o
+// by checking that the same switch follows it over the next few years. It
+// returns "" when no nth- or last-weekday rule fits.
+func observanceRule(loc *time.Location, o tzObservance) string {
+	local := o.local()
+	nth := (local.Day()-1)/7 + 1
+
+	// Each candidate reports whether a local switch time fits the rule
+	isLast := func(t time.Time) bool { return t.AddDate(0, 0, 7).Month() != t.Month() }
+	candidates := []struct {
+		byDay string
+		fits  func(time.Time) bool
+	}{
+		{fmt.Sprintf("%d%s", nth, icsWeekdays[local.Weekday()]), func(t time.Time) bool { return (t.Day()-1)/7+1 == nth }},
+		{"-1" + icsWeekdays[local.Weekday()], isLast},
+	}
+
+	for _, c := range candidates {
+		if !c.fits(local) {
+			continue
+		}
+		consistent := true
+		for year := local.Year() + 1; year <= local.Year()+3 && consistent; year++ {
+			consistent = false
+			for _, next := range zoneObservances(loc, year) {
+				l := next.local()
+				if next.dst == o.dst && next.to == o.to && next.from == o.from &&
+					l.Month() == local.Month() && l.Weekday() == local.Weekday() &&
+					l.Format("150405") == local.Format("150405") && c.fits(l) {
+					consistent = true
+				}
+			}
+		}
+		if consistent {
+			return fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYDAY=%s", local.Month(), c.byDay)
+		}
+	}
+	return ""
+}
+
+// icsOffset formats a UTC offset in seconds as +hhmm or -hhmm
+func icsOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign, seconds = "-", -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+// icsVTimezone renders a VTIMEZONE component defining zone's standard and
+// daylight time around ts, or "" when the event's times are emitted in UTC
+func icsVTimezone(zone string, ts *timestamppb.Timestamp) string {
+	loc := icsLocation(zone)
+	if loc == nil {
+		return ""
+	}
+	at := time.Now()
+	if ts != nil && ts.IsValid() {
+		at = ts.AsTime()
+	}
+	at = at.In(loc)
+
+	lines := []string{"BEGIN:VTIMEZONE", "TZID:" + zone}
+	observances := zoneObservances(loc, at.Year())
+	if len(observances) == 0 {
+		// No switches that year: a single fixed offset
+		name, offset := at.Zone()
+		lines = append(lines,
+			"BEGIN:STANDARD",
+			"DTSTART:19700101T000000",
+			"TZOFFSETFROM:"+icsOffset(offset),
+			"TZOFFSETTO:"+icsOffset(offset),
+			"TZNAME:"+name,
+			"END:STANDARD",
+		)
+	}
+	for _, o := range observances {
+		kind := "STANDARD"
+		if o.dst {
+			kind = "DAYLIGHT"
+		}
+		lines = append(lines,
+			"BEGIN:"+kind,
+			"DTSTART:"+o.local().Format(icsLocalTime),
+			"TZOFFSETFROM:"+icsOffset(o.from),
+			"TZOFFSETTO:"+icsOffset(o.to),
+			"TZNAME:"+o.name,
+		)
+		if rule := observanceRule(loc, o); rule != "" {
+			lines = append(lines, "RRULE:"+rule)
+		}
+		lines = append(lines, "END:"+kind)
+	}
+	lines = append(lines, "END:VTIMEZONE")
+	return strings.Join(lines, "\n")
+}